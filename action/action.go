@@ -13,6 +13,8 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/go-pkgs/crypto"
+
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 var (
@@ -42,12 +44,21 @@ type hasDestination interface {
 
 // Sign signs the action using sender's private key
 func Sign(act Envelope, sk crypto.PrivateKey) (SealedEnvelope, error) {
+	return SignWith(act, signer.NewLocalSigner(sk))
+}
+
+// SignWith signs the action using sender's signer
+func SignWith(act Envelope, s signer.Signer) (SealedEnvelope, error) {
 	sealed := SealedEnvelope{Envelope: act}
 
-	sealed.srcPubkey = sk.PublicKey()
+	sealed.srcPubkey = s.PublicKey()
 
 	hash := act.Hash()
-	sig, err := sk.Sign(hash[:])
+	sig, err := s.Sign(&signer.Message{
+		Kind:     signer.ActionKind,
+		Document: act.Serialize(),
+		Digest:   hash[:],
+	})
 	if err != nil {
 		return sealed, errors.Wrapf(ErrAction, "failed to sign action hash = %x", hash)
 	}
@@ -90,7 +101,10 @@ func Verify(sealed SealedEnvelope) error {
 		return errors.Wrap(ErrInsufficientBalanceForGas, "insufficient gas")
 	}
 
-	hash := sealed.Envelope.Hash()
+	hash, err := sealed.signingHash()
+	if err != nil {
+		return err
+	}
 	if sealed.SrcPubkey().Verify(hash[:], sealed.Signature()) {
 		return nil
 	}
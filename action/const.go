@@ -27,6 +27,8 @@ var (
 	ErrGasPrice = errors.New("invalid gas price")
 	// ErrVotee indicates the error of votee
 	ErrVotee = errors.New("votee is not a candidate")
+	// ErrRateLimit indicates the action exceeds the rate limit of actpool
+	ErrRateLimit = errors.New("rate limit exceeded")
 	// ErrNotFound indicates the nonexistence of action
 	ErrNotFound = errors.New("action not found")
 )
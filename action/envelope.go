@@ -88,6 +88,8 @@ func (elp *Envelope) Proto() *iotextypes.ActionCore {
 		actCore.Action = &iotextypes.ActionCore_DepositToRewardingFund{DepositToRewardingFund: act.Proto()}
 	case *PutPollResult:
 		actCore.Action = &iotextypes.ActionCore_PutPollResult{PutPollResult: act.Proto()}
+	case *SubmitEvidence:
+		setSubmitEvidenceField(actCore, act)
 	case *CreateStake:
 		actCore.Action = &iotextypes.ActionCore_StakeCreate{StakeCreate: act.Proto()}
 	case *Unstake:
@@ -219,6 +221,12 @@ func (elp *Envelope) LoadProto(pbAct *iotextypes.ActionCore) error {
 			return err
 		}
 		elp.payload = act
+	case len(pbAct.ProtoReflect().GetUnknown()) > 0:
+		act := &SubmitEvidence{}
+		if err := act.LoadProto(pbAct); err != nil {
+			return err
+		}
+		elp.payload = act
 	default:
 		return errors.Errorf("no applicable action to handle in action proto %+v", pbAct)
 	}
@@ -0,0 +1,159 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// Encoding is the encoding of the message signed by the sender of an action
+type Encoding uint8
+
+const (
+	// IotexProtobuf signs the hash of the protobuf serialized action core
+	IotexProtobuf Encoding = iota
+	// EthereumRLP signs the EIP-155 hash of the RLP encoded Ethereum transaction equivalent to the action, so that the
+	// actions can be signed by Ethereum wallets
+	EthereumRLP
+)
+
+const (
+	// _signatureLen is the length of a signature in the [R || S || V] format
+	_signatureLen = 65
+	// _ethSignatureLen is the length of the signature of an action signed as an Ethereum transaction, which carries the
+	// encoding and the EVM network ID after the signature, as the action proto has no field for them
+	_ethSignatureLen = _signatureLen + 1 + 4
+)
+
+// SealEthTx seals the action with the signature of its equivalent Ethereum transaction on the EVM network, which is
+// in the [R || S || V] format where V is the recovery ID, and recovers the public key of the sender from it
+func SealEthTx(act Envelope, evmNetworkID uint32, sig []byte) (SealedEnvelope, error) {
+	if len(sig) != _signatureLen {
+		return SealedEnvelope{}, errors.Wrapf(ErrAction, "invalid signature length %d", len(sig))
+	}
+	sealed := SealedEnvelope{
+		Envelope:     act,
+		signature:    make([]byte, len(sig)),
+		encoding:     EthereumRLP,
+		evmNetworkID: evmNetworkID,
+	}
+	copy(sealed.signature, sig)
+	h, err := sealed.signingHash()
+	if err != nil {
+		return sealed, err
+	}
+	if sealed.srcPubkey, err = crypto.RecoverPubkey(h[:], sealed.signature); err != nil {
+		return sealed, errors.Wrap(ErrAction, "failed to recover public key from Ethereum transaction signature")
+	}
+	sealed.payload.SetEnvelopeContext(sealed)
+	return sealed, nil
+}
+
+// SignEthTx signs the action as an Ethereum transaction on the EVM network using sender's private key
+func SignEthTx(act Envelope, evmNetworkID uint32, sk crypto.PrivateKey) (SealedEnvelope, error) {
+	tx, err := EthTx(act)
+	if err != nil {
+		return SealedEnvelope{}, err
+	}
+	h := types.NewEIP155Signer(new(big.Int).SetUint64(uint64(evmNetworkID))).Hash(tx)
+	sig, err := sk.Sign(h[:])
+	if err != nil {
+		return SealedEnvelope{}, errors.Wrapf(ErrAction, "failed to sign Ethereum transaction hash = %x", h)
+	}
+	return SealEthTx(act, evmNetworkID, sig)
+}
+
+// EthTx returns the unsigned Ethereum transaction equivalent to the action, which is either a transfer or an execution
+func EthTx(act Envelope) (*types.Transaction, error) {
+	var (
+		to     string
+		amount *big.Int
+		data   []byte
+	)
+	switch a := act.Action().(type) {
+	case *Transfer:
+		to, amount, data = a.Recipient(), a.Amount(), a.Payload()
+	case *Execution:
+		to, amount, data = a.Contract(), a.Amount(), a.Data()
+	default:
+		return nil, errors.Wrapf(ErrAction, "action %T has no equivalent Ethereum transaction", a)
+	}
+	if to == EmptyAddress {
+		return types.NewContractCreation(act.Nonce(), amount, act.GasLimit(), act.GasPrice(), data), nil
+	}
+	addr, err := address.FromString(to)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid recipient %s", to)
+	}
+	return types.NewTransaction(
+		act.Nonce(),
+		common.BytesToAddress(addr.Bytes()),
+		amount,
+		act.GasLimit(),
+		act.GasPrice(),
+		data,
+	), nil
+}
+
+// ethSignedTx returns the signed Ethereum transaction equivalent to the action
+func (sealed *SealedEnvelope) ethSignedTx() (*types.Transaction, error) {
+	tx, err := EthTx(sealed.Envelope)
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(types.NewEIP155Signer(new(big.Int).SetUint64(uint64(sealed.evmNetworkID))), sealed.signature)
+}
+
+// signingHash returns the hash of the message signed by the sender
+func (sealed *SealedEnvelope) signingHash() (hash.Hash256, error) {
+	if sealed.encoding != EthereumRLP {
+		return sealed.Envelope.Hash(), nil
+	}
+	tx, err := EthTx(sealed.Envelope)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return hash.BytesToHash256(
+		types.NewEIP155Signer(new(big.Int).SetUint64(uint64(sealed.evmNetworkID))).Hash(tx).Bytes(),
+	), nil
+}
+
+// encodeSignature appends the encoding and the EVM network ID to the signature of an action signed as an Ethereum
+// transaction
+func (sealed *SealedEnvelope) encodeSignature() []byte {
+	if sealed.encoding != EthereumRLP {
+		return sealed.signature
+	}
+	sig := make([]byte, 0, _ethSignatureLen)
+	sig = append(sig, sealed.signature...)
+	sig = append(sig, byte(sealed.encoding))
+	return append(sig, byteutil.Uint32ToBytesBigEndian(sealed.evmNetworkID)...)
+}
+
+// decodeSignature loads the signature, along with the encoding and the EVM network ID of an action signed as an
+// Ethereum transaction
+func (sealed *SealedEnvelope) decodeSignature(sig []byte) {
+	sealed.encoding = IotexProtobuf
+	sealed.evmNetworkID = 0
+	if len(sig) == _ethSignatureLen && Encoding(sig[_signatureLen]) == EthereumRLP {
+		sealed.encoding = EthereumRLP
+		sealed.evmNetworkID = binary.BigEndian.Uint32(sig[_signatureLen+1:])
+		sig = sig[:_signatureLen]
+	}
+	sealed.signature = make([]byte, len(sig))
+	copy(sealed.signature, sig)
+}
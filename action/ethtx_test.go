@@ -0,0 +1,73 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestSignEthTx(t *testing.T) {
+	require := require.New(t)
+
+	evlp, _ := createEnvelope()
+	sk := identityset.PrivateKey(1)
+	selp, err := SignEthTx(evlp, 4689, sk)
+	require.NoError(err)
+	require.Equal(EthereumRLP, selp.Encoding())
+	require.Equal(uint32(4689), selp.EVMNetworkID())
+	require.Equal(sk.PublicKey().Bytes(), selp.SrcPubkey().Bytes())
+	require.NoError(Verify(selp))
+
+	// the hash is the one of the signed Ethereum transaction
+	tx, err := EthTx(evlp)
+	require.NoError(err)
+	signer := types.NewEIP155Signer(big.NewInt(4689))
+	signed, err := types.SignTx(tx, signer, sk.EcdsaPrivateKey().(*ecdsa.PrivateKey))
+	require.NoError(err)
+	h := selp.Hash()
+	require.Equal(signed.Hash().Bytes(), h[:])
+	sender, err := types.Sender(signer, signed)
+	require.NoError(err)
+	require.Equal(identityset.Address(1).Bytes(), sender.Bytes())
+
+	// the encoding and the EVM network ID survive the proto round trip
+	pb := selp.Proto()
+	require.Equal(_ethSignatureLen, len(pb.GetSignature()))
+	loaded := SealedEnvelope{}
+	require.NoError(loaded.LoadProto(pb))
+	require.Equal(EthereumRLP, loaded.Encoding())
+	require.Equal(uint32(4689), loaded.EVMNetworkID())
+	require.Equal(selp.Signature(), loaded.Signature())
+	require.Equal(selp.Hash(), loaded.Hash())
+	require.NoError(Verify(loaded))
+
+	// the signature does not verify on another network
+	loaded.evmNetworkID = 1
+	require.Error(Verify(loaded))
+
+	// an action signed as protobuf keeps its encoding
+	selp, err = Sign(evlp, sk)
+	require.NoError(err)
+	require.NoError(loaded.LoadProto(selp.Proto()))
+	require.Equal(IotexProtobuf, loaded.Encoding())
+	require.Equal(selp.Hash(), loaded.Hash())
+
+	// only transfers and executions have equivalent Ethereum transactions
+	_, err = SealEthTx(evlp, 4689, []byte{1, 2, 3})
+	require.Error(err)
+	unstake, err := NewUnstake(1, 2, nil, 10000, big.NewInt(1))
+	require.NoError(err)
+	_, err = SignEthTx((&EnvelopeBuilder{}).SetAction(unstake).Build(), 4689, sk)
+	require.Error(err)
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+type (
+	// callFrame is a call in the tree reported by the call tracer, in the format of geth's callTracer
+	callFrame struct {
+		Type    string       `json:"type"`
+		From    string       `json:"from,omitempty"`
+		To      string       `json:"to,omitempty"`
+		Value   string       `json:"value,omitempty"`
+		Gas     string       `json:"gas,omitempty"`
+		GasUsed string       `json:"gasUsed,omitempty"`
+		Input   string       `json:"input,omitempty"`
+		Output  string       `json:"output,omitempty"`
+		Error   string       `json:"error,omitempty"`
+		Time    string       `json:"time,omitempty"`
+		Calls   []*callFrame `json:"calls,omitempty"`
+
+		gasIn   uint64
+		gasCost uint64
+		gas     uint64
+		hasGas  bool
+		outOff  *big.Int
+		outLen  *big.Int
+	}
+
+	// callTracer reports the tree of the calls made by the execution, the same as geth's callTracer does
+	callTracer struct {
+		callstack []*callFrame
+		descended bool
+		create    bool
+		from      common.Address
+		to        common.Address
+		input     []byte
+		gas       uint64
+		value     *big.Int
+		output    []byte
+		gasUsed   uint64
+		duration  time.Duration
+		err       error
+		started   bool
+	}
+)
+
+func newCallTracer() *callTracer {
+	return &callTracer{callstack: []*callFrame{{}}}
+}
+
+func (t *callTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.started = true
+	t.create = create
+	t.from = from
+	t.to = to
+	t.input = input
+	t.gas = gas
+	t.value = value
+	return nil
+}
+
+func (t *callTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if err != nil {
+		t.fault(err)
+		return nil
+	}
+	switch op {
+	case vm.CREATE, vm.CREATE2:
+		inOff, inLen := stack.Back(1), stack.Back(2)
+		t.callstack = append(t.callstack, &callFrame{
+			Type:    op.String(),
+			From:    hexutil.Encode(contract.Address().Bytes()),
+			Input:   hexutil.Encode(memorySlice(memory, inOff, inLen)),
+			Value:   hexBig(stack.Back(0)),
+			gasIn:   gas,
+			gasCost: cost,
+		})
+		t.descended = true
+		return nil
+	case vm.SELFDESTRUCT:
+		top := t.callstack[len(t.callstack)-1]
+		top.Calls = append(top.Calls, &callFrame{Type: op.String()})
+		return nil
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		to := common.BigToAddress(stack.Back(1))
+		if _, ok := vm.PrecompiledContractsByzantium[to]; ok {
+			return nil
+		}
+		off := 1
+		if op == vm.DELEGATECALL || op == vm.STATICCALL {
+			off = 0
+		}
+		call := &callFrame{
+			Type:    op.String(),
+			From:    hexutil.Encode(contract.Address().Bytes()),
+			To:      hexutil.Encode(to.Bytes()),
+			Input:   hexutil.Encode(memorySlice(memory, stack.Back(2+off), stack.Back(3+off))),
+			gasIn:   gas,
+			gasCost: cost,
+			outOff:  new(big.Int).Set(stack.Back(4 + off)),
+			outLen:  new(big.Int).Set(stack.Back(5 + off)),
+		}
+		if off == 1 {
+			call.Value = hexBig(stack.Back(2))
+		}
+		t.callstack = append(t.callstack, call)
+		t.descended = true
+		return nil
+	}
+	if t.descended {
+		// the gas of the call is known once the execution descends into it
+		if depth >= len(t.callstack) {
+			top := t.callstack[len(t.callstack)-1]
+			top.gas = gas
+			top.hasGas = true
+		}
+		t.descended = false
+	}
+	if op == vm.REVERT {
+		t.callstack[len(t.callstack)-1].Error = "execution reverted"
+		return nil
+	}
+	if depth != len(t.callstack)-1 {
+		return nil
+	}
+	// the call returns to its caller
+	call := t.callstack[len(t.callstack)-1]
+	t.callstack = t.callstack[:len(t.callstack)-1]
+	ret := stack.Back(0)
+	switch call.Type {
+	case vm.CREATE.String(), vm.CREATE2.String():
+		call.GasUsed = hexutil.EncodeUint64(call.gasIn - call.gasCost - gas)
+		if ret.Sign() != 0 {
+			addr := common.BigToAddress(ret)
+			call.To = hexutil.Encode(addr.Bytes())
+			call.Output = hexutil.Encode(env.StateDB.GetCode(addr))
+		} else if call.Error == "" {
+			call.Error = "internal failure"
+		}
+	default:
+		if call.hasGas {
+			call.GasUsed = hexutil.EncodeUint64(call.gasIn - call.gasCost + call.gas - gas)
+			if ret.Sign() != 0 {
+				call.Output = hexutil.Encode(memorySlice(memory, call.outOff, call.outLen))
+			} else if call.Error == "" {
+				call.Error = "internal failure"
+			}
+		}
+	}
+	if call.hasGas {
+		call.Gas = hexutil.EncodeUint64(call.gas)
+	}
+	top := t.callstack[len(t.callstack)-1]
+	top.Calls = append(top.Calls, call)
+	return nil
+}
+
+func (t *callTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	t.fault(err)
+	return nil
+}
+
+func (t *callTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	t.output = output
+	t.gasUsed = gasUsed
+	t.duration = d
+	t.err = err
+	return nil
+}
+
+// fault marks the current call failed, and returns to its caller
+func (t *callTracer) fault(err error) {
+	if t.callstack[len(t.callstack)-1].Error != "" {
+		return
+	}
+	call := t.callstack[len(t.callstack)-1]
+	t.callstack = t.callstack[:len(t.callstack)-1]
+	call.Error = err.Error()
+	if call.hasGas {
+		call.Gas = hexutil.EncodeUint64(call.gas)
+		call.GasUsed = call.Gas
+	}
+	if len(t.callstack) == 0 {
+		t.callstack = append(t.callstack, call)
+		return
+	}
+	top := t.callstack[len(t.callstack)-1]
+	top.Calls = append(top.Calls, call)
+}
+
+func (t *callTracer) GetResult() (json.RawMessage, error) {
+	if !t.started {
+		return json.Marshal(&callFrame{})
+	}
+	res := &callFrame{
+		Type:    vm.CALL.String(),
+		From:    hexutil.Encode(t.from.Bytes()),
+		To:      hexutil.Encode(t.to.Bytes()),
+		Value:   hexBig(t.value),
+		Gas:     hexutil.EncodeUint64(t.gas),
+		GasUsed: hexutil.EncodeUint64(t.gasUsed),
+		Input:   hexutil.Encode(t.input),
+		Output:  hexutil.Encode(t.output),
+		Time:    t.duration.String(),
+		Calls:   t.callstack[0].Calls,
+		Error:   t.callstack[0].Error,
+	}
+	if t.create {
+		res.Type = vm.CREATE.String()
+	}
+	if res.Error == "" && t.err != nil {
+		res.Error = t.err.Error()
+	}
+	if res.Error != "" {
+		res.Output = ""
+	}
+	return json.Marshal(res)
+}
@@ -187,14 +187,7 @@ func newContract(addr hash.Hash160, account *state.Account, sm protocol.StateMan
 		sm:        sm,
 		async:     enableAsync,
 	}
-	options := []mptrie.Option{
-		mptrie.KVStoreOption(newKVStoreForTrieWithStateManager(ContractKVNameSpace, sm)),
-		mptrie.KeyLengthOption(len(hash.Hash256{})),
-		mptrie.HashFuncOption(func(data []byte) []byte {
-			h := hash.Hash256b(append(addr[:], data...))
-			return h[:]
-		}),
-	}
+	options := storageTrieOptions(addr, newKVStoreForTrieWithStateManager(ContractKVNameSpace, sm))
 	if account.Root != hash.ZeroHash256 {
 		options = append(options, mptrie.RootHashOption(account.Root[:]))
 	}
@@ -212,3 +205,53 @@ func newContract(addr hash.Hash160, account *state.Account, sm protocol.StateMan
 	c.trie = tr
 	return c, nil
 }
+
+// ProveStorage returns the proof of a storage slot of the contract against the storage root of the contract account
+func ProveStorage(sr protocol.StateReader, addr hash.Hash160, account *state.Account, key hash.Hash256) ([][]byte, error) {
+	if account.Root == hash.ZeroHash256 {
+		// the storage is empty, nothing to prove
+		return nil, nil
+	}
+	options := append(
+		storageTrieOptions(addr, newKVStoreForTrieWithStateReader(ContractKVNameSpace, sr)),
+		mptrie.RootHashOption(account.Root[:]),
+	)
+	tr, err := mptrie.New(options...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create storage trie of contract %x", addr)
+	}
+	if err := tr.Start(context.Background()); err != nil {
+		return nil, err
+	}
+	defer tr.Stop(context.Background())
+
+	return tr.Prove(key[:])
+}
+
+// VerifyStorageProof verifies the proof of a storage slot of the contract against the storage root, and returns the
+// value of the slot, or trie.ErrNotExist if the proof proves the slot is not set
+func VerifyStorageProof(addr hash.Hash160, root hash.Hash256, key hash.Hash256, proof [][]byte) ([]byte, error) {
+	if root == hash.ZeroHash256 {
+		if len(proof) != 0 {
+			return nil, errors.Wrap(trie.ErrInvalidProof, "proof of empty storage should be empty")
+		}
+		return nil, errors.Wrapf(trie.ErrNotExist, "key %x does not exist", key)
+	}
+	tr, err := mptrie.New(storageTrieOptions(addr, trie.NewMemKVStore())...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tr.VerifyProof(root[:], key[:], proof)
+}
+
+func storageTrieOptions(addr hash.Hash160, kvStore trie.KVStore) []mptrie.Option {
+	return []mptrie.Option{
+		mptrie.KVStoreOption(kvStore),
+		mptrie.KeyLengthOption(len(hash.Hash256{})),
+		mptrie.HashFuncOption(func(data []byte) []byte {
+			h := hash.Hash256b(append(addr[:], data...))
+			return h[:]
+		}),
+	}
+}
@@ -21,6 +21,7 @@ import (
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/db/trie"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/test/mock/mock_chainmanager"
@@ -278,3 +279,40 @@ func TestSnapshot(t *testing.T) {
 		testfunc(true)
 	})
 }
+
+func TestStorageProof(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	sm, err := initMockStateManager(ctrl)
+	require.NoError(err)
+
+	addr := hash.BytesToHash160(c1[:])
+	account := &state.Account{}
+	// empty storage
+	proof, err := ProveStorage(sm, addr, account, k1b)
+	require.NoError(err)
+	require.Empty(proof)
+	_, err = VerifyStorageProof(addr, account.Root, k1b, proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+
+	cntr, err := newContract(addr, account, sm, false)
+	require.NoError(err)
+	require.NoError(cntr.SetState(k1b, v1b[:]))
+	require.NoError(cntr.SetState(k2b, v2b[:]))
+	require.NoError(cntr.Commit())
+	account = cntr.SelfState()
+
+	proof, err = ProveStorage(sm, addr, account, k1b)
+	require.NoError(err)
+	v, err := VerifyStorageProof(addr, account.Root, k1b, proof)
+	require.NoError(err)
+	require.Equal(v1b[:], v)
+	// the storage trie of another contract hashes the nodes differently
+	_, err = VerifyStorageProof(hash.BytesToHash160(c2[:]), account.Root, k1b, proof)
+	require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+	proof, err = ProveStorage(sm, addr, account, k3b)
+	require.NoError(err)
+	_, err = VerifyStorageProof(addr, account.Root, k3b, proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+}
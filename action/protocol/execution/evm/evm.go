@@ -70,6 +70,7 @@ type (
 		contract           *common.Address
 		gas                uint64
 		data               []byte
+		tracer             vm.Tracer
 	}
 )
 
@@ -133,6 +134,7 @@ func newParams(
 		GasPrice:    execution.GasPrice(),
 	}
 
+	tracer, _ := GetTracerCtx(ctx)
 	return &Params{
 		context,
 		execution.Nonce(),
@@ -141,6 +143,7 @@ func newParams(
 		contractAddrPointer,
 		gasLimit,
 		execution.Data(),
+		tracer,
 	}, nil
 }
 
@@ -251,11 +254,18 @@ func getChainConfig(hu config.HeightUpgrade) *params.ChainConfig {
 func executeInEVM(evmParams *Params, stateDB *StateDBAdapter, hu config.HeightUpgrade, gasLimit uint64, blockHeight uint64) ([]byte, uint64, uint64, string, uint64, error) {
 	isBering := hu.IsPost(config.Bering, blockHeight)
 	remainingGas := evmParams.gas
+	if t, ok := evmParams.tracer.(txStartCapturer); ok {
+		t.captureTxStart(stateDB, evmParams.context.Origin, evmParams.contract)
+	}
 	if err := securityDeposit(evmParams, stateDB, gasLimit); err != nil {
 		log.L().Warn("unexpected error: not enough security deposit", zap.Error(err))
 		return nil, 0, 0, action.EmptyAddress, uint64(iotextypes.ReceiptStatus_Failure), err
 	}
 	var config vm.Config
+	if evmParams.tracer != nil {
+		config.Debug = true
+		config.Tracer = evmParams.tracer
+	}
 	chainConfig := getChainConfig(hu)
 	evm := vm.NewEVM(evmParams.context, stateDB, chainConfig, config)
 	intriGas, err := intrinsicGas(evmParams.data)
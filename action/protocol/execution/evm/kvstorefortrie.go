@@ -17,13 +17,20 @@ import (
 	"github.com/iotexproject/iotex-core/state"
 )
 
+var errReadOnlyKVStore = errors.New("kv store for trie is read only")
+
 type kvStoreForTrie struct {
 	nsOpt protocol.StateOption
+	sr    protocol.StateReader
 	sm    protocol.StateManager
 }
 
 func newKVStoreForTrieWithStateManager(ns string, sm protocol.StateManager) trie.KVStore {
-	return &kvStoreForTrie{nsOpt: protocol.NamespaceOption(ns), sm: sm}
+	return &kvStoreForTrie{nsOpt: protocol.NamespaceOption(ns), sr: sm, sm: sm}
+}
+
+func newKVStoreForTrieWithStateReader(ns string, sr protocol.StateReader) trie.KVStore {
+	return &kvStoreForTrie{nsOpt: protocol.NamespaceOption(ns), sr: sr}
 }
 
 func (kv *kvStoreForTrie) Start(context.Context) error {
@@ -35,6 +42,9 @@ func (kv *kvStoreForTrie) Stop(context.Context) error {
 }
 
 func (kv *kvStoreForTrie) Put(key []byte, value []byte) error {
+	if kv.sm == nil {
+		return errReadOnlyKVStore
+	}
 	var sb SerializableBytes
 	if err := sb.Deserialize(value); err != nil {
 		return err
@@ -45,6 +55,9 @@ func (kv *kvStoreForTrie) Put(key []byte, value []byte) error {
 }
 
 func (kv *kvStoreForTrie) Delete(key []byte) error {
+	if kv.sm == nil {
+		return errReadOnlyKVStore
+	}
 	_, err := kv.sm.DelState(protocol.KeyOption(key), kv.nsOpt)
 	if errors.Cause(err) == state.ErrStateNotExist {
 		return nil
@@ -54,7 +67,7 @@ func (kv *kvStoreForTrie) Delete(key []byte) error {
 
 func (kv *kvStoreForTrie) Get(key []byte) ([]byte, error) {
 	var value SerializableBytes
-	_, err := kv.sm.State(&value, protocol.KeyOption(key), kv.nsOpt)
+	_, err := kv.sr.State(&value, protocol.KeyOption(key), kv.nsOpt)
 	switch errors.Cause(err) {
 	case state.ErrStateNotExist:
 		return nil, errors.Wrapf(db.ErrNotExist, "failed to find key %x", key)
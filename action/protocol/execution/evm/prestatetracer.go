@@ -0,0 +1,120 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type (
+	// prestateAccount is an account accessed by the execution, in the format of geth's prestateTracer
+	prestateAccount struct {
+		Balance string                      `json:"balance"`
+		Nonce   uint64                      `json:"nonce"`
+		Code    string                      `json:"code"`
+		Storage map[common.Hash]common.Hash `json:"storage"`
+	}
+
+	// prestateTracer reports the accounts and storage accessed by the execution with their states before it runs, the
+	// same as geth's prestateTracer does
+	prestateTracer struct {
+		prestate map[common.Address]*prestateAccount
+		stateDB  vm.StateDB
+		create   bool
+		to       common.Address
+	}
+
+	// txStartCapturer is a tracer capturing the sender and the recipient of an execution, before the security deposit
+	// of the gas, the nonce increment and the value transfer change their states
+	txStartCapturer interface {
+		captureTxStart(stateDB vm.StateDB, from common.Address, to *common.Address)
+	}
+)
+
+func newPrestateTracer() *prestateTracer {
+	return &prestateTracer{prestate: make(map[common.Address]*prestateAccount)}
+}
+
+func (t *prestateTracer) captureTxStart(stateDB vm.StateDB, from common.Address, to *common.Address) {
+	t.stateDB = stateDB
+	t.lookupAccount(from)
+	if to != nil {
+		t.lookupAccount(*to)
+	}
+}
+
+func (t *prestateTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.create = create
+	t.to = to
+	return nil
+}
+
+func (t *prestateTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if t.stateDB == nil {
+		t.stateDB = env.StateDB
+	}
+	t.lookupAccount(contract.Address())
+	switch op {
+	case vm.EXTCODECOPY, vm.EXTCODESIZE, vm.BALANCE:
+		t.lookupAccount(common.BigToAddress(stack.Back(0)))
+	case vm.CREATE:
+		from := contract.Address()
+		t.lookupAccount(crypto.CreateAddress(from, t.stateDB.GetNonce(from)))
+	case vm.CREATE2:
+		code := memorySlice(memory, stack.Back(1), stack.Back(2))
+		salt := common.BigToHash(stack.Back(3))
+		t.lookupAccount(crypto.CreateAddress2(contract.Address(), salt, crypto.Keccak256(code)))
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		t.lookupAccount(common.BigToAddress(stack.Back(1)))
+	case vm.SSTORE, vm.SLOAD:
+		t.lookupStorage(contract.Address(), common.BigToHash(stack.Back(0)))
+	}
+	return nil
+}
+
+func (t *prestateTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+func (t *prestateTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	if t.create {
+		// the contract created does not exist before the execution
+		delete(t.prestate, t.to)
+	}
+	return nil
+}
+
+func (t *prestateTracer) lookupAccount(addr common.Address) {
+	if _, ok := t.prestate[addr]; ok {
+		return
+	}
+	t.prestate[addr] = &prestateAccount{
+		Balance: hexBig(t.stateDB.GetBalance(addr)),
+		Nonce:   t.stateDB.GetNonce(addr),
+		Code:    hexutil.Encode(t.stateDB.GetCode(addr)),
+		Storage: make(map[common.Hash]common.Hash),
+	}
+}
+
+func (t *prestateTracer) lookupStorage(addr common.Address, key common.Hash) {
+	t.lookupAccount(addr)
+	if _, ok := t.prestate[addr].Storage[key]; ok {
+		return
+	}
+	t.prestate[addr].Storage[key] = t.stateDB.GetState(addr, key)
+}
+
+func (t *prestateTracer) GetResult() (json.RawMessage, error) {
+	return json.Marshal(t.prestate)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// _errorSelector is the selector of Solidity Error(string)
+	_errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+	// _panicSelector is the selector of Solidity Panic(uint256)
+	_panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// DecodeRevertReason decodes the reason from the return value of a reverted execution, which is the message of
+// Error(string), the code of Panic(uint256), or the hex string of custom error data
+func DecodeRevertReason(ret []byte) string {
+	if len(ret) == 0 {
+		return ""
+	}
+	if len(ret) >= 4 {
+		switch data := ret[4:]; {
+		case bytes.Equal(ret[:4], _errorSelector):
+			if msg, ok := decodeABIString(data); ok {
+				return msg
+			}
+		case bytes.Equal(ret[:4], _panicSelector):
+			if len(data) == 32 {
+				return fmt.Sprintf("panic code 0x%x", new(big.Int).SetBytes(data))
+			}
+		}
+	}
+	return "0x" + hex.EncodeToString(ret)
+}
+
+// decodeABIString decodes an ABI encoded string, which is an offset followed by the length and the content
+func decodeABIString(data []byte) (string, bool) {
+	if len(data) < 64 {
+		return "", false
+	}
+	offset := new(big.Int).SetBytes(data[:32])
+	if !offset.IsUint64() || offset.Uint64()+32 > uint64(len(data)) {
+		return "", false
+	}
+	start := offset.Uint64() + 32
+	size := new(big.Int).SetBytes(data[start-32 : start])
+	if !size.IsUint64() || size.Uint64() > uint64(len(data))-start {
+		return "", false
+	}
+	return string(data[start : start+size.Uint64()]), true
+}
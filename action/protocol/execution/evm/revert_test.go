@@ -0,0 +1,55 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRevertReason(t *testing.T) {
+	require := require.New(t)
+
+	tests := []struct {
+		ret    string
+		reason string
+	}{
+		{"", ""},
+		// Error("insufficient balance")
+		{
+			"08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"0000000000000000000000000000000000000000000000000000000000000014" +
+				"696e73756666696369656e742062616c616e6365000000000000000000000000",
+			"insufficient balance",
+		},
+		// Panic(0x11)
+		{
+			"4e487b71" +
+				"0000000000000000000000000000000000000000000000000000000000000011",
+			"panic code 0x11",
+		},
+		// custom error
+		{"deadbeef01", "0xdeadbeef01"},
+		// malformed Error(string) with out-of-range length
+		{
+			"08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"00000000000000000000000000000000000000000000000000000000000000ff",
+			"0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"00000000000000000000000000000000000000000000000000000000000000ff",
+		},
+	}
+	for _, test := range tests {
+		ret, err := hex.DecodeString(test.ret)
+		require.NoError(err)
+		require.Equal(test.reason, DecodeRevertReason(ret))
+	}
+}
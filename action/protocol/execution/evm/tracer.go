@@ -0,0 +1,158 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/pkg/errors"
+)
+
+const (
+	// CallTracer is the name of the tracer reporting the tree of the calls
+	CallTracer = "callTracer"
+	// PrestateTracer is the name of the tracer reporting the states accessed by the execution before it runs
+	PrestateTracer = "prestateTracer"
+)
+
+type (
+	tracerContextKey struct{}
+
+	// Tracer traces the contract executed in EVM, and reports the trace in JSON compatible with geth
+	Tracer interface {
+		vm.Tracer
+		// GetResult returns the JSON of the trace
+		GetResult() (json.RawMessage, error)
+	}
+
+	// structLogger reports the struct log of each opcode executed
+	structLogger struct {
+		*vm.StructLogger
+		gasUsed uint64
+	}
+
+	// structLogResult is the struct log of an opcode in the format of geth's debug API
+	structLogResult struct {
+		Pc      uint64             `json:"pc"`
+		Op      string             `json:"op"`
+		Gas     uint64             `json:"gas"`
+		GasCost uint64             `json:"gasCost"`
+		Depth   int                `json:"depth"`
+		Error   string             `json:"error,omitempty"`
+		Stack   *[]string          `json:"stack,omitempty"`
+		Memory  *[]string          `json:"memory,omitempty"`
+		Storage *map[string]string `json:"storage,omitempty"`
+	}
+
+	// executionResult is the result of the struct logger in the format of geth's debug API
+	executionResult struct {
+		Gas         uint64            `json:"gas"`
+		Failed      bool              `json:"failed"`
+		ReturnValue string            `json:"returnValue"`
+		StructLogs  []structLogResult `json:"structLogs"`
+	}
+)
+
+// ErrUnknownTracer indicates the tracer name is unknown
+var ErrUnknownTracer = errors.New("unknown tracer")
+
+// NewTracer creates the tracer by name, which is the struct logger configured by cfg if name is empty
+func NewTracer(name string, cfg *vm.LogConfig) (Tracer, error) {
+	switch name {
+	case "":
+		return &structLogger{StructLogger: vm.NewStructLogger(cfg)}, nil
+	case CallTracer:
+		return newCallTracer(), nil
+	case PrestateTracer:
+		return newPrestateTracer(), nil
+	default:
+		return nil, errors.Wrapf(ErrUnknownTracer, "tracer = %s", name)
+	}
+}
+
+// WithTracerCtx adds the tracer into context, which traces the contract executed with the context
+func WithTracerCtx(ctx context.Context, tracer vm.Tracer) context.Context {
+	return context.WithValue(ctx, tracerContextKey{}, tracer)
+}
+
+// GetTracerCtx returns the tracer from context
+func GetTracerCtx(ctx context.Context) (vm.Tracer, bool) {
+	tracer, ok := ctx.Value(tracerContextKey{}).(vm.Tracer)
+	return tracer, ok
+}
+
+func (l *structLogger) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) error {
+	l.gasUsed = gasUsed
+	return l.StructLogger.CaptureEnd(output, gasUsed, t, err)
+}
+
+func (l *structLogger) GetResult() (json.RawMessage, error) {
+	logs := make([]structLogResult, 0, len(l.StructLogs()))
+	for _, log := range l.StructLogs() {
+		res := structLogResult{
+			Pc:      log.Pc,
+			Op:      log.Op.String(),
+			Gas:     log.Gas,
+			GasCost: log.GasCost,
+			Depth:   log.Depth,
+		}
+		if log.Err != nil {
+			res.Error = log.Err.Error()
+		}
+		if log.Stack != nil {
+			stack := make([]string, len(log.Stack))
+			for i, v := range log.Stack {
+				stack[i] = common.Bytes2Hex(math.PaddedBigBytes(v, 32))
+			}
+			res.Stack = &stack
+		}
+		if log.Memory != nil {
+			memory := make([]string, 0, (len(log.Memory)+31)/32)
+			for i := 0; i+32 <= len(log.Memory); i += 32 {
+				memory = append(memory, common.Bytes2Hex(log.Memory[i:i+32]))
+			}
+			res.Memory = &memory
+		}
+		if log.Storage != nil {
+			storage := make(map[string]string, len(log.Storage))
+			for k, v := range log.Storage {
+				storage[common.Bytes2Hex(k[:])] = common.Bytes2Hex(v[:])
+			}
+			res.Storage = &storage
+		}
+		logs = append(logs, res)
+	}
+	return json.Marshal(&executionResult{
+		Gas:         l.gasUsed,
+		Failed:      l.Error() != nil,
+		ReturnValue: common.Bytes2Hex(l.Output()),
+		StructLogs:  logs,
+	})
+}
+
+// memorySlice returns a copy of the memory in [offset, offset+size), or nil if it is out of range
+func memorySlice(memory *vm.Memory, offset, size *big.Int) []byte {
+	if !offset.IsUint64() || !size.IsUint64() || offset.Uint64()+size.Uint64() < offset.Uint64() ||
+		offset.Uint64()+size.Uint64() > uint64(memory.Len()) {
+		return nil
+	}
+	return memory.Get(offset.Int64(), size.Int64())
+}
+
+func hexBig(v *big.Int) string {
+	if v == nil {
+		return "0x0"
+	}
+	return hexutil.EncodeBig(v)
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// callee returns 42 as a word
+	_tracerCallee     = common.HexToAddress("0xcc")
+	_tracerCalleeCode = common.FromHex("602a60005260206000f3")
+	// caller stores 1 in slot 1, calls the callee, and returns what the callee returns
+	_tracerCallerCode = common.FromHex("600160015560206000600060006000" + "60cc" + "61fffff1" + "60206000f3")
+	_tracerCaller     = common.BytesToAddress([]byte("contract"))
+)
+
+func traceCall(r *require.Assertions, tracer Tracer) map[string]interface{} {
+	sdb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	r.NoError(err)
+	sdb.SetCode(_tracerCallee, _tracerCalleeCode)
+	ret, _, err := runtime.Execute(_tracerCallerCode, nil, &runtime.Config{
+		State:     sdb,
+		EVMConfig: vm.Config{Debug: true, Tracer: tracer},
+	})
+	r.NoError(err)
+	r.Equal(common.LeftPadBytes([]byte{42}, 32), ret)
+
+	res, err := tracer.GetResult()
+	r.NoError(err)
+	var trace map[string]interface{}
+	r.NoError(json.Unmarshal(res, &trace))
+	return trace
+}
+
+func TestStructLogger(t *testing.T) {
+	r := require.New(t)
+	tracer, err := NewTracer("", &vm.LogConfig{})
+	r.NoError(err)
+	trace := traceCall(r, tracer)
+	r.False(trace["failed"].(bool))
+	r.NotZero(trace["gas"])
+	r.Equal(common.Bytes2Hex(common.LeftPadBytes([]byte{42}, 32)), trace["returnValue"])
+	logs := trace["structLogs"].([]interface{})
+	r.Equal("PUSH1", logs[0].(map[string]interface{})["op"])
+	var sstore, depth2 bool
+	for _, l := range logs {
+		log := l.(map[string]interface{})
+		switch {
+		case log["op"] == "SSTORE":
+			sstore = true
+			slot := common.Bytes2Hex(common.LeftPadBytes([]byte{1}, 32))
+			r.Equal(slot, log["storage"].(map[string]interface{})[slot])
+		case log["depth"].(float64) == 2:
+			depth2 = true
+		}
+	}
+	r.True(sstore)
+	r.True(depth2)
+
+	tracer, err = NewTracer("", &vm.LogConfig{DisableStack: true, DisableMemory: true, DisableStorage: true, Limit: 3})
+	r.NoError(err)
+	trace = traceCall(r, tracer)
+	logs = trace["structLogs"].([]interface{})
+	r.Len(logs, 3)
+	for _, l := range logs {
+		log := l.(map[string]interface{})
+		r.NotContains(log, "stack")
+		r.NotContains(log, "memory")
+		r.NotContains(log, "storage")
+	}
+}
+
+func TestCallTracer(t *testing.T) {
+	r := require.New(t)
+	tracer, err := NewTracer(CallTracer, nil)
+	r.NoError(err)
+	trace := traceCall(r, tracer)
+	r.Equal("CALL", trace["type"])
+	r.Equal(hexutil.Encode(_tracerCaller.Bytes()), trace["to"])
+	r.Equal(hexutil.Encode(common.LeftPadBytes([]byte{42}, 32)), trace["output"])
+	r.NotContains(trace, "error")
+	calls := trace["calls"].([]interface{})
+	r.Len(calls, 1)
+	call := calls[0].(map[string]interface{})
+	r.Equal("CALL", call["type"])
+	r.Equal(hexutil.Encode(_tracerCaller.Bytes()), call["from"])
+	r.Equal(hexutil.Encode(_tracerCallee.Bytes()), call["to"])
+	r.Equal("0x0", call["value"])
+	r.Equal("0x", call["input"])
+	r.Equal(hexutil.Encode(common.LeftPadBytes([]byte{42}, 32)), call["output"])
+	r.Contains(call, "gas")
+	r.Contains(call, "gasUsed")
+}
+
+func TestPrestateTracer(t *testing.T) {
+	r := require.New(t)
+	tracer, err := NewTracer(PrestateTracer, nil)
+	r.NoError(err)
+	trace := traceCall(r, tracer)
+	r.Len(trace, 2)
+	caller := trace[hexutil.Encode(_tracerCaller.Bytes())].(map[string]interface{})
+	r.Equal(hexutil.Encode(_tracerCallerCode), caller["code"])
+	r.Equal(map[string]interface{}{common.BigToHash(common.Big1).Hex(): common.Hash{}.Hex()}, caller["storage"])
+	callee := trace[hexutil.Encode(_tracerCallee.Bytes())].(map[string]interface{})
+	r.Equal(hexutil.Encode(_tracerCalleeCode), callee["code"])
+	r.Equal("0x0", callee["balance"])
+
+	// the sender and the recipient are captured before the security deposit, the nonce increment and the transfer,
+	// even if the recipient has no code
+	sender := common.BytesToAddress([]byte("sender"))
+	recipient := common.BytesToAddress([]byte("recipient"))
+	for _, to := range []common.Address{_tracerCallee, recipient} {
+		sdb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		r.NoError(err)
+		sdb.SetCode(_tracerCallee, _tracerCalleeCode)
+		sdb.AddBalance(sender, big.NewInt(1000))
+		sdb.SetNonce(sender, 3)
+		tracer, err := NewTracer(PrestateTracer, nil)
+		r.NoError(err)
+		tracer.(txStartCapturer).captureTxStart(sdb, sender, &to)
+		// the security deposit and the nonce increment done by executeInEVM
+		sdb.SubBalance(sender, big.NewInt(300))
+		sdb.SetNonce(sender, 4)
+		_, _, err = runtime.Call(to, nil, &runtime.Config{
+			State:     sdb,
+			Origin:    sender,
+			Value:     big.NewInt(100),
+			EVMConfig: vm.Config{Debug: true, Tracer: tracer},
+		})
+		r.NoError(err)
+		r.Equal(big.NewInt(600), sdb.GetBalance(sender))
+
+		res, err := tracer.GetResult()
+		r.NoError(err)
+		var trace map[string]interface{}
+		r.NoError(json.Unmarshal(res, &trace))
+		r.Len(trace, 2)
+		from := trace[hexutil.Encode(sender.Bytes())].(map[string]interface{})
+		r.Equal("0x3e8", from["balance"])
+		r.Equal(float64(3), from["nonce"])
+		r.Equal("0x0", trace[hexutil.Encode(to.Bytes())].(map[string]interface{})["balance"])
+	}
+}
+
+func TestNewTracer(t *testing.T) {
+	r := require.New(t)
+	_, err := NewTracer("jsTracer", nil)
+	r.Equal(ErrUnknownTracer, errors.Cause(err))
+
+	ctx := context.Background()
+	_, ok := GetTracerCtx(ctx)
+	r.False(ok)
+	tracer, err := NewTracer(CallTracer, nil)
+	r.NoError(err)
+	got, ok := GetTracerCtx(WithTracerCtx(ctx, tracer))
+	r.True(ok)
+	r.Equal(tracer, got)
+}
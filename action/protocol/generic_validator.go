@@ -23,19 +23,41 @@ type (
 	GenericValidator struct {
 		accountState AccountState
 		sr           StateReader
+		// ethTxHeight is the height starting which the actions signed as Ethereum transactions are accepted
+		ethTxHeight  uint64
+		evmNetworkID uint32
 	}
+	// GenericValidatorOption sets an option of the generic validator
+	GenericValidatorOption func(*GenericValidator)
 )
 
+// EthTxOption accepts the actions signed as Ethereum transactions on the EVM network, starting at the height
+func EthTxOption(height uint64, evmNetworkID uint32) GenericValidatorOption {
+	return func(v *GenericValidator) {
+		v.ethTxHeight = height
+		v.evmNetworkID = evmNetworkID
+	}
+}
+
 // NewGenericValidator constructs a new genericValidator
-func NewGenericValidator(sr StateReader, accountState AccountState) *GenericValidator {
-	return &GenericValidator{
+func NewGenericValidator(sr StateReader, accountState AccountState, opts ...GenericValidatorOption) *GenericValidator {
+	v := &GenericValidator{
 		sr:           sr,
 		accountState: accountState,
 	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
 // Validate validates a generic action
 func (v *GenericValidator) Validate(ctx context.Context, selp action.SealedEnvelope) error {
+	if selp.Encoding() == action.EthereumRLP {
+		if err := v.validateEthTx(ctx, selp); err != nil {
+			return err
+		}
+	}
 	// Verify action using action sender's public key
 	if err := action.Verify(selp); err != nil {
 		return errors.Wrap(err, "failed to verify action signature")
@@ -56,3 +78,28 @@ func (v *GenericValidator) Validate(ctx context.Context, selp action.SealedEnvel
 	}
 	return selp.Action().SanityCheck()
 }
+
+// validateEthTx rejects the action signed as an Ethereum transaction before the height or on another EVM network. The
+// height is the one of the block validated, or the next block for actions entering actpool
+func (v *GenericValidator) validateEthTx(ctx context.Context, selp action.SealedEnvelope) error {
+	if v.evmNetworkID == 0 {
+		return errors.Wrap(action.ErrAction, "actions signed as Ethereum transactions are not supported")
+	}
+	if selp.EVMNetworkID() != v.evmNetworkID {
+		return errors.Wrapf(action.ErrAction, "invalid EVM network ID %d", selp.EVMNetworkID())
+	}
+	var height uint64
+	if blkCtx, ok := GetBlockCtx(ctx); ok {
+		height = blkCtx.BlockHeight
+	} else {
+		tip, err := v.sr.Height()
+		if err != nil {
+			return err
+		}
+		height = tip + 1
+	}
+	if height < v.ethTxHeight {
+		return errors.Wrapf(action.ErrAction, "actions signed as Ethereum transactions are not accepted before height %d", v.ethTxHeight)
+	}
+	return nil
+}
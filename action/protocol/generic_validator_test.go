@@ -148,4 +148,29 @@ func TestActionProtoAndGenericValidator(t *testing.T) {
 		selp := action.FakeSeal(elp, identityset.PrivateKey(27).PublicKey())
 		require.True(strings.Contains(valid.Validate(ctx, selp).Error(), "failed to verify action signature"))
 	})
+	t.Run("Ethereum transaction", func(t *testing.T) {
+		v, err := action.NewTransfer(0, big.NewInt(10), caller.String(), nil, uint64(100000), big.NewInt(10))
+		require.NoError(err)
+		bd := &action.EnvelopeBuilder{}
+		elp := bd.SetGasPrice(big.NewInt(10)).
+			SetGasLimit(uint64(100000)).
+			SetAction(v).Build()
+		selp, err := action.SignEthTx(elp, 4689, identityset.PrivateKey(28))
+		require.NoError(err)
+		nselp := action.SealedEnvelope{}
+		require.NoError(nselp.LoadProto(selp.Proto()))
+		accountState := func(StateReader, string) (*state.Account, error) {
+			return &state.Account{Nonce: 2}, nil
+		}
+		// not supported
+		err = NewGenericValidator(nil, accountState).Validate(ctx, nselp)
+		require.True(strings.Contains(err.Error(), "are not supported"))
+		// another EVM network
+		err = NewGenericValidator(nil, accountState, EthTxOption(1, 1)).Validate(ctx, nselp)
+		require.True(strings.Contains(err.Error(), "invalid EVM network ID"))
+		// before the height
+		err = NewGenericValidator(nil, accountState, EthTxOption(2, 4689)).Validate(ctx, nselp)
+		require.True(strings.Contains(err.Error(), "not accepted before height 2"))
+		require.NoError(NewGenericValidator(nil, accountState, EthTxOption(1, 4689)).Validate(ctx, nselp))
+	})
 }
@@ -159,11 +159,11 @@ func (cc *consortiumCommittee) CreatePostSystemActions(ctx context.Context, sr p
 }
 
 func (cc *consortiumCommittee) Handle(ctx context.Context, act action.Action, sm protocol.StateManager) (*action.Receipt, error) {
-	return handle(ctx, act, sm, cc.indexer, cc.addr.String())
+	return handle(ctx, act, sm, cc.indexer, nil, cc.addr.String())
 }
 
 func (cc *consortiumCommittee) Validate(ctx context.Context, act action.Action, sr protocol.StateReader) error {
-	return validate(ctx, sr, cc, act)
+	return validate(ctx, sr, cc, nil, act)
 }
 
 func (cc *consortiumCommittee) ReadState(
@@ -0,0 +1,274 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package poll
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	evidencePrefix = "Evidence."
+	// delegatesPrefix is the prefix of the key of the delegates of an epoch, which the endorser of the evidence has to be
+	delegatesPrefix = "Delegates."
+	// doubleSignerKey is the key of the delegates proved to double sign in the current epoch
+	doubleSignerKey = "DoubleSigners."
+)
+
+var (
+	// ErrEvidenceNotSupported is an error that the evidence cannot be submitted to the poll protocol
+	ErrEvidenceNotSupported = errors.New("evidence is not supported")
+	// ErrEvidenceSubmitted is an error that the evidence of the offence has been submitted
+	ErrEvidenceSubmitted = errors.New("evidence of the offence has been submitted")
+)
+
+// validateEvidence validates the evidence submitted, which has to prove an offence not submitted before
+func (sh *Slasher) validateEvidence(
+	ctx context.Context,
+	sr protocol.StateReader,
+	act *action.SubmitEvidence,
+) (*evidence.Evidence, error) {
+	if sh == nil {
+		return nil, ErrEvidenceNotSupported
+	}
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	if sh.hu.IsPre(config.Iceland, blkCtx.BlockHeight) {
+		return nil, errors.Wrap(ErrEvidenceNotSupported, "there is no evidence before Iceland")
+	}
+	if len(act.Evidence()) == 0 {
+		return nil, action.ErrEmptyEvidence
+	}
+	e := &evidence.Evidence{}
+	if err := e.Deserialize(act.Evidence()); err != nil {
+		return nil, err
+	}
+	if e.Timestamp().After(blkCtx.BlockTimeStamp) || e.Height() > blkCtx.BlockHeight {
+		return nil, errors.Wrap(evidence.ErrInvalidEvidence, "offence is after the block")
+	}
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	epochNum := rp.GetEpochNum(e.Height())
+	if epochNum+sh.evidenceMaxAge < rp.GetEpochNum(blkCtx.BlockHeight) {
+		return nil, errors.Wrapf(evidence.ErrInvalidEvidence, "offence in epoch %d is too old", epochNum)
+	}
+	addr, err := e.EndorserAddress()
+	if err != nil {
+		return nil, err
+	}
+	delegates, err := getDelegates(sr, epochNum)
+	switch errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		return nil, errors.Wrapf(evidence.ErrInvalidEvidence, "no delegates of epoch %d", epochNum)
+	default:
+		return nil, err
+	}
+	isDelegate := false
+	for _, d := range delegates {
+		if d.Address == addr.String() {
+			isDelegate = true
+			break
+		}
+	}
+	if !isDelegate {
+		return nil, errors.Wrapf(evidence.ErrInvalidEvidence, "endorser %s is not a delegate of epoch %d", addr, epochNum)
+	}
+	key := evidenceKey(e)
+	_, err = sr.State(&evidence.Evidence{}, protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace))
+	switch errors.Cause(err) {
+	case nil:
+		return nil, ErrEvidenceSubmitted
+	case state.ErrStateNotExist:
+		return e, nil
+	default:
+		return nil, errors.Wrap(err, "failed to read evidence")
+	}
+}
+
+// handleEvidence records the endorser proved to double sign by the evidence, who is put on probation along with the
+// unproductive delegates at the end of the epoch
+func (sh *Slasher) handleEvidence(
+	ctx context.Context,
+	act *action.SubmitEvidence,
+	sm protocol.StateManager,
+	protocolAddr string,
+) (*action.Receipt, error) {
+	e, err := sh.validateEvidence(ctx, sm, act)
+	if err != nil {
+		switch cause := errors.Cause(err); cause {
+		case evidence.ErrInvalidEvidence, ErrEvidenceSubmitted:
+			zap.L().Debug("Reject evidence", zap.Error(err))
+			return sh.settleEvidence(ctx, sm, uint64(iotextypes.ReceiptStatus_Failure), protocolAddr)
+		default:
+			return nil, err
+		}
+	}
+	addr, err := e.EndorserAddress()
+	if err != nil {
+		return nil, err
+	}
+	key := evidenceKey(e)
+	if _, err := sm.PutState(e, protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to put evidence")
+	}
+	doubleSigners, err := getDoubleSigners(sm)
+	if err != nil {
+		return nil, err
+	}
+	doubleSigners.ProbationInfo[addr.String()]++
+	if err := setDoubleSigners(sm, doubleSigners); err != nil {
+		return nil, errors.Wrap(err, "failed to put double signers")
+	}
+	zap.L().Debug(
+		"Handle SubmitEvidence Action",
+		zap.String("endorser", addr.String()),
+		zap.Uint64("height", e.Height()),
+		zap.String("topic", e.Topic()),
+	)
+	return sh.settleEvidence(ctx, sm, uint64(iotextypes.ReceiptStatus_Success), protocolAddr)
+}
+
+// settleEvidence charges the gas of the submitEvidence action, and updates the nonce of the caller
+func (sh *Slasher) settleEvidence(
+	ctx context.Context,
+	sm protocol.StateManager,
+	status uint64,
+	protocolAddr string,
+) (*action.Receipt, error) {
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	gasFee := big.NewInt(0).Mul(actionCtx.GasPrice, big.NewInt(0).SetUint64(actionCtx.IntrinsicGas))
+	depositLog, err := sh.depositGas(ctx, sm, gasFee)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deposit gas")
+	}
+	acc, err := accountutil.LoadAccount(sm, hash.BytesToHash160(actionCtx.Caller.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	if actionCtx.Nonce > acc.Nonce {
+		acc.Nonce = actionCtx.Nonce
+	}
+	if err := accountutil.StoreAccount(sm, actionCtx.Caller, acc); err != nil {
+		return nil, errors.Wrap(err, "failed to update nonce")
+	}
+	r := action.Receipt{
+		Status:          status,
+		BlockHeight:     blkCtx.BlockHeight,
+		ActionHash:      actionCtx.ActionHash,
+		GasConsumed:     actionCtx.IntrinsicGas,
+		ContractAddress: protocolAddr,
+	}
+	r.AddTransactionLogs(depositLog)
+	return &r, nil
+}
+
+// mergeDoubleSigners adds the delegates proved to double sign in the current epoch to the unqualified delegates, and
+// clears the record for the next epoch
+func mergeDoubleSigners(sm protocol.StateManager, unqualified []string) ([]string, error) {
+	doubleSigners, err := getDoubleSigners(sm)
+	if err != nil {
+		return nil, err
+	}
+	if len(doubleSigners.ProbationInfo) == 0 {
+		return unqualified, nil
+	}
+	exists := make(map[string]bool, len(unqualified))
+	for _, addr := range unqualified {
+		exists[addr] = true
+	}
+	addrs := make([]string, 0, len(doubleSigners.ProbationInfo))
+	for addr := range doubleSigners.ProbationInfo {
+		if !exists[addr] {
+			addrs = append(addrs, addr)
+		}
+	}
+	sort.Strings(addrs)
+	key := candidatesutil.ConstructKey(doubleSignerKey)
+	if _, err := sm.DelState(protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to clear double signers")
+	}
+	return append(unqualified, addrs...), nil
+}
+
+func getDoubleSigners(sr protocol.StateReader) (*vote.ProbationList, error) {
+	doubleSigners := vote.NewProbationList(0)
+	key := candidatesutil.ConstructKey(doubleSignerKey)
+	_, err := sr.State(doubleSigners, protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace))
+	switch errors.Cause(err) {
+	case nil, state.ErrStateNotExist:
+		return doubleSigners, nil
+	default:
+		return nil, errors.Wrap(err, "failed to read double signers")
+	}
+}
+
+func setDoubleSigners(sm protocol.StateManager, doubleSigners *vote.ProbationList) error {
+	key := candidatesutil.ConstructKey(doubleSignerKey)
+	_, err := sm.PutState(doubleSigners, protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace))
+	return err
+}
+
+// recordDelegates records the delegates of the epoch, and removes the record too old for any evidence to be validated
+// against
+func (sh *Slasher) recordDelegates(sm protocol.StateManager, epochNum uint64, delegates state.CandidateList) error {
+	key := delegatesKey(epochNum)
+	if _, err := sm.PutState(&delegates, protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace)); err != nil {
+		return errors.Wrap(err, "failed to put delegates")
+	}
+	if epochNum <= sh.evidenceMaxAge+1 {
+		return nil
+	}
+	key = delegatesKey(epochNum - sh.evidenceMaxAge - 1)
+	switch _, err := getDelegates(sm, epochNum-sh.evidenceMaxAge-1); errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		return nil
+	default:
+		return err
+	}
+	if _, err := sm.DelState(protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace)); err != nil {
+		return errors.Wrap(err, "failed to delete delegates")
+	}
+	return nil
+}
+
+func getDelegates(sr protocol.StateReader, epochNum uint64) (state.CandidateList, error) {
+	var delegates state.CandidateList
+	key := delegatesKey(epochNum)
+	if _, err := sr.State(&delegates, protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace)); err != nil {
+		return nil, err
+	}
+	return delegates, nil
+}
+
+// delegatesKey returns the key of the delegates of the epoch
+func delegatesKey(epochNum uint64) hash.Hash256 {
+	return hash.Hash256b(append([]byte(delegatesPrefix), byteutil.Uint64ToBytesBigEndian(epochNum)...))
+}
+
+// evidenceKey returns the key of the evidence submitted, which identifies the offence regardless of the blocks signed
+func evidenceKey(e *evidence.Evidence) hash.Hash256 {
+	offence := e.Offence()
+	return hash.Hash256b(append([]byte(evidencePrefix), offence[:]...))
+}
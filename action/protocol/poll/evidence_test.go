@@ -0,0 +1,200 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package poll
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
+	"github.com/iotexproject/iotex-core/consensus/evidence/evidencepb"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/signer"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+type voteDoc []byte
+
+func (d voteDoc) Hash() ([]byte, error) { return d, nil }
+
+func signedVote(
+	t *testing.T,
+	sk crypto.PrivateKey,
+	blkHash []byte,
+	ts time.Time,
+) *iotextypes.ConsensusMessage {
+	vote := &iotextypes.ConsensusVote{
+		BlockHash: blkHash,
+		Topic:     iotextypes.ConsensusVote_COMMIT,
+	}
+	ser, err := proto.Marshal(vote)
+	require.NoError(t, err)
+	h := blake2b.Sum256(ser)
+	en, err := endorsement.Endorse(signer.NewLocalSigner(sk), voteDoc(h[:]), ts)
+	require.NoError(t, err)
+	enPb, err := en.Proto()
+	require.NoError(t, err)
+	return &iotextypes.ConsensusMessage{
+		Height:      10,
+		Endorsement: enPb,
+		Msg:         &iotextypes.ConsensusMessage_Vote{Vote: vote},
+	}
+}
+
+func submitEvidence(t *testing.T, first, second *iotextypes.ConsensusMessage) *action.SubmitEvidence {
+	ser, err := proto.Marshal(&evidencepb.Evidence{First: first, Second: second})
+	require.NoError(t, err)
+	act, err := action.NewSubmitEvidence(1, 100000, big.NewInt(10), ser)
+	require.NoError(t, err)
+	return act
+}
+
+func TestSlasherEvidence(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, ctx, sm, _, err := initConstruct(ctrl)
+	require.NoError(err)
+	g := config.Default.Genesis
+	g.EasterBlockHeight = 1
+	g.IcelandBlockHeight = 10
+	var deposited *big.Int
+	sh, err := NewSlasher(
+		&g,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		func(_ context.Context, _ protocol.StateManager, amount *big.Int) (*action.TransactionLog, error) {
+			deposited = amount
+			return nil, nil
+		},
+		2,
+		2,
+		g.DardanellesNumSubEpochs,
+		g.ProductivityThreshold,
+		g.ProbationEpochPeriod,
+		g.UnproductiveDelegateMaxCacheSize,
+		g.ProbationIntensityRate,
+	)
+	require.NoError(err)
+
+	ts := time.Unix(1600000000, 0)
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight:    10,
+		BlockTimeStamp: ts.Add(10 * time.Second),
+	})
+	ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{
+		Caller:       identityset.Address(27),
+		GasPrice:     big.NewInt(10),
+		IntrinsicGas: 20000,
+		Nonce:        1,
+	})
+	sk := identityset.PrivateKey(1)
+	blk1 := hash.Hash256b([]byte("block1"))
+	blk2 := hash.Hash256b([]byte("block2"))
+	act := submitEvidence(t, signedVote(t, sk, blk1[:], ts), signedVote(t, sk, blk2[:], ts))
+
+	// evidence is not supported by the protocols without slasher, nor before Iceland
+	var nilSlasher *Slasher
+	r, err := handle(ctx, act, sm, nil, nilSlasher, "poll")
+	require.NoError(err)
+	require.Nil(r)
+	err = validate(ctx, sm, nil, nilSlasher, act)
+	require.Equal(ErrEvidenceNotSupported, errors.Cause(err))
+	preIceland := protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 9, BlockTimeStamp: ts.Add(10 * time.Second)})
+	err = validate(preIceland, sm, nil, sh, act)
+	require.Equal(ErrEvidenceNotSupported, errors.Cause(err))
+	_, err = handle(preIceland, act, sm, nil, sh, "poll")
+	require.Equal(ErrEvidenceNotSupported, errors.Cause(err))
+
+	// the endorser has to be a delegate of the epoch of the offence
+	_, err = sh.validateEvidence(ctx, sm, act)
+	require.Equal(evidence.ErrInvalidEvidence, errors.Cause(err))
+	require.NoError(sh.recordDelegates(sm, 1, state.CandidateList{{Address: identityset.Address(1).String(), Votes: big.NewInt(1)}}))
+	sk2 := identityset.PrivateKey(2)
+	_, err = sh.validateEvidence(ctx, sm, submitEvidence(t, signedVote(t, sk2, blk1[:], ts), signedVote(t, sk2, blk2[:], ts)))
+	require.Equal(evidence.ErrInvalidEvidence, errors.Cause(err))
+
+	// the offence cannot be older than the max age
+	tooLate := protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 61, BlockTimeStamp: ts.Add(time.Hour)})
+	_, err = sh.validateEvidence(tooLate, sm, act)
+	require.Equal(evidence.ErrInvalidEvidence, errors.Cause(err))
+	inTime := protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 60, BlockTimeStamp: ts.Add(time.Hour)})
+	_, err = sh.validateEvidence(inTime, sm, act)
+	require.NoError(err)
+
+	require.NoError(validate(ctx, sm, nil, sh, act))
+	e, err := sh.validateEvidence(ctx, sm, act)
+	require.NoError(err)
+	require.Equal(uint64(10), e.Height())
+
+	r, err = handle(ctx, act, sm, nil, sh, "poll")
+	require.NoError(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_Success), r.Status)
+	require.Equal(uint64(20000), r.GasConsumed)
+	require.Equal(big.NewInt(200000), deposited)
+	doubleSigners, err := getDoubleSigners(sm)
+	require.NoError(err)
+	require.Equal(map[string]uint32{identityset.Address(1).String(): 1}, doubleSigners.ProbationInfo)
+
+	// the same offence cannot be submitted twice, even with the messages swapped
+	_, err = sh.validateEvidence(ctx, sm, submitEvidence(t, signedVote(t, sk, blk2[:], ts), signedVote(t, sk, blk1[:], ts)))
+	require.Equal(ErrEvidenceSubmitted, errors.Cause(err))
+	r, err = sh.handleEvidence(ctx, act, sm, "poll")
+	require.NoError(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_Failure), r.Status)
+
+	// messages on the same block do not prove an offence
+	act = submitEvidence(t, signedVote(t, sk, blk1[:], ts.Add(time.Second)), signedVote(t, sk, blk1[:], ts.Add(time.Second)))
+	_, err = sh.validateEvidence(ctx, sm, act)
+	require.Equal(evidence.ErrInvalidEvidence, errors.Cause(err))
+	r, err = sh.handleEvidence(ctx, act, sm, "poll")
+	require.NoError(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_Failure), r.Status)
+
+	// offence cannot be after the block
+	act = submitEvidence(t, signedVote(t, sk, blk1[:], ts.Add(time.Minute)), signedVote(t, sk, blk2[:], ts.Add(time.Minute)))
+	_, err = sh.validateEvidence(ctx, sm, act)
+	require.Equal(evidence.ErrInvalidEvidence, errors.Cause(err))
+
+	// the delegates are only kept for the epochs within the max age
+	require.NoError(sh.recordDelegates(sm, 2, state.CandidateList{{Address: identityset.Address(1).String(), Votes: big.NewInt(1)}}))
+	_, err = getDelegates(sm, 1)
+	require.NoError(err)
+	require.NoError(sh.recordDelegates(sm, 3, state.CandidateList{{Address: identityset.Address(2).String(), Votes: big.NewInt(1)}}))
+	_, err = getDelegates(sm, 1)
+	require.Equal(state.ErrStateNotExist, errors.Cause(err))
+	delegates, err := getDelegates(sm, 3)
+	require.NoError(err)
+	require.Equal(identityset.Address(2).String(), delegates[0].Address)
+
+	// double signers are merged into the unqualified delegates once
+	unqualified, err := mergeDoubleSigners(sm, []string{identityset.Address(2).String()})
+	require.NoError(err)
+	require.Equal([]string{identityset.Address(2).String(), identityset.Address(1).String()}, unqualified)
+	unqualified, err = mergeDoubleSigners(sm, []string{identityset.Address(2).String()})
+	require.NoError(err)
+	require.Equal([]string{identityset.Address(2).String()}, unqualified)
+}
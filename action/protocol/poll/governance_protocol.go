@@ -108,11 +108,11 @@ func (p *governanceChainCommitteeProtocol) CreatePreStates(ctx context.Context,
 }
 
 func (p *governanceChainCommitteeProtocol) Handle(ctx context.Context, act action.Action, sm protocol.StateManager) (*action.Receipt, error) {
-	return handle(ctx, act, sm, p.indexer, p.addr.String())
+	return handle(ctx, act, sm, p.indexer, p.sh, p.addr.String())
 }
 
 func (p *governanceChainCommitteeProtocol) Validate(ctx context.Context, act action.Action, sr protocol.StateReader) error {
-	return validate(ctx, sr, p, act)
+	return validate(ctx, sr, p, p.sh, act)
 }
 
 func (p *governanceChainCommitteeProtocol) candidatesByGravityChainHeight(height uint64) (state.CandidateList, error) {
@@ -184,6 +184,7 @@ func initConstruct(ctrl *gomock.Controller) (Protocol, context.Context, protocol
 		candidatesutil.ProbationListFromDB,
 		candidatesutil.UnproductiveDelegateFromDB,
 		indexer,
+		nil,
 		2,
 		2,
 		cfg.Genesis.DardanellesNumSubEpochs,
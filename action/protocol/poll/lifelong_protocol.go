@@ -64,14 +64,14 @@ func (p *lifeLongDelegatesProtocol) CreateGenesisStates(
 }
 
 func (p *lifeLongDelegatesProtocol) Handle(ctx context.Context, act action.Action, sm protocol.StateManager) (*action.Receipt, error) {
-	if err := validate(ctx, sm, p, act); err != nil {
+	if err := validate(ctx, sm, p, nil, act); err != nil {
 		return nil, err
 	}
-	return handle(ctx, act, sm, nil, p.addr.String())
+	return handle(ctx, act, sm, nil, nil, p.addr.String())
 }
 
 func (p *lifeLongDelegatesProtocol) Validate(ctx context.Context, act action.Action, sr protocol.StateReader) error {
-	return validate(ctx, sr, p, act)
+	return validate(ctx, sr, p, nil, act)
 }
 
 func (p *lifeLongDelegatesProtocol) CalculateCandidatesByHeight(ctx context.Context, _ protocol.StateReader, _ uint64) (state.CandidateList, error) {
@@ -14,6 +14,7 @@ import (
 	"github.com/iotexproject/iotex-election/committee"
 	"github.com/pkg/errors"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/action/protocol/staking"
@@ -68,6 +69,9 @@ type (
 	// Productivity returns the number of produced blocks per producer
 	Productivity func(uint64, uint64) (map[string]uint64, error)
 
+	// DepositGas deposits gas to some pool
+	DepositGas func(ctx context.Context, sm protocol.StateManager, amount *big.Int) (*action.TransactionLog, error)
+
 	// Protocol defines the protocol of handling votes
 	Protocol interface {
 		protocol.Protocol
@@ -132,6 +136,7 @@ func NewProtocol(
 	getBlockTimeFunc GetBlockTime,
 	productivity Productivity,
 	getBlockHash evm.GetBlockHash,
+	depositGas DepositGas,
 ) (Protocol, error) {
 	genesisConfig := cfg.Genesis
 	if cfg.Consensus.Scheme != config.RollDPoSScheme {
@@ -155,6 +160,7 @@ func NewProtocol(
 			getprobationList,
 			getUnproductiveDelegate,
 			candidateIndexer,
+			depositGas,
 			genesisConfig.NumCandidateDelegates,
 			genesisConfig.NumDelegates,
 			genesisConfig.DardanellesNumSubEpochs,
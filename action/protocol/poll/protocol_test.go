@@ -45,6 +45,7 @@ func TestNewProtocol(t *testing.T) {
 		func(uint64) (hash.Hash256, error) {
 			return hash.ZeroHash256, nil
 		},
+		nil,
 	)
 	require.NoError(err)
 	require.NotNil(p)
@@ -33,6 +33,7 @@ type Slasher struct {
 	getProbationList      GetProbationList
 	getUnprodDelegate     GetUnproductiveDelegate
 	indexer               *CandidateIndexer
+	depositGas            DepositGas
 	numCandidateDelegates uint64
 	numDelegates          uint64
 	numOfBlocksByEpoch    uint64
@@ -40,6 +41,7 @@ type Slasher struct {
 	probationEpochPeriod  uint64
 	maxProbationPeriod    uint64
 	probationIntensity    uint32
+	evidenceMaxAge        uint64
 }
 
 // NewSlasher returns a new Slasher
@@ -50,6 +52,7 @@ func NewSlasher(
 	getProbationList GetProbationList,
 	getUnprodDelegate GetUnproductiveDelegate,
 	indexer *CandidateIndexer,
+	depositGas DepositGas,
 	numCandidateDelegates, numDelegates, dardanellesNumSubEpochs, thres, koPeriod, maxKoPeriod uint64,
 	koIntensity uint32,
 ) (*Slasher, error) {
@@ -60,6 +63,7 @@ func NewSlasher(
 		getProbationList:      getProbationList,
 		getUnprodDelegate:     getUnprodDelegate,
 		indexer:               indexer,
+		depositGas:            depositGas,
 		numCandidateDelegates: numCandidateDelegates,
 		numDelegates:          numDelegates,
 		numOfBlocksByEpoch:    numDelegates * dardanellesNumSubEpochs,
@@ -67,6 +71,7 @@ func NewSlasher(
 		probationEpochPeriod:  koPeriod,
 		maxProbationPeriod:    maxKoPeriod,
 		probationIntensity:    koIntensity,
+		evidenceMaxAge:        gen.EvidenceMaxAgeEpochs,
 	}, nil
 }
 
@@ -120,6 +125,14 @@ func (sh *Slasher) CreatePreStates(ctx context.Context, sm protocol.StateManager
 		if prevHeight != afterHeight {
 			return errors.Wrap(ErrInconsistentHeight, "shifting candidate height is not same as shifting probation height")
 		}
+		if hu.IsPost(config.Iceland, epochStartHeight) {
+			// record the delegates of the epoch, against which the evidence of the offences in the epoch is validated
+			delegates, _, err := sh.GetActiveBlockProducers(ctx, sm, false)
+			if err != nil {
+				return err
+			}
+			return sh.recordDelegates(sm, epochNum, delegates)
+		}
 	}
 	return nil
 }
@@ -433,6 +446,11 @@ func (sh *Slasher) CalculateProbationList(
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to calculate current epoch upd %d", epochNum-1)
 		}
+		if sh.hu.IsPost(config.Iceland, rp.GetEpochHeight(epochNum)) {
+			if uq, err = mergeDoubleSigners(sm, uq); err != nil {
+				return nil, err
+			}
+		}
 		for _, addr := range uq {
 			if _, ok := unqualifiedDelegates[addr]; !ok {
 				unqualifiedDelegates[addr] = 1
@@ -472,6 +490,11 @@ func (sh *Slasher) CalculateProbationList(
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to calculate current epoch upd %d", epochNum-1)
 	}
+	if sh.hu.IsPost(config.Iceland, rp.GetEpochHeight(epochNum)) {
+		if addList, err = mergeDoubleSigners(sm, addList); err != nil {
+			return nil, err
+		}
+	}
 	if err := upd.AddRecentUPD(addList); err != nil {
 		return nil, errors.Wrap(err, "failed to add recent upd")
 	}
@@ -94,8 +94,15 @@ func (sc *stakingCommand) Handle(ctx context.Context, act action.Action, sm prot
 }
 
 func (sc *stakingCommand) Validate(ctx context.Context, act action.Action, sr protocol.StateReader) error {
+	if _, ok := act.(*action.Execution); ok {
+		// the evidence is validated by the slasher shared by v1 and v2
+		if sc.useV2(ctx, sr) {
+			return sc.stakingV2.Validate(ctx, act, sr)
+		}
+		return sc.stakingV1.Validate(ctx, act, sr)
+	}
 	// no height here,  v1 v2 has the same validate method, so directly use common one
-	return validate(ctx, sr, sc, act)
+	return validate(ctx, sr, sc, nil, act)
 }
 
 func (sc *stakingCommand) CalculateCandidatesByHeight(ctx context.Context, sr protocol.StateReader, height uint64) (state.CandidateList, error) {
@@ -190,7 +190,10 @@ func (sc *stakingCommittee) Handle(ctx context.Context, act action.Action, sm pr
 }
 
 func (sc *stakingCommittee) Validate(ctx context.Context, act action.Action, sr protocol.StateReader) error {
-	return validate(ctx, sr, sc, act)
+	if _, ok := act.(*action.Execution); ok {
+		return sc.governanceStaking.Validate(ctx, act, sr)
+	}
+	return validate(ctx, sr, sc, nil, act)
 }
 
 func (sc *stakingCommittee) Name() string {
@@ -108,6 +108,7 @@ func initConstructStakingCommittee(ctrl *gomock.Controller) (Protocol, context.C
 		nil,
 		nil,
 		nil,
+		nil,
 		cfg.Genesis.NumCandidateDelegates,
 		cfg.Genesis.NumDelegates,
 		cfg.Genesis.DardanellesNumSubEpochs,
@@ -50,7 +50,20 @@ func validateDelegates(cs state.CandidateList) error {
 	return nil
 }
 
-func handle(ctx context.Context, act action.Action, sm protocol.StateManager, indexer *CandidateIndexer, protocolAddr string) (*action.Receipt, error) {
+func handle(
+	ctx context.Context,
+	act action.Action,
+	sm protocol.StateManager,
+	indexer *CandidateIndexer,
+	sh *Slasher,
+	protocolAddr string,
+) (*action.Receipt, error) {
+	if se, ok := act.(*action.SubmitEvidence); ok {
+		if sh == nil {
+			return nil, nil
+		}
+		return sh.handleEvidence(ctx, se, sm, protocolAddr)
+	}
 	actionCtx := protocol.MustGetActionCtx(ctx)
 	blkCtx := protocol.MustGetBlockCtx(ctx)
 
@@ -72,7 +85,11 @@ func handle(ctx context.Context, act action.Action, sm protocol.StateManager, in
 	}, nil
 }
 
-func validate(ctx context.Context, sr protocol.StateReader, p Protocol, act action.Action) error {
+func validate(ctx context.Context, sr protocol.StateReader, p Protocol, sh *Slasher, act action.Action) error {
+	if se, ok := act.(*action.SubmitEvidence); ok {
+		_, err := sh.validateEvidence(ctx, sr, se)
+		return err
+	}
 	ppr, ok := act.(*action.PutPollResult)
 	if !ok {
 		return nil
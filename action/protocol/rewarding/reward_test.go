@@ -358,6 +358,7 @@ func TestProtocol_NoRewardAddr(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		2,
 		2,
 		cfg.Genesis.DardanellesNumSubEpochs,
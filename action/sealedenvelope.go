@@ -14,12 +14,19 @@ import (
 type SealedEnvelope struct {
 	Envelope
 
-	srcPubkey crypto.PublicKey
-	signature []byte
+	srcPubkey    crypto.PublicKey
+	signature    []byte
+	encoding     Encoding
+	evmNetworkID uint32
 }
 
-// Hash returns the hash value of SealedEnvelope.
+// Hash returns the hash value of SealedEnvelope, which is the hash of the Ethereum transaction if it is signed as one
 func (sealed *SealedEnvelope) Hash() hash.Hash256 {
+	if sealed.encoding == EthereumRLP {
+		if tx, err := sealed.ethSignedTx(); err == nil {
+			return hash.BytesToHash256(tx.Hash().Bytes())
+		}
+	}
 	return hash.Hash256b(byteutil.Must(proto.Marshal(sealed.Proto())))
 }
 
@@ -33,12 +40,18 @@ func (sealed *SealedEnvelope) Signature() []byte {
 	return sig
 }
 
+// Encoding returns the encoding of the message signed by the sender
+func (sealed *SealedEnvelope) Encoding() Encoding { return sealed.encoding }
+
+// EVMNetworkID returns the EVM network ID of the action signed as an Ethereum transaction
+func (sealed *SealedEnvelope) EVMNetworkID() uint32 { return sealed.evmNetworkID }
+
 // Proto converts it to it's proto scheme.
 func (sealed *SealedEnvelope) Proto() *iotextypes.Action {
 	return &iotextypes.Action{
 		Core:         sealed.Envelope.Proto(),
 		SenderPubKey: sealed.srcPubkey.Bytes(),
-		Signature:    sealed.signature,
+		Signature:    sealed.encodeSignature(),
 	}
 }
 
@@ -57,8 +70,7 @@ func (sealed *SealedEnvelope) LoadProto(pbAct *iotextypes.Action) error {
 	*sealed = SealedEnvelope{}
 
 	sealed.srcPubkey = srcPub
-	sealed.signature = make([]byte, len(pbAct.GetSignature()))
-	copy(sealed.signature, pbAct.GetSignature())
+	sealed.decodeSignature(pbAct.GetSignature())
 	if err := sealed.Envelope.LoadProto(pbAct.GetCore()); err != nil {
 		return err
 	}
@@ -0,0 +1,174 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+const (
+	// SubmitEvidenceFieldNumber is the field number of the submitEvidence action in the action oneof of ActionCore.
+	// The generated iotextypes do not have the field, so the action is encoded as a field unknown to them, which the
+	// nodes not supporting the action fail to load, as any other action they do not know
+	SubmitEvidenceFieldNumber protowire.Number = 60
+
+	// submitEvidenceEvidenceNumber is the field number of the evidence in the submitEvidence message
+	submitEvidenceEvidenceNumber protowire.Number = 1
+)
+
+var (
+	// SubmitEvidenceBaseGas represents the base intrinsic gas for submitEvidence
+	SubmitEvidenceBaseGas = uint64(10000)
+	// SubmitEvidenceGasPerByte represents the submitEvidence payload gas per uint
+	SubmitEvidenceGasPerByte = uint64(100)
+
+	// ErrEmptyEvidence indicates the evidence submitted is empty
+	ErrEmptyEvidence = errors.New("empty evidence")
+)
+
+// SubmitEvidence is the action to submit the evidence of a delegate signing conflicting consensus messages to the
+// poll protocol, which puts the delegate on probation
+type SubmitEvidence struct {
+	AbstractAction
+
+	evidence []byte
+}
+
+// NewSubmitEvidence returns a submitEvidence action
+func NewSubmitEvidence(nonce, gasLimit uint64, gasPrice *big.Int, evidence []byte) (*SubmitEvidence, error) {
+	if len(evidence) == 0 {
+		return nil, ErrEmptyEvidence
+	}
+	return &SubmitEvidence{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		evidence: evidence,
+	}, nil
+}
+
+// Evidence returns the serialized evidence
+func (se *SubmitEvidence) Evidence() []byte { return se.evidence }
+
+// Serialize returns a raw byte stream of a submitEvidence action
+func (se *SubmitEvidence) Serialize() []byte {
+	return protowire.AppendBytes(protowire.AppendTag(nil, submitEvidenceEvidenceNumber, protowire.BytesType), se.evidence)
+}
+
+// LoadProto loads the submitEvidence action from the fields of the action core unknown to the generated iotextypes
+func (se *SubmitEvidence) LoadProto(pbAct *iotextypes.ActionCore) error {
+	if pbAct == nil {
+		return errors.New("empty action proto to load")
+	}
+	msg, ok, err := submitEvidenceField(pbAct)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("no submitEvidence action in action proto")
+	}
+	*se = SubmitEvidence{}
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return errors.Wrap(protowire.ParseError(n), "failed to load submitEvidence")
+		}
+		msg = msg[n:]
+		if num != submitEvidenceEvidenceNumber || typ != protowire.BytesType {
+			return errors.Errorf("unknown field %d in submitEvidence", num)
+		}
+		v, n := protowire.ConsumeBytes(msg)
+		if n < 0 {
+			return errors.Wrap(protowire.ParseError(n), "failed to load evidence")
+		}
+		se.evidence = append([]byte{}, v...)
+		msg = msg[n:]
+	}
+	return nil
+}
+
+// IntrinsicGas returns the intrinsic gas of a submitEvidence action
+func (se *SubmitEvidence) IntrinsicGas() (uint64, error) {
+	dataLen := uint64(len(se.Evidence()))
+	return calculateIntrinsicGas(SubmitEvidenceBaseGas, SubmitEvidenceGasPerByte, dataLen)
+}
+
+// Cost returns the total cost of a submitEvidence action
+func (se *SubmitEvidence) Cost() (*big.Int, error) {
+	intrinsicGas, err := se.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting intrinsic gas for the submitEvidence action")
+	}
+	return big.NewInt(0).Mul(se.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas)), nil
+}
+
+// SanityCheck validates the variables in the action
+func (se *SubmitEvidence) SanityCheck() error {
+	if len(se.Evidence()) == 0 {
+		return ErrEmptyEvidence
+	}
+
+	return se.AbstractAction.SanityCheck()
+}
+
+// SubmitEvidenceBuilder is the struct to build SubmitEvidence
+type SubmitEvidenceBuilder struct {
+	Builder
+	submitEvidence SubmitEvidence
+}
+
+// SetEvidence sets the serialized evidence
+func (b *SubmitEvidenceBuilder) SetEvidence(evidence []byte) *SubmitEvidenceBuilder {
+	b.submitEvidence.evidence = evidence
+	return b
+}
+
+// Build builds a new submitEvidence action
+func (b *SubmitEvidenceBuilder) Build() SubmitEvidence {
+	b.submitEvidence.AbstractAction = b.Builder.Build()
+	return b.submitEvidence
+}
+
+// setSubmitEvidenceField sets the submitEvidence action as the field of the action core
+func setSubmitEvidenceField(pbAct *iotextypes.ActionCore, se *SubmitEvidence) {
+	field := protowire.AppendTag(nil, SubmitEvidenceFieldNumber, protowire.BytesType)
+	field = protowire.AppendBytes(field, se.Serialize())
+	pbAct.ProtoReflect().SetUnknown(field)
+}
+
+// submitEvidenceField returns the submitEvidence message in the action core, which is the only field unknown to the
+// generated iotextypes if present
+func submitEvidenceField(pbAct *iotextypes.ActionCore) ([]byte, bool, error) {
+	unknown := pbAct.ProtoReflect().GetUnknown()
+	if len(unknown) == 0 {
+		return nil, false, nil
+	}
+	num, typ, n := protowire.ConsumeTag(unknown)
+	if n < 0 {
+		return nil, false, errors.Wrap(protowire.ParseError(n), "failed to parse action proto")
+	}
+	if num != SubmitEvidenceFieldNumber || typ != protowire.BytesType {
+		return nil, false, errors.Errorf("unknown field %d in action proto", num)
+	}
+	msg, m := protowire.ConsumeBytes(unknown[n:])
+	if m < 0 {
+		return nil, false, errors.Wrap(protowire.ParseError(m), "failed to parse action proto")
+	}
+	if n+m != len(unknown) {
+		return nil, false, errors.New("more than one action in action proto")
+	}
+	return msg, true, nil
+}
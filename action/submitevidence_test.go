@@ -0,0 +1,73 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestSubmitEvidence(t *testing.T) {
+	require := require.New(t)
+	se, err := NewSubmitEvidence(1, 20000, big.NewInt(10), []byte("evidence"))
+	require.NoError(err)
+	require.NoError(se.SanityCheck())
+	gas, err := se.IntrinsicGas()
+	require.NoError(err)
+	require.Equal(SubmitEvidenceBaseGas+8*SubmitEvidenceGasPerByte, gas)
+	cost, err := se.Cost()
+	require.NoError(err)
+	require.Equal(big.NewInt(0).Mul(big.NewInt(10), big.NewInt(int64(gas))), cost)
+
+	_, err = NewSubmitEvidence(1, 20000, big.NewInt(10), nil)
+	require.Equal(ErrEmptyEvidence, errors.Cause(err))
+	bd := &SubmitEvidenceBuilder{}
+	empty := bd.Build()
+	require.Equal(ErrEmptyEvidence, errors.Cause(empty.SanityCheck()))
+}
+
+func TestSubmitEvidenceProto(t *testing.T) {
+	require := require.New(t)
+	se, err := NewSubmitEvidence(1, 20000, big.NewInt(10), []byte("evidence"))
+	require.NoError(err)
+	elp := (&EnvelopeBuilder{}).SetNonce(1).SetGasLimit(20000).SetGasPrice(big.NewInt(10)).SetAction(se).Build()
+	selp, err := Sign(elp, identityset.PrivateKey(27))
+	require.NoError(err)
+
+	// the signed action survives the round trip through the wire
+	ser, err := proto.Marshal(selp.Proto())
+	require.NoError(err)
+	pb := &iotextypes.Action{}
+	require.NoError(proto.Unmarshal(ser, pb))
+	selp2 := SealedEnvelope{}
+	require.NoError(selp2.LoadProto(pb))
+	require.Equal(selp.Hash(), selp2.Hash())
+	require.NoError(Verify(selp2))
+	se2, ok := selp2.Action().(*SubmitEvidence)
+	require.True(ok)
+	require.Equal([]byte("evidence"), se2.Evidence())
+
+	// the action is not loaded along with another action, nor from other unknown fields
+	core := elp.Proto()
+	core.Action = &iotextypes.ActionCore_Transfer{Transfer: &iotextypes.Transfer{Amount: "1", Recipient: identityset.Address(28).String()}}
+	elp2 := &Envelope{}
+	require.NoError(elp2.LoadProto(core))
+	_, ok = elp2.Action().(*Transfer)
+	require.True(ok)
+	core = &iotextypes.ActionCore{Nonce: 1}
+	field := protowire.AppendTag(nil, SubmitEvidenceFieldNumber+1, protowire.BytesType)
+	core.ProtoReflect().SetUnknown(protowire.AppendBytes(field, se.Serialize()))
+	require.Error(elp2.LoadProto(core))
+}
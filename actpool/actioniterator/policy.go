@@ -0,0 +1,261 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actioniterator
+
+import (
+	"container/heap"
+	"math"
+	"math/big"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+)
+
+const (
+	// GasPricePolicy packs the head action with the highest gas price among all accounts first
+	GasPricePolicy = "gasPrice"
+	// FeePerGasPolicy packs the account whose next actions pay the highest effective fee per gas first, and caps the
+	// number of actions of each sender in a block
+	FeePerGasPolicy = "feePerGas"
+	// FirstSeenPolicy packs the actions in the order they arrive in actpool
+	FirstSeenPolicy = "firstSeen"
+
+	// _feePerGasLookahead is the number of actions of an account taken into account for its effective fee per gas
+	_feePerGasLookahead = 16
+)
+
+type (
+	// Pool is the source of the pending actions to pack
+	Pool interface {
+		// PendingActionMap returns the pending actions of each account, sorted by nonce
+		PendingActionMap() map[string][]action.SealedEnvelope
+		// ArrivalSequence returns the sequence number of an action in the order actions arrive in pool
+		ArrivalSequence(hash.Hash256) (uint64, bool)
+	}
+
+	// Policy decides the order in which the pending actions are packed into a block
+	Policy interface {
+		NewIterator(Pool) ActionIterator
+		// ReservedGas returns the gas of a block kept for the system actions, which the pending actions cannot use
+		ReservedGas() uint64
+	}
+
+	reservation struct {
+		reservedGas uint64
+	}
+
+	gasPricePolicy struct {
+		reservation
+	}
+
+	feePerGasPolicy struct {
+		reservation
+		maxActsPerSender uint64
+	}
+
+	firstSeenPolicy struct {
+		reservation
+	}
+)
+
+// NewPolicy returns the packing policy set in actpool config
+func NewPolicy(cfg config.ActPool) (Policy, error) {
+	r := reservation{reservedGas: cfg.ReservedSystemActionGas}
+	switch cfg.PackingPolicy {
+	case "", GasPricePolicy:
+		return &gasPricePolicy{reservation: r}, nil
+	case FeePerGasPolicy:
+		return &feePerGasPolicy{reservation: r, maxActsPerSender: cfg.MaxActsPerSenderInBlock}, nil
+	case FirstSeenPolicy:
+		return &firstSeenPolicy{reservation: r}, nil
+	default:
+		return nil, errors.Errorf("unknown packing policy %s", cfg.PackingPolicy)
+	}
+}
+
+func (r reservation) ReservedGas() uint64 {
+	return r.reservedGas
+}
+
+func (p *gasPricePolicy) NewIterator(pool Pool) ActionIterator {
+	return NewActionIterator(pool.PendingActionMap())
+}
+
+func (p *feePerGasPolicy) NewIterator(pool Pool) ActionIterator {
+	return newPriorityIterator(
+		pool.PendingActionMap(),
+		func(c *accountCursor) bool {
+			if p.maxActsPerSender > 0 && c.packed >= p.maxActsPerSender {
+				return false
+			}
+			lookahead := len(c.acts)
+			if lookahead > _feePerGasLookahead {
+				lookahead = _feePerGasLookahead
+			}
+			if p.maxActsPerSender > 0 && uint64(lookahead) > p.maxActsPerSender-c.packed {
+				lookahead = int(p.maxActsPerSender - c.packed)
+			}
+			c.score = effectiveFeePerGas(c.acts[:lookahead])
+			return true
+		},
+		func(a, b *accountCursor) bool {
+			if cmp := a.score.Cmp(b.score); cmp != 0 {
+				return cmp > 0
+			}
+			return a.sender < b.sender
+		},
+	)
+}
+
+func (p *firstSeenPolicy) NewIterator(pool Pool) ActionIterator {
+	return newPriorityIterator(
+		pool.PendingActionMap(),
+		func(c *accountCursor) bool {
+			seq, ok := pool.ArrivalSequence(c.acts[0].Hash())
+			if !ok {
+				// the action has left pool, pack it last
+				seq = math.MaxUint64
+			}
+			c.seq = seq
+			return true
+		},
+		func(a, b *accountCursor) bool {
+			if a.seq != b.seq {
+				return a.seq < b.seq
+			}
+			return a.sender < b.sender
+		},
+	)
+}
+
+// effectiveFeePerGas returns the highest average gas price weighted by gas limit among the prefixes of the actions,
+// so that a cheap head action is packed early if the actions following it pay well
+func effectiveFeePerGas(acts []action.SealedEnvelope) *big.Rat {
+	var (
+		best     *big.Rat
+		totalFee = new(big.Int)
+		totalGas uint64
+	)
+	for _, act := range acts {
+		totalFee.Add(totalFee, new(big.Int).Mul(act.GasPrice(), new(big.Int).SetUint64(act.GasLimit())))
+		totalGas += act.GasLimit()
+		score := new(big.Rat).SetInt(act.GasPrice())
+		if totalGas > 0 {
+			score.SetFrac(totalFee, new(big.Int).SetUint64(totalGas))
+		}
+		if best == nil || score.Cmp(best) > 0 {
+			best = score
+		}
+	}
+	return best
+}
+
+// accountCursor points to the next action of an account to pack
+type accountCursor struct {
+	sender string
+	acts   []action.SealedEnvelope
+	packed uint64
+	index  int
+	score  *big.Rat
+	seq    uint64
+}
+
+type cursorHeap struct {
+	cursors []*accountCursor
+	less    func(a, b *accountCursor) bool
+}
+
+func (h *cursorHeap) Len() int           { return len(h.cursors) }
+func (h *cursorHeap) Less(i, j int) bool { return h.less(h.cursors[i], h.cursors[j]) }
+func (h *cursorHeap) Swap(i, j int) {
+	h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i]
+	h.cursors[i].index = i
+	h.cursors[j].index = j
+}
+
+func (h *cursorHeap) Push(x interface{}) {
+	c := x.(*accountCursor)
+	c.index = len(h.cursors)
+	h.cursors = append(h.cursors, c)
+}
+
+func (h *cursorHeap) Pop() interface{} {
+	old := h.cursors
+	n := len(old)
+	c := old[n-1]
+	c.index = -1
+	h.cursors = old[0 : n-1]
+	return c
+}
+
+// priorityIterator iterates the actions of the account with the highest priority first. load refreshes the priority
+// of an account once its head action changes, and returns false if no more action of the account should be packed
+type priorityIterator struct {
+	heads cursorHeap
+	load  func(*accountCursor) bool
+	last  *accountCursor
+}
+
+func newPriorityIterator(
+	accountActs map[string][]action.SealedEnvelope,
+	load func(*accountCursor) bool,
+	less func(a, b *accountCursor) bool,
+) ActionIterator {
+	ai := &priorityIterator{
+		heads: cursorHeap{
+			cursors: make([]*accountCursor, 0, len(accountActs)),
+			less:    less,
+		},
+		load: load,
+	}
+	for sender, acts := range accountActs {
+		if len(acts) == 0 {
+			continue
+		}
+		c := &accountCursor{sender: sender, acts: acts}
+		if !load(c) {
+			continue
+		}
+		c.index = len(ai.heads.cursors)
+		ai.heads.cursors = append(ai.heads.cursors, c)
+	}
+	heap.Init(&ai.heads)
+	return ai
+}
+
+// Next returns the head action of the account with the highest priority
+func (ai *priorityIterator) Next() (action.SealedEnvelope, bool) {
+	if ai.heads.Len() == 0 {
+		ai.last = nil
+		return action.SealedEnvelope{}, false
+	}
+	c := ai.heads.cursors[0]
+	act := c.acts[0]
+	c.acts = c.acts[1:]
+	c.packed++
+	if len(c.acts) == 0 || !ai.load(c) {
+		heap.Pop(&ai.heads)
+	} else {
+		heap.Fix(&ai.heads, 0)
+	}
+	ai.last = c
+	return act, true
+}
+
+// PopAccount removes the remaining actions of the account of the last action returned by Next
+func (ai *priorityIterator) PopAccount() {
+	if ai.last == nil {
+		return
+	}
+	if ai.last.index >= 0 {
+		heap.Remove(&ai.heads, ai.last.index)
+	}
+	ai.last = nil
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actioniterator
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+type testPool struct {
+	accountActs map[string][]action.SealedEnvelope
+	arrivals    map[hash.Hash256]uint64
+}
+
+func (p *testPool) PendingActionMap() map[string][]action.SealedEnvelope {
+	accountActs := make(map[string][]action.SealedEnvelope, len(p.accountActs))
+	for sender, acts := range p.accountActs {
+		accountActs[sender] = append([]action.SealedEnvelope{}, acts...)
+	}
+	return accountActs
+}
+
+func (p *testPool) ArrivalSequence(h hash.Hash256) (uint64, bool) {
+	seq, ok := p.arrivals[h]
+	return seq, ok
+}
+
+func (p *testPool) add(sender string, act action.SealedEnvelope) {
+	p.arrivals[act.Hash()] = uint64(len(p.arrivals))
+	p.accountActs[sender] = append(p.accountActs[sender], act)
+}
+
+func newTestPool() *testPool {
+	return &testPool{
+		accountActs: make(map[string][]action.SealedEnvelope),
+		arrivals:    make(map[hash.Hash256]uint64),
+	}
+}
+
+func signedTransfer(t require.TestingT, priKey crypto.PrivateKey, nonce uint64, gasLimit uint64, gasPrice int64) action.SealedEnvelope {
+	tsf, err := action.NewTransfer(nonce, big.NewInt(100), identityset.Address(1).String(), nil, gasLimit, big.NewInt(gasPrice))
+	require.NoError(t, err)
+	bd := &action.EnvelopeBuilder{}
+	elp := bd.SetNonce(nonce).
+		SetGasLimit(gasLimit).
+		SetGasPrice(big.NewInt(gasPrice)).
+		SetAction(tsf).Build()
+	selp, err := action.Sign(elp, priKey)
+	require.NoError(t, err)
+	return selp
+}
+
+func iterateAll(ai ActionIterator) []action.SealedEnvelope {
+	acts := make([]action.SealedEnvelope, 0)
+	for {
+		act, ok := ai.Next()
+		if !ok {
+			return acts
+		}
+		acts = append(acts, act)
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default.ActPool
+	cfg.ReservedSystemActionGas = 100000
+	for _, name := range []string{"", GasPricePolicy, FeePerGasPolicy, FirstSeenPolicy} {
+		cfg.PackingPolicy = name
+		p, err := NewPolicy(cfg)
+		require.NoError(err)
+		require.NotNil(p)
+		require.Equal(cfg.ReservedSystemActionGas, p.ReservedGas())
+	}
+	cfg.PackingPolicy = "unknown"
+	_, err := NewPolicy(cfg)
+	require.Error(err)
+}
+
+func TestFeePerGasPolicy(t *testing.T) {
+	require := require.New(t)
+
+	a := identityset.Address(28).String()
+	priKeyA := identityset.PrivateKey(28)
+	b := identityset.Address(29).String()
+	priKeyB := identityset.PrivateKey(29)
+	pool := newTestPool()
+	// a cheap head action followed by an expensive one pays more on average than b
+	selpA1 := signedTransfer(t, priKeyA, 1, 10000, 1)
+	selpA2 := signedTransfer(t, priKeyA, 2, 10000, 100)
+	selpA3 := signedTransfer(t, priKeyA, 3, 10000, 2)
+	selpB1 := signedTransfer(t, priKeyB, 1, 10000, 30)
+	selpB2 := signedTransfer(t, priKeyB, 2, 10000, 20)
+	pool.add(a, selpA1)
+	pool.add(a, selpA2)
+	pool.add(a, selpA3)
+	pool.add(b, selpB1)
+	pool.add(b, selpB2)
+
+	p, err := NewPolicy(config.ActPool{PackingPolicy: FeePerGasPolicy})
+	require.NoError(err)
+	require.Equal([]action.SealedEnvelope{selpA1, selpA2, selpB1, selpB2, selpA3}, iterateAll(p.NewIterator(pool)))
+
+	// the actions beyond the cap of a sender do not count for its fee per gas
+	p, err = NewPolicy(config.ActPool{PackingPolicy: FeePerGasPolicy, MaxActsPerSenderInBlock: 1})
+	require.NoError(err)
+	require.Equal([]action.SealedEnvelope{selpB1, selpA1}, iterateAll(p.NewIterator(pool)))
+
+	// pop the account of the last action
+	p, err = NewPolicy(config.ActPool{PackingPolicy: FeePerGasPolicy})
+	require.NoError(err)
+	ai := p.NewIterator(pool)
+	act, ok := ai.Next()
+	require.True(ok)
+	require.Equal(selpA1, act)
+	ai.PopAccount()
+	require.Equal([]action.SealedEnvelope{selpB1, selpB2}, iterateAll(ai))
+}
+
+func TestFirstSeenPolicy(t *testing.T) {
+	require := require.New(t)
+
+	a := identityset.Address(28).String()
+	priKeyA := identityset.PrivateKey(28)
+	b := identityset.Address(29).String()
+	priKeyB := identityset.PrivateKey(29)
+	pool := newTestPool()
+	selpB1 := signedTransfer(t, priKeyB, 1, 10000, 1)
+	selpA1 := signedTransfer(t, priKeyA, 1, 10000, 100)
+	selpB2 := signedTransfer(t, priKeyB, 2, 10000, 1)
+	selpA2 := signedTransfer(t, priKeyA, 2, 10000, 100)
+	pool.add(b, selpB1)
+	pool.add(a, selpA1)
+	pool.add(b, selpB2)
+	pool.add(a, selpA2)
+
+	p, err := NewPolicy(config.ActPool{PackingPolicy: FirstSeenPolicy})
+	require.NoError(err)
+	require.Equal([]action.SealedEnvelope{selpB1, selpA1, selpB2, selpA2}, iterateAll(p.NewIterator(pool)))
+
+	// gas price policy ignores arrival
+	p, err = NewPolicy(config.ActPool{PackingPolicy: GasPricePolicy})
+	require.NoError(err)
+	require.Equal([]action.SealedEnvelope{selpA1, selpA2, selpB1, selpB2}, iterateAll(p.NewIterator(pool)))
+}
+
+// BenchmarkPolicies packs a block out of the same pool with each policy, and reports the fee paid by the block
+func BenchmarkPolicies(b *testing.B) {
+	const (
+		numAccounts   = 200
+		actsPerAcct   = 10
+		blockGasLimit = 500 * 10000
+	)
+	r := rand.New(rand.NewSource(0))
+	pool := newTestPool()
+	// actions interleave across accounts in arrival
+	keys := make([]crypto.PrivateKey, numAccounts)
+	for i := range keys {
+		priKey, err := crypto.HexStringToPrivateKey(fmt.Sprintf("1%063x", i))
+		require.NoError(b, err)
+		keys[i] = priKey
+	}
+	for nonce := uint64(1); nonce <= actsPerAcct; nonce++ {
+		for _, priKey := range keys {
+			addr, err := address.FromBytes(priKey.PublicKey().Hash())
+			require.NoError(b, err)
+			pool.add(addr.String(), signedTransfer(b, priKey, nonce, 10000, r.Int63n(1000)+1))
+		}
+	}
+
+	for _, name := range []string{GasPricePolicy, FeePerGasPolicy, FirstSeenPolicy} {
+		p, err := NewPolicy(config.ActPool{PackingPolicy: name, MaxActsPerSenderInBlock: actsPerAcct / 2})
+		require.NoError(b, err)
+		b.Run(name, func(b *testing.B) {
+			fee := new(big.Int)
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fee.SetUint64(0)
+				gasLeft := uint64(blockGasLimit)
+				b.StartTimer()
+				ai := p.NewIterator(pool)
+				for gasLeft >= 10000 {
+					act, ok := ai.Next()
+					if !ok {
+						break
+					}
+					gasLeft -= act.GasLimit()
+					fee.Add(fee, new(big.Int).Mul(act.GasPrice(), new(big.Int).SetUint64(act.GasLimit())))
+				}
+			}
+			revenue, _ := new(big.Float).SetInt(fee).Float64()
+			b.ReportMetric(revenue, "fee/block")
+		})
+	}
+}
@@ -7,10 +7,13 @@
 package actpool
 
 import (
+	"container/heap"
 	"context"
+	"math/big"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -24,8 +27,11 @@ import (
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
+	"github.com/iotexproject/iotex-core/pkg/routine"
 )
 
 var (
@@ -42,6 +48,7 @@ func init() {
 // ActPool is the interface of actpool
 type ActPool interface {
 	action.SealedEnvelopeValidator
+	lifecycle.StartStopper
 	// Reset resets actpool state
 	Reset()
 	// PendingActionMap returns an action map with all accepted actions
@@ -54,6 +61,8 @@ type ActPool interface {
 	GetUnconfirmedActs(addr string) []action.SealedEnvelope
 	// GetActionByHash returns the pending action in pool given action's hash
 	GetActionByHash(hash hash.Hash256) (action.SealedEnvelope, error)
+	// ArrivalSequence returns the sequence number of an action in the order actions arrive in pool
+	ArrivalSequence(hash hash.Hash256) (uint64, bool)
 	// GetSize returns the act pool size
 	GetSize() uint64
 	// GetCapacity returns the act pool capacity
@@ -68,6 +77,20 @@ type ActPool interface {
 	ReceiveBlock(*block.Block) error
 
 	AddActionEnvelopeValidators(...action.SealedEnvelopeValidator)
+	// AddSubscriber makes the subscriber to be notified of every action accepted by the pool
+	AddSubscriber(Subscriber) error
+	// RemoveSubscriber removes a subscriber
+	RemoveSubscriber(Subscriber) error
+	// Bans returns the senders and peers banned by rate limiting
+	Bans() []Ban
+	// LiftBan lifts the ban of a sender or peer, and returns false if it is not banned
+	LiftBan(name string) bool
+}
+
+// Subscriber is the interface of the subscriber to actions accepted by the pool. OnAdded is called while the pool is
+// locked, so it must not block
+type Subscriber interface {
+	OnAdded(action.SealedEnvelope)
 }
 
 // SortedActions is a slice of actions that implements sort.Interface to sort by Value.
@@ -88,6 +111,17 @@ func EnableExperimentalActions() Option {
 	}
 }
 
+// WithJournal records the accepted actions in the given kv store, which are replayed when the pool starts
+func WithJournal(kvStore db.KVStore) Option {
+	return func(pool *actPool) error {
+		if kvStore == nil {
+			return errors.New("journal kv store is nil")
+		}
+		pool.journal = newJournal(kvStore)
+		return nil
+	}
+}
+
 // actPool implements ActPool interface
 type actPool struct {
 	mutex                     sync.RWMutex
@@ -96,11 +130,17 @@ type actPool struct {
 	accountActs               map[string]ActQueue
 	accountDesActs            map[string]map[hash.Hash256]action.SealedEnvelope
 	allActions                map[hash.Hash256]action.SealedEnvelope
+	arrivals                  map[hash.Hash256]uint64
+	arrivalSeq                uint64
 	gasInPool                 uint64
 	actionEnvelopeValidators  []action.SealedEnvelopeValidator
 	timerFactory              *prometheustimer.TimerFactory
 	enableExperimentalActions bool
 	senderBlackList           map[string]bool
+	journal                   *journal
+	journalTask               *routine.RecurringTask
+	subscribers               []Subscriber
+	rateLimiter               *rateLimiter
 }
 
 // NewActPool constructs a new actpool
@@ -121,6 +161,10 @@ func NewActPool(sf protocol.StateReader, cfg config.ActPool, opts ...Option) (Ac
 		accountActs:     make(map[string]ActQueue),
 		accountDesActs:  make(map[string]map[hash.Hash256]action.SealedEnvelope),
 		allActions:      make(map[hash.Hash256]action.SealedEnvelope),
+		arrivals:        make(map[hash.Hash256]uint64),
+	}
+	if cfg.RateLimit.Enabled {
+		ap.rateLimiter = newRateLimiter(cfg.RateLimit)
 	}
 	for _, opt := range opts {
 		if err := opt(ap); err != nil {
@@ -140,6 +184,103 @@ func NewActPool(sf protocol.StateReader, cfg config.ActPool, opts ...Option) (Ac
 	return ap, nil
 }
 
+// AddSubscriber adds a subscriber to the actions accepted by the pool
+func (ap *actPool) AddSubscriber(s Subscriber) error {
+	if s == nil {
+		return errors.New("subscriber could not be nil")
+	}
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	ap.subscribers = append(ap.subscribers, s)
+	return nil
+}
+
+// RemoveSubscriber removes a subscriber
+func (ap *actPool) RemoveSubscriber(s Subscriber) error {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	for i, subscriber := range ap.subscribers {
+		if subscriber == s {
+			ap.subscribers = append(ap.subscribers[:i], ap.subscribers[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("cannot find subscription")
+}
+
+// Bans returns the senders and peers banned by rate limiting
+func (ap *actPool) Bans() []Ban {
+	if ap.rateLimiter == nil {
+		return nil
+	}
+	return ap.rateLimiter.Bans(time.Now())
+}
+
+// LiftBan lifts the ban of a sender or peer
+func (ap *actPool) LiftBan(name string) bool {
+	if ap.rateLimiter == nil {
+		return false
+	}
+	return ap.rateLimiter.LiftBan(name)
+}
+
+// Start starts the journal and replays the recorded actions through validation
+func (ap *actPool) Start(ctx context.Context) error {
+	if ap.journal == nil {
+		return nil
+	}
+	if err := ap.journal.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start actpool journal")
+	}
+	acts, err := ap.journal.Actions()
+	if err != nil {
+		return errors.Wrap(err, "failed to load actions from actpool journal")
+	}
+	ap.mutex.Lock()
+	replayed := 0
+	for _, act := range acts {
+		if err := ap.add(ctx, act, false); err != nil {
+			actHash := act.Hash()
+			log.L().Debug("Dropped journaled action.", log.Hex("hash", actHash[:]), zap.Error(err))
+			ap.journal.Remove(actHash)
+			continue
+		}
+		replayed++
+	}
+	ap.mutex.Unlock()
+	log.L().Info("Replayed actpool journal.", zap.Int("total", len(acts)), zap.Int("replayed", replayed))
+	if err := ap.journal.Flush(); err != nil {
+		return err
+	}
+	if ap.cfg.JournalFlushInterval == 0 {
+		return nil
+	}
+	ap.journalTask = routine.NewRecurringTask(ap.flushJournal, ap.cfg.JournalFlushInterval)
+	return ap.journalTask.Start(ctx)
+}
+
+// Stop flushes and stops the journal
+func (ap *actPool) Stop(ctx context.Context) error {
+	if ap.journal == nil {
+		return nil
+	}
+	if ap.journalTask != nil {
+		if err := ap.journalTask.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	return ap.journal.Stop(ctx)
+}
+
+// flushJournal writes the staged changes of journal to disk
+func (ap *actPool) flushJournal() {
+	if err := ap.journal.Flush(); err != nil {
+		log.L().Error("Error when flushing actpool journal.", zap.Error(err))
+	}
+}
+
 func (ap *actPool) AddActionEnvelopeValidators(fs ...action.SealedEnvelopeValidator) {
 	ap.actionEnvelopeValidators = append(ap.actionEnvelopeValidators, fs...)
 }
@@ -186,19 +327,26 @@ func (ap *actPool) Add(ctx context.Context, act action.SealedEnvelope) error {
 	ap.mutex.Lock()
 	defer ap.mutex.Unlock()
 
-	// Reject action if pool space is full
-	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool {
-		actpoolMtc.WithLabelValues("overMaxNumActsPerPool").Inc()
-		return errors.Wrap(action.ErrActPool, "insufficient space for action")
+	if err := ap.add(ctx, act, true); err != nil {
+		return err
 	}
+	if ap.journal != nil {
+		ap.journal.Put(act)
+	}
+	return nil
+}
+
+// add adds the action into pool. The action is charged against the rate limits if rateLimited is true, which happens
+// only after it is checked to be new and valid, so that the duplicates gossiped by peers do not drain the buckets
+func (ap *actPool) add(ctx context.Context, act action.SealedEnvelope, rateLimited bool) error {
 	intrinsicGas, err := act.IntrinsicGas()
 	if err != nil {
 		actpoolMtc.WithLabelValues("failedGetIntrinsicGas").Inc()
 		return errors.Wrap(err, "failed to get action's intrinsic gas")
 	}
-	if ap.gasInPool+intrinsicGas > ap.cfg.MaxGasLimitPerPool {
-		actpoolMtc.WithLabelValues("overMaxGasLimitPerPool").Inc()
-		return errors.Wrap(action.ErrActPool, "insufficient gas space for action")
+	caller, err := address.FromBytes(act.SrcPubkey().Hash())
+	if err != nil {
+		return err
 	}
 	hash := act.Hash()
 	// Reject action if it already exists in pool
@@ -219,12 +367,25 @@ func (ap *actPool) Add(ctx context.Context, act action.SealedEnvelope) error {
 	if err := ap.validate(ctx, act); err != nil {
 		return err
 	}
-
-	caller, err := address.FromBytes(act.SrcPubkey().Hash())
+	if rateLimited && ap.rateLimiter != nil {
+		peer, _ := GetPeer(ctx)
+		if err := ap.rateLimiter.Allow(caller.String(), peer, time.Now()); err != nil {
+			return err
+		}
+	}
+	// Reject action if pool space is full and no cheaper action can be evicted
+	evictees, err := ap.makeRoom(caller.String(), act, intrinsicGas)
 	if err != nil {
 		return err
 	}
-	return ap.enqueueAction(caller.String(), act, hash, act.Nonce())
+	if err := ap.enqueueAction(caller.String(), act, hash, act.Nonce()); err != nil {
+		return err
+	}
+	ap.evictActions(evictees)
+	for _, s := range ap.subscribers {
+		s.OnAdded(act)
+	}
+	return nil
 }
 
 // GetPendingNonce returns pending nonce in pool or confirmed nonce given an account address
@@ -275,6 +436,15 @@ func (ap *actPool) GetActionByHash(hash hash.Hash256) (action.SealedEnvelope, er
 	return act, nil
 }
 
+// ArrivalSequence returns the sequence number of an action in the order actions arrive in pool
+func (ap *actPool) ArrivalSequence(hash hash.Hash256) (uint64, bool) {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	seq, ok := ap.arrivals[hash]
+	return seq, ok
+}
+
 // GetSize returns the act pool size
 func (ap *actPool) GetSize() uint64 {
 	ap.mutex.RLock()
@@ -366,8 +536,7 @@ func (ap *actPool) enqueueAction(sender string, act action.SealedEnvelope, actHa
 	}
 	if queue.Overlaps(act) {
 		// Nonce already exists
-		actpoolMtc.WithLabelValues("nonceUsed").Inc()
-		return errors.Wrapf(action.ErrNonce, "duplicate nonce for action %x", actHash)
+		return ap.replaceAction(sender, queue, act, actHash)
 	}
 
 	if actNonce-confirmedNonce-1 >= ap.cfg.MaxNumActsPerAcct {
@@ -402,7 +571,180 @@ func (ap *actPool) enqueueAction(sender string, act action.SealedEnvelope, actHa
 		actpoolMtc.WithLabelValues("failedPutActQueue").Inc()
 		return errors.Wrapf(err, "cannot put action %x into ActQueue", actHash)
 	}
+	ap.addToPool(sender, act, actHash)
+	// If the pending nonce equals this nonce, update queue
+	nonce := queue.PendingNonce()
+	if actNonce == nonce {
+		ap.updateAccount(sender)
+	}
+	return nil
+}
+
+// replaceAction replaces the pending action of the same nonce if the new action pays a high enough gas price
+func (ap *actPool) replaceAction(sender string, queue ActQueue, act action.SealedEnvelope, actHash hash.Hash256) error {
+	if ap.cfg.ReplaceGasPriceBump == 0 {
+		actpoolMtc.WithLabelValues("nonceUsed").Inc()
+		return errors.Wrapf(action.ErrNonce, "duplicate nonce for action %x", actHash)
+	}
+	old, _ := queue.Get(act.Nonce())
+	minGasPrice := new(big.Int).Mul(old.GasPrice(), new(big.Int).SetUint64(100+ap.cfg.ReplaceGasPriceBump))
+	minGasPrice.Div(minGasPrice, big.NewInt(100))
+	if act.GasPrice().Cmp(old.GasPrice()) <= 0 || act.GasPrice().Cmp(minGasPrice) < 0 {
+		actpoolMtc.WithLabelValues("replaceUnderpriced").Inc()
+		return errors.Wrapf(
+			action.ErrGasPrice,
+			"gas price %s of replacement action %x is lower than required %s",
+			act.GasPrice(),
+			actHash,
+			minGasPrice,
+		)
+	}
+	cost, err := act.Cost()
+	if err != nil {
+		actpoolMtc.WithLabelValues("failedToGetCost").Inc()
+		return errors.Wrapf(err, "failed to get cost of action %x", actHash)
+	}
+	// The cost of the replaced action is refunded if it has been accounted in the pending balance
+	balance := new(big.Int).Set(queue.PendingBalance())
+	if act.Nonce() < queue.PendingNonce() {
+		oldCost, err := old.Cost()
+		if err != nil {
+			actpoolMtc.WithLabelValues("failedToGetCost").Inc()
+			return errors.Wrapf(err, "failed to get cost of action %x", old.Hash())
+		}
+		balance.Add(balance, oldCost)
+	}
+	if balance.Cmp(cost) < 0 {
+		actpoolMtc.WithLabelValues("insufficientBalance").Inc()
+		return errors.Wrapf(
+			action.ErrBalance,
+			"insufficient balance for replacement action %x, cost = %s, pending balance = %s, sender = %s",
+			actHash,
+			cost.String(),
+			balance.String(),
+			sender,
+		)
+	}
+	if err := queue.Replace(act); err != nil {
+		actpoolMtc.WithLabelValues("failedPutActQueue").Inc()
+		return errors.Wrapf(err, "cannot replace action %x in ActQueue", actHash)
+	}
+	ap.removeFromPool(old)
+	ap.addToPool(sender, act, actHash)
+	actpoolMtc.WithLabelValues("replaced").Inc()
+	return ap.resetAccount(sender)
+}
+
+// makeRoom checks whether the pool has enough space for the given action, and returns the actions to be evicted
+// otherwise. Only the tail actions of other accounts whose gas price is lower than the given action can be evicted,
+// so the remaining actions of every account keep consecutive nonces, and only for an action executable right away
+func (ap *actPool) makeRoom(sender string, act action.SealedEnvelope, intrinsicGas uint64) ([]action.SealedEnvelope, error) {
+	numActs, gasInPool := uint64(len(ap.allActions)), ap.gasInPool
+	queue, hasQueue := ap.accountActs[sender]
+	if hasQueue {
+		// A replacement takes the space of the action it replaces
+		if old, ok := queue.Get(act.Nonce()); ok {
+			oldGas, _ := old.IntrinsicGas()
+			numActs--
+			gasInPool -= oldGas
+		}
+	}
+	if numActs < ap.cfg.MaxNumActsPerPool && gasInPool+intrinsicGas <= ap.cfg.MaxGasLimitPerPool {
+		return nil, nil
+	}
+	if !ap.cfg.EnableEviction {
+		if numActs >= ap.cfg.MaxNumActsPerPool {
+			actpoolMtc.WithLabelValues("overMaxNumActsPerPool").Inc()
+			return nil, errors.Wrap(action.ErrActPool, "insufficient space for action")
+		}
+		actpoolMtc.WithLabelValues("overMaxGasLimitPerPool").Inc()
+		return nil, errors.Wrap(action.ErrActPool, "insufficient gas space for action")
+	}
+	// An action with a nonce gap cannot be executed yet, and does not evict executable actions
+	var pendingNonce uint64
+	if hasQueue {
+		pendingNonce = queue.PendingNonce()
+	} else {
+		confirmedState, err := accountutil.AccountState(ap.sf, sender)
+		if err != nil {
+			return nil, err
+		}
+		pendingNonce = confirmedState.Nonce + 1
+	}
+	if act.Nonce() > pendingNonce {
+		actpoolMtc.WithLabelValues("evictionNonceGap").Inc()
+		return nil, errors.Wrap(action.ErrActPool, "insufficient space for action with nonce gap")
+	}
+
+	tails := make(evictionQueue, 0, len(ap.accountActs))
+	for from, queue := range ap.accountActs {
+		if from == sender {
+			continue
+		}
+		if acts := queue.AllActs(); len(acts) > 0 {
+			tails = append(tails, &evictionCandidate{acts: acts, idx: len(acts) - 1})
+		}
+	}
+	heap.Init(&tails)
+	var evictees []action.SealedEnvelope
+	for numActs >= ap.cfg.MaxNumActsPerPool || gasInPool+intrinsicGas > ap.cfg.MaxGasLimitPerPool {
+		if tails.Len() == 0 || tails[0].tail().GasPrice().Cmp(act.GasPrice()) >= 0 {
+			actpoolMtc.WithLabelValues("evictionUnderpriced").Inc()
+			// no action with lower gas price to evict
+			return nil, errors.Wrap(action.ErrActPool, "insufficient space for action")
+		}
+		evictee := *tails[0].tail()
+		evictees = append(evictees, evictee)
+		evicteeGas, _ := evictee.IntrinsicGas()
+		numActs--
+		if gasInPool < evicteeGas {
+			gasInPool = 0
+		} else {
+			gasInPool -= evicteeGas
+		}
+		if tails[0].idx == 0 {
+			heap.Pop(&tails)
+		} else {
+			tails[0].idx--
+			heap.Fix(&tails, 0)
+		}
+	}
+	return evictees, nil
+}
+
+// evictActions removes the given actions, along with any action of a higher nonce of the same account, from pool
+func (ap *actPool) evictActions(evictees []action.SealedEnvelope) {
+	lowest := make(map[string]uint64)
+	for _, act := range evictees {
+		caller, err := address.FromBytes(act.SrcPubkey().Hash())
+		if err != nil {
+			log.L().Error("Error when evicting action.", zap.Error(err))
+			continue
+		}
+		from := caller.String()
+		if nonce, ok := lowest[from]; !ok || act.Nonce() < nonce {
+			lowest[from] = act.Nonce()
+		}
+	}
+	for from, nonce := range lowest {
+		queue, ok := ap.accountActs[from]
+		if !ok {
+			continue
+		}
+		acts := queue.RemoveFrom(nonce)
+		actpoolMtc.WithLabelValues("evicted").Add(float64(len(acts)))
+		ap.removeInvalidActs(acts)
+		if err := ap.resetAccount(from); err != nil {
+			log.L().Error("Error when evicting actions.", zap.Error(err))
+		}
+	}
+}
+
+// addToPool adds an action that has been put into the account queue to pool
+func (ap *actPool) addToPool(sender string, act action.SealedEnvelope, actHash hash.Hash256) {
 	ap.allActions[actHash] = act
+	ap.arrivals[actHash] = ap.arrivalSeq
+	ap.arrivalSeq++
 
 	//add actions to destination map
 	desAddress, ok := act.Destination()
@@ -416,12 +758,6 @@ func (ap *actPool) enqueueAction(sender string, act action.SealedEnvelope, actHa
 
 	intrinsicGas, _ := act.IntrinsicGas()
 	ap.gasInPool += intrinsicGas
-	// If the pending nonce equals this nonce, update queue
-	nonce := queue.PendingNonce()
-	if actNonce == nonce {
-		ap.updateAccount(sender)
-	}
-	return nil
 }
 
 // removeConfirmedActs removes processed (committed to block) actions from pool
@@ -449,14 +785,23 @@ func (ap *actPool) removeInvalidActs(acts []action.SealedEnvelope) {
 	for _, act := range acts {
 		hash := act.Hash()
 		log.L().Debug("Removed invalidated action.", log.Hex("hash", hash[:]))
-		delete(ap.allActions, hash)
-		intrinsicGas, _ := act.IntrinsicGas()
-		ap.subGasFromPool(intrinsicGas)
-		//del actions in destination map
-		ap.deleteAccountDestinationActions(act)
+		ap.removeFromPool(act)
 	}
 }
 
+func (ap *actPool) removeFromPool(act action.SealedEnvelope) {
+	actHash := act.Hash()
+	delete(ap.allActions, actHash)
+	delete(ap.arrivals, actHash)
+	if ap.journal != nil {
+		ap.journal.Remove(actHash)
+	}
+	intrinsicGas, _ := act.IntrinsicGas()
+	ap.subGasFromPool(intrinsicGas)
+	//del actions in destination map
+	ap.deleteAccountDestinationActions(act)
+}
+
 // deleteAccountDestinationActions just for destination map
 func (ap *actPool) deleteAccountDestinationActions(acts ...action.SealedEnvelope) {
 	for _, act := range acts {
@@ -489,21 +834,33 @@ func (ap *actPool) reset() {
 
 	// Remove confirmed actions in actpool
 	ap.removeConfirmedActs()
-	for from, queue := range ap.accountActs {
-		// Reset pending balance for each account
-		state, err := accountutil.AccountState(ap.sf, from)
-		if err != nil {
+	for from := range ap.accountActs {
+		if err := ap.resetAccount(from); err != nil {
 			log.L().Error("Error when resetting actpool state.", zap.Error(err))
 			return
 		}
-		queue.SetPendingBalance(state.Balance)
+	}
+}
 
-		// Reset pending nonce and remove invalid actions for each account
-		confirmedNonce := state.Nonce
-		pendingNonce := confirmedNonce + 1
-		queue.SetPendingNonce(pendingNonce)
-		ap.updateAccount(from)
+// resetAccount resets the pending balance and nonce of an account queue based on its confirmed state
+func (ap *actPool) resetAccount(sender string) error {
+	queue, ok := ap.accountActs[sender]
+	if !ok {
+		return nil
+	}
+	// Reset pending balance for the account
+	state, err := accountutil.AccountState(ap.sf, sender)
+	if err != nil {
+		return err
 	}
+	queue.SetPendingBalance(state.Balance)
+
+	// Reset pending nonce and remove invalid actions for the account
+	confirmedNonce := state.Nonce
+	pendingNonce := confirmedNonce + 1
+	queue.SetPendingNonce(pendingNonce)
+	ap.updateAccount(sender)
+	return nil
 }
 
 func (ap *actPool) subGasFromPool(gas uint64) {
@@ -513,3 +870,36 @@ func (ap *actPool) subGasFromPool(gas uint64) {
 	}
 	ap.gasInPool -= gas
 }
+
+// evictionCandidate tracks the tail action of an account that can be evicted
+type evictionCandidate struct {
+	acts []action.SealedEnvelope
+	idx  int
+}
+
+func (c *evictionCandidate) tail() *action.SealedEnvelope { return &c.acts[c.idx] }
+
+// evictionQueue is a min heap of eviction candidates ordered by the gas price of their tail actions
+type evictionQueue []*evictionCandidate
+
+func (h evictionQueue) Len() int { return len(h) }
+func (h evictionQueue) Less(i, j int) bool {
+	return h[i].tail().GasPrice().Cmp(h[j].tail().GasPrice()) < 0
+}
+func (h evictionQueue) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *evictionQueue) Push(x interface{}) {
+	in, ok := x.(*evictionCandidate)
+	if !ok {
+		return
+	}
+	*h = append(*h, in)
+}
+
+func (h *evictionQueue) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[0 : n-1]
+	return x
+}
@@ -289,6 +289,224 @@ func TestActPool_AddActs(t *testing.T) {
 	require.Equal(action.ErrInsufficientBalanceForGas, errors.Cause(err))
 }
 
+func TestActPool_ReplaceAction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		acct.Nonce = 0
+		acct.Balance = big.NewInt(1000000)
+		return 0, nil
+	}).AnyTimes()
+	apConfig := getActPoolCfg()
+	apConfig.ReplaceGasPriceBump = 10
+	Ap, err := NewActPool(sf, apConfig, EnableExperimentalActions())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ctx := context.Background()
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(10))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(10))
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, tsf1))
+	require.NoError(ap.Add(ctx, tsf2))
+	gasSize := ap.GetGasSize()
+
+	// Case I: gas price bump is not enough
+	underpriced, err := testutil.SignedTransfer(addr3, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(10))
+	require.NoError(err)
+	require.Equal(action.ErrGasPrice, errors.Cause(ap.Add(ctx, underpriced)))
+	// Case II: insufficient balance for the replacement
+	expensive, err := testutil.SignedTransfer(addr3, priKey1, uint64(1), big.NewInt(1000000), []byte{}, uint64(10000), big.NewInt(11))
+	require.NoError(err)
+	require.Equal(action.ErrBalance, errors.Cause(ap.Add(ctx, expensive)))
+	// Case III: replace the action
+	replacement, err := testutil.SignedTransfer(addr3, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(11))
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, replacement))
+	require.Equal(uint64(2), ap.GetSize())
+	require.Equal(gasSize, ap.GetGasSize())
+	_, err = ap.GetActionByHash(tsf1.Hash())
+	require.Equal(action.ErrNotFound, errors.Cause(err))
+	act, err := ap.GetActionByHash(replacement.Hash())
+	require.NoError(err)
+	require.Equal(replacement, act)
+	require.Equal([]action.SealedEnvelope{replacement, tsf2}, ap.PendingActionMap()[addr1])
+	require.Len(ap.accountDesActs[addr2], 1)
+	require.Len(ap.accountDesActs[addr3], 1)
+	pNonce, err := ap.getPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(3), pNonce)
+	pBalance, err := ap.getPendingBalance(addr1)
+	require.NoError(err)
+	require.Equal(big.NewInt(1000000-10-110000-10-100000).String(), pBalance.String())
+
+	// Case IV: replacement is disabled
+	ap.cfg.ReplaceGasPriceBump = 0
+	replacement, err = testutil.SignedTransfer(addr3, priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(100))
+	require.NoError(err)
+	require.Equal(action.ErrNonce, errors.Cause(ap.Add(ctx, replacement)))
+}
+
+func TestActPool_EvictActions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		acct.Nonce = 0
+		acct.Balance = big.NewInt(1000000)
+		return 0, nil
+	}).AnyTimes()
+	apConfig := getActPoolCfg()
+	apConfig.MaxNumActsPerPool = 4
+	apConfig.MaxNumActsPerAcct = 4
+	apConfig.EnableEviction = true
+	Ap, err := NewActPool(sf, apConfig, EnableExperimentalActions())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ctx := context.Background()
+
+	tsf1, err := testutil.SignedTransfer(addr3, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(5))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr3, priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr3, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(2))
+	require.NoError(err)
+	tsf4, err := testutil.SignedTransfer(addr3, priKey2, uint64(2), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(3))
+	require.NoError(err)
+	for _, tsf := range []action.SealedEnvelope{tsf1, tsf2, tsf3, tsf4} {
+		require.NoError(ap.Add(ctx, tsf))
+	}
+	require.Equal(uint64(4), ap.GetSize())
+
+	// Case I: the incoming action is not more expensive than any tail action
+	cheap, err := testutil.SignedTransfer(addr3, priKey4, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	require.Equal(action.ErrActPool, errors.Cause(ap.Add(ctx, cheap)))
+	require.Equal(uint64(4), ap.GetSize())
+
+	// Case II: the cheapest tail action is evicted
+	tsf5, err := testutil.SignedTransfer(addr3, priKey4, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(2))
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, tsf5))
+	require.Equal(uint64(4), ap.GetSize())
+	_, err = ap.GetActionByHash(tsf2.Hash())
+	require.Equal(action.ErrNotFound, errors.Cause(err))
+	require.Equal([]action.SealedEnvelope{tsf1}, ap.PendingActionMap()[addr1])
+
+	// Case III: evicting a tail action in the middle of the price order evicts the head action as well
+	tsf6, err := testutil.SignedTransfer(addr3, priKey4, uint64(2), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(6))
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, tsf6))
+	require.Equal(uint64(4), ap.GetSize())
+	_, err = ap.GetActionByHash(tsf4.Hash())
+	require.Equal(action.ErrNotFound, errors.Cause(err))
+	require.Equal([]action.SealedEnvelope{tsf3}, ap.PendingActionMap()[addr2])
+	tsf7, err := testutil.SignedTransfer(addr3, priKey4, uint64(3), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(6))
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, tsf7))
+	require.Equal(uint64(4), ap.GetSize())
+	require.Empty(ap.PendingActionMap()[addr2])
+	require.Equal([]action.SealedEnvelope{tsf5, tsf6, tsf7}, ap.PendingActionMap()[addr4])
+
+	// Case IV: an action with a nonce gap does not evict executable actions
+	gapped, err := testutil.SignedTransfer(addr3, priKey5, uint64(2), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(100))
+	require.NoError(err)
+	require.Equal(action.ErrActPool, errors.Cause(ap.Add(ctx, gapped)))
+	require.Equal(uint64(4), ap.GetSize())
+	require.Equal([]action.SealedEnvelope{tsf1}, ap.PendingActionMap()[addr1])
+
+	// Case V: eviction is disabled
+	ap.cfg.EnableEviction = false
+	tsf8, err := testutil.SignedTransfer(addr3, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(100))
+	require.NoError(err)
+	require.Equal(action.ErrActPool, errors.Cause(ap.Add(ctx, tsf8)))
+}
+
+type testSubscriber struct {
+	acts []action.SealedEnvelope
+}
+
+func (s *testSubscriber) OnAdded(act action.SealedEnvelope) {
+	s.acts = append(s.acts, act)
+}
+
+func TestActPool_ArrivalSequence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		acct.Nonce = 0
+		acct.Balance = big.NewInt(1000000)
+		return 0, nil
+	}).AnyTimes()
+	ap, err := NewActPool(sf, getActPoolCfg())
+	require.NoError(err)
+
+	ctx := context.Background()
+	tsf1, err := testutil.SignedTransfer(addr2, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, tsf1))
+	require.NoError(ap.Add(ctx, tsf2))
+	seq1, ok := ap.ArrivalSequence(tsf1.Hash())
+	require.True(ok)
+	seq2, ok := ap.ArrivalSequence(tsf2.Hash())
+	require.True(ok)
+	require.True(seq1 < seq2)
+
+	ap.DeleteAction(identityset.Address(29))
+	_, ok = ap.ArrivalSequence(tsf1.Hash())
+	require.False(ok)
+}
+
+func TestActPool_Subscriber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		acct.Nonce = 0
+		acct.Balance = big.NewInt(1000000)
+		return 0, nil
+	}).AnyTimes()
+	ap, err := NewActPool(sf, getActPoolCfg())
+	require.NoError(err)
+	require.Error(ap.AddSubscriber(nil))
+	s1, s2 := &testSubscriber{}, &testSubscriber{}
+	require.NoError(ap.AddSubscriber(s1))
+	require.NoError(ap.AddSubscriber(s2))
+
+	ctx := context.Background()
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, tsf1))
+	// rejected action is not notified
+	require.Error(ap.Add(ctx, tsf1))
+	require.NoError(ap.RemoveSubscriber(s2))
+	require.Error(ap.RemoveSubscriber(s2))
+	require.NoError(ap.Add(ctx, tsf2))
+	require.Equal([]action.SealedEnvelope{tsf1, tsf2}, s1.acts)
+	require.Equal([]action.SealedEnvelope{tsf1}, s2.acts)
+}
+
 func TestActPool_PickActs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -52,6 +52,9 @@ func (h *noncePriorityQueue) Pop() interface{} {
 type ActQueue interface {
 	Overlaps(action.SealedEnvelope) bool
 	Put(action.SealedEnvelope) error
+	Get(uint64) (action.SealedEnvelope, bool)
+	Replace(action.SealedEnvelope) error
+	RemoveFrom(uint64) []action.SealedEnvelope
 	FilterNonce(uint64) []action.SealedEnvelope
 	UpdateQueue(uint64) []action.SealedEnvelope
 	SetPendingNonce(uint64)
@@ -120,6 +123,40 @@ func (q *actQueue) Put(act action.SealedEnvelope) error {
 	return nil
 }
 
+// Get returns the action of the given nonce in the queue
+func (q *actQueue) Get(nonce uint64) (action.SealedEnvelope, bool) {
+	act, exist := q.items[nonce]
+	return act, exist
+}
+
+// Replace replaces the action of the same nonce in the queue, and refreshes its expiry deadline
+func (q *actQueue) Replace(act action.SealedEnvelope) error {
+	nonce := act.Nonce()
+	if _, exist := q.items[nonce]; !exist {
+		return errors.Wrapf(action.ErrNonce, "nonce %d does not exist", nonce)
+	}
+	for i := range q.index {
+		if q.index[i].nonce == nonce {
+			q.index[i].deadline = q.clock.Now().Add(q.ttl)
+			break
+		}
+	}
+	q.items[nonce] = act
+	return nil
+}
+
+// RemoveFrom removes all actions from the map with a nonce no lower than the given threshold
+func (q *actQueue) RemoveFrom(threshold uint64) []action.SealedEnvelope {
+	sort.Sort(q.index)
+	i := 0
+	for ; i < q.index.Len(); i++ {
+		if q.index[i].nonce >= threshold {
+			break
+		}
+	}
+	return q.removeActs(i)
+}
+
 // FilterNonce removes all actions from the map with a nonce lower than the given threshold
 func (q *actQueue) FilterNonce(threshold uint64) []action.SealedEnvelope {
 	var removed []action.SealedEnvelope
@@ -14,6 +14,7 @@ import (
 
 	"github.com/facebookgo/clock"
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -196,6 +197,45 @@ func TestActQueueRemoveActs(t *testing.T) {
 	require.Equal([]action.SealedEnvelope{tsf5, tsf6}, removed)
 }
 
+func TestActQueueReplace(t *testing.T) {
+	require := require.New(t)
+	q := NewActQueue(nil, "").(*actQueue)
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, 1, big.NewInt(100), nil, uint64(0), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, 1, big.NewInt(100), nil, uint64(0), big.NewInt(2))
+	require.NoError(err)
+	require.Equal(action.ErrNonce, errors.Cause(q.Replace(tsf2)))
+	require.NoError(q.Put(tsf1))
+	act, ok := q.Get(1)
+	require.True(ok)
+	require.Equal(tsf1, act)
+	require.NoError(q.Replace(tsf2))
+	act, ok = q.Get(1)
+	require.True(ok)
+	require.Equal(tsf2, act)
+	require.Equal(1, q.Len())
+	_, ok = q.Get(2)
+	require.False(ok)
+}
+
+func TestActQueueRemoveFrom(t *testing.T) {
+	require := require.New(t)
+	q := NewActQueue(nil, "").(*actQueue)
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, 1, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, 2, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, 3, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(q.Put(tsf3))
+	require.NoError(q.Put(tsf1))
+	require.NoError(q.Put(tsf2))
+	require.Equal([]action.SealedEnvelope{tsf2, tsf3}, q.RemoveFrom(2))
+	require.Equal([]action.SealedEnvelope{tsf1}, q.AllActs())
+	require.Empty(q.RemoveFrom(5))
+	require.Equal(1, q.Len())
+}
+
 func TestActQueueTimeOutAction(t *testing.T) {
 	c := clock.NewMock()
 	q := NewActQueue(nil, "", WithClock(c), WithTimeOut(3*time.Minute))
@@ -0,0 +1,73 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// BanController inspects and lifts the bans of actpool rate limiting
+type BanController struct {
+	ap    ActPool
+	token string
+}
+
+// NewBanController constructs a ban controller instance. Lifting a ban requires the given token as bearer token, and is
+// disabled if the token is empty
+func NewBanController(ap ActPool, token string) *BanController {
+	return &BanController{
+		ap:    ap,
+		token: token,
+	}
+}
+
+// Handle handles admin request, which lists the current bans on GET, or lifts the ban of the sender or peer given by
+// "name" on POST or DELETE
+func (bc *BanController) Handle(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		type payload struct {
+			Bans []Ban `json:"bans"`
+		}
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(&payload{Bans: bc.ap.Bans()}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+	case http.MethodPost, http.MethodDelete:
+		if bc.token == "" {
+			http.Error(w, "lifting bans is disabled", http.StatusForbidden)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+bc.token)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			http.Error(w, "missing name", http.StatusBadRequest)
+			return
+		}
+		if !bc.ap.LiftBan(name) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.S().Infof("Lifted the actpool ban of %s", name)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
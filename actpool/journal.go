@@ -0,0 +1,165 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+)
+
+const (
+	// JournalNamespace is the bucket name for the actions recorded by actpool journal
+	JournalNamespace = "ActPoolJournal"
+)
+
+// journal records the actions accepted by actpool on disk, so that they survive node restarts. The additions and
+// removals are staged in memory, and written to disk in a batch upon flush, so that actpool admission does not wait for
+// disk I/O
+type journal struct {
+	kvStore db.KVStore
+	mu      sync.Mutex
+	flushMu sync.Mutex
+	// added stages the actions accepted by pool, which are written to journal upon flush
+	added map[hash.Hash256]action.SealedEnvelope
+	// removed stages the actions removed from pool, which are deleted from journal upon flush
+	removed map[hash.Hash256]struct{}
+}
+
+func newJournal(kvStore db.KVStore) *journal {
+	return &journal{
+		kvStore: kvStore,
+		added:   make(map[hash.Hash256]action.SealedEnvelope),
+		removed: make(map[hash.Hash256]struct{}),
+	}
+}
+
+// Start opens the underlying db
+func (j *journal) Start(ctx context.Context) error {
+	return j.kvStore.Start(ctx)
+}
+
+// Stop flushes the journal and closes the underlying db
+func (j *journal) Stop(ctx context.Context) error {
+	if err := j.Flush(); err != nil {
+		return err
+	}
+	return j.kvStore.Stop(ctx)
+}
+
+// Put stages the recording of an accepted action, which takes effect upon next flush
+func (j *journal) Put(act action.SealedEnvelope) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	actHash := act.Hash()
+	delete(j.removed, actHash)
+	j.added[actHash] = act
+}
+
+// Remove stages the removal of an action, which takes effect upon next flush
+func (j *journal) Remove(actHash hash.Hash256) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	delete(j.added, actHash)
+	j.removed[actHash] = struct{}{}
+}
+
+// Flush writes the staged additions and removals to disk in a batch
+func (j *journal) Flush() error {
+	j.flushMu.Lock()
+	defer j.flushMu.Unlock()
+
+	j.mu.Lock()
+	added, removed := j.added, j.removed
+	j.added = make(map[hash.Hash256]action.SealedEnvelope)
+	j.removed = make(map[hash.Hash256]struct{})
+	j.mu.Unlock()
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	b := batch.NewBatch()
+	for actHash := range removed {
+		key := actHash
+		b.Delete(JournalNamespace, key[:], "failed to delete action %x from journal", key)
+	}
+	for actHash, act := range added {
+		key := actHash
+		ser, err := proto.Marshal(act.Proto())
+		if err != nil {
+			// the action cannot be recorded, and is dropped from journal
+			delete(added, key)
+			j.restage(added, removed)
+			return errors.Wrapf(err, "failed to serialize action %x", key)
+		}
+		b.Put(JournalNamespace, key[:], ser, "failed to put action %x into journal", key)
+	}
+	if err := j.kvStore.WriteBatch(b); err != nil {
+		j.restage(added, removed)
+		return err
+	}
+	return nil
+}
+
+// restage stages again the additions and removals failed to flush, unless they have been superseded since
+func (j *journal) restage(added map[hash.Hash256]action.SealedEnvelope, removed map[hash.Hash256]struct{}) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for actHash, act := range added {
+		if _, ok := j.removed[actHash]; !ok {
+			j.added[actHash] = act
+		}
+	}
+	for actHash := range removed {
+		if _, ok := j.added[actHash]; !ok {
+			j.removed[actHash] = struct{}{}
+		}
+	}
+}
+
+// Actions returns all the recorded actions, sorted by nonce and gas price, so that a replacement is always replayed
+// after the action it replaces
+func (j *journal) Actions() ([]action.SealedEnvelope, error) {
+	_, values, err := j.kvStore.Filter(JournalNamespace, func(k, v []byte) bool { return true }, nil, nil)
+	if err != nil {
+		if cause := errors.Cause(err); cause == db.ErrNotExist || cause == db.ErrBucketNotExist {
+			return nil, nil
+		}
+		return nil, err
+	}
+	acts := make([]action.SealedEnvelope, 0, len(values))
+	for _, v := range values {
+		pb := &iotextypes.Action{}
+		if err := proto.Unmarshal(v, pb); err != nil {
+			return nil, errors.Wrap(err, "failed to deserialize action")
+		}
+		act := action.SealedEnvelope{}
+		if err := act.LoadProto(pb); err != nil {
+			return nil, errors.Wrap(err, "failed to load action")
+		}
+		acts = append(acts, act)
+	}
+	sort.SliceStable(acts, func(i, k int) bool {
+		if acts[i].Nonce() != acts[k].Nonce() {
+			return acts[i].Nonce() < acts[k].Nonce()
+		}
+		return acts[i].GasPrice().Cmp(acts[k].GasPrice()) < 0
+	})
+	return acts, nil
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_chainmanager"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestJournal(t *testing.T) {
+	require := require.New(t)
+	testPath, err := testutil.PathOfTempFile("journal")
+	require.NoError(err)
+	defer testutil.CleanupPath(t, testPath)
+	cfg := config.Default.DB
+	cfg.DbPath = testPath
+
+	ctx := context.Background()
+	j := newJournal(db.NewBoltDB(cfg))
+	require.NoError(j.Start(ctx))
+	acts, err := j.Actions()
+	require.NoError(err)
+	require.Empty(acts)
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, 2, big.NewInt(10), nil, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, 1, big.NewInt(10), nil, uint64(10000), big.NewInt(2))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, 1, big.NewInt(10), nil, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	for _, tsf := range []action.SealedEnvelope{tsf1, tsf2, tsf3} {
+		j.Put(tsf)
+	}
+	// addition takes effect upon flush
+	acts, err = j.Actions()
+	require.NoError(err)
+	require.Empty(acts)
+	require.NoError(j.Flush())
+	acts, err = j.Actions()
+	require.NoError(err)
+	require.Equal([]action.SealedEnvelope{tsf3, tsf2, tsf1}, acts)
+
+	// removal takes effect upon flush
+	j.Remove(tsf3.Hash())
+	j.Remove(tsf1.Hash())
+	j.Put(tsf1)
+	acts, err = j.Actions()
+	require.NoError(err)
+	require.Len(acts, 3)
+	require.NoError(j.Stop(ctx))
+
+	j = newJournal(db.NewBoltDB(cfg))
+	require.NoError(j.Start(ctx))
+	acts, err = j.Actions()
+	require.NoError(err)
+	require.Equal([]action.SealedEnvelope{tsf2, tsf1}, acts)
+	require.NoError(j.Stop(ctx))
+}
+
+func TestActPool_Journal(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	testPath, err := testutil.PathOfTempFile("journal")
+	require.NoError(err)
+	defer testutil.CleanupPath(t, testPath)
+	cfg := config.Default.DB
+	cfg.DbPath = testPath
+
+	nonce := uint64(0)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		cfg := &protocol.StateConfig{}
+		for _, opt := range opts {
+			opt(cfg)
+		}
+		acct.Nonce = 0
+		if bytes.Equal(cfg.Key, identityset.Address(28).Bytes()) {
+			acct.Nonce = nonce
+		}
+		acct.Balance = big.NewInt(1000000)
+		return 0, nil
+	}).AnyTimes()
+
+	ctx := context.Background()
+	ap, err := NewActPool(sf, getActPoolCfg(), WithJournal(db.NewBoltDB(cfg)))
+	require.NoError(err)
+	require.NoError(ap.Start(ctx))
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, 1, big.NewInt(10), nil, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, 2, big.NewInt(10), nil, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey2, 1, big.NewInt(10), nil, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	for _, tsf := range []action.SealedEnvelope{tsf1, tsf2, tsf3} {
+		require.NoError(ap.Add(ctx, tsf))
+	}
+	// tsf1 is confirmed
+	nonce = 1
+	require.NoError(ap.ReceiveBlock(nil))
+	require.Equal(uint64(2), ap.GetSize())
+	require.NoError(ap.Stop(ctx))
+
+	// the pending actions are recovered after restart
+	ap, err = NewActPool(sf, getActPoolCfg(), WithJournal(db.NewBoltDB(cfg)))
+	require.NoError(err)
+	require.NoError(ap.Start(ctx))
+	require.Equal(uint64(2), ap.GetSize())
+	_, err = ap.GetActionByHash(tsf1.Hash())
+	require.Error(err)
+	for _, tsf := range []action.SealedEnvelope{tsf2, tsf3} {
+		act, err := ap.GetActionByHash(tsf.Hash())
+		require.NoError(err)
+		require.Equal(tsf, act)
+	}
+	require.NoError(ap.Stop(ctx))
+
+	_, err = NewActPool(sf, getActPoolCfg(), WithJournal(nil))
+	require.Error(err)
+}
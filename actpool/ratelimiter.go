@@ -0,0 +1,185 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/p2p"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+type peerContextKey struct{}
+
+// WithPeer adds the id of the peer which relays the action into context
+func WithPeer(ctx context.Context, peer string) context.Context {
+	return context.WithValue(ctx, peerContextKey{}, peer)
+}
+
+// GetPeer gets the id of the peer which relays the action from context
+func GetPeer(ctx context.Context) (string, bool) {
+	peer, ok := ctx.Value(peerContextKey{}).(string)
+	return peer, ok
+}
+
+// Ban is a sender or peer temporarily banned from adding actions into actpool
+type Ban struct {
+	Name   string    `json:"name"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// tokenBucket holds up to burst tokens, which are refilled at rate per second
+type tokenBucket struct {
+	burst  float64
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(burst uint64, rate float64, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		burst:  float64(burst),
+		rate:   rate,
+		tokens: float64(burst),
+		last:   now,
+	}
+}
+
+// take refills the bucket for the elapsed time, and then takes a token if there is any
+func (b *tokenBucket) take(now time.Time) bool {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// rateLimiter limits the actions from each sender, relayed by each peer and in total with token buckets. A sender or
+// peer repeatedly exceeding its limit is banned for a while
+type rateLimiter struct {
+	mutex      sync.Mutex
+	cfg        config.ActPoolRateLimit
+	global     *tokenBucket
+	senders    *cache.ThreadSafeLruCache
+	peers      *cache.ThreadSafeLruCache
+	senderBans *p2p.BlockList
+	peerBans   *p2p.BlockList
+	// banned tracks the banned names and the blocklist banning them, for inspection
+	banned map[string]*p2p.BlockList
+}
+
+func newRateLimiter(cfg config.ActPoolRateLimit) *rateLimiter {
+	rl := &rateLimiter{
+		cfg:        cfg,
+		senders:    cache.NewThreadSafeLruCache(cfg.CacheSize),
+		peers:      cache.NewThreadSafeLruCache(cfg.CacheSize),
+		senderBans: p2p.NewCustomBlockList(cfg.CacheSize, cfg.BanThreshold, cfg.BanDuration),
+		peerBans:   p2p.NewCustomBlockList(cfg.CacheSize, cfg.BanThreshold, cfg.BanDuration),
+		banned:     make(map[string]*p2p.BlockList),
+	}
+	if cfg.GlobalBurst > 0 {
+		rl.global = newTokenBucket(cfg.GlobalBurst, cfg.GlobalRefillRate, time.Now())
+	}
+	return rl
+}
+
+// Allow takes a token for the action from sender relayed by peer, and returns error if any limit is exceeded. peer is
+// empty if the action is not received from p2p network
+func (rl *rateLimiter) Allow(sender, peer string, now time.Time) error {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	if rl.senderBans.Blocked(sender, now) {
+		actpoolMtc.WithLabelValues("senderBanned").Inc()
+		return errors.Wrapf(action.ErrRateLimit, "sender %s is banned", sender)
+	}
+	if peer != "" && rl.peerBans.Blocked(peer, now) {
+		actpoolMtc.WithLabelValues("peerBanned").Inc()
+		return errors.Wrapf(action.ErrRateLimit, "peer %s is banned", peer)
+	}
+	if rl.cfg.SenderBurst > 0 && !rl.take(rl.senders, sender, rl.cfg.SenderBurst, rl.cfg.SenderRefillRate, now) {
+		actpoolMtc.WithLabelValues("senderRateLimited").Inc()
+		rl.fault(rl.senderBans, sender, now)
+		return errors.Wrapf(action.ErrRateLimit, "sender %s exceeds rate limit", sender)
+	}
+	if peer != "" && rl.cfg.PeerBurst > 0 && !rl.take(rl.peers, peer, rl.cfg.PeerBurst, rl.cfg.PeerRefillRate, now) {
+		actpoolMtc.WithLabelValues("peerRateLimited").Inc()
+		rl.fault(rl.peerBans, peer, now)
+		return errors.Wrapf(action.ErrRateLimit, "peer %s exceeds rate limit", peer)
+	}
+	if rl.global != nil && !rl.global.take(now) {
+		actpoolMtc.WithLabelValues("globalRateLimited").Inc()
+		return errors.Wrap(action.ErrRateLimit, "actpool exceeds global rate limit")
+	}
+	return nil
+}
+
+// Bans returns the senders and peers being banned, sorted by name
+func (rl *rateLimiter) Bans(now time.Time) []Ban {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	bans := make([]Ban, 0, len(rl.banned))
+	for name, bl := range rl.banned {
+		expiry, ok := bl.Expiry(name)
+		if !ok || !expiry.After(now) {
+			delete(rl.banned, name)
+			continue
+		}
+		bans = append(bans, Ban{Name: name, Expiry: expiry})
+	}
+	sort.Slice(bans, func(i, j int) bool { return bans[i].Name < bans[j].Name })
+	return bans
+}
+
+// LiftBan lifts the ban of a sender or peer, and returns false if it is not banned
+func (rl *rateLimiter) LiftBan(name string) bool {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	bl, ok := rl.banned[name]
+	if !ok {
+		return false
+	}
+	bl.Remove(name)
+	delete(rl.banned, name)
+	return true
+}
+
+func (rl *rateLimiter) take(buckets *cache.ThreadSafeLruCache, name string, burst uint64, rate float64, now time.Time) bool {
+	v, ok := buckets.Get(name)
+	if !ok {
+		v = newTokenBucket(burst, rate, now)
+		buckets.Add(name, v)
+	}
+	return v.(*tokenBucket).take(now)
+}
+
+func (rl *rateLimiter) fault(bans *p2p.BlockList, name string, now time.Time) {
+	bans.Add(name, now)
+	if !bans.Blocked(name, now) {
+		return
+	}
+	if _, ok := rl.banned[name]; !ok {
+		log.L().Warn("Banned from adding actions into actpool.", zap.String("name", name))
+	}
+	rl.banned[name] = bans
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/mock/mock_chainmanager"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func testRateLimitCfg() config.ActPoolRateLimit {
+	return config.ActPoolRateLimit{
+		Enabled:          true,
+		SenderBurst:      2,
+		SenderRefillRate: 1,
+		PeerBurst:        3,
+		PeerRefillRate:   1,
+		CacheSize:        10,
+		BanThreshold:     2,
+		BanDuration:      time.Minute,
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	b := newTokenBucket(2, 0.5, now)
+	require.True(b.take(now))
+	require.True(b.take(now))
+	require.False(b.take(now))
+	require.False(b.take(now.Add(time.Second)))
+	require.True(b.take(now.Add(2 * time.Second)))
+	// refill never exceeds burst
+	now = now.Add(time.Hour)
+	require.True(b.take(now))
+	require.True(b.take(now))
+	require.False(b.take(now))
+}
+
+func TestRateLimiter(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	rl := newRateLimiter(testRateLimitCfg())
+	require.NoError(rl.Allow(addr1, "", now))
+	require.NoError(rl.Allow(addr1, "", now))
+	err := rl.Allow(addr1, "", now)
+	require.Equal(action.ErrRateLimit, errors.Cause(err))
+	require.Empty(rl.Bans(now))
+	// the second fault bans the sender
+	require.Error(rl.Allow(addr1, "", now))
+	require.Equal([]Ban{{Name: addr1, Expiry: now.Add(time.Minute)}}, rl.Bans(now))
+	// banned sender is rejected even if its bucket is refilled
+	require.Error(rl.Allow(addr1, "", now.Add(10*time.Second)))
+	// other senders are not affected
+	require.NoError(rl.Allow(addr2, "", now))
+
+	// peer limit is shared by the senders it relays
+	require.NoError(rl.Allow(addr3, "peer", now))
+	require.NoError(rl.Allow(addr4, "peer", now))
+	require.NoError(rl.Allow(addr5, "peer", now))
+	require.Error(rl.Allow(addr2, "peer", now))
+	require.Error(rl.Allow(addr6, "peer", now))
+	require.Equal([]Ban{{Name: addr1, Expiry: now.Add(time.Minute)}, {Name: "peer", Expiry: now.Add(time.Minute)}}, rl.Bans(now))
+
+	// lift the ban
+	require.True(rl.LiftBan("peer"))
+	require.False(rl.LiftBan("peer"))
+	require.NoError(rl.Allow(addr2, "peer", now.Add(time.Second)))
+	// ban expires
+	require.Empty(rl.Bans(now.Add(time.Hour)))
+	require.NoError(rl.Allow(addr1, "", now.Add(time.Hour)))
+
+	// global limit
+	cfg := testRateLimitCfg()
+	cfg.GlobalBurst = 1
+	rl = newRateLimiter(cfg)
+	require.NoError(rl.Allow(addr1, "", time.Now()))
+	err = rl.Allow(addr2, "", time.Now())
+	require.Equal(action.ErrRateLimit, errors.Cause(err))
+	require.Empty(rl.Bans(time.Now()))
+}
+
+func TestActPool_RateLimit(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		acct.Balance = big.NewInt(1000000)
+		return 0, nil
+	}).AnyTimes()
+	apCfg := getActPoolCfg()
+	apCfg.RateLimit = testRateLimitCfg()
+	ap, err := NewActPool(sf, apCfg)
+	require.NoError(err)
+
+	ctx := WithPeer(context.Background(), "peer")
+	for i := uint64(1); i <= 4; i++ {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, i, big.NewInt(10), nil, uint64(10000), big.NewInt(1))
+		require.NoError(err)
+		err = ap.Add(ctx, tsf)
+		if i <= 2 {
+			require.NoError(err)
+		} else {
+			require.Equal(action.ErrRateLimit, errors.Cause(err))
+		}
+	}
+	require.Equal(uint64(2), ap.GetSize())
+	bans := ap.Bans()
+	require.Len(bans, 1)
+	require.Equal(addr1, bans[0].Name)
+
+	// inspect and lift the ban via admin handler
+	bc := NewBanController(ap, "token")
+	w := httptest.NewRecorder()
+	bc.Handle(w, httptest.NewRequest(http.MethodGet, "/actpool/bans", nil))
+	require.Equal(http.StatusOK, w.Code)
+	var payload struct {
+		Bans []Ban `json:"bans"`
+	}
+	require.NoError(json.NewDecoder(w.Body).Decode(&payload))
+	require.Len(payload.Bans, 1)
+	require.Equal(addr1, payload.Bans[0].Name)
+	// GET never changes the bans
+	w = httptest.NewRecorder()
+	bc.Handle(w, httptest.NewRequest(http.MethodGet, "/actpool/bans?name="+addr1, nil))
+	require.Equal(http.StatusOK, w.Code)
+	require.Len(ap.Bans(), 1)
+	w = httptest.NewRecorder()
+	bc.Handle(w, httptest.NewRequest(http.MethodPut, "/actpool/bans?name="+addr1, nil))
+	require.Equal(http.StatusMethodNotAllowed, w.Code)
+	// lifting a ban requires the token
+	authorized := func(method, target, token string) *http.Request {
+		r := httptest.NewRequest(method, target, nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		return r
+	}
+	w = httptest.NewRecorder()
+	bc.Handle(w, httptest.NewRequest(http.MethodDelete, "/actpool/bans?name="+addr1, nil))
+	require.Equal(http.StatusUnauthorized, w.Code)
+	w = httptest.NewRecorder()
+	bc.Handle(w, authorized(http.MethodDelete, "/actpool/bans?name="+addr1, "wrong"))
+	require.Equal(http.StatusUnauthorized, w.Code)
+	w = httptest.NewRecorder()
+	NewBanController(ap, "").Handle(w, authorized(http.MethodDelete, "/actpool/bans?name="+addr1, ""))
+	require.Equal(http.StatusForbidden, w.Code)
+	require.Len(ap.Bans(), 1)
+	w = httptest.NewRecorder()
+	bc.Handle(w, authorized(http.MethodDelete, "/actpool/bans", "token"))
+	require.Equal(http.StatusBadRequest, w.Code)
+	w = httptest.NewRecorder()
+	bc.Handle(w, authorized(http.MethodDelete, "/actpool/bans?name="+addr1, "token"))
+	require.Equal(http.StatusOK, w.Code)
+	require.Empty(ap.Bans())
+	w = httptest.NewRecorder()
+	bc.Handle(w, authorized(http.MethodPost, "/actpool/bans?name="+addr1, "token"))
+	require.Equal(http.StatusNotFound, w.Code)
+
+	// the duplicates of an accepted action are not charged
+	tsf, err := testutil.SignedTransfer(addr2, priKey2, 1, big.NewInt(10), nil, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, tsf))
+	for i := 0; i < 4; i++ {
+		require.NotEqual(action.ErrRateLimit, errors.Cause(ap.Add(ctx, tsf)))
+	}
+	require.Empty(ap.Bans())
+
+	// rate limiting is disabled by default
+	ap, err = NewActPool(sf, getActPoolCfg())
+	require.NoError(err)
+	require.Nil(ap.Bans())
+	require.False(ap.LiftBan(addr1))
+}
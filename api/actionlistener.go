@@ -0,0 +1,111 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"sync"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const _pendingActionBufferSize = 1000
+
+type (
+	// ActionListener passes new pending actions accepted by actpool to all responders
+	ActionListener interface {
+		Start() error
+		Stop() error
+		OnAdded(action.SealedEnvelope)
+		AddResponder(ActionResponder) error
+		RemoveResponder(ActionResponder)
+	}
+
+	// actionListener implements the ActionListener interface
+	actionListener struct {
+		streamMap   sync.Map // all registered <ActionResponder, struct{}>
+		pendingActs chan action.SealedEnvelope
+		cancel      chan interface{}
+	}
+)
+
+// NewActionListener returns a new pending action listener
+func NewActionListener() ActionListener {
+	return &actionListener{
+		pendingActs: make(chan action.SealedEnvelope, _pendingActionBufferSize),
+		cancel:      make(chan interface{}),
+	}
+}
+
+// Start starts the actionListener
+func (al *actionListener) Start() error {
+	go func() {
+		for {
+			select {
+			case <-al.cancel:
+				return
+			case act := <-al.pendingActs:
+				al.respond(act)
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops the actionListener
+func (al *actionListener) Stop() error {
+	close(al.cancel)
+	// notify all responders to exit
+	al.streamMap.Range(func(key, _ interface{}) bool {
+		r, ok := key.(ActionResponder)
+		if !ok {
+			log.S().Panic("streamMap stores a key which is not an ActionResponder")
+		}
+		r.Exit()
+		al.streamMap.Delete(key)
+		return true
+	})
+	return nil
+}
+
+// OnAdded queues the new pending action, which is dropped if the buffer is full, so it never blocks actpool
+func (al *actionListener) OnAdded(act action.SealedEnvelope) {
+	select {
+	case al.pendingActs <- act:
+	default:
+		actHash := act.Hash()
+		log.L().Warn("Pending action buffer is full, drop the action.", log.Hex("hash", actHash[:]))
+	}
+}
+
+// AddResponder adds a new responder
+func (al *actionListener) AddResponder(r ActionResponder) error {
+	_, loaded := al.streamMap.LoadOrStore(r, struct{}{})
+	if loaded {
+		return errorResponderAdded
+	}
+	return nil
+}
+
+// RemoveResponder removes a responder
+func (al *actionListener) RemoveResponder(r ActionResponder) {
+	al.streamMap.Delete(r)
+}
+
+func (al *actionListener) respond(act action.SealedEnvelope) {
+	// pass the action to every responder
+	al.streamMap.Range(func(key, _ interface{}) bool {
+		r, ok := key.(ActionResponder)
+		if !ok {
+			log.S().Panic("streamMap stores a key which is not an ActionResponder")
+		}
+		if err := r.RespondAction(act); err != nil {
+			al.streamMap.Delete(key)
+		}
+		return true
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_apiresponder"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestActionListener(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	responder := mock_apiresponder.NewMockActionResponder(ctrl)
+	listener := NewActionListener()
+	require.NoError(listener.Start())
+	require.NoError(listener.AddResponder(responder))
+	require.Equal(errorResponderAdded, listener.AddResponder(responder))
+
+	tsf, err := testutil.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(0), 1, big.NewInt(1), nil, 10000, big.NewInt(1))
+	require.NoError(err)
+	received := make(chan action.SealedEnvelope, 2)
+	responder.EXPECT().RespondAction(gomock.Any()).DoAndReturn(func(act action.SealedEnvelope) error {
+		received <- act
+		return nil
+	}).Times(1)
+	listener.OnAdded(tsf)
+	require.Equal(tsf, waitForAction(t, received))
+
+	// responder is removed after failing to respond
+	responder.EXPECT().RespondAction(gomock.Any()).DoAndReturn(func(act action.SealedEnvelope) error {
+		received <- act
+		return errors.New("Error when streaming the action")
+	}).Times(1)
+	listener.OnAdded(tsf)
+	require.Equal(tsf, waitForAction(t, received))
+	require.NoError(listener.AddResponder(responder))
+	listener.RemoveResponder(responder)
+	require.NoError(listener.AddResponder(responder))
+
+	responder.EXPECT().Exit().Return().Times(1)
+	require.NoError(listener.Stop())
+}
+
+func waitForAction(t *testing.T, received chan action.SealedEnvelope) action.SealedEnvelope {
+	select {
+	case act := <-received:
+		return act
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for pending action")
+	}
+	return action.SealedEnvelope{}
+}
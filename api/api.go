@@ -14,8 +14,10 @@ import (
 	"math/big"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
@@ -36,9 +38,11 @@ import (
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/api/apipb"
 	logfilter "github.com/iotexproject/iotex-core/api/logfilter"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
@@ -46,7 +50,9 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/filedao"
 	"github.com/iotexproject/iotex-core/blockindex"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
 	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/trie"
 	"github.com/iotexproject/iotex-core/gasstation"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/version"
@@ -70,6 +76,8 @@ type BroadcastOutbound func(ctx context.Context, chainID uint32, msg proto.Messa
 type Config struct {
 	broadcastHandler  BroadcastOutbound
 	electionCommittee committee.Committee
+	roundInspector    scheme.RoundInspector
+	evidenceReader    scheme.EvidenceReader
 }
 
 // Option is the option to override the api config
@@ -91,6 +99,22 @@ func WithNativeElection(committee committee.Committee) Option {
 	}
 }
 
+// WithRoundInspector is the option to return the consensus rounds through API.
+func WithRoundInspector(ri scheme.RoundInspector) Option {
+	return func(cfg *Config) error {
+		cfg.roundInspector = ri
+		return nil
+	}
+}
+
+// WithEvidenceReader is the option to return the double-sign evidence detected by consensus through API.
+func WithEvidenceReader(er scheme.EvidenceReader) Option {
+	return func(cfg *Config) error {
+		cfg.evidenceReader = er
+		return nil
+	}
+}
+
 // Server provides api for user to query blockchain data
 type Server struct {
 	bc                blockchain.Blockchain
@@ -104,9 +128,12 @@ type Server struct {
 	cfg               config.Config
 	registry          *protocol.Registry
 	chainListener     Listener
+	actionListener    ActionListener
 	grpcServer        *grpc.Server
 	hasActionIndex    bool
 	electionCommittee committee.Committee
+	roundInspector    scheme.RoundInspector
+	evidenceReader    scheme.EvidenceReader
 }
 
 // NewServer creates a new server
@@ -148,8 +175,11 @@ func NewServer(
 		cfg:               cfg,
 		registry:          registry,
 		chainListener:     NewChainListener(),
+		actionListener:    NewActionListener(),
 		gs:                gasstation.NewGasStation(chain, sf.SimulateExecution, dao, cfg.API),
 		electionCommittee: apiCfg.electionCommittee,
+		roundInspector:    apiCfg.roundInspector,
+		evidenceReader:    apiCfg.evidenceReader,
 	}
 	if _, ok := cfg.Plugins[config.GatewayPlugin]; ok {
 		svr.hasActionIndex = true
@@ -159,6 +189,7 @@ func NewServer(
 		grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
 	)
 	iotexapi.RegisterAPIServiceServer(svr.grpcServer, svr)
+	apipb.RegisterAPIServiceServer(svr.grpcServer, svr)
 	grpc_prometheus.Register(svr.grpcServer)
 	reflection.Register(svr.grpcServer)
 
@@ -171,13 +202,20 @@ func (api *Server) GetAccount(ctx context.Context, in *iotexapi.GetAccountReques
 		return api.getProtocolAccount(ctx, in.Address)
 	}
 
-	state, tipHeight, err := accountutil.AccountStateWithHeight(api.sf, in.Address)
+	height, history, err := api.stateHeight(ctx)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, err
 	}
-	pendingNonce, err := api.ap.GetPendingNonce(in.Address)
+	state, height, err := accountutil.AccountStateWithHeight(api.stateReader(height, history), in.Address)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	// the pending actions only apply on top of the tip
+	pendingNonce := state.Nonce + 1
+	if !history {
+		if pendingNonce, err = api.ap.GetPendingNonce(in.Address); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 	}
 	if api.indexer == nil {
 		return nil, status.Error(codes.NotFound, blockindex.ErrActionIndexNA.Error())
@@ -198,17 +236,91 @@ func (api *Server) GetAccount(ctx context.Context, in *iotexapi.GetAccountReques
 		NumActions:   numActions,
 		IsContract:   state.IsContract(),
 	}
-	header, err := api.bc.BlockHeaderByHeight(tipHeight)
+	header, err := api.bc.BlockHeaderByHeight(height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 	hash := header.HashBlock()
 	return &iotexapi.GetAccountResponse{AccountMeta: accountMeta, BlockIdentifier: &iotextypes.BlockIdentifier{
 		Hash:   hex.EncodeToString(hash[:]),
-		Height: tipHeight,
+		Height: height,
 	}}, nil
 }
 
+// GetAccountProof returns the merkle proofs of an account and the storage slots of a contract in the state trie
+func (api *Server) GetAccountProof(ctx context.Context, in *apipb.GetAccountProofRequest) (*apipb.GetAccountProofResponse, error) {
+	addr, err := address.FromString(in.GetAddress())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	keys := make([]hash.Hash256, 0, len(in.GetStorageKeys()))
+	for _, k := range in.GetStorageKeys() {
+		key, err := hex.DecodeString(strings.TrimPrefix(k, "0x"))
+		if err != nil || len(key) != len(hash.ZeroHash256) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid storage key %s", k)
+		}
+		keys = append(keys, hash.BytesToHash256(key))
+	}
+	height, history, err := api.stateHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !history {
+		height = api.bc.TipHeight()
+	}
+	pkHash := hash.BytesToHash160(addr.Bytes())
+	root, proof, err := api.sf.ProveState(height, protocol.LegacyKeyOption(pkHash))
+	if err != nil {
+		if errors.Cause(err) == factory.ErrNotSupported {
+			return nil, status.Error(codes.Unimplemented, "state proof is not available without the state trie")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	// the account is decoded from the proven leaf, so that it always agrees with the proof
+	account := state.EmptyAccount()
+	value, err := factory.VerifyStateProof(root, proof, protocol.LegacyKeyOption(pkHash))
+	switch errors.Cause(err) {
+	case nil:
+		if err := state.Deserialize(&account, value); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	case state.ErrStateNotExist:
+	default:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	sr := api.stateReader(height, history)
+	blkHash, err := api.dao.GetBlockHash(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	res := &apipb.GetAccountProofResponse{
+		StateRoot:    root,
+		AccountProof: proof,
+		Balance:      account.Balance.String(),
+		Nonce:        account.Nonce,
+		CodeHash:     account.CodeHash,
+		StorageRoot:  account.Root[:],
+		Height:       height,
+		BlockHash:    hex.EncodeToString(blkHash[:]),
+	}
+	for i, key := range keys {
+		storageProof, err := evm.ProveStorage(sr, pkHash, &account, key)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		value, err := evm.VerifyStorageProof(pkHash, account.Root, key, storageProof)
+		if err != nil && errors.Cause(err) != trie.ErrNotExist {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		res.StorageProofs = append(res.StorageProofs, &apipb.StorageProof{
+			Key:   in.GetStorageKeys()[i],
+			Value: value,
+			Proof: storageProof,
+		})
+	}
+	return res, nil
+}
+
 // GetActions returns actions
 func (api *Server) GetActions(ctx context.Context, in *iotexapi.GetActionsRequest) (*iotexapi.GetActionsResponse, error) {
 	if (!api.hasActionIndex || api.indexer == nil) && (in.GetByHash() != nil || in.GetByAddr() != nil) {
@@ -360,6 +472,8 @@ func (api *Server) SendAction(ctx context.Context, in *iotexapi.SendActionReques
 			desc = "Invalid actpool"
 		case action.ErrGasPrice:
 			desc = "Invalid gas price"
+		case action.ErrRateLimit:
+			desc = "Rate limited"
 		default:
 			desc = "Unknown"
 		}
@@ -396,7 +510,7 @@ func (api *Server) GetReceiptByAction(ctx context.Context, in *iotexapi.GetRecei
 	}
 	receipt, err := api.GetReceiptByActionHash(actHash)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, blockDataError(err, codes.NotFound)
 	}
 	blkHash, err := api.getBlockHashByActionHash(actHash)
 	if err != nil {
@@ -414,42 +528,80 @@ func (api *Server) GetReceiptByAction(ctx context.Context, in *iotexapi.GetRecei
 func (api *Server) ReadContract(ctx context.Context, in *iotexapi.ReadContractRequest) (*iotexapi.ReadContractResponse, error) {
 	log.L().Debug("receive read smart contract request")
 
+	retval, receipt, err := api.simulateExecution(ctx, in.CallerAddress, in.Execution, api.cfg.Genesis.BlockGasLimit, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &iotexapi.ReadContractResponse{
+		Data:    hex.EncodeToString(retval),
+		Receipt: receipt.ConvertToReceiptPb(),
+	}, nil
+}
+
+// simulateExecution simulates the execution on top of the state queried by the metadata of ctx, which is traced by
+// tracer if it is not nil
+func (api *Server) simulateExecution(
+	ctx context.Context,
+	callerAddress string,
+	execution *iotextypes.Execution,
+	gasLimit uint64,
+	tracer vm.Tracer,
+) ([]byte, *action.Receipt, error) {
 	sc := &action.Execution{}
-	if err := sc.LoadProto(in.Execution); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+	if err := sc.LoadProto(execution); err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	state, err := accountutil.AccountState(api.sf, in.CallerAddress)
+	height, history, err := api.stateHeight(ctx)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, nil, err
+	}
+	state, err := accountutil.AccountState(api.stateReader(height, history), callerAddress)
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	sc, _ = action.NewExecution(
 		sc.Contract(),
 		state.Nonce+1,
 		sc.Amount(),
-		api.cfg.Genesis.BlockGasLimit,
+		gasLimit,
 		big.NewInt(0),
 		sc.Data(),
 	)
 
-	callerAddr, err := address.FromString(in.CallerAddress)
+	callerAddr, err := address.FromString(callerAddress)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	ctx, err = api.bc.Context()
-	if err != nil {
-		return nil, err
+	var (
+		retval  []byte
+		receipt *action.Receipt
+	)
+	if history {
+		ctx, err = api.historyContext(height)
+		if err != nil {
+			return nil, nil, status.Error(codes.NotFound, err.Error())
+		}
+		if tracer != nil {
+			ctx = evm.WithTracerCtx(ctx, tracer)
+		}
+		retval, receipt, err = api.sf.SimulateExecutionAtHeight(ctx, height, callerAddr, sc, api.dao.GetBlockHash)
+	} else {
+		ctx, err = api.bc.Context()
+		if err != nil {
+			return nil, nil, err
+		}
+		if tracer != nil {
+			ctx = evm.WithTracerCtx(ctx, tracer)
+		}
+		retval, receipt, err = api.sf.SimulateExecution(ctx, callerAddr, sc, api.dao.GetBlockHash)
 	}
-	retval, receipt, err := api.sf.SimulateExecution(ctx, callerAddr, sc, api.dao.GetBlockHash)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, nil, status.Error(codes.Internal, err.Error())
 	}
-	return &iotexapi.ReadContractResponse{
-		Data:    hex.EncodeToString(retval),
-		Receipt: receipt.ConvertToReceiptPb(),
-	}, nil
+	return retval, receipt, nil
 }
 
 // ReadState reads state on blockchain
@@ -458,7 +610,26 @@ func (api *Server) ReadState(ctx context.Context, in *iotexapi.ReadStateRequest)
 	if !ok {
 		return nil, status.Errorf(codes.Internal, "protocol %s isn't registered", string(in.ProtocolID))
 	}
-	data, readStateHeight, err := api.readState(ctx, p, in.GetHeight(), in.MethodName, in.Arguments...)
+	height := in.GetHeight()
+	if height == "" {
+		h, history, err := api.stateHeight(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if history {
+			height = strconv.FormatUint(h, 10)
+		}
+	} else {
+		h, err := strconv.ParseUint(height, 0, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid block height %s", height)
+		}
+		// protocols keeping the states per epoch serve the earlier epochs without the archive mode
+		if _, _, err := api.checkStateHeight(h, api.bc.TipHeight()); err != nil && status.Code(err) != codes.FailedPrecondition {
+			return nil, err
+		}
+	}
+	data, readStateHeight, err := api.readState(ctx, p, height, in.MethodName, in.Arguments...)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -488,11 +659,52 @@ func (api *Server) SuggestGasPrice(ctx context.Context, in *iotexapi.SuggestGasP
 	return &iotexapi.SuggestGasPriceResponse{GasPrice: suggestPrice}, nil
 }
 
+// SuggestGasPriceTiers suggests gas prices for slow, standard and fast tiers
+func (api *Server) SuggestGasPriceTiers(
+	ctx context.Context,
+	in *apipb.SuggestGasPriceTiersRequest,
+) (*apipb.SuggestGasPriceTiersResponse, error) {
+	slow, standard, fast, err := api.gs.SuggestGasPriceTiers()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &apipb.SuggestGasPriceTiersResponse{Slow: slow, Standard: standard, Fast: fast}, nil
+}
+
+// GetFeeHistory returns the gas used ratios and the gas price percentiles of a range of blocks
+func (api *Server) GetFeeHistory(ctx context.Context, in *apipb.GetFeeHistoryRequest) (*apipb.GetFeeHistoryResponse, error) {
+	if in.GetBlockCount() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "block count must be positive")
+	}
+	if in.GetBlockCount() > api.cfg.API.RangeQueryLimit {
+		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	fh, err := api.gs.FeeHistory(in.GetNewestBlock(), in.GetBlockCount(), in.GetRewardPercentiles())
+	if err != nil {
+		if errors.Cause(err) == gasstation.ErrInvalidFeeHistoryQuery {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := &apipb.GetFeeHistoryResponse{
+		OldestBlock:   fh.OldestBlock,
+		GasUsedRatios: fh.GasUsedRatios,
+	}
+	for _, rewards := range fh.Rewards {
+		br := &apipb.BlockRewards{}
+		for _, reward := range rewards {
+			br.Rewards = append(br.Rewards, reward.String())
+		}
+		res.Rewards = append(res.Rewards, br)
+	}
+	return res, nil
+}
+
 // EstimateGasForAction estimates gas for action
 func (api *Server) EstimateGasForAction(ctx context.Context, in *iotexapi.EstimateGasForActionRequest) (*iotexapi.EstimateGasForActionResponse, error) {
 	estimateGas, err := api.gs.EstimateGasForAction(in.Action)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, estimateGasError(err)
 	}
 	return &iotexapi.EstimateGasForActionResponse{Gas: estimateGas}, nil
 }
@@ -630,13 +842,13 @@ func (api *Server) GetRawBlocks(
 		}
 		blk, err := api.dao.GetBlockByHeight(uint64(height))
 		if err != nil {
-			return nil, status.Error(codes.NotFound, err.Error())
+			return nil, blockDataError(err, codes.NotFound)
 		}
 		var receiptsPb []*iotextypes.Receipt
 		if in.WithReceipts {
 			receipts, err := api.dao.GetReceipts(uint64(height))
 			if err != nil {
-				return nil, status.Error(codes.NotFound, err.Error())
+				return nil, blockDataError(err, codes.NotFound)
 			}
 			for _, receipt := range receipts {
 				receiptsPb = append(receiptsPb, receipt.ConvertToReceiptPb())
@@ -645,7 +857,7 @@ func (api *Server) GetRawBlocks(
 		var transactionLogs *iotextypes.TransactionLogs
 		if in.WithTransactionLogs {
 			if transactionLogs, err = api.dao.TransactionLogs(uint64(height)); err != nil {
-				return nil, status.Error(codes.NotFound, err.Error())
+				return nil, blockDataError(err, codes.NotFound)
 			}
 		}
 		res = append(res, &iotexapi.BlockInfo{
@@ -741,6 +953,33 @@ func (api *Server) StreamLogs(in *iotexapi.StreamLogsRequest, stream iotexapi.AP
 	}
 }
 
+// StreamPendingActions streams pending actions accepted by actpool that match the filter condition
+func (api *Server) StreamPendingActions(
+	in *apipb.StreamPendingActionsRequest,
+	stream apipb.APIService_StreamPendingActionsServer,
+) error {
+	errChan := make(chan error, 1)
+	filter, err := NewPendingActionFilter(in.GetFilter(), stream, errChan)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := api.actionListener.AddResponder(filter); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	// the responder is removed once the stream ends, including when the client disconnects
+	defer api.actionListener.RemoveResponder(filter)
+
+	select {
+	case <-stream.Context().Done():
+		return status.Error(codes.Canceled, stream.Context().Err().Error())
+	case err := <-errChan:
+		if err != nil {
+			err = status.Error(codes.Aborted, err.Error())
+		}
+		return err
+	}
+}
+
 // GetElectionBuckets returns the native election buckets.
 func (api *Server) GetElectionBuckets(
 	ctx context.Context,
@@ -833,7 +1072,7 @@ func (api *Server) GetTransactionLogByActionHash(
 		if errors.Cause(err) == db.ErrNotExist {
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, blockDataError(err, codes.Internal)
 	}
 
 	for _, log := range sysLog.Logs {
@@ -883,7 +1122,7 @@ func (api *Server) GetTransactionLogByBlockHeight(
 			res.TransactionLogs = &iotextypes.TransactionLogs{}
 			return res, nil
 		}
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, blockDataError(err, codes.Internal)
 	}
 
 	res.TransactionLogs = sysLog
@@ -911,6 +1150,15 @@ func (api *Server) Start() error {
 	if err := api.chainListener.Start(); err != nil {
 		return errors.Wrap(err, "failed to start blockchain listener")
 	}
+	if err := api.bc.AddSubscriber(api.gs); err != nil {
+		return errors.Wrap(err, "failed to subscribe gas station to block creations")
+	}
+	if err := api.ap.AddSubscriber(api.actionListener); err != nil {
+		return errors.Wrap(err, "failed to subscribe to pending actions")
+	}
+	if err := api.actionListener.Start(); err != nil {
+		return errors.Wrap(err, "failed to start pending action listener")
+	}
 	return nil
 }
 
@@ -920,6 +1168,15 @@ func (api *Server) Stop() error {
 	if err := api.bc.RemoveSubscriber(api.chainListener); err != nil {
 		return errors.Wrap(err, "failed to unsubscribe blockchain listener")
 	}
+	if err := api.bc.RemoveSubscriber(api.gs); err != nil {
+		return errors.Wrap(err, "failed to unsubscribe gas station")
+	}
+	if err := api.ap.RemoveSubscriber(api.actionListener); err != nil {
+		return errors.Wrap(err, "failed to unsubscribe pending action listener")
+	}
+	if err := api.actionListener.Stop(); err != nil {
+		return errors.Wrap(err, "failed to stop pending action listener")
+	}
 	return api.chainListener.Stop()
 }
 
@@ -952,6 +1209,9 @@ func (api *Server) readState(ctx context.Context, p protocol.Protocol, height st
 			// old data, wrap to history state reader
 			return p.ReadState(ctx, factory.NewHistoryStateReader(api.sf, rp.GetEpochHeight(inputEpochNum)), methodName, arguments...)
 		}
+		if api.cfg.Chain.EnableArchiveMode && inputHeight < tipHeight {
+			return p.ReadState(ctx, factory.NewHistoryStateReader(api.sf, inputHeight), methodName, arguments...)
+		}
 	}
 
 	// TODO: need to distinguish user error and system error
@@ -1014,7 +1274,7 @@ func (api *Server) getActions(start uint64, count uint64) (*iotexapi.GetActionsR
 	for height := api.bc.TipHeight(); height >= 1 && count > 0; height-- {
 		blk, err := api.dao.GetBlockByHeight(height)
 		if err != nil {
-			return nil, status.Error(codes.NotFound, err.Error())
+			return nil, blockDataError(err, codes.NotFound)
 		}
 		if !hit && reverseStart >= uint64(len(blk.Actions)) {
 			reverseStart -= uint64(len(blk.Actions))
@@ -1041,7 +1301,7 @@ func (api *Server) getSingleAction(actionHash string, checkPending bool) (*iotex
 	}
 	act, err := api.getAction(actHash, checkPending)
 	if err != nil {
-		return nil, status.Error(codes.Unavailable, err.Error())
+		return nil, blockDataError(err, codes.Unavailable)
 	}
 	return &iotexapi.GetActionsResponse{
 		Total:      1,
@@ -1153,7 +1413,7 @@ func (api *Server) getActionsByBlock(blkHash string, start uint64, count uint64)
 	}
 	blk, err := api.dao.GetBlock(hash)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, blockDataError(err, codes.NotFound)
 	}
 	if start >= uint64(len(blk.Actions)) {
 		return nil, status.Error(codes.InvalidArgument, "start exceeds the limit")
@@ -1267,7 +1527,7 @@ func (api *Server) getBlockMetasByHeader(height uint64) (*iotextypes.BlockMeta,
 func (api *Server) getBlockMetasByBlock(height uint64) (*iotextypes.BlockMeta, error) {
 	blk, err := api.dao.GetBlockByHeight(height)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, blockDataError(err, codes.NotFound)
 	}
 	blockMeta := api.getCommonBlockMeta(blk)
 	blockMeta = api.putBlockMetaUpgradeByBlock(blk, blockMeta)
@@ -1292,7 +1552,7 @@ func (api *Server) getBlockMetaByHeader(h hash.Hash256) (*iotextypes.BlockMeta,
 func (api *Server) getBlockMetaByBlock(h hash.Hash256) (*iotextypes.BlockMeta, error) {
 	blk, err := api.dao.GetBlock(h)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, blockDataError(err, codes.NotFound)
 	}
 	blockMeta := api.getCommonBlockMeta(blk)
 	blockMeta = api.putBlockMetaUpgradeByBlock(blk, blockMeta)
@@ -1394,6 +1654,9 @@ func (api *Server) getAction(actHash hash.Hash256, checkPending bool) (*iotexapi
 	if err == nil {
 		return api.committedAction(selp, blkHash, blkHeight)
 	}
+	if errors.Cause(err) == filedao.ErrBlockPruned {
+		return nil, err
+	}
 	// Try to fetch pending action from actpool
 	if checkPending {
 		selp, err = api.ap.GetActionByHash(actHash)
@@ -1476,7 +1739,7 @@ func (api *Server) getLogsInBlock(filter *logfilter.LogFilter, blockNumber uint6
 	}
 	receipts, err := api.dao.GetReceipts(blockNumber)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, blockDataError(err, codes.InvalidArgument)
 	}
 	return filter.MatchLogs(receipts), nil
 }
@@ -1512,7 +1775,7 @@ func (api *Server) getLogsInRange(filter *logfilter.LogFilter, start, end, pagin
 	return logs, nil
 }
 
-// TODO: Since GasConsumed on the receipt may not be enough for the gas limit, we use binary search for the gas estimate. Need a better way to address it later.
+// estimateActionGasConsumptionForExecution binary searches the minimal gas limit with which the execution succeeds
 func (api *Server) estimateActionGasConsumptionForExecution(exec *iotextypes.Execution, sender string) (*iotexapi.EstimateActionGasConsumptionResponse, error) {
 	sc := &action.Execution{}
 	if err := sc.LoadProto(exec); err != nil {
@@ -1529,49 +1792,10 @@ func (api *Server) estimateActionGasConsumptionForExecution(exec *iotextypes.Exe
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	sc, _ = action.NewExecution(
-		sc.Contract(),
-		nonce,
-		sc.Amount(),
-		api.cfg.Genesis.BlockGasLimit,
-		big.NewInt(0),
-		sc.Data(),
-	)
-
-	ctx, err := api.bc.Context()
-	if err != nil {
-		return nil, err
-	}
-	_, receipt, err := api.sf.SimulateExecution(ctx, callerAddr, sc, api.dao.GetBlockHash)
-
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-	if receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
-		return nil, status.Error(codes.Internal, "execution simulation gets failure status")
-	}
-	estimatedGas := receipt.GasConsumed
-	enough, err := api.isGasLimitEnough(callerAddr, sc, nonce, estimatedGas)
+	estimatedGas, err := api.gs.EstimateExecutionGas(callerAddr, sc, nonce)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-	if !enough {
-		low, high := estimatedGas, api.cfg.Genesis.BlockGasLimit
-		estimatedGas = high
-		for low <= high {
-			mid := (low + high) / 2
-			enough, err = api.isGasLimitEnough(callerAddr, sc, nonce, mid)
-			if err != nil {
-				return nil, status.Error(codes.Internal, err.Error())
-			}
-			if enough {
-				estimatedGas = mid
-				break
-			}
-			low = mid + 1
-		}
+		return nil, estimateGasError(err)
 	}
-
 	return &iotexapi.EstimateActionGasConsumptionResponse{
 		Gas: estimatedGas,
 	}, nil
@@ -1584,31 +1808,6 @@ func (api *Server) estimateActionGasConsumptionForTransfer(transfer *iotextypes.
 	}, nil
 }
 
-func (api *Server) isGasLimitEnough(
-	caller address.Address,
-	sc *action.Execution,
-	nonce uint64,
-	gasLimit uint64,
-) (bool, error) {
-	sc, _ = action.NewExecution(
-		sc.Contract(),
-		nonce,
-		sc.Amount(),
-		gasLimit,
-		big.NewInt(0),
-		sc.Data(),
-	)
-	ctx, err := api.bc.Context()
-	if err != nil {
-		return false, err
-	}
-	_, receipt, err := api.sf.SimulateExecution(ctx, caller, sc, api.dao.GetBlockHash)
-	if err != nil {
-		return false, err
-	}
-	return receipt.Status == uint64(iotextypes.ReceiptStatus_Success), nil
-}
-
 func (api *Server) getProductivityByEpoch(
 	rp *rolldpos.Protocol,
 	epochNum uint64,
@@ -1718,3 +1917,33 @@ func (api *Server) GetActPoolActions(ctx context.Context, in *iotexapi.GetActPoo
 
 	return ret, nil
 }
+
+// estimateGasError converts the error of gas estimation into gRPC error, attaching the revert reason if the execution
+// fails
+func estimateGasError(err error) error {
+	execErr, ok := errors.Cause(err).(*gasstation.ExecutionError)
+	if !ok {
+		return status.Error(codes.Internal, err.Error())
+	}
+	st := status.New(codes.Internal, execErr.Error())
+	st, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: iotextypes.ReceiptStatus_name[int32(execErr.Status)],
+		Domain: "iotex.io",
+		Metadata: map[string]string{
+			"revertReason": execErr.RevertReason(),
+			"revertData":   hex.EncodeToString(execErr.RevertData),
+		},
+	})
+	if detailErr != nil {
+		log.S().Panicf("Unexpected error attaching metadata: %v", detailErr)
+	}
+	return st.Err()
+}
+
+// blockDataError returns the error of reading the block data, which is out of range if the block data has been pruned
+func blockDataError(err error, c codes.Code) error {
+	if errors.Cause(err) == filedao.ErrBlockPruned {
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	return status.Error(c, err.Error())
+}
@@ -9,6 +9,7 @@ package api
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"math"
 	"math/big"
 	"strconv"
@@ -20,7 +21,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/iotexproject/go-pkgs/hash"
@@ -38,6 +41,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/api/apipb"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/blockdao"
@@ -51,6 +55,7 @@ import (
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/test/mock/mock_apiserver"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/testutil"
 )
@@ -798,6 +803,115 @@ func TestServer_GetAccount(t *testing.T) {
 	require.Contains(err.Error(), "protocol staking isn't registered")
 }
 
+func TestServer_GetAccountAtHeight(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig(t)
+	cfg.Chain.EnableArchiveMode = true
+	cfg.DB.HistoryStateRetention = 3
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	tipHeight := svr.bc.TipHeight()
+	require.True(tipHeight > 3)
+
+	addr := identityset.Address(27).String()
+	tip, err := svr.GetAccount(context.Background(), &iotexapi.GetAccountRequest{Address: addr})
+	require.NoError(err)
+	require.Equal(tipHeight, tip.BlockIdentifier.Height)
+
+	// success: state at the tip and at an earlier height
+	res, err := svr.GetAccount(WithStateHeight(context.Background(), tipHeight), &iotexapi.GetAccountRequest{Address: addr})
+	require.NoError(err)
+	require.Equal(tip.AccountMeta, res.AccountMeta)
+	res, err = svr.GetAccount(WithStateHeight(context.Background(), tipHeight-3), &iotexapi.GetAccountRequest{Address: addr})
+	require.NoError(err)
+	require.Equal(tipHeight-3, res.BlockIdentifier.Height)
+	require.Equal("9999999999999999999999999990", res.AccountMeta.Balance)
+	require.NotEqual(tip.AccountMeta.Balance, res.AccountMeta.Balance)
+	require.Equal(res.AccountMeta.Nonce+1, res.AccountMeta.PendingNonce)
+	blkHash, err := svr.dao.GetBlockHash(tipHeight - 3)
+	require.NoError(err)
+	require.Equal(hex.EncodeToString(blkHash[:]), res.BlockIdentifier.Hash)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(BlockHashMetadataKey, hex.EncodeToString(blkHash[:])))
+	res2, err := svr.GetAccount(ctx, &iotexapi.GetAccountRequest{Address: addr})
+	require.NoError(err)
+	require.Equal(res.AccountMeta, res2.AccountMeta)
+
+	// failure: invalid height or hash, height higher than tip, pruned state
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(BlockHeightMetadataKey, "abc"))
+	_, err = svr.GetAccount(ctx, &iotexapi.GetAccountRequest{Address: addr})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(BlockHashMetadataKey, hex.EncodeToString(hash.ZeroHash256[:])))
+	_, err = svr.GetAccount(ctx, &iotexapi.GetAccountRequest{Address: addr})
+	require.Equal(codes.NotFound, status.Code(err))
+	_, err = svr.GetAccount(WithStateHeight(context.Background(), tipHeight+1), &iotexapi.GetAccountRequest{Address: addr})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = svr.GetAccount(WithStateHeight(context.Background(), tipHeight-4), &iotexapi.GetAccountRequest{Address: addr})
+	require.Equal(codes.OutOfRange, status.Code(err))
+	_, err = svr.ReadContract(WithStateHeight(context.Background(), tipHeight-4), &iotexapi.ReadContractRequest{
+		Execution:     &iotextypes.Execution{Amount: "0"},
+		CallerAddress: addr,
+	})
+	require.Equal(codes.OutOfRange, status.Code(err))
+
+	// failure: archive mode is disabled
+	svr.cfg.Chain.EnableArchiveMode = false
+	_, err = svr.GetAccount(WithStateHeight(context.Background(), tipHeight-3), &iotexapi.GetAccountRequest{Address: addr})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	_, err = svr.ReadState(WithStateHeight(context.Background(), tipHeight-3), &iotexapi.ReadStateRequest{
+		ProtocolID: []byte("rewarding"),
+		MethodName: []byte("TotalBalance"),
+	})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func TestServer_GetAccountProof(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig(t)
+	cfg.Chain.EnableArchiveMode = true
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	tipHeight := svr.bc.TipHeight()
+
+	addr := identityset.Address(27)
+	key := protocol.LegacyKeyOption(hash.BytesToHash160(addr.Bytes()))
+	for _, ctx := range []context.Context{
+		context.Background(),
+		WithStateHeight(context.Background(), tipHeight-3),
+	} {
+		res, err := svr.GetAccountProof(ctx, &apipb.GetAccountProofRequest{
+			Address:     addr.String(),
+			StorageKeys: []string{"0x" + hex.EncodeToString(hash.ZeroHash256[:])},
+		})
+		require.NoError(err)
+		account, err := svr.GetAccount(ctx, &iotexapi.GetAccountRequest{Address: addr.String()})
+		require.NoError(err)
+		require.Equal(account.BlockIdentifier.Height, res.Height)
+		require.Equal(account.BlockIdentifier.Hash, res.BlockHash)
+		require.Equal(account.AccountMeta.Balance, res.Balance)
+		data, err := factory.VerifyStateProof(res.StateRoot, res.AccountProof, key)
+		require.NoError(err)
+		var s state.Account
+		require.NoError(state.Deserialize(&s, data))
+		require.Equal(res.Balance, s.Balance.String())
+		require.Equal(res.Nonce, s.Nonce)
+		// the account has no storage
+		require.Len(res.StorageProofs, 1)
+		require.Empty(res.StorageProofs[0].Value)
+		require.Empty(res.StorageProofs[0].Proof)
+	}
+
+	// failure: invalid address or storage key
+	_, err = svr.GetAccountProof(context.Background(), &apipb.GetAccountProofRequest{Address: "io1"})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = svr.GetAccountProof(context.Background(), &apipb.GetAccountProofRequest{
+		Address:     addr.String(),
+		StorageKeys: []string{"0x1234"},
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
 func TestServer_GetActions(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig(t)
@@ -1055,6 +1169,7 @@ func TestServer_GetChainMeta(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				cfg.Genesis.NumCandidateDelegates,
 				cfg.Genesis.NumDelegates,
 				cfg.Genesis.DardanellesNumSubEpochs,
@@ -1167,6 +1282,31 @@ func TestServer_StreamLogs(t *testing.T) {
 	require.Error(err)
 }
 
+func TestServer_StreamPendingActions(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cfg := newConfig(t)
+
+	svr, err := createServer(cfg, true)
+	require.NoError(err)
+
+	// the responder is removed when the client disconnects
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stream := mock_apiserver.NewMockStreamPendingActionsServer(ctrl)
+	stream.EXPECT().Context().Return(ctx).AnyTimes()
+	svr.actionListener = NewActionListener()
+	err = svr.StreamPendingActions(&apipb.StreamPendingActionsRequest{}, stream)
+	require.Equal(codes.Canceled, status.Code(err))
+	removed := true
+	svr.actionListener.(*actionListener).streamMap.Range(func(_, _ interface{}) bool {
+		removed = false
+		return false
+	})
+	require.True(removed)
+}
+
 func TestServer_GetReceiptByAction(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig(t)
@@ -1210,6 +1350,69 @@ func TestServer_ReadContract(t *testing.T) {
 	}
 }
 
+func TestServer_Trace(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig(t)
+	cfg.Chain.EnableArchiveMode = true
+	cfg.API.TraceLimit = 2
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+
+	// the debug API is disabled by default
+	_, err = svr.TraceTransaction(context.Background(), &apipb.TraceTransactionRequest{
+		ActionHash: hex.EncodeToString(executionHash2[:]),
+	})
+	require.Equal(codes.Unimplemented, status.Code(err))
+	svr.cfg.API.EnableDebugAPI = true
+
+	ai, err := svr.indexer.GetActionIndex(executionHash2[:])
+	require.NoError(err)
+	exec, err := svr.dao.GetActionByActionHash(executionHash2, ai.BlockHeight())
+	require.NoError(err)
+	res, err := svr.TraceCall(context.Background(), &apipb.TraceCallRequest{
+		CallerAddress: identityset.Address(30).String(),
+		Execution:     exec.Proto().GetCore().GetExecution(),
+		Options:       &apipb.TraceOptions{Tracer: "callTracer"},
+	})
+	require.NoError(err)
+	require.NotNil(res.Receipt)
+	var call map[string]interface{}
+	require.NoError(json.Unmarshal(res.Trace, &call))
+	require.Equal("CALL", call["type"])
+
+	res, err = svr.TraceTransaction(context.Background(), &apipb.TraceTransactionRequest{
+		ActionHash: hex.EncodeToString(executionHash2[:]),
+	})
+	require.NoError(err)
+	require.Equal(executionHash2[:], res.Receipt.ActHash)
+	var logs struct {
+		StructLogs []map[string]interface{} `json:"structLogs"`
+	}
+	require.NoError(json.Unmarshal(res.Trace, &logs))
+	// the struct logs are capped by the trace limit, and capture no memory by default
+	require.LessOrEqual(len(logs.StructLogs), 2)
+	for _, log := range logs.StructLogs {
+		require.NotContains(log, "memory")
+	}
+
+	// failure: not an execution, unknown tracer, archive mode is disabled
+	_, err = svr.TraceTransaction(context.Background(), &apipb.TraceTransactionRequest{
+		ActionHash: hex.EncodeToString(transferHash1[:]),
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = svr.TraceTransaction(context.Background(), &apipb.TraceTransactionRequest{
+		ActionHash: hex.EncodeToString(executionHash2[:]),
+		Options:    &apipb.TraceOptions{Tracer: "jsTracer"},
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	svr.cfg.Chain.EnableArchiveMode = false
+	_, err = svr.TraceTransaction(context.Background(), &apipb.TraceTransactionRequest{
+		ActionHash: hex.EncodeToString(executionHash2[:]),
+	})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
 func TestServer_SuggestGasPrice(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig(t)
@@ -1224,6 +1427,50 @@ func TestServer_SuggestGasPrice(t *testing.T) {
 	}
 }
 
+func TestServer_SuggestGasPriceTiers(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig(t)
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	res, err := svr.SuggestGasPriceTiers(context.Background(), &apipb.SuggestGasPriceTiersRequest{})
+	require.NoError(err)
+	require.True(res.Slow <= res.Standard)
+	require.True(res.Standard <= res.Fast)
+}
+
+func TestServer_GetFeeHistory(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig(t)
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	tip := svr.bc.TipHeight()
+	res, err := svr.GetFeeHistory(context.Background(), &apipb.GetFeeHistoryRequest{
+		BlockCount:        2,
+		RewardPercentiles: []float64{25, 75},
+	})
+	require.NoError(err)
+	require.Equal(tip-1, res.OldestBlock)
+	require.Equal(2, len(res.GasUsedRatios))
+	require.Equal(2, len(res.Rewards))
+	for _, rewards := range res.Rewards {
+		require.Equal(2, len(rewards.Rewards))
+	}
+
+	_, err = svr.GetFeeHistory(context.Background(), &apipb.GetFeeHistoryRequest{})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = svr.GetFeeHistory(context.Background(), &apipb.GetFeeHistoryRequest{
+		BlockCount: cfg.API.RangeQueryLimit + 1,
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = svr.GetFeeHistory(context.Background(), &apipb.GetFeeHistoryRequest{
+		BlockCount:        1,
+		RewardPercentiles: []float64{-1},
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
 func TestServer_EstimateGasForAction(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig(t)
@@ -1246,6 +1493,33 @@ func TestServer_EstimateGasForAction(t *testing.T) {
 	}
 }
 
+func TestEstimateGasError(t *testing.T) {
+	require := require.New(t)
+
+	err := estimateGasError(errors.New("failed to simulate"))
+	sta, ok := status.FromError(err)
+	require.True(ok)
+	require.Equal(codes.Internal, sta.Code())
+	require.Empty(sta.Details())
+
+	revertData, err := hex.DecodeString("deadbeef")
+	require.NoError(err)
+	err = estimateGasError(errors.Wrap(&gasstation.ExecutionError{
+		Status:     uint64(iotextypes.ReceiptStatus_ErrExecutionReverted),
+		RevertData: revertData,
+	}, "failed to estimate"))
+	sta, ok = status.FromError(err)
+	require.True(ok)
+	require.Equal(codes.Internal, sta.Code())
+	require.Equal("execution simulation gets failure status ErrExecutionReverted: 0xdeadbeef", sta.Message())
+	require.Len(sta.Details(), 1)
+	info, ok := sta.Details()[0].(*errdetails.ErrorInfo)
+	require.True(ok)
+	require.Equal("ErrExecutionReverted", info.Reason)
+	require.Equal("0xdeadbeef", info.Metadata["revertReason"])
+	require.Equal("deadbeef", info.Metadata["revertData"])
+}
+
 func TestServer_EstimateActionGasConsumption(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig(t)
@@ -1516,6 +1790,7 @@ func TestServer_ReadCandidatesByEpoch(t *testing.T) {
 				nil,
 				nil,
 				indexer,
+				nil,
 				cfg.Genesis.NumCandidateDelegates,
 				cfg.Genesis.NumDelegates,
 				cfg.Genesis.DardanellesNumSubEpochs,
@@ -1586,6 +1861,7 @@ func TestServer_ReadBlockProducersByEpoch(t *testing.T) {
 				nil,
 				nil,
 				indexer,
+				nil,
 				test.numCandidateDelegates,
 				cfg.Genesis.NumDelegates,
 				cfg.Genesis.DardanellesNumSubEpochs,
@@ -1656,6 +1932,7 @@ func TestServer_ReadActiveBlockProducersByEpoch(t *testing.T) {
 				nil,
 				nil,
 				indexer,
+				nil,
 				cfg.Genesis.NumCandidateDelegates,
 				test.numDelegates,
 				cfg.Genesis.DardanellesNumSubEpochs,
@@ -1784,6 +2061,7 @@ func TestServer_GetEpochMeta(t *testing.T) {
 				nil,
 				nil,
 				indexer,
+				nil,
 				cfg.Genesis.NumCandidateDelegates,
 				cfg.Genesis.NumDelegates,
 				cfg.Genesis.DardanellesNumSubEpochs,
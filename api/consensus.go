@@ -0,0 +1,155 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/api/apipb"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+)
+
+// GetConsensusRound returns the live consensus round, and the last finished rounds of the requested number
+func (api *Server) GetConsensusRound(ctx context.Context, in *apipb.GetConsensusRoundRequest) (*apipb.GetConsensusRoundResponse, error) {
+	if api.roundInspector == nil {
+		return nil, status.Error(codes.Unimplemented, scheme.ErrNotImplemented.Error())
+	}
+	round, err := api.roundInspector.CurrentRound()
+	if err != nil {
+		return nil, roundError(err)
+	}
+	history, err := api.roundInspector.RoundHistory(int(in.GetHistorySize()))
+	if err != nil {
+		return nil, roundError(err)
+	}
+	res := &apipb.GetConsensusRoundResponse{
+		Round:   consensusRoundProto(round),
+		History: make([]*apipb.ConsensusRound, 0, len(history)),
+	}
+	for _, r := range history {
+		res.History = append(res.History, consensusRoundProto(r))
+	}
+	return res, nil
+}
+
+// StreamConsensusRound streams the live consensus round whenever it changes
+func (api *Server) StreamConsensusRound(in *apipb.StreamConsensusRoundRequest, stream apipb.APIService_StreamConsensusRoundServer) error {
+	if api.roundInspector == nil {
+		return status.Error(codes.Unimplemented, scheme.ErrNotImplemented.Error())
+	}
+	interval := scheme.MinRoundWatchInterval
+	if in.GetInterval() > 0 {
+		interval = time.Duration(in.GetInterval()) * time.Millisecond
+		if interval < scheme.MinRoundWatchInterval {
+			return status.Errorf(codes.InvalidArgument, "interval is less than %s", scheme.MinRoundWatchInterval)
+		}
+	}
+	if err := scheme.WatchRound(stream.Context(), api.roundInspector, interval, func(round *scheme.ConsensusRound) error {
+		return stream.Send(consensusRoundProto(round))
+	}); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return roundError(err)
+	}
+	return nil
+}
+
+// GetDoubleSignEvidence returns the evidence of delegates signing conflicting consensus messages from the start height
+func (api *Server) GetDoubleSignEvidence(ctx context.Context, in *apipb.GetDoubleSignEvidenceRequest) (*apipb.GetDoubleSignEvidenceResponse, error) {
+	if api.evidenceReader == nil {
+		return nil, status.Error(codes.Unimplemented, scheme.ErrNotImplemented.Error())
+	}
+	if in.GetCount() == 0 || in.GetCount() > api.cfg.API.RangeQueryLimit {
+		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	evidence, err := api.evidenceReader.Evidence(in.GetStartHeight(), in.GetCount())
+	if err != nil {
+		if errors.Cause(err) == scheme.ErrNotImplemented {
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := &apipb.GetDoubleSignEvidenceResponse{
+		Evidence: make([]*apipb.DoubleSignEvidence, 0, len(evidence)),
+	}
+	for _, e := range evidence {
+		endorser, err := e.EndorserAddress()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		ser, err := e.Serialize()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		pb := &apipb.DoubleSignEvidence{
+			Endorser:  endorser.String(),
+			Height:    e.Height(),
+			Topic:     e.Topic(),
+			Timestamp: timestampProto(e.Timestamp()),
+			Evidence:  ser,
+		}
+		for _, h := range e.BlockHashes() {
+			pb.BlockHashes = append(pb.BlockHashes, hex.EncodeToString(h))
+		}
+		res.Evidence = append(res.Evidence, pb)
+	}
+	return res, nil
+}
+
+func roundError(err error) error {
+	if errors.Cause(err) == scheme.ErrNotImplemented {
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+func consensusRoundProto(round *scheme.ConsensusRound) *apipb.ConsensusRound {
+	pb := &apipb.ConsensusRound{
+		Height:       round.Height,
+		Epoch:        round.Epoch,
+		Round:        round.Round,
+		Proposer:     round.Proposer,
+		Delegates:    round.Delegates,
+		State:        round.State,
+		BlockHash:    round.BlockHash,
+		Locked:       round.Locked,
+		Committed:    round.Committed,
+		Endorsements: make([]*apipb.ConsensusStageEndorsements, 0, len(round.Endorsements)),
+		StartTime:    timestampProto(round.StartTime),
+		EndTime:      timestampProto(round.EndTime),
+		Remaining:    int64(round.Remaining),
+		Timestamp:    timestampProto(round.Timestamp),
+	}
+	for _, en := range round.Endorsements {
+		pb.Endorsements = append(pb.Endorsements, &apipb.ConsensusStageEndorsements{
+			Topic:       en.Topic,
+			Endorsed:    en.Endorsed,
+			NotEndorsed: en.NotEndorsed,
+		})
+	}
+	return pb
+}
+
+func timestampProto(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/api/apipb"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/signer"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_apiserver"
+	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
+)
+
+func TestServer_GetConsensusRound(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	delegates := []string{identityset.Address(0).String(), identityset.Address(1).String()}
+	round := &scheme.ConsensusRound{
+		Height:    10,
+		Epoch:     1,
+		Round:     2,
+		Proposer:  delegates[0],
+		Delegates: delegates,
+		State:     "S_ACCEPT_PROPOSAL_ENDORSEMENT",
+		BlockHash: "abcd",
+		Endorsements: []*scheme.StageEndorsements{
+			{Topic: "PROPOSAL", Endorsed: delegates[:1], NotEndorsed: delegates[1:]},
+		},
+		StartTime: now.Add(-time.Second),
+		EndTime:   now.Add(time.Second),
+		Remaining: time.Second,
+		Timestamp: now,
+	}
+	history := []*scheme.ConsensusRound{{Height: 9, Committed: true}}
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().CurrentRound().Return(round, nil).AnyTimes()
+	cs.EXPECT().RoundHistory(1).Return(history, nil).Times(1)
+	svr := &Server{roundInspector: cs}
+
+	res, err := svr.GetConsensusRound(context.Background(), &apipb.GetConsensusRoundRequest{HistorySize: 1})
+	require.NoError(err)
+	pb := res.GetRound()
+	require.Equal(uint64(10), pb.GetHeight())
+	require.Equal(uint32(2), pb.GetRound())
+	require.Equal(delegates, pb.GetDelegates())
+	require.Equal("S_ACCEPT_PROPOSAL_ENDORSEMENT", pb.GetState())
+	require.Equal("abcd", pb.GetBlockHash())
+	require.Len(pb.GetEndorsements(), 1)
+	require.Equal(delegates[:1], pb.GetEndorsements()[0].GetEndorsed())
+	require.Equal(int64(time.Second), pb.GetRemaining())
+	require.Equal(now.Add(time.Second).UnixNano(), pb.GetEndTime().AsTime().UnixNano())
+	require.Len(res.GetHistory(), 1)
+	require.Equal(uint64(9), res.GetHistory()[0].GetHeight())
+	require.True(res.GetHistory()[0].GetCommitted())
+	require.Nil(res.GetHistory()[0].GetStartTime())
+
+	t.Run("stream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream := mock_apiserver.NewMockStreamConsensusRoundServer(ctrl)
+		stream.EXPECT().Context().Return(ctx).AnyTimes()
+		stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(pb *apipb.ConsensusRound) error {
+			require.Equal(uint64(10), pb.GetHeight())
+			cancel()
+			return nil
+		}).Times(1)
+		require.NoError(svr.StreamConsensusRound(&apipb.StreamConsensusRoundRequest{Interval: 1000}, stream))
+		// the interval is less than the minimum
+		err := svr.StreamConsensusRound(&apipb.StreamConsensusRoundRequest{Interval: 1}, stream)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := (&Server{}).GetConsensusRound(context.Background(), &apipb.GetConsensusRoundRequest{})
+		require.Equal(codes.Unimplemented, status.Code(err))
+		cs := mock_consensus.NewMockConsensus(ctrl)
+		cs.EXPECT().CurrentRound().Return(nil, scheme.ErrNotImplemented).Times(1)
+		_, err = (&Server{roundInspector: cs}).GetConsensusRound(context.Background(), &apipb.GetConsensusRoundRequest{})
+		require.Equal(codes.Unimplemented, status.Code(err))
+		cs.EXPECT().CurrentRound().Return(nil, errors.New("not started")).Times(1)
+		stream := mock_apiserver.NewMockStreamConsensusRoundServer(ctrl)
+		stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+		err = (&Server{roundInspector: cs}).StreamConsensusRound(&apipb.StreamConsensusRoundRequest{}, stream)
+		require.Equal(codes.Unavailable, status.Code(err))
+	})
+}
+
+type voteDoc []byte
+
+func (d voteDoc) Hash() ([]byte, error) { return d, nil }
+
+func TestServer_GetDoubleSignEvidence(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := time.Unix(1600000000, 0)
+	msgs := make([]*iotextypes.ConsensusMessage, 0, 2)
+	for _, blk := range []string{"block1", "block2"} {
+		blkHash := hash.Hash256b([]byte(blk))
+		vote := &iotextypes.ConsensusVote{
+			BlockHash: blkHash[:],
+			Topic:     iotextypes.ConsensusVote_LOCK,
+		}
+		ser, err := proto.Marshal(vote)
+		require.NoError(err)
+		docHash := blake2b.Sum256(ser)
+		en, err := endorsement.Endorse(signer.NewLocalSigner(identityset.PrivateKey(1)), voteDoc(docHash[:]), ts)
+		require.NoError(err)
+		enPb, err := en.Proto()
+		require.NoError(err)
+		msgs = append(msgs, &iotextypes.ConsensusMessage{
+			Height:      10,
+			Endorsement: enPb,
+			Msg:         &iotextypes.ConsensusMessage_Vote{Vote: vote},
+		})
+	}
+	e, err := evidence.New(msgs[0], msgs[1])
+	require.NoError(err)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().Evidence(uint64(1), uint64(10)).Return([]*evidence.Evidence{e}, nil).Times(1)
+	svr := &Server{cfg: config.Default, evidenceReader: cs}
+
+	res, err := svr.GetDoubleSignEvidence(context.Background(), &apipb.GetDoubleSignEvidenceRequest{StartHeight: 1, Count: 10})
+	require.NoError(err)
+	require.Len(res.GetEvidence(), 1)
+	pb := res.GetEvidence()[0]
+	require.Equal(identityset.Address(1).String(), pb.GetEndorser())
+	require.Equal(uint64(10), pb.GetHeight())
+	require.Equal("LOCK", pb.GetTopic())
+	require.Equal(ts.Unix(), pb.GetTimestamp().GetSeconds())
+	require.Len(pb.GetBlockHashes(), 2)
+	require.Equal(hex.EncodeToString(e.BlockHashes()[0]), pb.GetBlockHashes()[0])
+	loaded := &evidence.Evidence{}
+	require.NoError(loaded.Deserialize(pb.GetEvidence()))
+	require.Equal(e.Offence(), loaded.Offence())
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := (&Server{cfg: config.Default}).GetDoubleSignEvidence(context.Background(), &apipb.GetDoubleSignEvidenceRequest{Count: 1})
+		require.Equal(codes.Unimplemented, status.Code(err))
+		_, err = svr.GetDoubleSignEvidence(context.Background(), &apipb.GetDoubleSignEvidenceRequest{})
+		require.Equal(codes.InvalidArgument, status.Code(err))
+		cs.EXPECT().Evidence(uint64(0), uint64(1)).Return(nil, errors.Wrap(scheme.ErrNotImplemented, "evidence store is disabled")).Times(1)
+		_, err = svr.GetDoubleSignEvidence(context.Background(), &apipb.GetDoubleSignEvidenceRequest{Count: 1})
+		require.Equal(codes.Unimplemented, status.Code(err))
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/api/apipb"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// pendingActionFilter streams the pending actions that match the filter condition
+type pendingActionFilter struct {
+	stream      apipb.APIService_StreamPendingActionsServer
+	errChan     chan error
+	senders     map[string]bool
+	recipients  map[string]bool
+	actionTypes map[string]bool
+	minGasPrice *big.Int
+}
+
+// NewPendingActionFilter returns a new pending action filter
+func NewPendingActionFilter(
+	in *apipb.PendingActionFilter,
+	stream apipb.APIService_StreamPendingActionsServer,
+	errChan chan error,
+) (ActionResponder, error) {
+	filter := &pendingActionFilter{
+		stream:      stream,
+		errChan:     errChan,
+		senders:     make(map[string]bool),
+		recipients:  make(map[string]bool),
+		actionTypes: make(map[string]bool),
+	}
+	for _, sender := range in.GetSenders() {
+		if _, err := address.FromString(sender); err != nil {
+			return nil, errors.Wrapf(err, "invalid sender %s", sender)
+		}
+		filter.senders[sender] = true
+	}
+	for _, recipient := range in.GetRecipients() {
+		if _, err := address.FromString(recipient); err != nil {
+			return nil, errors.Wrapf(err, "invalid recipient %s", recipient)
+		}
+		filter.recipients[recipient] = true
+	}
+	for _, actType := range in.GetActionTypes() {
+		filter.actionTypes[actType] = true
+	}
+	if in.GetMinGasPrice() != "" {
+		minGasPrice, ok := new(big.Int).SetString(in.GetMinGasPrice(), 10)
+		if !ok {
+			return nil, errors.Errorf("invalid min gas price %s", in.GetMinGasPrice())
+		}
+		filter.minGasPrice = minGasPrice
+	}
+	return filter, nil
+}
+
+// RespondAction sends the new pending action if it matches the filter
+func (f *pendingActionFilter) RespondAction(act action.SealedEnvelope) error {
+	actPb := act.Proto()
+	if !f.match(act, actPb) {
+		return nil
+	}
+	actHash := act.Hash()
+	if err := f.stream.Send(&apipb.StreamPendingActionsResponse{
+		Action:  actPb,
+		ActHash: hex.EncodeToString(actHash[:]),
+	}); err != nil {
+		log.L().Info("Error when streaming the pending action", log.Hex("hash", actHash[:]), zap.Error(err))
+		f.notify(err)
+		return err
+	}
+	return nil
+}
+
+// Exit send to error channel
+func (f *pendingActionFilter) Exit() {
+	f.notify(nil)
+}
+
+// notify sends to error channel without blocking, in case the stream has already ended
+func (f *pendingActionFilter) notify(err error) {
+	select {
+	case f.errChan <- err:
+	default:
+	}
+}
+
+func (f *pendingActionFilter) match(act action.SealedEnvelope, actPb *iotextypes.Action) bool {
+	if len(f.senders) > 0 {
+		caller, err := address.FromBytes(act.SrcPubkey().Hash())
+		if err != nil || !f.senders[caller.String()] {
+			return false
+		}
+	}
+	if len(f.recipients) > 0 {
+		dst, ok := act.Destination()
+		if !ok || !f.recipients[dst] {
+			return false
+		}
+	}
+	if len(f.actionTypes) > 0 && !f.actionTypes[actionType(actPb.GetCore())] {
+		return false
+	}
+	if f.minGasPrice != nil && act.GasPrice().Cmp(f.minGasPrice) < 0 {
+		return false
+	}
+	return true
+}
+
+// actionType returns the field name of the action set in action core
+func actionType(core *iotextypes.ActionCore) string {
+	msg := core.ProtoReflect()
+	field := msg.WhichOneof(msg.Descriptor().Oneofs().ByName("action"))
+	if field == nil {
+		return ""
+	}
+	return string(field.Name())
+}
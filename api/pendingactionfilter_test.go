@@ -0,0 +1,105 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/api/apipb"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_apiserver"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestPendingActionFilter(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sender := identityset.Address(27).String()
+	recipient := identityset.Address(28).String()
+	tsf, err := testutil.SignedTransfer(recipient, identityset.PrivateKey(27), 1, big.NewInt(1), nil, 10000, big.NewInt(10))
+	require.NoError(err)
+	exec, err := testutil.SignedExecution(recipient, identityset.PrivateKey(27), 2, big.NewInt(1), 10000, big.NewInt(10), nil)
+	require.NoError(err)
+	otherTsf, err := testutil.SignedTransfer(sender, identityset.PrivateKey(28), 1, big.NewInt(1), nil, 10000, big.NewInt(10))
+	require.NoError(err)
+	cheapTsf, err := testutil.SignedTransfer(recipient, identityset.PrivateKey(27), 3, big.NewInt(1), nil, 10000, big.NewInt(1))
+	require.NoError(err)
+
+	tests := []struct {
+		filter  *apipb.PendingActionFilter
+		matched []action.SealedEnvelope
+	}{
+		{
+			nil,
+			[]action.SealedEnvelope{tsf, exec, otherTsf, cheapTsf},
+		},
+		{
+			&apipb.PendingActionFilter{Senders: []string{sender}},
+			[]action.SealedEnvelope{tsf, exec, cheapTsf},
+		},
+		{
+			&apipb.PendingActionFilter{Recipients: []string{sender}},
+			[]action.SealedEnvelope{otherTsf},
+		},
+		{
+			&apipb.PendingActionFilter{ActionTypes: []string{"execution"}},
+			[]action.SealedEnvelope{exec},
+		},
+		{
+			&apipb.PendingActionFilter{Senders: []string{sender}, ActionTypes: []string{"transfer"}, MinGasPrice: "10"},
+			[]action.SealedEnvelope{tsf},
+		},
+	}
+	for _, test := range tests {
+		errChan := make(chan error, 10)
+		server := mock_apiserver.NewMockStreamPendingActionsServer(ctrl)
+		filter, err := NewPendingActionFilter(test.filter, server, errChan)
+		require.NoError(err)
+		var sent []action.SealedEnvelope
+		server.EXPECT().Send(gomock.Any()).DoAndReturn(func(res *apipb.StreamPendingActionsResponse) error {
+			act := action.SealedEnvelope{}
+			require.NoError(act.LoadProto(res.GetAction()))
+			actHash := act.Hash()
+			require.Equal(res.GetActHash(), hex.EncodeToString(actHash[:]))
+			sent = append(sent, act)
+			return nil
+		}).AnyTimes()
+		for _, act := range []action.SealedEnvelope{tsf, exec, otherTsf, cheapTsf} {
+			require.NoError(filter.RespondAction(act))
+		}
+		require.Equal(test.matched, sent)
+	}
+
+	// invalid filters
+	for _, in := range []*apipb.PendingActionFilter{
+		{Senders: []string{"io1invalid"}},
+		{Recipients: []string{"0x123"}},
+		{MinGasPrice: "abc"},
+	} {
+		_, err := NewPendingActionFilter(in, nil, nil)
+		require.Error(err)
+	}
+
+	// failed to send
+	errChan := make(chan error, 10)
+	server := mock_apiserver.NewMockStreamPendingActionsServer(ctrl)
+	filter, err := NewPendingActionFilter(nil, server, errChan)
+	require.NoError(err)
+	server.EXPECT().Send(gomock.Any()).Return(errorSend).Times(1)
+	require.Equal(errorSend, filter.RespondAction(tsf))
+	filter.Exit()
+	require.Equal(errorSend, <-errChan)
+	require.NoError(<-errChan)
+}
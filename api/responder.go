@@ -7,6 +7,7 @@
 package api
 
 import (
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 )
 
@@ -15,3 +16,9 @@ type Responder interface {
 	Respond(*block.Block) error
 	Exit()
 }
+
+// ActionResponder responds to new pending action
+type ActionResponder interface {
+	RespondAction(action.SealedEnvelope) error
+	Exit()
+}
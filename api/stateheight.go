@@ -0,0 +1,126 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+// The state read endpoints answer for the tip by default. A client queries the state at an earlier block by attaching
+// the block height or the block hash to the metadata of the request, which requires the archive mode
+const (
+	// BlockHeightMetadataKey is the metadata key of the height of the block at which the state is queried
+	BlockHeightMetadataKey = "x-iotex-block-height"
+	// BlockHashMetadataKey is the metadata key of the hex encoded hash of the block at which the state is queried
+	BlockHashMetadataKey = "x-iotex-block-hash"
+)
+
+// WithStateHeight returns a context which queries the state at the height, for calling the server in process
+func WithStateHeight(ctx context.Context, height uint64) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = metadata.Join(md, metadata.Pairs(BlockHeightMetadataKey, strconv.FormatUint(height, 10)))
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// stateHeight returns the height at which the state is queried, and whether it is a historical height below the tip.
+// The height is not resolved if the state is queried at the tip
+func (api *Server) stateHeight(ctx context.Context) (uint64, bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, false, nil
+	}
+	var height uint64
+	if v := md.Get(BlockHeightMetadataKey); len(v) > 0 {
+		h, err := strconv.ParseUint(v[0], 0, 64)
+		if err != nil {
+			return 0, false, status.Errorf(codes.InvalidArgument, "invalid block height %s", v[0])
+		}
+		height = h
+	} else if v := md.Get(BlockHashMetadataKey); len(v) > 0 {
+		blkHash, err := hash.HexStringToHash256(v[0])
+		if err != nil {
+			return 0, false, status.Errorf(codes.InvalidArgument, "invalid block hash %s", v[0])
+		}
+		if height, err = api.dao.GetBlockHeight(blkHash); err != nil {
+			return 0, false, status.Errorf(codes.NotFound, "block %s not found", v[0])
+		}
+	} else {
+		return 0, false, nil
+	}
+	return api.checkStateHeight(height, api.bc.TipHeight())
+}
+
+// checkStateHeight returns an error if the state at height is not available
+func (api *Server) checkStateHeight(height, tipHeight uint64) (uint64, bool, error) {
+	switch {
+	case height > tipHeight:
+		return 0, false, status.Errorf(codes.InvalidArgument, "block height %d is higher than tip height %d", height, tipHeight)
+	case height == tipHeight:
+		return tipHeight, false, nil
+	case !api.cfg.Chain.EnableArchiveMode:
+		return 0, false, status.Errorf(
+			codes.FailedPrecondition,
+			"state at height %d is not available, the node is not running in archive mode",
+			height,
+		)
+	}
+	if retention := api.cfg.DB.HistoryStateRetention; retention > 0 && tipHeight-height > retention {
+		return 0, false, status.Errorf(
+			codes.OutOfRange,
+			"state at height %d has been pruned, only the states of the latest %d blocks are retained",
+			height,
+			retention,
+		)
+	}
+	return height, true, nil
+}
+
+// stateReader returns the reader of the state at height
+func (api *Server) stateReader(height uint64, history bool) protocol.StateReader {
+	if history {
+		return factory.NewHistoryStateReader(api.sf, height)
+	}
+	return api.sf
+}
+
+// historyContext returns the context of the blockchain whose tip is the block at height
+func (api *Server) historyContext(height uint64) (context.Context, error) {
+	tip := protocol.TipInfo{
+		Height:    0,
+		Hash:      api.cfg.Genesis.Hash(),
+		Timestamp: time.Unix(api.cfg.Genesis.Timestamp, 0),
+	}
+	if height > 0 {
+		header, err := api.dao.HeaderByHeight(height)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the header of block %d", height)
+		}
+		tip = protocol.TipInfo{
+			Height:    height,
+			Hash:      header.HashBlock(),
+			Timestamp: header.Timestamp(),
+		}
+	}
+	return protocol.WithBlockchainCtx(
+		context.Background(),
+		protocol.BlockchainCtx{
+			Genesis: api.cfg.Genesis,
+			Tip:     tip,
+		},
+	), nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/api/apipb"
+	"github.com/iotexproject/iotex-core/blockindex"
+)
+
+var errDebugAPIDisabled = status.Error(codes.Unimplemented, "debug API is disabled")
+
+// TraceTransaction traces the committed execution by re-executing it on top of the state before its block, which
+// requires the archive mode
+func (api *Server) TraceTransaction(ctx context.Context, in *apipb.TraceTransactionRequest) (*apipb.TraceResponse, error) {
+	if !api.cfg.API.EnableDebugAPI {
+		return nil, errDebugAPIDisabled
+	}
+	if api.indexer == nil {
+		return nil, status.Error(codes.NotFound, blockindex.ErrActionIndexNA.Error())
+	}
+	actHash, err := hash.HexStringToHash256(in.GetActionHash())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	tracer, err := api.newTracer(in.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	actIndex, err := api.indexer.GetActionIndex(actHash[:])
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	blk, err := api.dao.GetBlockByHeight(actIndex.BlockHeight())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	index := -1
+	for i, selp := range blk.Actions {
+		if selp.Hash() == actHash {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, status.Errorf(codes.NotFound, "action %s not found in block %d", in.GetActionHash(), blk.Height())
+	}
+	if _, ok := blk.Actions[index].Action().(*action.Execution); !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "action %s is not an execution", in.GetActionHash())
+	}
+	if _, _, err := api.checkStateHeight(blk.Height()-1, api.bc.TipHeight()); err != nil {
+		return nil, err
+	}
+	ctx, err = api.historyContext(blk.Height() - 1)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	receipt, err := api.sf.TraceAction(ctx, blk, index, tracer)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return traceResponse(tracer, receipt)
+}
+
+// TraceCall traces the simulated execution of a contract call, on top of the state queried by the metadata of ctx
+func (api *Server) TraceCall(ctx context.Context, in *apipb.TraceCallRequest) (*apipb.TraceResponse, error) {
+	if !api.cfg.API.EnableDebugAPI {
+		return nil, errDebugAPIDisabled
+	}
+	tracer, err := api.newTracer(in.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := in.GetGasLimit()
+	if gasLimit == 0 || gasLimit > api.cfg.API.TraceGasLimit {
+		gasLimit = api.cfg.API.TraceGasLimit
+	}
+	_, receipt, err := api.simulateExecution(ctx, in.GetCallerAddress(), in.GetExecution(), gasLimit, tracer)
+	if err != nil {
+		return nil, err
+	}
+	return traceResponse(tracer, receipt)
+}
+
+// newTracer creates the tracer of the options, whose struct logs are capped by the trace limit and capture no memory
+// unless enabled
+func (api *Server) newTracer(opts *apipb.TraceOptions) (evm.Tracer, error) {
+	limit := opts.GetLimit()
+	if limit == 0 || limit > api.cfg.API.TraceLimit {
+		limit = api.cfg.API.TraceLimit
+	}
+	tracer, err := evm.NewTracer(opts.GetTracer(), &vm.LogConfig{
+		DisableStack:   opts.GetDisableStack(),
+		DisableMemory:  !opts.GetEnableMemory(),
+		DisableStorage: opts.GetDisableStorage(),
+		Limit:          int(limit),
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return tracer, nil
+}
+
+func traceResponse(tracer evm.Tracer, receipt *action.Receipt) (*apipb.TraceResponse, error) {
+	trace, err := tracer.GetResult()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	var receiptPb *iotextypes.Receipt
+	if receipt != nil {
+		receiptPb = receipt.ConvertToReceiptPb()
+	}
+	return &apipb.TraceResponse{
+		Trace:   trace,
+		Receipt: receiptPb,
+	}, nil
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 // Builder is used to construct Block.
@@ -86,11 +87,21 @@ func (b *Builder) SetLogsBloom(f bloom.BloomFilter) *Builder {
 
 // SignAndBuild signs and then builds a block.
 func (b *Builder) SignAndBuild(signerPrvKey crypto.PrivateKey) (Block, error) {
-	b.blk.Header.pubkey = signerPrvKey.PublicKey()
-	h := b.blk.Header.HashHeaderCore()
-	sig, err := signerPrvKey.Sign(h[:])
+	return b.SignAndBuildWith(signer.NewLocalSigner(signerPrvKey))
+}
+
+// SignAndBuildWith signs with the signer and then builds a block.
+func (b *Builder) SignAndBuildWith(s signer.Signer) (Block, error) {
+	b.blk.Header.pubkey = s.PublicKey()
+	core := b.blk.Header.SerializeCore()
+	h := hash.Hash256b(core)
+	sig, err := s.Sign(&signer.Message{
+		Kind:     signer.BlockKind,
+		Document: core,
+		Digest:   h[:],
+	})
 	if err != nil {
-		return Block{}, errors.New("failed to sign block")
+		return Block{}, errors.Wrap(err, "failed to sign block")
 	}
 	b.blk.Header.blockSig = sig
 	return b.blk, nil
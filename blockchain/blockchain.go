@@ -30,6 +30,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 var (
@@ -131,6 +132,7 @@ type blockchain struct {
 	clk            clock.Clock
 	pubSubManager  PubSubManager
 	timerFactory   *prometheustimer.TimerFactory
+	signer         signer.Signer
 
 	// used by account-based model
 	bbf BlockBuilderFactory
@@ -180,6 +182,15 @@ func ClockOption(clk clock.Clock) Option {
 	}
 }
 
+// SignerOption overrides the signer of the new blocks, which signs with the producer private key by default
+func SignerOption(s signer.Signer) Option {
+	return func(bc *blockchain, conf config.Config) error {
+		bc.signer = s
+
+		return nil
+	}
+}
+
 // NewBlockchain creates a new blockchain and DB instance
 // TODO: replace sf with blockbuilderfactory
 func NewBlockchain(cfg config.Config, dao blockdao.BlockDAO, bbf BlockBuilderFactory, opts ...Option) Blockchain {
@@ -387,17 +398,20 @@ func (bc *blockchain) MintNewBlock(timestamp time.Time) (*block.Block, error) {
 	}
 	ctx = bc.contextWithBlock(ctx, bc.config.ProducerAddress(), newblockHeight, timestamp)
 	// run execution and update state trie root hash
-	minterPrivateKey := bc.config.ProducerPrivateKey()
+	minter := bc.signer
+	if minter == nil {
+		minter = signer.NewLocalSigner(bc.config.ProducerPrivateKey())
+	}
 	blockBuilder, err := bc.bbf.NewBlockBuilder(
 		ctx,
 		func(elp action.Envelope) (action.SealedEnvelope, error) {
-			return action.Sign(elp, minterPrivateKey)
+			return action.SignWith(elp, minter)
 		},
 	)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create block builder at new block height %d", newblockHeight)
 	}
-	blk, err := blockBuilder.SignAndBuild(minterPrivateKey)
+	blk, err := blockBuilder.SignAndBuildWith(minter)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create block")
 	}
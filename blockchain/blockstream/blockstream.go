@@ -0,0 +1,278 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package blockstream implements a portable stream format of blocks, which is independent of the layout of the chain
+// db files. A stream starts with a magic and a header, followed by one record per block of the consecutive heights in
+// [start, end] of the header. Each record is compressed and followed by its checksum:
+//
+//	magic | len(header) | header | len(record 1) | record 1 | checksum 1 | ... | len(record n) | record n | checksum n
+package blockstream
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockstream/blockstreampb"
+	"github.com/iotexproject/iotex-core/pkg/compress"
+)
+
+// Version is the version of the stream format
+const Version = 1
+
+// maxFrameSize limits the size of the header and a record, to guard against a corrupted length
+const maxFrameSize = 1 << 30
+
+var magic = []byte("IOTXBLKS")
+
+// vars
+var (
+	ErrInvalidStream      = errors.New("invalid block stream")
+	ErrUnsupportedVersion = errors.New("unsupported block stream version")
+	ErrChecksum           = errors.New("block stream checksum mismatch")
+	ErrTruncated          = errors.New("block stream is truncated")
+)
+
+type (
+	// Writer writes the blocks into the stream
+	Writer struct {
+		w      *bufio.Writer
+		header *blockstreampb.Header
+		next   uint64
+	}
+
+	// Reader reads the blocks from the stream
+	Reader struct {
+		r        *bufio.Reader
+		header   *blockstreampb.Header
+		next     uint64
+		prevHash hash.Hash256
+	}
+)
+
+// NewWriter writes the header of the blocks in [start, end] into w, and returns the writer of the blocks. An empty
+// compressor writes the records uncompressed
+func NewWriter(w io.Writer, chainID uint32, start, end uint64, compressor string) (*Writer, error) {
+	if start == 0 || start > end {
+		return nil, errors.Errorf("invalid height range [%d, %d]", start, end)
+	}
+	if err := checkCompressor(compressor); err != nil {
+		return nil, err
+	}
+	header := &blockstreampb.Header{
+		Version:    Version,
+		ChainID:    chainID,
+		Start:      start,
+		End:        end,
+		Compressor: compressor,
+	}
+	data, err := proto.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(magic); err != nil {
+		return nil, err
+	}
+	if err := writeFrame(bw, data); err != nil {
+		return nil, err
+	}
+	return &Writer{w: bw, header: header, next: start}, nil
+}
+
+// Write writes the block with its receipts and transaction logs as the next record
+func (w *Writer) Write(blk *block.Block, receipts []*action.Receipt, logs *iotextypes.TransactionLogs) error {
+	if w.next > w.header.GetEnd() {
+		return errors.Errorf("block at height %d is out of range [%d, %d]", blk.Height(), w.header.GetStart(), w.header.GetEnd())
+	}
+	if blk.Height() != w.next {
+		return errors.Errorf("expecting block at height %d, got %d", w.next, blk.Height())
+	}
+	store := &block.Store{Block: blk, Receipts: receipts}
+	data, err := proto.Marshal(&blockstreampb.Record{
+		Store:           store.ToProto(),
+		TransactionLogs: logs,
+	})
+	if err != nil {
+		return err
+	}
+	if w.header.GetCompressor() != "" {
+		if data, err = compress.Compress(data, w.header.GetCompressor()); err != nil {
+			return err
+		}
+	}
+	if err := writeFrame(w.w, data); err != nil {
+		return err
+	}
+	checksum := hash.Hash256b(data)
+	if _, err := w.w.Write(checksum[:]); err != nil {
+		return err
+	}
+	w.next++
+	return nil
+}
+
+// Close flushes the stream, and returns an error if not all the blocks of the header are written
+func (w *Writer) Close() error {
+	if err := w.w.Flush(); err != nil {
+		return err
+	}
+	if w.next <= w.header.GetEnd() {
+		return errors.Errorf("blocks from height %d to %d are not written", w.next, w.header.GetEnd())
+	}
+	return nil
+}
+
+// NewReader reads the header from r, and returns the reader of the blocks
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	buf := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, buf); err != nil || !bytes.Equal(buf, magic) {
+		return nil, ErrInvalidStream
+	}
+	data, err := readFrame(br)
+	if err != nil {
+		return nil, err
+	}
+	header := &blockstreampb.Header{}
+	if err := proto.Unmarshal(data, header); err != nil {
+		return nil, errors.Wrap(ErrInvalidStream, err.Error())
+	}
+	if header.GetVersion() != Version {
+		return nil, errors.Wrapf(ErrUnsupportedVersion, "version %d", header.GetVersion())
+	}
+	if header.GetStart() == 0 || header.GetStart() > header.GetEnd() {
+		return nil, errors.Wrapf(ErrInvalidStream, "invalid height range [%d, %d]", header.GetStart(), header.GetEnd())
+	}
+	if err := checkCompressor(header.GetCompressor()); err != nil {
+		return nil, err
+	}
+	return &Reader{r: br, header: header, next: header.GetStart()}, nil
+}
+
+// Header returns the header of the stream
+func (r *Reader) Header() *blockstreampb.Header {
+	return r.header
+}
+
+// Read returns the next block, with its receipts and transaction logs attached to the receipts. Every block must link
+// to the previous one. It returns io.EOF after the last block of the header
+func (r *Reader) Read() (*block.Block, error) {
+	if r.next > r.header.GetEnd() {
+		return nil, io.EOF
+	}
+	data, err := readFrame(r.r)
+	if err != nil {
+		return nil, err
+	}
+	var checksum hash.Hash256
+	if _, err := io.ReadFull(r.r, checksum[:]); err != nil {
+		return nil, errors.Wrapf(ErrTruncated, "missing checksum of block at height %d", r.next)
+	}
+	if hash.Hash256b(data) != checksum {
+		return nil, errors.Wrapf(ErrChecksum, "block at height %d", r.next)
+	}
+	if r.header.GetCompressor() != "" {
+		if data, err = compress.Decompress(data, r.header.GetCompressor()); err != nil {
+			return nil, err
+		}
+	}
+	record := &blockstreampb.Record{}
+	if err := proto.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+	store := &block.Store{}
+	if err := store.FromProto(record.GetStore()); err != nil {
+		return nil, err
+	}
+	blk := store.Block
+	if blk.Height() != r.next {
+		return nil, errors.Wrapf(ErrInvalidStream, "expecting block at height %d, got %d", r.next, blk.Height())
+	}
+	if r.next > r.header.GetStart() && blk.PrevHash() != r.prevHash {
+		return nil, errors.Wrapf(ErrInvalidStream, "block at height %d does not link to the previous one", blk.Height())
+	}
+	if err := attachTransactionLogs(store.Receipts, record.GetTransactionLogs()); err != nil {
+		return nil, err
+	}
+	blk.Receipts = store.Receipts
+	r.next++
+	r.prevHash = blk.HashBlock()
+	return blk, nil
+}
+
+// attachTransactionLogs restores the transaction logs into the receipts of the actions, which are not serialized
+// along with the receipts
+func attachTransactionLogs(receipts []*action.Receipt, logs *iotextypes.TransactionLogs) error {
+	if len(logs.GetLogs()) == 0 {
+		return nil
+	}
+	receiptMap := make(map[hash.Hash256]*action.Receipt, len(receipts))
+	for _, r := range receipts {
+		receiptMap[r.ActionHash] = r
+	}
+	for _, l := range logs.GetLogs() {
+		r, ok := receiptMap[hash.BytesToHash256(l.GetActionHash())]
+		if !ok {
+			return errors.Wrapf(ErrInvalidStream, "no receipt of transaction log of action %x", l.GetActionHash())
+		}
+		for _, tx := range l.GetTransactions() {
+			amount, ok := new(big.Int).SetString(tx.GetAmount(), 10)
+			if !ok {
+				return errors.Wrapf(ErrInvalidStream, "invalid amount %s in transaction log", tx.GetAmount())
+			}
+			r.AddTransactionLogs(&action.TransactionLog{
+				Type:      tx.GetType(),
+				Amount:    amount,
+				Sender:    tx.GetSender(),
+				Recipient: tx.GetRecipient(),
+			})
+		}
+	}
+	return nil
+}
+
+func checkCompressor(compressor string) error {
+	if compressor != "" && !compress.IsSupported(compressor) {
+		return errors.Errorf("unsupported compressor %s", compressor)
+	}
+	return nil
+}
+
+func writeFrame(w io.Writer, data []byte) error {
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(data)))
+	if _, err := w.Write(size[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+func readFrame(r io.Reader) ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, errors.Wrap(ErrTruncated, err.Error())
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > maxFrameSize {
+		return nil, errors.Wrapf(ErrInvalidStream, "frame of %d bytes is too large", n)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, errors.Wrap(ErrTruncated, err.Error())
+	}
+	return data, nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockstream
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/compress"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func getTestBlocks(t *testing.T, n int) []*block.Block {
+	require := require.New(t)
+	var (
+		blks     []*block.Block
+		prevHash = hash.ZeroHash256
+	)
+	for i := 1; i <= n; i++ {
+		tsf, err := testutil.SignedTransfer(identityset.Address(29).String(), identityset.PrivateKey(28), uint64(i), big.NewInt(int64(i)), nil, testutil.TestGasLimit, big.NewInt(0))
+		require.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(uint64(i)).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow().UTC()).
+			AddActions(tsf).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		receipt := &action.Receipt{
+			Status:      uint64(iotextypes.ReceiptStatus_Success),
+			BlockHeight: uint64(i),
+			ActionHash:  tsf.Hash(),
+			GasConsumed: 10000,
+		}
+		receipt.AddTransactionLogs(&action.TransactionLog{
+			Type:      iotextypes.TransactionLogType_NATIVE_TRANSFER,
+			Amount:    big.NewInt(int64(i)),
+			Sender:    identityset.Address(28).String(),
+			Recipient: identityset.Address(29).String(),
+		})
+		blk.Receipts = []*action.Receipt{receipt}
+		blks = append(blks, &blk)
+		prevHash = blk.HashBlock()
+	}
+	return blks
+}
+
+func writeTestStream(t *testing.T, blks []*block.Block, compressor string) []byte {
+	require := require.New(t)
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, 1, blks[0].Height(), blks[len(blks)-1].Height(), compressor)
+	require.NoError(err)
+	for _, blk := range blks {
+		logs := &iotextypes.TransactionLogs{}
+		if blkLog := blk.TransactionLog(); blkLog != nil {
+			logs, err = block.DeserializeSystemLogPb(blkLog.Serialize())
+			require.NoError(err)
+		}
+		require.NoError(w.Write(blk, blk.Receipts, logs))
+	}
+	require.NoError(w.Close())
+	return buf.Bytes()
+}
+
+func TestBlockStream(t *testing.T) {
+	require := require.New(t)
+	blks := getTestBlocks(t, 3)
+
+	for _, compressor := range []string{"", compress.Gzip, compress.Snappy, compress.Zstd, compress.Lz4} {
+		r, err := NewReader(bytes.NewReader(writeTestStream(t, blks, compressor)))
+		require.NoError(err)
+		require.EqualValues(Version, r.Header().GetVersion())
+		require.EqualValues(1, r.Header().GetChainID())
+		require.EqualValues(1, r.Header().GetStart())
+		require.EqualValues(3, r.Header().GetEnd())
+		require.Equal(compressor, r.Header().GetCompressor())
+		for _, expected := range blks {
+			blk, err := r.Read()
+			require.NoError(err)
+			require.Equal(expected.HashBlock(), blk.HashBlock())
+			require.Equal(expected.Receipts[0].Hash(), blk.Receipts[0].Hash())
+			require.Equal(expected.TransactionLog().Serialize(), blk.TransactionLog().Serialize())
+		}
+		_, err = r.Read()
+		require.Equal(io.EOF, err)
+	}
+
+	// the blocks must be written in order
+	w, err := NewWriter(&bytes.Buffer{}, 1, 1, 2, "")
+	require.NoError(err)
+	require.Error(w.Write(blks[1], nil, nil))
+	require.NoError(w.Write(blks[0], nil, nil))
+	require.Error(w.Close())
+	_, err = NewWriter(&bytes.Buffer{}, 1, 2, 1, "")
+	require.Error(err)
+	_, err = NewWriter(&bytes.Buffer{}, 1, 1, 2, "unknown")
+	require.Error(err)
+}
+
+func TestBlockStreamCorruption(t *testing.T) {
+	require := require.New(t)
+	data := writeTestStream(t, getTestBlocks(t, 2), compress.Gzip)
+	readAll := func(data []byte) error {
+		r, err := NewReader(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		for {
+			if _, err := r.Read(); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	require.NoError(readAll(data))
+
+	// invalid magic
+	corrupted := append([]byte{}, data...)
+	corrupted[0] = 'X'
+	require.Equal(ErrInvalidStream, errors.Cause(readAll(corrupted)))
+
+	// corrupted record
+	corrupted = append([]byte{}, data...)
+	corrupted[len(corrupted)-40] ^= 0xff
+	require.Equal(ErrChecksum, errors.Cause(readAll(corrupted)))
+
+	// truncated stream
+	require.Equal(ErrTruncated, errors.Cause(readAll(data[:len(data)-10])))
+	require.Equal(ErrTruncated, errors.Cause(readAll(data[:len(data)-100])))
+
+	// the blocks do not link
+	blks := getTestBlocks(t, 2)
+	unlinked, err := block.NewTestingBuilder().
+		SetHeight(2).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow().UTC()).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	blks[1] = &unlinked
+	require.Equal(ErrInvalidStream, errors.Cause(readAll(writeTestStream(t, blks, ""))))
+}
@@ -26,6 +26,8 @@ import (
 const (
 	blockHashHeightMappingNS = "h2h"
 	systemLogNS              = "syl"
+	// pruneInterval is the number of blocks to check for the files to prune
+	pruneInterval = 1000
 )
 
 var (
@@ -42,6 +44,7 @@ var (
 	ErrAlreadyExist     = errors.New("block already exist")
 	ErrInvalidTipHeight = errors.New("invalid tip height")
 	ErrDataCorruption   = errors.New("data is corrupted")
+	ErrBlockPruned      = errors.New("block data has been pruned")
 )
 
 type (
@@ -78,17 +81,23 @@ type (
 		currFd      BaseFileDAO
 		legacyFd    FileDAO
 		v2Fd        *FileV2Manager // a collection of v2 db files
+		pruned      *prunedStore   // headers and footers of the pruned blocks
+		pruneCh     chan struct{}  // signals the prune worker to check for the files to prune
+		pruneWG     sync.WaitGroup
 	}
 )
 
 // NewFileDAO creates an instance of FileDAO
 func NewFileDAO(cfg config.DB) (FileDAO, error) {
-	header, err := checkMasterChainDBFile(cfg.DbPath)
-	if err == ErrFileInvalid {
+	header, err := checkMasterChainDBFile(cfg)
+	if err != nil && err != ErrFileNotExist {
 		return nil, err
 	}
 
 	if err == ErrFileNotExist {
+		if cfg.ReadOnly {
+			return nil, err
+		}
 		// start new chain db using v2 format
 		if err := createNewV2File(1, cfg); err != nil {
 			return nil, err
@@ -114,6 +123,11 @@ func NewFileDAOInMemForTest() (FileDAO, error) {
 }
 
 func (fd *fileDAO) Start(ctx context.Context) error {
+	if fd.pruned != nil {
+		if err := fd.pruned.Start(ctx); err != nil {
+			return err
+		}
+	}
 	if fd.legacyFd != nil {
 		if err := fd.legacyFd.Start(ctx); err != nil {
 			return err
@@ -130,17 +144,35 @@ func (fd *fileDAO) Start(ctx context.Context) error {
 	} else {
 		fd.currFd = fd.legacyFd
 	}
+	if err := fd.prune(); err != nil {
+		return err
+	}
+	if fd.pruneEnabled() {
+		fd.pruneCh = make(chan struct{}, 1)
+		fd.pruneWG.Add(1)
+		go fd.pruneWorker()
+	}
 	return nil
 }
 
 func (fd *fileDAO) Stop(ctx context.Context) error {
+	if fd.pruneCh != nil {
+		close(fd.pruneCh)
+		fd.pruneWG.Wait()
+		fd.pruneCh = nil
+	}
 	if fd.legacyFd != nil {
 		if err := fd.legacyFd.Stop(ctx); err != nil {
 			return err
 		}
 	}
 	if fd.v2Fd != nil {
-		return fd.v2Fd.Stop(ctx)
+		if err := fd.v2Fd.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	if fd.pruned != nil {
+		return fd.pruned.Stop(ctx)
 	}
 	return nil
 }
@@ -150,6 +182,9 @@ func (fd *fileDAO) Height() (uint64, error) {
 }
 
 func (fd *fileDAO) GetBlockHash(height uint64) (hash.Hash256, error) {
+	if fd.isPruned(height) {
+		return fd.pruned.GetBlockHash(height)
+	}
 	if fd.v2Fd != nil {
 		if height == 0 {
 			return hash.ZeroHash256, nil
@@ -177,12 +212,24 @@ func (fd *fileDAO) GetBlockHeight(hash hash.Hash256) (uint64, error) {
 	}
 
 	if fd.legacyFd != nil {
-		return fd.legacyFd.GetBlockHeight(hash)
+		if height, err = fd.legacyFd.GetBlockHeight(hash); err == nil {
+			return height, nil
+		}
+	}
+	if fd.pruned != nil {
+		if height, err := fd.pruned.GetBlockHeight(hash); err == nil {
+			return height, nil
+		}
 	}
 	return 0, err
 }
 
 func (fd *fileDAO) GetBlock(hash hash.Hash256) (*block.Block, error) {
+	if fd.pruned != nil {
+		if height, err := fd.GetBlockHeight(hash); err == nil && fd.isPruned(height) {
+			return nil, errors.Wrapf(ErrBlockPruned, "block %x at height %d", hash, height)
+		}
+	}
 	var (
 		blk *block.Block
 		err error
@@ -200,6 +247,9 @@ func (fd *fileDAO) GetBlock(hash hash.Hash256) (*block.Block, error) {
 }
 
 func (fd *fileDAO) GetBlockByHeight(height uint64) (*block.Block, error) {
+	if fd.isPruned(height) {
+		return nil, errors.Wrapf(ErrBlockPruned, "block at height %d", height)
+	}
 	if fd.v2Fd != nil {
 		if v2 := fd.v2Fd.FileDAOByHeight(height); v2 != nil {
 			return v2.GetBlockByHeight(height)
@@ -213,6 +263,11 @@ func (fd *fileDAO) GetBlockByHeight(height uint64) (*block.Block, error) {
 }
 
 func (fd *fileDAO) Header(hash hash.Hash256) (*block.Header, error) {
+	if fd.pruned != nil {
+		if height, err := fd.pruned.GetBlockHeight(hash); err == nil {
+			return fd.pruned.HeaderByHeight(height)
+		}
+	}
 	var (
 		blk *block.Block
 		err error
@@ -230,6 +285,9 @@ func (fd *fileDAO) Header(hash hash.Hash256) (*block.Header, error) {
 }
 
 func (fd *fileDAO) HeaderByHeight(height uint64) (*block.Header, error) {
+	if fd.isPruned(height) {
+		return fd.pruned.HeaderByHeight(height)
+	}
 	if fd.v2Fd != nil {
 		if v2 := fd.v2Fd.FileDAOByHeight(height); v2 != nil {
 			blk, err := v2.GetBlockByHeight(height)
@@ -247,6 +305,9 @@ func (fd *fileDAO) HeaderByHeight(height uint64) (*block.Header, error) {
 }
 
 func (fd *fileDAO) FooterByHeight(height uint64) (*block.Footer, error) {
+	if fd.isPruned(height) {
+		return fd.pruned.FooterByHeight(height)
+	}
 	if fd.v2Fd != nil {
 		if v2 := fd.v2Fd.FileDAOByHeight(height); v2 != nil {
 			blk, err := v2.GetBlockByHeight(height)
@@ -264,6 +325,9 @@ func (fd *fileDAO) FooterByHeight(height uint64) (*block.Footer, error) {
 }
 
 func (fd *fileDAO) GetReceipts(height uint64) ([]*action.Receipt, error) {
+	if fd.isPruned(height) {
+		return nil, errors.Wrapf(ErrBlockPruned, "receipts at height %d", height)
+	}
 	if fd.v2Fd != nil {
 		if v2 := fd.v2Fd.FileDAOByHeight(height); v2 != nil {
 			return v2.GetReceipts(height)
@@ -282,6 +346,9 @@ func (fd *fileDAO) ContainsTransactionLog() bool {
 }
 
 func (fd *fileDAO) TransactionLogs(height uint64) (*iotextypes.TransactionLogs, error) {
+	if fd.isPruned(height) {
+		return nil, errors.Wrapf(ErrBlockPruned, "transaction logs at height %d", height)
+	}
 	if fd.v2Fd != nil {
 		if v2 := fd.v2Fd.FileDAOByHeight(height); v2 != nil {
 			return v2.TransactionLogs(height)
@@ -308,7 +375,17 @@ func (fd *fileDAO) PutBlock(ctx context.Context, blk *block.Block) error {
 			return err
 		}
 	}
-	return fd.currFd.PutBlock(ctx, blk)
+	if err := fd.currFd.PutBlock(ctx, blk); err != nil {
+		return err
+	}
+	if blk.Height()%pruneInterval == 0 && fd.pruneCh != nil {
+		select {
+		case fd.pruneCh <- struct{}{}:
+		default:
+			// the worker is pruning
+		}
+	}
+	return nil
 }
 
 func (fd *fileDAO) prepNextDbFile(height uint64) error {
@@ -366,18 +443,99 @@ func (fd *fileDAO) DeleteTipBlock() error {
 	return fd.currFd.DeleteTipBlock()
 }
 
+func (fd *fileDAO) isPruned(height uint64) bool {
+	return fd.pruned != nil && fd.pruned.Contains(height)
+}
+
+func (fd *fileDAO) pruneEnabled() bool {
+	return fd.cfg.BlockDataRetention > 0 && fd.pruned != nil && !fd.cfg.ReadOnly
+}
+
+// pruneWorker prunes the files in the background, so that PutBlock does not wait for the headers and footers to be
+// copied and the files to be deleted
+func (fd *fileDAO) pruneWorker() {
+	defer fd.pruneWG.Done()
+	for range fd.pruneCh {
+		if err := fd.prune(); err != nil {
+			log.L().Error("Failed to prune chain db files.", zap.Error(err))
+		}
+	}
+}
+
+// prune drops the v2 files and legacy segments whose blocks are all below the retained blocks, after their headers and
+// footers are kept in the pruned store
+func (fd *fileDAO) prune() error {
+	if !fd.pruneEnabled() {
+		return nil
+	}
+	files, err := fd.prunableFiles()
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := fd.pruned.Add(f.heightRange, fd.headerAndFooter); err != nil {
+			return err
+		}
+		if err := f.remove(); err != nil {
+			return err
+		}
+		log.L().Info("Pruned chain db file.", zap.Uint64("start", f.start), zap.Uint64("end", f.end))
+	}
+	return nil
+}
+
+// prunableFiles returns the files whose blocks are all below the retained blocks
+func (fd *fileDAO) prunableFiles() ([]*prunableFile, error) {
+	retention := fd.cfg.BlockDataRetention
+	fd.lock.Lock()
+	defer fd.lock.Unlock()
+	tip, err := fd.currFd.Height()
+	if err != nil {
+		return nil, err
+	}
+	if tip <= retention {
+		return nil, nil
+	}
+	height := tip - retention
+	var files []*prunableFile
+	if legacy, ok := fd.legacyFd.(*fileDAOLegacy); ok {
+		if files, err = legacy.prunableFiles(height, fd.v2Fd == nil); err != nil {
+			return nil, err
+		}
+	}
+	if fd.v2Fd != nil {
+		files = append(files, fd.v2Fd.prunableFiles(height, fd.cfg.DbPath)...)
+	}
+	return files, nil
+}
+
+func (fd *fileDAO) headerAndFooter(height uint64) (*block.Header, *block.Footer, error) {
+	header, err := fd.HeaderByHeight(height)
+	if err != nil {
+		return nil, nil, err
+	}
+	footer, err := fd.FooterByHeight(height)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, footer, nil
+}
+
 // CreateFileDAO creates FileDAO according to master file
 func CreateFileDAO(legacy bool, cfg config.DB) (FileDAO, error) {
 	fd := fileDAO{splitHeight: 1, cfg: cfg}
+	if _, exist := fileExists(prunedFileName(cfg.DbPath)); exist || (cfg.BlockDataRetention > 0 && !cfg.ReadOnly) {
+		fd.pruned = newPrunedStore(cfg)
+	}
 	fds := []*fileDAOv2{}
-	v2Top, v2Files := checkAuxFiles(cfg.DbPath, FileV2)
+	v2Top, v2Files := checkAuxFiles(cfg, FileV2)
 	if legacy {
 		legacyFd, err := newFileDAOLegacy(cfg)
 		if err != nil {
 			return nil, err
 		}
 		fd.legacyFd = legacyFd
-		fd.topIndex, _ = checkAuxFiles(cfg.DbPath, FileLegacyAuxiliary)
+		fd.topIndex, _ = checkAuxFiles(cfg, FileLegacyAuxiliary)
 
 		// legacy master file with no v2 files, early exit
 		if len(v2Files) == 0 {
@@ -420,3 +578,46 @@ func createNewV2File(start uint64, cfg config.DB) error {
 	}
 	return v2.Stop(ctx)
 }
+
+// ImportBottomBlock puts the block into the empty chain db file as its first block, so that the chain starts from the
+// block instead of genesis. It is used by a node bootstrapped from the state snapshot at the height of the block
+func ImportBottomBlock(cfg config.DB, blk *block.Block) error {
+	header, err := checkMasterChainDBFile(cfg)
+	if err != nil {
+		return err
+	}
+	if _, files := checkAuxFiles(cfg, FileV2); header.Version != FileV2 || len(files) > 0 {
+		return errors.Wrap(ErrNotSupported, "chain db is not a single v2 file")
+	}
+	ctx := context.Background()
+	fd := openFileDAOv2(cfg)
+	if err := fd.Start(ctx); err != nil {
+		return err
+	}
+	if fd.loadTip().Height+1 != fd.header.Start {
+		if err := fd.Stop(ctx); err != nil {
+			return err
+		}
+		return errors.Wrap(ErrAlreadyExist, "chain db is not empty")
+	}
+	fd.header.Start = blk.Height()
+	if err := WriteHeaderV2(fd.kvStore, fd.header); err != nil {
+		return err
+	}
+	if err := WriteTip(fd.kvStore, headerDataNs, topHeightKey, &FileTip{Height: blk.Height() - 1}); err != nil {
+		return err
+	}
+	if err := fd.Stop(ctx); err != nil {
+		return err
+	}
+
+	// reopen the file with the new bottom
+	fd = openFileDAOv2(cfg)
+	if err := fd.Start(ctx); err != nil {
+		return err
+	}
+	if err := fd.PutBlock(ctx, blk); err != nil {
+		return err
+	}
+	return fd.Stop(ctx)
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package filedao
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/compress"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// No dictionary is committed, as a dictionary trained on the synthetic blocks of createSampleBlock does not tell how it
+// performs on the chain. To test and benchmark a dictionary, write the serialized block stores of the real blocks into
+// $IOTEX_BLOCK_SAMPLE_DIR one per file, and train on them by `zstd --train -r $IOTEX_BLOCK_SAMPLE_DIR -o $IOTEX_ZSTD_DICT`
+const (
+	// benchmarkSampleDir is the env of the dir of the real block stores to benchmark, each file is a serialized block
+	// store. The samples of createSampleBlock are used if it is not set
+	benchmarkSampleDir = "IOTEX_BLOCK_SAMPLE_DIR"
+	// benchmarkZstdDict is the env of the zstd dictionary trained on the real block stores. The dictionary is not tested
+	// if it is not set
+	benchmarkZstdDict = "IOTEX_ZSTD_DICT"
+)
+
+// createSampleBlock creates a block with a mix of transfers, contract calls and stakings, with the receipts and
+// transaction logs like those on the chain
+func createSampleBlock(height uint64, prevHash hash.Hash256) (*block.Block, error) {
+	var (
+		actions  []action.SealedEnvelope
+		receipts []*action.Receipt
+	)
+	for i := 0; i < 20; i++ {
+		sender, recipient := identityset.PrivateKey(i%10), identityset.Address(10+i%10)
+		nonce := height*100 + uint64(i)
+		var (
+			selp action.SealedEnvelope
+			err  error
+		)
+		switch i % 4 {
+		case 0, 1:
+			selp, err = testutil.SignedTransfer(recipient.String(), sender, nonce, big.NewInt(int64(nonce)*1e15), nil, 10000, big.NewInt(1e12))
+		case 2:
+			// ERC20 transfer(address,uint256)
+			data, _ := hex.DecodeString("a9059cbb000000000000000000000000" + hex.EncodeToString(recipient.Bytes()))
+			amount := hash.BytesToHash256(big.NewInt(int64(nonce) * 1e15).Bytes())
+			data = append(data, amount[:]...)
+			selp, err = testutil.SignedExecution(identityset.Address(20).String(), sender, nonce, big.NewInt(0), 200000, big.NewInt(1e12), data)
+		default:
+			selp, err = testutil.SignedCreateStake(nonce, "delegate"+hex.EncodeToString([]byte{byte(i)}), "100000000000000000000", 91, true, nil, 10000, big.NewInt(1e12), sender)
+		}
+		if err != nil {
+			return nil, err
+		}
+		actHash := selp.Hash()
+		r := &action.Receipt{
+			Status:          uint64(iotextypes.ReceiptStatus_Success),
+			BlockHeight:     height,
+			ActionHash:      actHash,
+			GasConsumed:     10000 + uint64(i)*1000,
+			ContractAddress: identityset.Address(20).String(),
+		}
+		if i%4 == 2 {
+			r.AddLogs(&action.Log{
+				Address:     identityset.Address(20).String(),
+				Topics:      []hash.Hash256{hash.Hash256b([]byte("Transfer(address,address,uint256)")), hash.BytesToHash256(recipient.Bytes())},
+				Data:        big.NewInt(int64(nonce) * 1e15).Bytes(),
+				BlockHeight: height,
+				ActionHash:  actHash,
+				Index:       uint(i),
+			})
+		}
+		r.AddTransactionLogs(&action.TransactionLog{
+			Type:      iotextypes.TransactionLogType_GAS_FEE,
+			Amount:    big.NewInt(int64(r.GasConsumed) * 1e12),
+			Sender:    identityset.Address(i % 10).String(),
+			Recipient: "",
+		})
+		actions = append(actions, selp)
+		receipts = append(receipts, r)
+	}
+	blk, err := block.NewTestingBuilder().
+		SetHeight(height).
+		SetPrevBlockHash(prevHash).
+		SetTimeStamp(testutil.TimestampNow().UTC()).
+		AddActions(actions...).
+		SetReceipts(receipts).
+		SignAndBuild(identityset.PrivateKey(27))
+	if err != nil {
+		return nil, err
+	}
+	return &blk, nil
+}
+
+func TestFileDAOv2Compressors(t *testing.T) {
+	r := require.New(t)
+
+	cfg := config.Default.DB
+	cfg.V2BlocksToSplitDB = 10
+	cfg.DbPath = "./filedao_compressor.db"
+	defer os.RemoveAll(cfg.DbPath)
+
+	// each file is written with a different compressor, and read by the compressor in its own header
+	ctx := context.Background()
+	prevHash := hash.ZeroHash256
+	dictFile := os.Getenv(benchmarkZstdDict)
+	for i, comp := range []string{compress.Snappy, compress.Zstd, compress.Lz4, compress.Zstd, ""} {
+		cfg.Compressor, cfg.CompressorDict = comp, ""
+		if i == 3 {
+			cfg.CompressorDict = dictFile
+		}
+		fd, err := NewFileDAO(cfg)
+		r.NoError(err)
+		r.NoError(fd.Start(ctx))
+		start := uint64(i*10 + 1)
+		for height := start; height < start+10; height++ {
+			blk, err := createSampleBlock(height, prevHash)
+			r.NoError(err)
+			r.NoError(fd.PutBlock(ctx, blk))
+			prevHash = blk.HashBlock()
+		}
+		r.NoError(fd.Stop(ctx))
+		if i > 0 {
+			file := kthAuxFileName(cfg.DbPath, uint64(i))
+			defer os.RemoveAll(file)
+			h, err := readFileHeader(config.DB{DbPath: file}, FileV2)
+			r.NoError(err)
+			r.Equal(comp, h.Compressor)
+			r.Equal(i == 3 && dictFile != "", len(h.CompressorDict) > 0)
+		}
+	}
+
+	// the dictionary is not needed by the config to read the file
+	cfg.Compressor, cfg.CompressorDict = compress.Gzip, ""
+	fd, err := NewFileDAO(cfg)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	defer fd.Stop(ctx)
+	height, err := fd.Height()
+	r.NoError(err)
+	r.EqualValues(50, height)
+	for i := uint64(50); i >= 1; i-- {
+		blk, err := fd.GetBlockByHeight(i)
+		r.NoError(err)
+		r.Equal(prevHash, blk.HashBlock())
+		r.Len(blk.Actions, 20)
+		receipts, err := fd.GetReceipts(i)
+		r.NoError(err)
+		r.Len(receipts, 20)
+		logs, err := fd.TransactionLogs(i)
+		r.NoError(err)
+		r.Len(logs.Logs, 20)
+		prevHash = blk.PrevHash()
+	}
+
+	// invalid dictionary
+	cfg.Compressor, cfg.CompressorDict = compress.Zstd, "./filedao_compressor_not_exist.dict"
+	_, err = newFileDAOv2(1, cfg)
+	r.Error(err)
+}
+
+// BenchmarkCompressor compresses the block stores with each compressor, as a single block in the staging buffer and
+// as a batch of blocks in the block store, to pick the default compressor. Set the env IOTEX_BLOCK_SAMPLE_DIR to the
+// dir of the real block stores, and IOTEX_ZSTD_DICT to the zstd dictionary trained on them
+func BenchmarkCompressor(b *testing.B) {
+	r := require.New(b)
+
+	var samples [][]byte
+	if dir := os.Getenv(benchmarkSampleDir); dir != "" {
+		files, err := ioutil.ReadDir(dir)
+		r.NoError(err)
+		for _, f := range files {
+			data, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+			r.NoError(err)
+			samples = append(samples, data)
+		}
+	} else {
+		prevHash := hash.ZeroHash256
+		for height := uint64(1); height <= 64; height++ {
+			blk, err := createSampleBlock(height, prevHash)
+			r.NoError(err)
+			data, err := (&block.Store{Block: blk, Receipts: blk.Receipts}).Serialize()
+			r.NoError(err)
+			samples = append(samples, data)
+			prevHash = blk.HashBlock()
+		}
+	}
+	r.NotEmpty(samples)
+
+	// pack the samples into batches as the block store
+	batchSize := uint64(config.Default.DB.BlockStoreBatchSize)
+	var batches [][]byte
+	buffer := newStagingBuffer(batchSize)
+	for i, data := range samples {
+		full, err := buffer.Put(uint64(i)%batchSize, data)
+		r.NoError(err)
+		if full {
+			data, err := buffer.Serialize()
+			r.NoError(err)
+			batches = append(batches, data)
+		}
+	}
+
+	type compressor struct {
+		name   string
+		comp   func([]byte) ([]byte, error)
+		decomp func([]byte) ([]byte, error)
+	}
+	compressors := []compressor{
+		{name: compress.Gzip, comp: compress.CompGzip, decomp: compress.DecompGzip},
+		{name: compress.Snappy, comp: compress.CompSnappy, decomp: compress.DecompSnappy},
+		{name: compress.Zstd, comp: compress.CompZstd, decomp: compress.DecompZstd},
+		{name: compress.Lz4, comp: compress.CompLz4, decomp: compress.DecompLz4},
+	}
+	if dictFile := os.Getenv(benchmarkZstdDict); dictFile != "" {
+		dict, err := ioutil.ReadFile(dictFile)
+		r.NoError(err)
+		zstdDict, err := compress.NewZstdDict(dict)
+		r.NoError(err)
+		defer zstdDict.Close()
+		compressors = append(compressors, compressor{
+			name:   compress.Zstd + "-dict",
+			comp:   zstdDict.Compress,
+			decomp: zstdDict.Decompress,
+		})
+	}
+	for _, test := range []struct {
+		name string
+		data [][]byte
+	}{
+		{"block", samples},
+		{"batch", batches},
+	} {
+		if len(test.data) == 0 {
+			continue
+		}
+		size := 0
+		for _, data := range test.data {
+			size += len(data)
+		}
+		for _, c := range compressors {
+			compressed := make([][]byte, len(test.data))
+			b.Run(test.name+"-"+c.name+"-compress", func(b *testing.B) {
+				b.SetBytes(int64(size))
+				for n := 0; n < b.N; n++ {
+					total := 0
+					for i, data := range test.data {
+						v, err := c.comp(data)
+						if err != nil {
+							b.Fatal(err)
+						}
+						compressed[i] = v
+						total += len(v)
+					}
+					b.ReportMetric(float64(size)/float64(total), "ratio")
+				}
+			})
+			b.Run(test.name+"-"+c.name+"-decompress", func(b *testing.B) {
+				b.SetBytes(int64(size))
+				for n := 0; n < b.N; n++ {
+					for _, v := range compressed {
+						if _, err := c.decomp(v); err != nil {
+							b.Fatal(err)
+						}
+					}
+				}
+			})
+		}
+	}
+}
@@ -31,6 +31,7 @@ type (
 		Compressor     string
 		BlockStoreSize uint64
 		Start          uint64
+		CompressorDict []byte
 	}
 
 	// FileTip is tip info of chain
@@ -85,6 +86,7 @@ func (h *FileHeader) toProto() *headerpb.FileHeader {
 		Compressor:     h.Compressor,
 		BlockStoreSize: h.BlockStoreSize,
 		Start:          h.Start,
+		CompressorDict: h.CompressorDict,
 	}
 }
 
@@ -94,6 +96,7 @@ func fromProtoFileHeader(pb *headerpb.FileHeader) *FileHeader {
 		Compressor:     pb.Compressor,
 		BlockStoreSize: pb.BlockStoreSize,
 		Start:          pb.Start,
+		CompressorDict: pb.CompressorDict,
 	}
 }
 
@@ -75,7 +75,7 @@ func (fd *fileDAOLegacy) Start(ctx context.Context) error {
 
 	// set init height value and transaction log flag
 	if _, err := fd.kvStore.Get(blockNS, topHeightKey); err != nil &&
-		errors.Cause(err) == db.ErrNotExist {
+		errors.Cause(err) == db.ErrNotExist && !fd.cfg.ReadOnly {
 		zero8bytes := make([]byte, 8)
 		if err := fd.kvStore.Put(blockNS, topHeightKey, zero8bytes); err != nil {
 			return errors.Wrap(err, "failed to write initial value for top height")
@@ -87,7 +87,7 @@ func (fd *fileDAOLegacy) Start(ctx context.Context) error {
 
 	// loop thru all legacy files
 	base := fd.cfg.DbPath
-	_, files := checkAuxFiles(base, FileLegacyAuxiliary)
+	_, files := checkAuxFiles(fd.cfg, FileLegacyAuxiliary)
 	var maxN uint64
 	for _, file := range files {
 		index, ok := isAuxFile(file, base)
@@ -595,6 +595,9 @@ func (fd *fileDAOLegacy) openDB(idx uint64) (kvStore db.KVStore, index uint64, e
 			// something wrong getting FileInfo
 			return
 		}
+		if fd.cfg.ReadOnly {
+			return
+		}
 		newFile = true
 	}
 
@@ -615,6 +618,92 @@ func (fd *fileDAOLegacy) openDB(idx uint64) (kvStore db.KVStore, index uint64, e
 	return
 }
 
+// prunableFiles returns the auxiliary files whose blocks are all at or below height. The top file is included only if
+// it is no longer written
+func (fd *fileDAOLegacy) prunableFiles(height uint64, current bool) ([]*prunableFile, error) {
+	if fd.cfg.SplitDBSizeMB == 0 {
+		return nil, nil
+	}
+	tip, err := fd.Height()
+	if err != nil {
+		return nil, err
+	}
+	topIndex := fd.topIndex.Load().(uint64)
+	last := topIndex
+	if height < tip {
+		// the file storing the block above height cannot be pruned
+		value, err := fd.getFileIndex(height + 1)
+		if err != nil {
+			return nil, err
+		}
+		if last = byteutil.BytesToUint64BigEndian(value); last == 0 {
+			return nil, nil
+		}
+		last--
+	} else if current {
+		last = topIndex - 1
+	}
+
+	var files []*prunableFile
+	for idx := uint64(1); idx <= last && idx <= topIndex; idx++ {
+		if _, ok := fileExists(kthAuxFileName(fd.cfg.DbPath, idx)); !ok {
+			continue
+		}
+		start, err := fd.fileStart(idx, tip)
+		if err != nil {
+			return nil, err
+		}
+		end := tip
+		if idx < topIndex {
+			next, err := fd.fileStart(idx+1, tip)
+			if err != nil {
+				return nil, err
+			}
+			end = next - 1
+		}
+		if start > end {
+			continue
+		}
+		idx := idx
+		files = append(files, &prunableFile{
+			heightRange: heightRange{start: start, end: end},
+			remove: func() error {
+				return fd.removeFile(idx)
+			},
+		})
+	}
+	return files, nil
+}
+
+// fileStart returns the lowest height stored in the idx-th auxiliary file
+func (fd *fileDAOLegacy) fileStart(idx, tip uint64) (uint64, error) {
+	lo, hi := fd.cfg.SplitDBHeight+1, tip+1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		value, err := fd.getFileIndex(mid)
+		if err != nil {
+			return 0, err
+		}
+		if byteutil.BytesToUint64BigEndian(value) >= idx {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo, nil
+}
+
+// removeFile closes and deletes the idx-th auxiliary file
+func (fd *fileDAOLegacy) removeFile(idx uint64) error {
+	if kv, ok := fd.kvStores.Load(idx); ok {
+		fd.kvStores.Delete(idx)
+		if err := kv.(db.KVStore).Stop(context.Background()); err != nil {
+			return err
+		}
+	}
+	return os.Remove(kthAuxFileName(fd.cfg.DbPath, idx))
+}
+
 func heightKey(height uint64) []byte {
 	return append(heightPrefix, byteutil.Uint64ToBytes(height)...)
 }
@@ -0,0 +1,218 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package filedao
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// namespace for the headers and footers of the pruned blocks
+const (
+	prunedHeaderNS = "phr"
+	prunedFooterNS = "pfr"
+	prunedHashNS   = "phs"
+	prunedMetaNS   = "pmt"
+	// prunedBatchSize is the number of blocks whose headers and footers are written in a batch
+	prunedBatchSize = 1000
+)
+
+var (
+	prunedRangesKey = []byte("rg")
+)
+
+type (
+	// heightRange is the range of heights [start, end]
+	heightRange struct {
+		start, end uint64
+	}
+
+	// prunableFile is a chain db file whose blocks are all below the retained blocks
+	prunableFile struct {
+		heightRange
+		remove func() error
+	}
+
+	// prunedStore keeps the headers and footers of the blocks in the pruned files, which are still needed by consensus
+	// and block sync after the block bodies, receipts and transaction logs are dropped
+	prunedStore struct {
+		mu      sync.RWMutex
+		kvStore db.KVStore
+		ranges  []heightRange // sorted and disjoint ranges of the pruned heights
+	}
+)
+
+// prunedFileName returns the filename of the pruned store of the chain db file
+func prunedFileName(file string) string {
+	ext := path.Ext(file)
+	return strings.TrimSuffix(file, ext) + "-pruned" + ext
+}
+
+func newPrunedStore(cfg config.DB) *prunedStore {
+	cfg.DbPath = prunedFileName(cfg.DbPath)
+	return &prunedStore{kvStore: db.NewBoltDB(cfg)}
+}
+
+func (s *prunedStore) Start(ctx context.Context) error {
+	if err := s.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	value, err := s.kvStore.Get(prunedMetaNS, prunedRangesKey)
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil
+	default:
+		return err
+	}
+	if len(value)%16 != 0 {
+		return errors.Wrap(ErrDataCorruption, "invalid pruned ranges")
+	}
+	s.ranges = nil
+	for i := 0; i < len(value); i += 16 {
+		s.ranges = append(s.ranges, heightRange{
+			start: byteutil.BytesToUint64BigEndian(value[i : i+8]),
+			end:   byteutil.BytesToUint64BigEndian(value[i+8 : i+16]),
+		})
+	}
+	return nil
+}
+
+func (s *prunedStore) Stop(ctx context.Context) error {
+	return s.kvStore.Stop(ctx)
+}
+
+// Contains returns true if the block data at height has been pruned
+func (s *prunedStore) Contains(height uint64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.contains(height, height)
+}
+
+// Add keeps the headers and footers of the blocks in r, and then marks r as pruned
+func (s *prunedStore) Add(r heightRange, get func(uint64) (*block.Header, *block.Footer, error)) error {
+	s.mu.RLock()
+	added := s.contains(r.start, r.end)
+	s.mu.RUnlock()
+	if added {
+		return nil
+	}
+
+	b := batch.NewBatch()
+	for h := r.start; h <= r.end; h++ {
+		header, footer, err := get(h)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get header and footer at height %d", h)
+		}
+		serHeader, err := header.Serialize()
+		if err != nil {
+			return err
+		}
+		serFooter, err := footer.Serialize()
+		if err != nil {
+			return err
+		}
+		blkHash := header.HashBlock()
+		key := byteutil.Uint64ToBytesBigEndian(h)
+		b.Put(prunedHeaderNS, key, serHeader, "failed to put block header")
+		b.Put(prunedFooterNS, key, serFooter, "failed to put block footer")
+		b.Put(prunedHashNS, blkHash[:], key, "failed to put hash -> height mapping")
+		if b.Size() >= 3*prunedBatchSize || h == r.end {
+			if err := s.kvStore.WriteBatch(b); err != nil {
+				return err
+			}
+			b = batch.NewBatch()
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ranges := append(append([]heightRange{}, s.ranges...), r)
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].start < ranges[j].start })
+	merged := ranges[:1]
+	for _, next := range ranges[1:] {
+		if last := &merged[len(merged)-1]; next.start <= last.end+1 {
+			if next.end > last.end {
+				last.end = next.end
+			}
+			continue
+		}
+		merged = append(merged, next)
+	}
+	value := make([]byte, 0, 16*len(merged))
+	for _, r := range merged {
+		value = append(value, byteutil.Uint64ToBytesBigEndian(r.start)...)
+		value = append(value, byteutil.Uint64ToBytesBigEndian(r.end)...)
+	}
+	if err := s.kvStore.Put(prunedMetaNS, prunedRangesKey, value); err != nil {
+		return err
+	}
+	s.ranges = merged
+	return nil
+}
+
+// GetBlockHash returns the hash of the pruned block at height
+func (s *prunedStore) GetBlockHash(height uint64) (hash.Hash256, error) {
+	header, err := s.HeaderByHeight(height)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return header.HashBlock(), nil
+}
+
+// GetBlockHeight returns the height of the pruned block by hash
+func (s *prunedStore) GetBlockHeight(h hash.Hash256) (uint64, error) {
+	value, err := s.kvStore.Get(prunedHashNS, h[:])
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get height of pruned block %x", h)
+	}
+	return byteutil.BytesToUint64BigEndian(value), nil
+}
+
+// HeaderByHeight returns the header of the pruned block at height
+func (s *prunedStore) HeaderByHeight(height uint64) (*block.Header, error) {
+	value, err := s.kvStore.Get(prunedHeaderNS, byteutil.Uint64ToBytesBigEndian(height))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get header of pruned block %d", height)
+	}
+	header := &block.Header{}
+	if err := header.Deserialize(value); err != nil {
+		return nil, errors.Wrapf(err, "failed to deserialize header of pruned block %d", height)
+	}
+	return header, nil
+}
+
+// FooterByHeight returns the footer of the pruned block at height
+func (s *prunedStore) FooterByHeight(height uint64) (*block.Footer, error) {
+	value, err := s.kvStore.Get(prunedFooterNS, byteutil.Uint64ToBytesBigEndian(height))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get footer of pruned block %d", height)
+	}
+	footer := &block.Footer{}
+	if err := footer.Deserialize(value); err != nil {
+		return nil, errors.Wrapf(err, "failed to deserialize footer of pruned block %d", height)
+	}
+	return footer, nil
+}
+
+// contains returns true if [start, end] is in a pruned range
+func (s *prunedStore) contains(start, end uint64) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].end >= start })
+	return i < len(s.ranges) && s.ranges[i].start <= start && end <= s.ranges[i].end
+}
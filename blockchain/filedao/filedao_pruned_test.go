@@ -0,0 +1,95 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package filedao
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+)
+
+func TestFileDAOPruned(t *testing.T) {
+	r := require.New(t)
+
+	cfg := config.Default.DB
+	cfg.V2BlocksToSplitDB = 10
+	cfg.BlockDataRetention = 5
+	cfg.DbPath = "./filedao_pruned.db"
+	file1 := kthAuxFileName(cfg.DbPath, 1)
+	file2 := kthAuxFileName(cfg.DbPath, 2)
+	prunedFile := prunedFileName(cfg.DbPath)
+	for _, file := range []string{cfg.DbPath, file1, file2, prunedFile} {
+		defer os.RemoveAll(file)
+	}
+
+	ctx := context.Background()
+	fd, err := NewFileDAO(cfg)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	r.NoError(testCommitBlocks(t, fd, 1, 25, hash.ZeroHash256))
+	testVerifyChainDB(t, fd, 1, 25)
+	hashes := make(map[uint64]hash.Hash256)
+	for i := uint64(1); i <= 25; i++ {
+		h, err := fd.GetBlockHash(i)
+		r.NoError(err)
+		hashes[i] = h
+	}
+	r.NoError(fd.Stop(ctx))
+
+	// the file of blocks 11 to 20 is pruned on start, and stays pruned after restart
+	for i := 0; i < 2; i++ {
+		fd, err = NewFileDAO(cfg)
+		r.NoError(err)
+		r.NoError(fd.Start(ctx))
+		_, err = os.Stat(file1)
+		r.True(os.IsNotExist(err))
+		_, err = os.Stat(prunedFile)
+		r.NoError(err)
+
+		height, err := fd.Height()
+		r.NoError(err)
+		r.EqualValues(25, height)
+		for i := uint64(11); i <= 20; i++ {
+			h, err := fd.GetBlockHash(i)
+			r.NoError(err)
+			r.Equal(hashes[i], h)
+			height, err := fd.GetBlockHeight(h)
+			r.NoError(err)
+			r.Equal(i, height)
+			header, err := fd.HeaderByHeight(i)
+			r.NoError(err)
+			r.Equal(h, header.HashBlock())
+			header, err = fd.Header(h)
+			r.NoError(err)
+			r.Equal(i, header.Height())
+			_, err = fd.FooterByHeight(i)
+			r.NoError(err)
+
+			_, err = fd.GetBlockByHeight(i)
+			r.Equal(ErrBlockPruned, errors.Cause(err))
+			_, err = fd.GetBlock(h)
+			r.Equal(ErrBlockPruned, errors.Cause(err))
+			_, err = fd.GetReceipts(i)
+			r.Equal(ErrBlockPruned, errors.Cause(err))
+			_, err = fd.TransactionLogs(i)
+			r.Equal(ErrBlockPruned, errors.Cause(err))
+		}
+		// the blocks in the master file and the retained blocks are kept
+		for _, i := range []uint64{1, 10, 21, 25} {
+			blk, err := fd.GetBlockByHeight(i)
+			r.NoError(err)
+			r.Equal(hashes[i], blk.HashBlock())
+		}
+		r.NoError(fd.Stop(ctx))
+	}
+}
@@ -12,12 +12,14 @@ import (
 	"os"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/compress"
 )
 
@@ -63,9 +65,9 @@ func TestReadFileHeader(t *testing.T) {
 	cfg.DbPath = "./filedao_v2.db"
 
 	// test non-existing file
-	h, err := readFileHeader(cfg.DbPath, FileLegacyMaster)
+	h, err := readFileHeader(cfg, FileLegacyMaster)
 	r.Equal(ErrFileNotExist, err)
-	h, err = readFileHeader(cfg.DbPath, FileAll)
+	h, err = readFileHeader(cfg, FileAll)
 	r.Equal(ErrFileNotExist, err)
 
 	// empty legacy file is invalid
@@ -74,9 +76,9 @@ func TestReadFileHeader(t *testing.T) {
 	ctx := context.Background()
 	r.NoError(legacy.Start(ctx))
 	r.NoError(legacy.Stop(ctx))
-	h, err = readFileHeader(cfg.DbPath, FileLegacyMaster)
+	h, err = readFileHeader(cfg, FileLegacyMaster)
 	r.Equal(ErrFileInvalid, err)
-	h, err = readFileHeader(cfg.DbPath, FileAll)
+	h, err = readFileHeader(cfg, FileAll)
 	r.Equal(ErrFileInvalid, err)
 
 	// commit 1 block to make it a valid legacy file
@@ -102,7 +104,7 @@ func TestReadFileHeader(t *testing.T) {
 		{FileAll, FileLegacyMaster, nil},
 	}
 	for _, v := range test1 {
-		h, err = readFileHeader(cfg.DbPath, v.checkType)
+		h, err = readFileHeader(cfg, v.checkType)
 		r.Equal(v.err, err)
 		if err == nil {
 			r.Equal(v.version, h.Version)
@@ -121,14 +123,14 @@ func TestReadFileHeader(t *testing.T) {
 		{FileAll, FileV2, nil},
 	}
 	for _, v := range test2 {
-		h, err = readFileHeader(cfg.DbPath, v.checkType)
+		h, err = readFileHeader(cfg, v.checkType)
 		r.Equal(v.err, err)
 		if err == nil {
 			r.Equal(v.version, h.Version)
 		}
 	}
 
-	r.Panics(func() { readFileHeader(cfg.DbPath, "") })
+	r.Panics(func() { readFileHeader(cfg, "") })
 }
 
 func TestNewFileDAOSplitV2(t *testing.T) {
@@ -140,14 +142,14 @@ func TestNewFileDAOSplitV2(t *testing.T) {
 	defer os.RemoveAll(cfg.DbPath)
 
 	// test non-existing file
-	h, err := checkMasterChainDBFile(cfg.DbPath)
+	h, err := checkMasterChainDBFile(cfg)
 	r.Equal(ErrFileNotExist, err)
 
 	// test empty db file, this will create new v2 file
 	fd, err := NewFileDAO(cfg)
 	r.NoError(err)
 	r.NotNil(fd)
-	h, err = readFileHeader(cfg.DbPath, FileAll)
+	h, err = readFileHeader(cfg, FileAll)
 	r.NoError(err)
 	r.Equal(FileV2, h.Version)
 	ctx := context.Background()
@@ -175,7 +177,7 @@ func TestNewFileDAOSplitV2(t *testing.T) {
 	r.EqualValues(21, fm.splitHeight)
 	testVerifyChainDB(t, fd, 1, 25)
 	r.NoError(fd.Stop(ctx))
-	top, files := checkAuxFiles(cfg.DbPath, FileV2)
+	top, files := checkAuxFiles(cfg, FileV2)
 	r.EqualValues(2, top)
 	r.Equal(2, len(files))
 	file1 := kthAuxFileName("./filedao_v2.db", 1)
@@ -186,6 +188,32 @@ func TestNewFileDAOSplitV2(t *testing.T) {
 	os.RemoveAll(file2)
 }
 
+func TestImportBottomBlock(t *testing.T) {
+	r := require.New(t)
+
+	cfg := config.Default.DB
+	cfg.DbPath = "./filedao_bottom.db"
+	defer os.RemoveAll(cfg.DbPath)
+
+	r.Equal(ErrFileNotExist, errors.Cause(ImportBottomBlock(cfg, nil)))
+	fd, err := NewFileDAO(cfg)
+	r.NoError(err)
+
+	// the chain starts from block 5
+	blk := createTestingBlock(block.NewTestingBuilder(), 5, hash.ZeroHash256)
+	r.NoError(ImportBottomBlock(cfg, blk))
+	ctx := context.Background()
+	r.NoError(fd.Start(ctx))
+	r.NoError(testCommitBlocks(t, fd, 6, 8, blk.HashBlock()))
+	testVerifyChainDB(t, fd, 5, 8)
+	_, err = fd.GetBlockByHeight(4)
+	r.Error(err)
+	r.NoError(fd.Stop(ctx))
+
+	// cannot import into a non-empty file
+	r.Equal(ErrAlreadyExist, errors.Cause(ImportBottomBlock(cfg, blk)))
+}
+
 func TestNewFileDAOSplitLegacy(t *testing.T) {
 	r := require.New(t)
 
@@ -237,26 +265,26 @@ func TestNewFileDAOSplitLegacy(t *testing.T) {
 	defer os.RemoveAll(file2)
 	defer os.RemoveAll(file3)
 	defer os.RemoveAll(file4)
-	h, err := readFileHeader(cfg.DbPath, FileAll)
+	h, err := readFileHeader(cfg, FileAll)
 	r.NoError(err)
 	r.Equal(FileLegacyMaster, h.Version)
-	h, err = readFileHeader(file1, FileLegacyAuxiliary)
+	h, err = readFileHeader(config.DB{DbPath: file1}, FileLegacyAuxiliary)
 	r.NoError(err)
 	r.Equal(FileLegacyAuxiliary, h.Version)
-	h, err = readFileHeader(file2, FileV2)
+	h, err = readFileHeader(config.DB{DbPath: file2}, FileV2)
 	r.NoError(err)
 	r.Equal(FileV2, h.Version)
-	h, err = readFileHeader(file3, FileV2)
+	h, err = readFileHeader(config.DB{DbPath: file3}, FileV2)
 	r.NoError(err)
 	r.Equal(FileV2, h.Version)
-	h, err = readFileHeader(file4, FileV2)
+	h, err = readFileHeader(config.DB{DbPath: file4}, FileV2)
 	r.NoError(err)
 	r.Equal(FileV2, h.Version)
-	top, files := checkAuxFiles(cfg.DbPath, FileLegacyAuxiliary)
+	top, files := checkAuxFiles(cfg, FileLegacyAuxiliary)
 	r.EqualValues(1, top)
 	r.Equal(1, len(files))
 	r.Equal(files[0], file1)
-	top, files = checkAuxFiles(cfg.DbPath, FileV2)
+	top, files = checkAuxFiles(cfg, FileV2)
 	r.EqualValues(4, top)
 	r.Equal(3, len(files))
 	r.Equal(files[0], file2)
@@ -308,9 +336,9 @@ func TestCheckFiles(t *testing.T) {
 
 	cfg := config.Default.DB
 	cfg.DbPath = "./filedao_v2.db"
-	_, files := checkAuxFiles(cfg.DbPath, FileLegacyAuxiliary)
+	_, files := checkAuxFiles(cfg, FileLegacyAuxiliary)
 	r.Nil(files)
-	_, files = checkAuxFiles(cfg.DbPath, FileV2)
+	_, files = checkAuxFiles(cfg, FileV2)
 	r.Nil(files)
 
 	// create 3 v2 files
@@ -323,10 +351,66 @@ func TestCheckFiles(t *testing.T) {
 			os.RemoveAll(kthAuxFileName("./filedao_v2.db", uint64(i)))
 		}
 	}()
-	top, files := checkAuxFiles("./filedao_v2.db", FileV2)
+	top, files := checkAuxFiles(config.DB{DbPath: "./filedao_v2.db"}, FileV2)
 	r.EqualValues(3, top)
 	r.Equal(3, len(files))
 	for i := 1; i <= 3; i++ {
 		r.Equal(files[i-1], kthAuxFileName("./filedao_v2.db", uint64(i)))
 	}
 }
+
+func TestFileDAOReadOnly(t *testing.T) {
+	r := require.New(t)
+
+	cfg := config.Default.DB
+	cfg.V2BlocksToSplitDB = 10
+	cfg.DbPath = "./filedao_readonly.db"
+	defer func() {
+		os.RemoveAll(cfg.DbPath)
+		os.RemoveAll(kthAuxFileName(cfg.DbPath, 1))
+	}()
+	readOnly := cfg
+	readOnly.ReadOnly = true
+	_, err := NewFileDAO(readOnly)
+	r.Equal(ErrFileNotExist, err)
+
+	ctx := context.Background()
+	fd, err := NewFileDAO(cfg)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	prevHash := hash.ZeroHash256
+	for height := uint64(1); height <= 15; height++ {
+		blk, err := createSampleBlock(height, prevHash)
+		r.NoError(err)
+		r.NoError(fd.PutBlock(ctx, blk))
+		prevHash = blk.HashBlock()
+	}
+
+	hashes := make(map[uint64]hash.Hash256)
+	for height := uint64(1); height <= 15; height++ {
+		blk, err := fd.GetBlockByHeight(height)
+		r.NoError(err)
+		hashes[height] = blk.HashBlock()
+	}
+
+	// the files locked by the running writer cannot be opened
+	_, err = NewFileDAO(readOnly)
+	r.Equal(db.ErrLocked, errors.Cause(err))
+	r.NoError(fd.Stop(ctx))
+
+	secondary, err := NewFileDAO(readOnly)
+	r.NoError(err)
+	r.NoError(secondary.Start(ctx))
+	height, err := secondary.Height()
+	r.NoError(err)
+	r.EqualValues(15, height)
+	for height := uint64(1); height <= 15; height++ {
+		blk, err := secondary.GetBlockByHeight(height)
+		r.NoError(err)
+		r.Equal(hashes[height], blk.HashBlock())
+	}
+	blk, err := createSampleBlock(16, prevHash)
+	r.NoError(err)
+	r.Error(secondary.PutBlock(ctx, blk))
+	r.NoError(secondary.Stop(ctx))
+}
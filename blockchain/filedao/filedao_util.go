@@ -16,14 +16,15 @@ import (
 	"strings"
 
 	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db"
 )
 
-func checkMasterChainDBFile(defaultName string) (*FileHeader, error) {
-	h, err := readFileHeader(defaultName, FileAll)
-	if err == ErrFileNotExist || err == ErrFileInvalid {
+func checkMasterChainDBFile(cfg config.DB) (*FileHeader, error) {
+	h, err := readFileHeader(cfg, FileAll)
+	if err != nil {
 		return nil, err
 	}
 
@@ -38,16 +39,25 @@ func checkMasterChainDBFile(defaultName string) (*FileHeader, error) {
 	}
 }
 
-func readFileHeader(filename, fileType string) (*FileHeader, error) {
-	size, exist := fileExists(filename)
+// readFileHeader reads the header of the file cfg.DbPath, which is opened in read-only mode if cfg.ReadOnly is set
+func readFileHeader(cfg config.DB, fileType string) (*FileHeader, error) {
+	size, exist := fileExists(cfg.DbPath)
 	if !exist || size == 0 {
 		// default chain db file does not exist
 		return nil, ErrFileNotExist
 	}
 
-	file := db.NewBoltDB(config.DB{DbPath: filename, NumRetries: 3})
+	file := db.NewBoltDB(config.DB{
+		DbPath:     cfg.DbPath,
+		NumRetries: 3,
+		ReadOnly:   cfg.ReadOnly,
+	})
 	ctx := context.Background()
 	if err := file.Start(ctx); err != nil {
+		if errors.Cause(err) == db.ErrLocked {
+			// the file is locked by a running node
+			return nil, err
+		}
 		// not a valid db file
 		return nil, ErrFileInvalid
 	}
@@ -82,7 +92,8 @@ func fileExists(name string) (int64, bool) {
 	return info.Size(), true
 }
 
-func checkAuxFiles(filename, fileType string) (uint64, []string) {
+func checkAuxFiles(cfg config.DB, fileType string) (uint64, []string) {
+	filename := cfg.DbPath
 	file := path.Base(filename)
 	if file == "/" {
 		return 0, nil
@@ -107,7 +118,8 @@ func checkAuxFiles(filename, fileType string) (uint64, []string) {
 			continue
 		}
 		name := dir + "/" + v.Name()
-		header, err := readFileHeader(name, fileType)
+		cfg.DbPath = name
+		header, err := readFileHeader(cfg, fileType)
 		if err == nil && header.Version == fileType {
 			possible = append(possible, name)
 			if index > top {
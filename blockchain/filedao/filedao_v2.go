@@ -8,6 +8,7 @@ package filedao
 
 import (
 	"context"
+	"io/ioutil"
 	"sync/atomic"
 	"unsafe"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/compress"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
@@ -49,6 +51,8 @@ type (
 		hashStore db.CountingIndex // store block hash
 		blkStore  db.CountingIndex // store raw blocks
 		sysStore  db.CountingIndex // store transaction log
+		zstdDict  *compress.ZstdDict
+		readOnly  bool
 	}
 )
 
@@ -58,6 +62,15 @@ func newFileDAOv2(bottom uint64, cfg config.DB) (*fileDAOv2, error) {
 		return nil, ErrNotSupported
 	}
 
+	// the dictionary is kept in the file header, so the file can be read regardless of the config
+	var dict []byte
+	if cfg.Compressor == compress.Zstd && cfg.CompressorDict != "" {
+		var err error
+		if dict, err = ioutil.ReadFile(cfg.CompressorDict); err != nil {
+			return nil, errors.Wrap(err, "failed to read compressor dictionary")
+		}
+	}
+
 	fd := fileDAOv2{
 		filename: cfg.DbPath,
 		header: &FileHeader{
@@ -65,6 +78,7 @@ func newFileDAOv2(bottom uint64, cfg config.DB) (*fileDAOv2, error) {
 			Compressor:     cfg.Compressor,
 			BlockStoreSize: uint64(cfg.BlockStoreBatchSize),
 			Start:          bottom,
+			CompressorDict: dict,
 		},
 		tip: &FileTip{
 			Height: bottom - 1,
@@ -72,6 +86,7 @@ func newFileDAOv2(bottom uint64, cfg config.DB) (*fileDAOv2, error) {
 		blkCache: cache.NewThreadSafeLruCache(16),
 		kvStore:  db.NewBoltDB(cfg),
 		batch:    batch.NewBatch(),
+		readOnly: cfg.ReadOnly,
 	}
 	return &fd, nil
 }
@@ -83,6 +98,7 @@ func openFileDAOv2(cfg config.DB) *fileDAOv2 {
 		blkCache: cache.NewThreadSafeLruCache(16),
 		kvStore:  db.NewBoltDB(cfg),
 		batch:    batch.NewBatch(),
+		readOnly: cfg.ReadOnly,
 	}
 }
 
@@ -97,6 +113,9 @@ func (fd *fileDAOv2) Start(ctx context.Context) error {
 		if errors.Cause(err) != db.ErrBucketNotExist && errors.Cause(err) != db.ErrNotExist {
 			return errors.Wrap(err, "failed to get file header")
 		}
+		if fd.readOnly {
+			return errors.Wrapf(ErrFileInvalid, "file %s has no header", fd.filename)
+		}
 		// write file header and tip
 		if err = WriteHeaderV2(fd.kvStore, fd.header); err != nil {
 			return err
@@ -112,6 +131,19 @@ func (fd *fileDAOv2) Start(ctx context.Context) error {
 		}
 	}
 
+	// each file is compressed by the compressor in its own header
+	if comp := fd.header.Compressor; comp != "" && !compress.IsSupported(comp) {
+		return errors.Errorf("unsupported compressor %s of file %s", comp, fd.filename)
+	}
+	if len(fd.header.CompressorDict) > 0 {
+		if fd.header.Compressor != compress.Zstd {
+			return errors.Errorf("compressor dictionary is not supported by %s", fd.header.Compressor)
+		}
+		if fd.zstdDict, err = compress.NewZstdDict(fd.header.CompressorDict); err != nil {
+			return err
+		}
+	}
+
 	// create counting index for hash, blk, and transaction log
 	if fd.hashStore, err = db.NewCountingIndexNX(fd.kvStore, []byte(hashDataNS)); err != nil {
 		return err
@@ -131,6 +163,10 @@ func (fd *fileDAOv2) Start(ctx context.Context) error {
 }
 
 func (fd *fileDAOv2) Stop(ctx context.Context) error {
+	if fd.zstdDict != nil {
+		fd.zstdDict.Close()
+		fd.zstdDict = nil
+	}
 	return fd.kvStore.Stop(ctx)
 }
 
@@ -206,7 +242,7 @@ func (fd *fileDAOv2) TransactionLogs(height uint64) (*iotextypes.TransactionLogs
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get transaction log at height %d", height)
 	}
-	value, err = decompBytes(value, fd.header.Compressor)
+	value, err = fd.decompBytes(value)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get transaction log at height %d", height)
 	}
@@ -2,7 +2,9 @@ package filedao
 
 import (
 	"context"
+	"os"
 	"sort"
+	"sync"
 
 	"github.com/iotexproject/go-pkgs/hash"
 
@@ -18,6 +20,7 @@ type (
 
 	// FileV2Manager manages collection of v2 files
 	FileV2Manager struct {
+		lock    sync.RWMutex // guards Indices against the files added and pruned
 		Indices []*fileV2Index
 	}
 )
@@ -74,6 +77,8 @@ func (fm *FileV2Manager) Stop(ctx context.Context) error {
 
 // FileDAOByHeight returns FileDAO for the given height
 func (fm *FileV2Manager) FileDAOByHeight(height uint64) BaseFileDAO {
+	fm.lock.RLock()
+	defer fm.lock.RUnlock()
 	right := len(fm.Indices) - 1
 	if height >= fm.Indices[right].start {
 		return fm.Indices[right].fd
@@ -97,6 +102,8 @@ func (fm *FileV2Manager) FileDAOByHeight(height uint64) BaseFileDAO {
 
 // GetBlockHeight returns height by hash
 func (fm *FileV2Manager) GetBlockHeight(hash hash.Hash256) (uint64, error) {
+	fm.lock.RLock()
+	defer fm.lock.RUnlock()
 	for _, file := range fm.Indices {
 		if height, err := file.fd.GetBlockHeight(hash); err == nil {
 			return height, nil
@@ -107,6 +114,8 @@ func (fm *FileV2Manager) GetBlockHeight(hash hash.Hash256) (uint64, error) {
 
 // GetBlock returns block by hash
 func (fm *FileV2Manager) GetBlock(hash hash.Hash256) (*block.Block, error) {
+	fm.lock.RLock()
+	defer fm.lock.RUnlock()
 	for _, file := range fm.Indices {
 		if blk, err := file.fd.GetBlock(hash); err == nil {
 			return blk, nil
@@ -117,6 +126,8 @@ func (fm *FileV2Manager) GetBlock(hash hash.Hash256) (*block.Block, error) {
 
 // AddFileDAO add a new v2 file
 func (fm *FileV2Manager) AddFileDAO(fd *fileDAOv2, start uint64) error {
+	fm.lock.Lock()
+	defer fm.lock.Unlock()
 	// update current top's end
 	top := fm.Indices[len(fm.Indices)-1]
 	end, err := top.fd.Height()
@@ -135,6 +146,46 @@ func (fm *FileV2Manager) AddFileDAO(fd *fileDAOv2, start uint64) error {
 
 // TopFd returns the top (with maximum height) v2 file
 func (fm *FileV2Manager) TopFd() (BaseFileDAO, uint64) {
+	fm.lock.RLock()
+	defer fm.lock.RUnlock()
 	top := fm.Indices[len(fm.Indices)-1]
 	return top.fd, top.start
 }
+
+// prunableFiles returns the v2 files whose blocks are all at or below height, except the top file being written and the
+// master file
+func (fm *FileV2Manager) prunableFiles(height uint64, master string) []*prunableFile {
+	fm.lock.RLock()
+	defer fm.lock.RUnlock()
+	var files []*prunableFile
+	for _, index := range fm.Indices[:len(fm.Indices)-1] {
+		if index.end > height || index.fd.filename == master {
+			continue
+		}
+		index := index
+		files = append(files, &prunableFile{
+			heightRange: heightRange{start: index.start, end: index.end},
+			remove: func() error {
+				return fm.removeFileDAO(index)
+			},
+		})
+	}
+	return files
+}
+
+// removeFileDAO closes and deletes the v2 file, once it is no longer in the indices for the readers to find
+func (fm *FileV2Manager) removeFileDAO(index *fileV2Index) error {
+	fm.lock.Lock()
+	indices := make([]*fileV2Index, 0, len(fm.Indices))
+	for _, v := range fm.Indices {
+		if v != index {
+			indices = append(indices, v)
+		}
+	}
+	fm.Indices = indices
+	fm.lock.Unlock()
+	if err := index.fd.Stop(context.Background()); err != nil {
+		return err
+	}
+	return os.Remove(index.fd.filename)
+}
@@ -30,7 +30,7 @@ func (fd *fileDAOv2) populateStagingBuffer() (*stagingBuffer, error) {
 			return nil, err
 		}
 
-		v, err = decompBytes(v, fd.header.Compressor)
+		v, err = fd.decompBytes(v)
 		if err != nil {
 			return nil, err
 		}
@@ -79,7 +79,7 @@ func (fd *fileDAOv2) putBlock(blk *block.Block) error {
 	if err != nil {
 		return err
 	}
-	blkBytes, err := compBytes(ser, fd.header.Compressor)
+	blkBytes, err := fd.compBytes(ser)
 	if err != nil {
 		return err
 	}
@@ -99,7 +99,7 @@ func (fd *fileDAOv2) putBlock(blk *block.Block) error {
 	if ser, err = fd.blkBuffer.Serialize(); err != nil {
 		return err
 	}
-	if blkBytes, err = compBytes(ser, fd.header.Compressor); err != nil {
+	if blkBytes, err = fd.compBytes(ser); err != nil {
 		return err
 	}
 	return addOneEntryToBatch(fd.blkStore, blkBytes, fd.batch)
@@ -110,7 +110,7 @@ func (fd *fileDAOv2) putTransactionLog(blk *block.Block) error {
 	if sysLog == nil {
 		sysLog = &block.BlkTransactionLog{}
 	}
-	logBytes, err := compBytes(sysLog.Serialize(), fd.header.Compressor)
+	logBytes, err := fd.compBytes(sysLog.Serialize())
 	if err != nil {
 		return err
 	}
@@ -127,15 +127,21 @@ func addOneEntryToBatch(c db.CountingIndex, v []byte, b batch.KVStoreBatch) erro
 	return c.Finalize()
 }
 
-func compBytes(v []byte, comp string) ([]byte, error) {
-	if comp != "" {
+func (fd *fileDAOv2) compBytes(v []byte) ([]byte, error) {
+	if fd.zstdDict != nil {
+		return fd.zstdDict.Compress(v)
+	}
+	if comp := fd.header.Compressor; comp != "" {
 		return compress.Compress(v, comp)
 	}
 	return v, nil
 }
 
-func decompBytes(v []byte, comp string) ([]byte, error) {
-	if comp != "" {
+func (fd *fileDAOv2) decompBytes(v []byte) ([]byte, error) {
+	if fd.zstdDict != nil {
+		return fd.zstdDict.Decompress(v)
+	}
+	if comp := fd.header.Compressor; comp != "" {
 		return compress.Decompress(v, comp)
 	}
 	return v, nil
@@ -192,7 +198,7 @@ func (fd *fileDAOv2) getBlockStore(height uint64) (*block.Store, error) {
 	if err != nil {
 		return nil, err
 	}
-	value, err = decompBytes(value, fd.header.Compressor)
+	value, err = fd.decompBytes(value)
 	if err != nil {
 		return nil, err
 	}
@@ -8,6 +8,7 @@ package genesis
 
 import (
 	"flag"
+	"math"
 	"math/big"
 	"sort"
 	"time"
@@ -59,6 +60,7 @@ func defaultConfig() Genesis {
 			FairbankBlockHeight:     5165641,
 			GreenlandBlockHeight:    6544441,
 			HawaiiBlockHeight:       11073241,
+			IcelandBlockHeight:      math.MaxUint64,
 		},
 		Account: Account{
 			InitBalanceMap: make(map[string]string),
@@ -70,6 +72,7 @@ func defaultConfig() Genesis {
 			ProbationEpochPeriod:             6,
 			ProbationIntensityRate:           90,
 			UnproductiveDelegateMaxCacheSize: 20,
+			EvidenceMaxAgeEpochs:             1,
 		},
 		Rewarding: Rewarding{
 			InitBalanceStr:                 unit.ConvertIotxToRau(200000000).String(),
@@ -172,6 +175,10 @@ type (
 		GreenlandBlockHeight uint64 `yaml:"greenlandHeight"`
 		// HawaiiBlockHeight is the start height to fix GetBlockHash in EVM
 		HawaiiBlockHeight uint64 `yaml:"hawaiiHeight"`
+		// IcelandBlockHeight is the start height of the submitEvidence action to the poll protocol, and of accepting
+		// actions signed as Ethereum transactions, which is not scheduled on the mainnet yet
+		// TODO: IcelandBlockHeight is not added into protobuf definition for backward compatibility
+		IcelandBlockHeight uint64 `yaml:"icelandHeight"`
 	}
 	// Account contains the configs for account protocol
 	Account struct {
@@ -214,6 +221,9 @@ type (
 		ProbationIntensityRate uint32 `yaml:"probationIntensityRate"`
 		// UnproductiveDelegateMaxCacheSize is a max cache size of upd which is stored into state DB (probationEpochPeriod <= UnproductiveDelegateMaxCacheSize)
 		UnproductiveDelegateMaxCacheSize uint64 `yaml:unproductiveDelegateMaxCacheSize`
+		// EvidenceMaxAgeEpochs is the number of epochs before the current one, within which the offence proved by the
+		// evidence submitted has to be
+		EvidenceMaxAgeEpochs uint64 `yaml:"evidenceMaxAgeEpochs"`
 	}
 	// Delegate defines a delegate with address and votes
 	Delegate struct {
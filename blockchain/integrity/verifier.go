@@ -0,0 +1,371 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package integrity verifies the chain data in the chain db files, and the indexes built from the blocks, so damaged
+// heights are found before a random read fails
+package integrity
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/blockindex"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// kinds of damage
+const (
+	// DamageBlock means the block cannot be read
+	DamageBlock = "block"
+	// DamageHash means the hash of the block mismatches the hash <-> height mapping
+	DamageHash = "hash"
+	// DamageSignature means the signature of the block is invalid
+	DamageSignature = "signature"
+	// DamageTxRoot means the actions of the block mismatch the tx root
+	DamageTxRoot = "txRoot"
+	// DamageReceipts means the receipts of the block cannot be read
+	DamageReceipts = "receipts"
+	// DamageReceiptRoot means the receipts of the block mismatch the receipt root
+	DamageReceiptRoot = "receiptRoot"
+	// DamageChain means the block does not link to the previous block
+	DamageChain = "chain"
+	// DamageBlockIndex means the block index mismatches the block
+	DamageBlockIndex = "blockIndex"
+	// DamageActionIndex means the action index mismatches the actions of the block
+	DamageActionIndex = "actionIndex"
+	// DamageBloomFilter means the bloom filter misses the logs of the block
+	DamageBloomFilter = "bloomFilter"
+)
+
+// batchSize is the number of heights verified in parallel before reporting the progress
+const batchSize = 1000
+
+type (
+	// BlockReader reads the blocks to verify, which is implemented by filedao.FileDAO
+	BlockReader interface {
+		Height() (uint64, error)
+		GetBlockHash(uint64) (hash.Hash256, error)
+		GetBlockHeight(hash.Hash256) (uint64, error)
+		GetBlockByHeight(uint64) (*block.Block, error)
+		GetReceipts(uint64) ([]*action.Receipt, error)
+		HeaderByHeight(uint64) (*block.Header, error)
+	}
+
+	// Damage is a damage found at a height
+	Damage struct {
+		Height uint64 `json:"height"`
+		Kind   string `json:"kind"`
+		Reason string `json:"reason"`
+	}
+
+	// Report is the result of the verification
+	Report struct {
+		Start uint64 `json:"start"`
+		End   uint64 `json:"end"`
+		// PrunedBlocks is the number of the pruned blocks, whose headers are verified only
+		PrunedBlocks uint64    `json:"prunedBlocks"`
+		Damages      []*Damage `json:"damages"`
+	}
+
+	// Option sets the verifier
+	Option func(*Verifier) error
+
+	// Verifier verifies the chain data
+	Verifier struct {
+		dao         BlockReader
+		genesisHash hash.Hash256
+		indexer     blockindex.Indexer
+		bfIndexer   blockindex.BloomFilterIndexer
+		workers     int
+		progress    func(uint64)
+	}
+)
+
+// WithIndexer verifies the block index and action index against the blocks
+func WithIndexer(indexer blockindex.Indexer) Option {
+	return func(v *Verifier) error {
+		v.indexer = indexer
+		return nil
+	}
+}
+
+// WithBloomFilterIndexer verifies the block bloom filters against the logs of the blocks
+func WithBloomFilterIndexer(bfIndexer blockindex.BloomFilterIndexer) Option {
+	return func(v *Verifier) error {
+		v.bfIndexer = bfIndexer
+		return nil
+	}
+}
+
+// WithWorkers sets the number of heights verified in parallel
+func WithWorkers(workers int) Option {
+	return func(v *Verifier) error {
+		if workers <= 0 {
+			return errors.Errorf("invalid number of workers %d", workers)
+		}
+		v.workers = workers
+		return nil
+	}
+}
+
+// WithProgress sets the callback of the number of verified heights
+func WithProgress(progress func(uint64)) Option {
+	return func(v *Verifier) error {
+		v.progress = progress
+		return nil
+	}
+}
+
+// NewVerifier creates a verifier of the blocks read from dao. The genesis hash is the previous hash of block 1
+func NewVerifier(dao BlockReader, genesisHash hash.Hash256, opts ...Option) (*Verifier, error) {
+	v := &Verifier{
+		dao:         dao,
+		genesisHash: genesisHash,
+		workers:     1,
+	}
+	for _, opt := range opts {
+		if err := opt(v); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// Verify verifies the heights in [start, end], and reports the damages sorted by height. An end of 0 means the tip
+func (v *Verifier) Verify(ctx context.Context, start, end uint64) (*Report, error) {
+	tip, err := v.dao.Height()
+	if err != nil {
+		return nil, err
+	}
+	if end == 0 || end > tip {
+		end = tip
+	}
+	if start == 0 {
+		start = 1
+	}
+	if start > end {
+		return nil, errors.Errorf("invalid height range [%d, %d]", start, end)
+	}
+	var indexerHeight, bfIndexerHeight uint64
+	if v.indexer != nil {
+		if indexerHeight, err = v.indexer.Height(); err != nil {
+			return nil, errors.Wrap(err, "failed to get height of block index")
+		}
+	}
+	if v.bfIndexer != nil {
+		if bfIndexerHeight, err = v.bfIndexer.Height(); err != nil {
+			return nil, errors.Wrap(err, "failed to get height of bloom filter index")
+		}
+	}
+
+	report := &Report{Start: start, End: end}
+	for batchStart := start; batchStart <= end; batchStart += batchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		batchEnd := batchStart + batchSize - 1
+		if batchEnd > end {
+			batchEnd = end
+		}
+		results := make([]*result, batchEnd-batchStart+1)
+		heights := make(chan uint64)
+		var wg sync.WaitGroup
+		for i := 0; i < v.workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for height := range heights {
+					results[height-batchStart] = v.verify(height, height <= indexerHeight, height <= bfIndexerHeight)
+				}
+			}()
+		}
+		for height := batchStart; height <= batchEnd; height++ {
+			heights <- height
+		}
+		close(heights)
+		wg.Wait()
+
+		for _, r := range results {
+			if r.pruned {
+				report.PrunedBlocks++
+			}
+			report.Damages = append(report.Damages, r.damages...)
+		}
+		if v.progress != nil {
+			v.progress(batchEnd - start + 1)
+		}
+	}
+	return report, nil
+}
+
+// DamagedHeights returns the sorted heights with damages
+func (r *Report) DamagedHeights() []uint64 {
+	var heights []uint64
+	for _, d := range r.Damages {
+		// the damages are sorted by height
+		if len(heights) == 0 || heights[len(heights)-1] != d.Height {
+			heights = append(heights, d.Height)
+		}
+	}
+	return heights
+}
+
+type result struct {
+	pruned  bool
+	damages []*Damage
+}
+
+func (r *result) add(height uint64, kind string, err error) {
+	r.damages = append(r.damages, &Damage{Height: height, Kind: kind, Reason: err.Error()})
+}
+
+func (v *Verifier) verify(height uint64, withIndex, withBloomFilter bool) *result {
+	r := &result{}
+	h, err := v.dao.GetBlockHash(height)
+	if err != nil {
+		r.add(height, DamageHash, err)
+	}
+	blk, err := v.dao.GetBlockByHeight(height)
+	if errors.Cause(err) == filedao.ErrBlockPruned {
+		// only the header of the pruned block is kept
+		r.pruned = true
+		header, err := v.dao.HeaderByHeight(height)
+		if err != nil {
+			r.add(height, DamageBlock, err)
+			return r
+		}
+		v.verifyHeader(r, header, h)
+		return r
+	}
+	if err != nil {
+		r.add(height, DamageBlock, err)
+		return r
+	}
+	v.verifyHeader(r, &blk.Header, h)
+	if !blk.VerifySignature() {
+		r.add(height, DamageSignature, errors.Errorf("invalid signature of producer %x", blk.PublicKey().Bytes()))
+	}
+	if err := blk.VerifyTxRoot(blk.CalculateTxRoot()); err != nil {
+		r.add(height, DamageTxRoot, err)
+	}
+
+	receipts, err := v.dao.GetReceipts(height)
+	if err != nil {
+		r.add(height, DamageReceipts, err)
+	} else if err := blk.VerifyReceiptRoot(calculateReceiptRoot(receipts)); err != nil {
+		r.add(height, DamageReceiptRoot, err)
+	}
+	if withIndex {
+		v.verifyIndex(r, blk)
+	}
+	if withBloomFilter && receipts != nil {
+		v.verifyBloomFilter(r, height, receipts)
+	}
+	return r
+}
+
+// verifyHeader verifies the hash of the header and the link to the previous block
+func (v *Verifier) verifyHeader(r *result, header *block.Header, h hash.Hash256) {
+	height := header.Height()
+	blkHash := header.HashBlock()
+	if h != hash.ZeroHash256 && h != blkHash {
+		r.add(height, DamageHash, errors.Errorf("block hash %x mismatches hash %x of height", blkHash, h))
+	}
+	if hh, err := v.dao.GetBlockHeight(blkHash); err != nil {
+		r.add(height, DamageHash, err)
+	} else if hh != height {
+		r.add(height, DamageHash, errors.Errorf("block %x is mapped to height %d", blkHash, hh))
+	}
+
+	prevHash := v.genesisHash
+	if height > 1 {
+		var err error
+		if prevHash, err = v.dao.GetBlockHash(height - 1); err != nil {
+			// either the chain starts from height, or the previous block is damaged and reported by itself
+			return
+		}
+	}
+	if header.PrevHash() != prevHash {
+		r.add(height, DamageChain, errors.Errorf("previous hash %x mismatches %x", header.PrevHash(), prevHash))
+	}
+}
+
+// verifyIndex verifies the block index and action index of the block
+func (v *Verifier) verifyIndex(r *result, blk *block.Block) {
+	height := blk.Height()
+	blkHash := blk.HashBlock()
+	index, err := v.indexer.GetBlockIndex(height)
+	if err != nil {
+		r.add(height, DamageBlockIndex, err)
+		return
+	}
+	if h := hash.BytesToHash256(index.Hash()); h != blkHash {
+		r.add(height, DamageBlockIndex, errors.Errorf("indexed hash %x mismatches block hash %x", h, blkHash))
+	}
+	if index.NumAction() != uint32(len(blk.Actions)) {
+		r.add(height, DamageBlockIndex, errors.Errorf("indexed %d actions, block has %d", index.NumAction(), len(blk.Actions)))
+	}
+	if amount := blk.CalculateTransferAmount(); index.TsfAmount().Cmp(amount) != 0 {
+		r.add(height, DamageBlockIndex, errors.Errorf("indexed transfer amount %s mismatches %s", index.TsfAmount(), amount))
+	}
+	if h, err := v.indexer.GetBlockHeight(blkHash); err != nil {
+		r.add(height, DamageBlockIndex, err)
+	} else if h != height {
+		r.add(height, DamageBlockIndex, errors.Errorf("block %x is indexed at height %d", blkHash, h))
+	}
+	for _, selp := range blk.Actions {
+		actHash := selp.Hash()
+		actIndex, err := v.indexer.GetActionIndex(actHash[:])
+		if err != nil {
+			r.add(height, DamageActionIndex, errors.Wrapf(err, "action %x", actHash))
+			continue
+		}
+		if actIndex.BlockHeight() != height {
+			r.add(height, DamageActionIndex, errors.Errorf("action %x is indexed at height %d", actHash, actIndex.BlockHeight()))
+		}
+	}
+}
+
+// verifyBloomFilter verifies the block bloom filter contains the addresses and topics of the logs
+func (v *Verifier) verifyBloomFilter(r *result, height uint64, receipts []*action.Receipt) {
+	bf, err := v.bfIndexer.BloomFilterByHeight(height)
+	if err != nil {
+		r.add(height, DamageBloomFilter, err)
+		return
+	}
+	for _, receipt := range receipts {
+		for _, l := range receipt.Logs() {
+			if !bf.Exist([]byte(l.Address)) {
+				r.add(height, DamageBloomFilter, errors.Errorf("missing address %s of log of action %x", l.Address, l.ActionHash))
+				return
+			}
+			for i, topic := range l.Topics {
+				if !bf.Exist(append(byteutil.Uint64ToBytes(uint64(i)), topic[:]...)) {
+					r.add(height, DamageBloomFilter, errors.Errorf("missing topic %x of log of action %x", topic, l.ActionHash))
+					return
+				}
+			}
+		}
+	}
+}
+
+func calculateReceiptRoot(receipts []*action.Receipt) hash.Hash256 {
+	if len(receipts) == 0 {
+		return hash.ZeroHash256
+	}
+	h := make([]hash.Hash256, 0, len(receipts))
+	for _, receipt := range receipts {
+		h = append(h, receipt.Hash())
+	}
+	return crypto.NewMerkleTree(h).HashTree()
+}
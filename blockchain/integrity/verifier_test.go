@@ -0,0 +1,209 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package integrity
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/blockindex"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+type (
+	// damagedDAO returns the receipts of the previous block at receiptHeight, and fails to read the block at
+	// missingHeight
+	damagedDAO struct {
+		BlockReader
+		receiptHeight uint64
+		missingHeight uint64
+	}
+
+	// damagedIndexer maps the block hash to a wrong height
+	damagedIndexer struct {
+		blockindex.Indexer
+		blkHash hash.Hash256
+	}
+)
+
+func (d *damagedDAO) GetBlockByHeight(height uint64) (*block.Block, error) {
+	if height == d.missingHeight {
+		return nil, errors.Wrap(db.ErrNotExist, "block is missing")
+	}
+	return d.BlockReader.GetBlockByHeight(height)
+}
+
+func (d *damagedDAO) GetReceipts(height uint64) ([]*action.Receipt, error) {
+	if height == d.receiptHeight {
+		height--
+	}
+	return d.BlockReader.GetReceipts(height)
+}
+
+func (d *damagedIndexer) GetBlockHeight(h hash.Hash256) (uint64, error) {
+	height, err := d.Indexer.GetBlockHeight(h)
+	if err != nil || h != d.blkHash {
+		return height, err
+	}
+	return height + 1, nil
+}
+
+func createTestBlock(height uint64, prevHash hash.Hash256) (*block.Block, error) {
+	var (
+		actions  []action.SealedEnvelope
+		receipts []*action.Receipt
+	)
+	for i := 0; i < 3; i++ {
+		nonce := height*10 + uint64(i)
+		selp, err := testutil.SignedTransfer(identityset.Address(i+10).String(), identityset.PrivateKey(i), nonce, big.NewInt(int64(nonce)), nil, testutil.TestGasLimit, big.NewInt(0))
+		if err != nil {
+			return nil, err
+		}
+		r := &action.Receipt{
+			Status:      uint64(iotextypes.ReceiptStatus_Success),
+			BlockHeight: height,
+			ActionHash:  selp.Hash(),
+			GasConsumed: 10000,
+		}
+		r.AddLogs(&action.Log{
+			Address:     identityset.Address(20).String(),
+			Topics:      []hash.Hash256{hash.Hash256b([]byte("Transfer")), hash.BytesToHash256(identityset.Address(i + 10).Bytes())},
+			BlockHeight: height,
+			ActionHash:  selp.Hash(),
+			Index:       uint(i),
+		})
+		actions = append(actions, selp)
+		receipts = append(receipts, r)
+	}
+	blk, err := block.NewBuilder(block.NewRunnableActionsBuilder().AddActions(actions...).Build()).
+		SetHeight(height).
+		SetPrevBlockHash(prevHash).
+		SetTimestamp(testutil.TimestampNow().UTC()).
+		SetReceipts(receipts).
+		SetReceiptRoot(calculateReceiptRoot(receipts)).
+		SignAndBuild(identityset.PrivateKey(27))
+	if err != nil {
+		return nil, err
+	}
+	return &blk, nil
+}
+
+func TestVerifier(t *testing.T) {
+	r := require.New(t)
+
+	testPath, err := testutil.PathOfTempFile("integrity-chain")
+	r.NoError(err)
+	indexPath, err := testutil.PathOfTempFile("integrity-index")
+	r.NoError(err)
+	bfIndexPath, err := testutil.PathOfTempFile("integrity-bloomfilter")
+	r.NoError(err)
+	for _, path := range []string{testPath, indexPath, bfIndexPath} {
+		testutil.CleanupPath(t, path)
+		defer testutil.CleanupPath(t, path)
+	}
+
+	cfg := config.Default.DB
+	cfg.DbPath = testPath
+	indexCfg, bfIndexCfg := cfg, cfg
+	indexCfg.DbPath, bfIndexCfg.DbPath = indexPath, bfIndexPath
+
+	// write the blocks, and index 8 of them
+	ctx := context.Background()
+	genesisHash := hash.Hash256b([]byte("genesis"))
+	fd, err := filedao.NewFileDAO(cfg)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	indexer, err := blockindex.NewIndexer(db.NewBoltDB(indexCfg), genesisHash)
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	bfIndexer, err := blockindex.NewBloomfilterIndexer(db.NewBoltDB(bfIndexCfg), config.Default.Chain.RangeBloomFilterSize)
+	r.NoError(err)
+	r.NoError(bfIndexer.Start(ctx))
+	prevHash := genesisHash
+	var blkHash hash.Hash256
+	for height := uint64(1); height <= 10; height++ {
+		blk, err := createTestBlock(height, prevHash)
+		r.NoError(err)
+		r.NoError(fd.PutBlock(ctx, blk))
+		if height <= 8 {
+			r.NoError(indexer.PutBlock(ctx, blk))
+			r.NoError(bfIndexer.PutBlock(ctx, blk))
+		}
+		if height == 6 {
+			blkHash = blk.HashBlock()
+		}
+		prevHash = blk.HashBlock()
+	}
+	r.NoError(fd.Stop(ctx))
+	r.NoError(indexer.Stop(ctx))
+	r.NoError(bfIndexer.Stop(ctx))
+
+	// verify the files opened read-only
+	cfg.ReadOnly, indexCfg.ReadOnly, bfIndexCfg.ReadOnly = true, true, true
+	fd, err = filedao.NewFileDAO(cfg)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	defer fd.Stop(ctx)
+	indexer, err = blockindex.NewIndexer(db.NewBoltDB(indexCfg), genesisHash)
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	defer indexer.Stop(ctx)
+	bfIndexer, err = blockindex.NewBloomfilterIndexer(db.NewBoltDB(bfIndexCfg), config.Default.Chain.RangeBloomFilterSize)
+	r.NoError(err)
+	r.NoError(bfIndexer.Start(ctx))
+	defer bfIndexer.Stop(ctx)
+
+	var progress []uint64
+	v, err := NewVerifier(fd, genesisHash, WithIndexer(indexer), WithBloomFilterIndexer(bfIndexer), WithWorkers(4), WithProgress(func(n uint64) {
+		progress = append(progress, n)
+	}))
+	r.NoError(err)
+	report, err := v.Verify(ctx, 1, 0)
+	r.NoError(err)
+	r.EqualValues(1, report.Start)
+	r.EqualValues(10, report.End)
+	r.Empty(report.Damages)
+	r.Equal([]uint64{10}, progress)
+
+	// a wrong genesis hash breaks the chain at height 1
+	v, err = NewVerifier(fd, hash.ZeroHash256)
+	r.NoError(err)
+	report, err = v.Verify(ctx, 1, 2)
+	r.NoError(err)
+	r.Len(report.Damages, 1)
+	r.Equal(&Damage{Height: 1, Kind: DamageChain}, &Damage{Height: report.Damages[0].Height, Kind: report.Damages[0].Kind})
+
+	// damaged blocks and index
+	dao := &damagedDAO{BlockReader: fd, receiptHeight: 3, missingHeight: 5}
+	v, err = NewVerifier(dao, genesisHash, WithIndexer(&damagedIndexer{Indexer: indexer, blkHash: blkHash}), WithBloomFilterIndexer(bfIndexer), WithWorkers(2))
+	r.NoError(err)
+	report, err = v.Verify(ctx, 2, 8)
+	r.NoError(err)
+	r.Equal([]uint64{3, 5, 6}, report.DamagedHeights())
+	var kinds []string
+	for _, d := range report.Damages {
+		kinds = append(kinds, d.Kind)
+	}
+	r.Equal([]string{DamageReceiptRoot, DamageBlock, DamageBlockIndex}, kinds)
+
+	_, err = v.Verify(ctx, 9, 8)
+	r.Error(err)
+	_, err = NewVerifier(fd, genesisHash, WithWorkers(0))
+	r.Error(err)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/blockdao"
 	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/pkg/errors"
 )
@@ -34,6 +35,8 @@ type (
 	// BloomFilterIndexer is the interface for bloomfilter indexer
 	BloomFilterIndexer interface {
 		blockdao.BlockIndexer
+		// PutBlocks processes the blocks in order and commits them in a batch
+		PutBlocks([]*block.Block) error
 		// RangeBloomFilterSize returns the number of blocks that each rangeBloomfilter includes
 		RangeBloomFilterSize() uint64
 		// BloomFilterByHeight returns the block-level bloomfilter which includes not only topic but also address of logs info by given block height
@@ -124,6 +127,34 @@ func (bfx *bloomfilterIndexer) PutBlock(ctx context.Context, blk *block.Block) (
 	return nil
 }
 
+// PutBlocks processes the blocks in order by adding logs into rangebloomfilters, and commits them in a batch
+func (bfx *bloomfilterIndexer) PutBlocks(blks []*block.Block) (err error) {
+	if len(blks) == 0 {
+		return nil
+	}
+	bfx.mutex.Lock()
+	defer bfx.mutex.Unlock()
+	ctx := context.Background()
+	b := batch.NewBatch()
+	for i, blk := range blks {
+		height := blk.Height()
+		bfx.addLogsToRangeBloomFilter(ctx, height, blk.Receipts)
+		b.Put(BlockBloomFilterNamespace, byteutil.Uint64ToBytes(height), bfx.calculateBlockBloomFilter(ctx, blk.Receipts).Bytes(), "failed to put BlockBloomFilter")
+		// the rangebloomfilter is written once it is full, or at the last block
+		if height%bfx.rangeSize == 0 || i == len(blks)-1 {
+			b.Put(RangeBloomFilterNamespace, byteutil.Uint64ToBytes(bfx.rangeBloomfilterKey(height)), bfx.curRangeBloomfilter.Bytes(), "failed to put RangeBloomFilter")
+		}
+		if height%bfx.rangeSize == 0 {
+			bfx.curRangeBloomfilter, err = bloom.NewBloomFilter(2048, 3)
+			if err != nil {
+				return errors.Wrapf(err, "Can not create new bloomfilter")
+			}
+		}
+	}
+	b.Put(RangeBloomFilterNamespace, []byte(CurrentHeightKey), byteutil.Uint64ToBytes(blks[len(blks)-1].Height()), "failed to put current height")
+	return bfx.kvStore.WriteBatch(b)
+}
+
 // DeleteTipBlock deletes tip height from underlying DB if necessary
 func (bfx *bloomfilterIndexer) DeleteTipBlock(blk *block.Block) (err error) {
 	bfx.mutex.Lock()
@@ -0,0 +1,310 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
+)
+
+// names of the indexers
+const (
+	// IndexerBlock is the block and action index in Chain.IndexDBPath
+	IndexerBlock = "index"
+	// IndexerBloomFilter is the bloom filter index in Chain.BloomfilterIndexDBPath
+	IndexerBloomFilter = "bloomfilter"
+	// IndexerCandidate is the candidate index in Chain.CandidateIndexDBPath
+	IndexerCandidate = "candidate"
+	// IndexerStaking is the staking candidates and buckets index in Chain.StakingIndexDBPath
+	IndexerStaking = "staking"
+)
+
+const (
+	// rebuildSuffix is the suffix of the marker file, which exists until the rebuild of the indexer finishes
+	rebuildSuffix = ".rebuild"
+	// defaultRebuildBatchSize is the number of blocks committed in a batch, same as the index builder
+	defaultRebuildBatchSize = 5000
+)
+
+type (
+	// BlockReader reads the blocks to rebuild the indexers from, which is implemented by filedao.FileDAO
+	BlockReader interface {
+		Height() (uint64, error)
+		GetBlockByHeight(uint64) (*block.Block, error)
+		GetReceipts(uint64) ([]*action.Receipt, error)
+	}
+
+	batchIndexer interface {
+		Start(context.Context) error
+		Stop(context.Context) error
+		Height() (uint64, error)
+		PutBlocks([]*block.Block) error
+	}
+
+	// RebuilderOption sets the rebuilder
+	RebuilderOption func(*Rebuilder) error
+
+	// Rebuilder drops the indexers and rebuilds them from the blocks
+	Rebuilder struct {
+		dao       BlockReader
+		cfg       config.Config
+		workers   int
+		batchSize uint64
+		progress  func(string, uint64, uint64)
+	}
+)
+
+// WithRebuildWorkers sets the number of blocks read in parallel
+func WithRebuildWorkers(workers int) RebuilderOption {
+	return func(r *Rebuilder) error {
+		if workers <= 0 {
+			return errors.Errorf("invalid number of workers %d", workers)
+		}
+		r.workers = workers
+		return nil
+	}
+}
+
+// WithRebuildBatchSize sets the number of blocks committed in a batch
+func WithRebuildBatchSize(batchSize uint64) RebuilderOption {
+	return func(r *Rebuilder) error {
+		if batchSize == 0 {
+			return errors.New("batch size is 0")
+		}
+		r.batchSize = batchSize
+		return nil
+	}
+}
+
+// WithRebuildProgress sets the callback of the indexer name, the height rebuilt to, and the tip height
+func WithRebuildProgress(progress func(string, uint64, uint64)) RebuilderOption {
+	return func(r *Rebuilder) error {
+		r.progress = progress
+		return nil
+	}
+}
+
+// NewRebuilder creates a rebuilder of the indexers in the paths of cfg.Chain, from the blocks read from dao
+func NewRebuilder(dao BlockReader, cfg config.Config, opts ...RebuilderOption) (*Rebuilder, error) {
+	r := &Rebuilder{
+		dao:       dao,
+		cfg:       cfg,
+		workers:   1,
+		batchSize: defaultRebuildBatchSize,
+	}
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// PendingRebuilds returns the indexers whose rebuild has not finished
+func PendingRebuilds(cfg config.Config) []string {
+	var names []string
+	for _, name := range []string{IndexerBlock, IndexerBloomFilter, IndexerCandidate, IndexerStaking} {
+		if path, err := indexerPath(cfg, name); err == nil && fileutil.FileExists(path+rebuildSuffix) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Rebuild drops the indexer and rebuilds it to the tip height of the blocks. An interrupted rebuild is resumed from
+// the height of the indexer, instead of dropping it again.
+//
+// The candidate and staking indexers are written by the poll and staking protocols from the states at the start of
+// each epoch, which cannot be read back from the latest states, so the state DB has to be dropped along with them.
+// Since that replays all the blocks from genesis, it is only done if cfg.Chain.DropStateDB is set. They are rebuilt as
+// the state factory replays the blocks through the protocols at the startup of the node, which resumes from the
+// height of the state factory if interrupted. FinishStateRebuilds marks them done once the replay is done
+func (r *Rebuilder) Rebuild(ctx context.Context, name string) error {
+	path, err := indexerPath(r.cfg, name)
+	if err != nil {
+		return err
+	}
+	if name == IndexerStaking && !r.cfg.Chain.EnableStakingIndexer {
+		return errors.Errorf("indexer %s is not enabled", name)
+	}
+	marker := path + rebuildSuffix
+	if !fileutil.FileExists(marker) {
+		// the marker is created after the indexer is dropped, so the old indexer is never resumed
+		paths := []string{path}
+		if isStateIndexer(name) {
+			if !r.cfg.Chain.DropStateDB {
+				return errors.Errorf(
+					"indexer %s is rebuilt by replaying all the blocks from genesis, which requires dropping the state DB",
+					name,
+				)
+			}
+			log.L().Warn("Dropping the state DB to rebuild the indexer, all the blocks are replayed from genesis.",
+				zap.String("indexer", name),
+				zap.String("stateDB", r.cfg.Chain.TrieDBPath))
+			paths = append(paths, r.cfg.Chain.TrieDBPath)
+		}
+		for _, p := range paths {
+			if err := os.RemoveAll(p); err != nil {
+				return errors.Wrapf(err, "failed to drop indexer %s", name)
+			}
+		}
+		if err := ioutil.WriteFile(marker, nil, 0600); err != nil {
+			return errors.Wrapf(err, "failed to mark the rebuild of indexer %s", name)
+		}
+	}
+	if isStateIndexer(name) {
+		log.L().Info("Indexer is rebuilt by replaying the blocks.", zap.String("indexer", name))
+		return nil
+	}
+
+	cfg := r.cfg.DB
+	cfg.DbPath = path
+	var indexer batchIndexer
+	switch name {
+	case IndexerBlock:
+		indexer, err = NewIndexer(db.NewOnDiskDB(cfg), r.cfg.Genesis.Hash())
+	case IndexerBloomFilter:
+		indexer, err = NewBloomfilterIndexer(db.NewOnDiskDB(cfg), r.cfg.Chain.RangeBloomFilterSize)
+	}
+	if err != nil {
+		return err
+	}
+	if err := indexer.Start(ctx); err != nil {
+		return err
+	}
+	if err := r.catchUp(ctx, name, indexer); err != nil {
+		indexer.Stop(ctx)
+		return err
+	}
+	if err := indexer.Stop(ctx); err != nil {
+		return err
+	}
+	return os.Remove(marker)
+}
+
+func (r *Rebuilder) catchUp(ctx context.Context, name string, indexer batchIndexer) error {
+	height, err := indexer.Height()
+	if err != nil {
+		return err
+	}
+	tipHeight, err := r.dao.Height()
+	if err != nil {
+		return err
+	}
+	if height > tipHeight {
+		return errors.Errorf("indexer %s height %d is higher than blocks height %d", name, height, tipHeight)
+	}
+	log.L().Info("Rebuilding indexer.", zap.String("indexer", name), zap.Uint64("height", height), zap.Uint64("tipHeight", tipHeight))
+	for height < tipHeight {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		end := height + r.batchSize
+		if end > tipHeight {
+			end = tipHeight
+		}
+		blks, err := r.readBlocks(height+1, end)
+		if err != nil {
+			return err
+		}
+		if err := indexer.PutBlocks(blks); err != nil {
+			return errors.Wrapf(err, "failed to index blocks up to height %d", end)
+		}
+		height = end
+		log.L().Info("Finished indexing blocks up to", zap.String("indexer", name), zap.Uint64("height", height))
+		if r.progress != nil {
+			r.progress(name, height, tipHeight)
+		}
+	}
+	return nil
+}
+
+// readBlocks reads the blocks with receipts in [start, end] in parallel
+func (r *Rebuilder) readBlocks(start, end uint64) ([]*block.Block, error) {
+	var (
+		blks    = make([]*block.Block, end-start+1)
+		heights = make(chan uint64)
+		wg      sync.WaitGroup
+		errOnce sync.Once
+		readErr error
+	)
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for height := range heights {
+				blk, err := r.dao.GetBlockByHeight(height)
+				if err == nil && blk.Receipts == nil {
+					blk.Receipts, err = r.dao.GetReceipts(height)
+				}
+				if err != nil {
+					errOnce.Do(func() {
+						readErr = errors.Wrapf(err, "failed to read block %d", height)
+					})
+					continue
+				}
+				blks[height-start] = blk
+			}
+		}()
+	}
+	for height := start; height <= end; height++ {
+		heights <- height
+	}
+	close(heights)
+	wg.Wait()
+	if readErr != nil {
+		return nil, readErr
+	}
+	return blks, nil
+}
+
+// FinishStateRebuilds marks the rebuilds of the candidate and staking indexers done, once the state factory has
+// replayed the blocks
+func FinishStateRebuilds(cfg config.Config) error {
+	for _, name := range []string{IndexerCandidate, IndexerStaking} {
+		path, err := indexerPath(cfg, name)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path + rebuildSuffix); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to finish the rebuild of indexer %s", name)
+		}
+	}
+	return nil
+}
+
+// isStateIndexer returns true if the indexer is built from the states instead of the blocks
+func isStateIndexer(name string) bool {
+	return name == IndexerCandidate || name == IndexerStaking
+}
+
+func indexerPath(cfg config.Config, name string) (string, error) {
+	switch name {
+	case IndexerBlock:
+		return cfg.Chain.IndexDBPath, nil
+	case IndexerBloomFilter:
+		return cfg.Chain.BloomfilterIndexDBPath, nil
+	case IndexerCandidate:
+		return cfg.Chain.CandidateIndexDBPath, nil
+	case IndexerStaking:
+		return cfg.Chain.StakingIndexDBPath, nil
+	default:
+		return "", errors.Errorf("unknown indexer %s", name)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestRebuilder(t *testing.T) {
+	require := require.New(t)
+
+	chainPath, err := testutil.PathOfTempFile("rebuild-chain")
+	require.NoError(err)
+	indexPath, err := testutil.PathOfTempFile("rebuild-index")
+	require.NoError(err)
+	bfIndexPath, err := testutil.PathOfTempFile("rebuild-bloomfilter")
+	require.NoError(err)
+	testutil.CleanupPath(t, chainPath)
+	defer func() {
+		for _, path := range []string{chainPath, indexPath, bfIndexPath} {
+			testutil.CleanupPath(t, path)
+			testutil.CleanupPath(t, path+rebuildSuffix)
+		}
+	}()
+
+	cfg := config.Default
+	cfg.DB.DbPath = chainPath
+	cfg.Chain.IndexDBPath = indexPath
+	cfg.Chain.BloomfilterIndexDBPath = bfIndexPath
+	cfg.Chain.RangeBloomFilterSize = 4
+
+	ctx := context.Background()
+	blks := getTestLogBlocks(t)
+	dao, err := filedao.NewFileDAO(cfg.DB)
+	require.NoError(err)
+	require.NoError(dao.Start(ctx))
+	defer dao.Stop(ctx)
+	for _, blk := range blks {
+		require.NoError(dao.PutBlock(ctx, blk))
+	}
+
+	// the bloom filter index built block by block
+	expected, err := NewBloomfilterIndexer(db.NewMemKVStore(), cfg.Chain.RangeBloomFilterSize)
+	require.NoError(err)
+	require.NoError(expected.Start(ctx))
+	defer expected.Stop(ctx)
+	for _, blk := range blks {
+		require.NoError(expected.PutBlock(ctx, blk))
+	}
+
+	// the corrupted index is dropped, and the interrupted rebuild of bloom filter index is resumed
+	require.NoError(ioutil.WriteFile(indexPath, []byte("corrupted"), 0600))
+	dbCfg := cfg.DB
+	dbCfg.DbPath = bfIndexPath
+	bfIndexer, err := NewBloomfilterIndexer(db.NewBoltDB(dbCfg), cfg.Chain.RangeBloomFilterSize)
+	require.NoError(err)
+	require.NoError(bfIndexer.Start(ctx))
+	require.NoError(bfIndexer.PutBlocks(blks[:2]))
+	require.NoError(bfIndexer.Stop(ctx))
+	require.NoError(ioutil.WriteFile(bfIndexPath+rebuildSuffix, nil, 0600))
+	require.Equal([]string{IndexerBloomFilter}, PendingRebuilds(cfg))
+
+	progress := map[string][]uint64{}
+	rebuilder, err := NewRebuilder(dao, cfg, WithRebuildWorkers(3), WithRebuildBatchSize(2), WithRebuildProgress(func(name string, height, tipHeight uint64) {
+		require.EqualValues(5, tipHeight)
+		progress[name] = append(progress[name], height)
+	}))
+	require.NoError(err)
+	require.NoError(rebuilder.Rebuild(ctx, IndexerBlock))
+	require.NoError(rebuilder.Rebuild(ctx, IndexerBloomFilter))
+	require.Equal(map[string][]uint64{
+		IndexerBlock:       {2, 4, 5},
+		IndexerBloomFilter: {4, 5},
+	}, progress)
+	require.Empty(PendingRebuilds(cfg))
+	require.False(fileutil.FileExists(indexPath + rebuildSuffix))
+
+	dbCfg.DbPath = indexPath
+	indexer, err := NewIndexer(db.NewBoltDB(dbCfg), cfg.Genesis.Hash())
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	defer indexer.Stop(ctx)
+	height, err := indexer.Height()
+	require.NoError(err)
+	require.EqualValues(5, height)
+	for _, blk := range blks {
+		h, err := indexer.GetBlockHash(blk.Height())
+		require.NoError(err)
+		require.Equal(blk.HashBlock(), h)
+	}
+
+	dbCfg.DbPath = bfIndexPath
+	bfIndexer, err = NewBloomfilterIndexer(db.NewBoltDB(dbCfg), cfg.Chain.RangeBloomFilterSize)
+	require.NoError(err)
+	require.NoError(bfIndexer.Start(ctx))
+	defer bfIndexer.Stop(ctx)
+	height, err = bfIndexer.Height()
+	require.NoError(err)
+	require.EqualValues(5, height)
+	bfx, expectedBfx := bfIndexer.(*bloomfilterIndexer), expected.(*bloomfilterIndexer)
+	for _, blk := range blks {
+		bf, err := bfx.blockBloomFilter(blk.Height())
+		require.NoError(err)
+		expectedBf, err := expectedBfx.blockBloomFilter(blk.Height())
+		require.NoError(err)
+		require.Equal(expectedBf.Bytes(), bf.Bytes())
+		bf, err = bfx.rangeBloomFilter(blk.Height())
+		require.NoError(err)
+		expectedBf, err = expectedBfx.rangeBloomFilter(blk.Height())
+		require.NoError(err)
+		require.Equal(expectedBf.Bytes(), bf.Bytes())
+	}
+
+	// the indexers built from the states are dropped along with the states, and rebuilt by replaying the blocks
+	candidatePath, err := testutil.PathOfTempFile("rebuild-candidate")
+	require.NoError(err)
+	triePath, err := testutil.PathOfTempFile("rebuild-trie")
+	require.NoError(err)
+	defer func() {
+		for _, path := range []string{candidatePath, triePath} {
+			testutil.CleanupPath(t, path)
+			testutil.CleanupPath(t, path+rebuildSuffix)
+		}
+	}()
+	rebuilder.cfg.Chain.CandidateIndexDBPath = candidatePath
+	rebuilder.cfg.Chain.TrieDBPath = triePath
+	for _, path := range []string{candidatePath, triePath} {
+		require.NoError(ioutil.WriteFile(path, []byte("corrupted"), 0600))
+	}
+	// the state DB is not dropped unless asked
+	require.Error(rebuilder.Rebuild(ctx, IndexerCandidate))
+	require.True(fileutil.FileExists(triePath))
+	require.Empty(PendingRebuilds(rebuilder.cfg))
+	rebuilder.cfg.Chain.DropStateDB = true
+	require.NoError(rebuilder.Rebuild(ctx, IndexerCandidate))
+	require.False(fileutil.FileExists(candidatePath))
+	require.False(fileutil.FileExists(triePath))
+	require.Equal([]string{IndexerCandidate}, PendingRebuilds(rebuilder.cfg))
+	// the replay is resumed from the states at the next start
+	require.NoError(ioutil.WriteFile(triePath, []byte("replayed"), 0600))
+	require.NoError(rebuilder.Rebuild(ctx, IndexerCandidate))
+	require.True(fileutil.FileExists(triePath))
+	require.NoError(FinishStateRebuilds(rebuilder.cfg))
+	require.Empty(PendingRebuilds(rebuilder.cfg))
+
+	// the staking indexer is not enabled
+	require.Error(rebuilder.Rebuild(ctx, IndexerStaking))
+	require.Error(rebuilder.Rebuild(ctx, "unknown"))
+	_, err = NewRebuilder(dao, cfg, WithRebuildWorkers(0))
+	require.Error(err)
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// sqlIndexRetryInterval is the interval to retry writing the SQL database after it fails
+const sqlIndexRetryInterval = 10 * time.Second
+
+// SQLIndexBuilder writes the committed blocks into the SQL indexer in the background, so that an outage of the SQL
+// database does not stop the blocks from being committed. It is registered as an indexer of the block DAO, and catches
+// up from the height of the SQL indexer to the tip of the block DAO whenever a block is put or deleted, and every
+// retry interval
+type SQLIndexBuilder struct {
+	dao           blockdao.BlockDAO
+	indexer       SQLIndexer
+	retryInterval time.Duration
+	started       bool
+	syncCh        chan struct{}
+	stopCh        chan struct{}
+	wg            sync.WaitGroup
+}
+
+// NewSQLIndexBuilder instantiates a SQL index builder, which reads the blocks from the block DAO set by SetBlockDAO
+func NewSQLIndexBuilder(indexer SQLIndexer) *SQLIndexBuilder {
+	return &SQLIndexBuilder{
+		indexer:       indexer,
+		retryInterval: sqlIndexRetryInterval,
+		syncCh:        make(chan struct{}, 1),
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// SetBlockDAO sets the block DAO, which is created with the index builder in its indexers
+func (ib *SQLIndexBuilder) SetBlockDAO(dao blockdao.BlockDAO) {
+	ib.dao = dao
+}
+
+// Start starts the worker catching up the SQL indexer
+func (ib *SQLIndexBuilder) Start(_ context.Context) error {
+	if ib.dao == nil {
+		return errors.New("block DAO is not set")
+	}
+	ib.wg.Add(1)
+	go ib.worker()
+	ib.signal()
+	return nil
+}
+
+// Stop stops the worker and the SQL indexer
+func (ib *SQLIndexBuilder) Stop(ctx context.Context) error {
+	close(ib.stopCh)
+	ib.wg.Wait()
+	return ib.indexer.Stop(ctx)
+}
+
+// Height returns the tip height of the block DAO, since every block is accepted and written in the background
+func (ib *SQLIndexBuilder) Height() (uint64, error) {
+	if ib.dao == nil {
+		return 0, errors.New("block DAO is not set")
+	}
+	return ib.dao.Height()
+}
+
+// PutBlock signals the worker to catch up to the new block, without waiting for the SQL database
+func (ib *SQLIndexBuilder) PutBlock(context.Context, *block.Block) error {
+	ib.signal()
+	return nil
+}
+
+// DeleteTipBlock signals the worker to delete the rows of the blocks rolled back
+func (ib *SQLIndexBuilder) DeleteTipBlock(*block.Block) error {
+	ib.signal()
+	return nil
+}
+
+func (ib *SQLIndexBuilder) signal() {
+	select {
+	case ib.syncCh <- struct{}{}:
+	default:
+	}
+}
+
+func (ib *SQLIndexBuilder) worker() {
+	defer ib.wg.Done()
+	ticker := time.NewTicker(ib.retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ib.stopCh:
+			return
+		case <-ib.syncCh:
+		case <-ticker.C:
+		}
+		if err := ib.catchUp(); err != nil {
+			log.L().Error("Failed to write blocks into the SQL database.", zap.Error(err))
+		}
+	}
+}
+
+// catchUp deletes the blocks rolled back from the SQL indexer, and writes the blocks above the common ancestor until
+// the tip of the block DAO
+func (ib *SQLIndexBuilder) catchUp() error {
+	if !ib.started {
+		// the tables are created once the SQL database is available
+		if err := ib.indexer.Start(context.Background()); err != nil {
+			return err
+		}
+		ib.started = true
+	}
+	height, err := ib.indexer.Height()
+	if err != nil {
+		return err
+	}
+	tipHeight, err := ib.dao.Height()
+	if err != nil {
+		return err
+	}
+	ancestor, err := ib.commonAncestor(height, tipHeight)
+	if err != nil {
+		return err
+	}
+	if ancestor < height {
+		log.L().Info("Deleting blocks rolled back from the SQL database.",
+			zap.Uint64("height", height),
+			zap.Uint64("commonAncestor", ancestor))
+		if err := ib.indexer.DeleteBlocksAbove(ancestor); err != nil {
+			return err
+		}
+	}
+	for height = ancestor + 1; height <= tipHeight; height++ {
+		select {
+		case <-ib.stopCh:
+			return nil
+		default:
+		}
+		blk, err := ib.dao.GetBlockByHeight(height)
+		if err != nil {
+			return err
+		}
+		if blk.Receipts == nil {
+			if blk.Receipts, err = ib.dao.GetReceipts(height); err != nil {
+				return err
+			}
+		}
+		if err := ib.indexer.PutBlock(context.Background(), blk); err != nil {
+			return err
+		}
+		if height%100 == 0 {
+			log.L().Info("Wrote blocks into the SQL database.", zap.Uint64("height", height))
+		}
+	}
+	return nil
+}
+
+// commonAncestor returns the highest height at which the SQL indexer and the block DAO have the same block
+func (ib *SQLIndexBuilder) commonAncestor(height, tipHeight uint64) (uint64, error) {
+	if height > tipHeight {
+		height = tipHeight
+	}
+	for ; height > 0; height-- {
+		h, err := ib.indexer.GetBlockHash(height)
+		if err != nil {
+			return 0, err
+		}
+		tipHash, err := ib.dao.GetBlockHash(height)
+		if err != nil {
+			return 0, err
+		}
+		if h == tipHash {
+			break
+		}
+	}
+	return height, nil
+}
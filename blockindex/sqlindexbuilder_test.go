@@ -0,0 +1,111 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db/sql"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockdao"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestSQLIndexBuilder(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	buildBlocks := func(n int, producer int) []*block.Block {
+		blks := make([]*block.Block, n)
+		prevHash := hash.ZeroHash256
+		for i := range blks {
+			blk, err := block.NewTestingBuilder().
+				SetHeight(uint64(i + 1)).
+				SetPrevBlockHash(prevHash).
+				SetTimeStamp(testutil.TimestampNow()).
+				SignAndBuild(identityset.PrivateKey(producer))
+			r.NoError(err)
+			blks[i] = &blk
+			prevHash = blk.HashBlock()
+		}
+		return blks
+	}
+	blks := buildBlocks(3, 27)
+	tip := uint64(2)
+	dao := mock_blockdao.NewMockBlockDAO(ctrl)
+	dao.EXPECT().Height().DoAndReturn(func() (uint64, error) { return tip, nil }).AnyTimes()
+	dao.EXPECT().GetBlockByHeight(gomock.Any()).DoAndReturn(func(height uint64) (*block.Block, error) {
+		return blks[height-1], nil
+	}).AnyTimes()
+	dao.EXPECT().GetBlockHash(gomock.Any()).DoAndReturn(func(height uint64) (hash.Hash256, error) {
+		return blks[height-1].HashBlock(), nil
+	}).AnyTimes()
+	dao.EXPECT().GetReceipts(gomock.Any()).Return([]*action.Receipt{}, nil).AnyTimes()
+
+	// the SQL database is not available
+	testPath, err := testutil.PathOfTempFile("sqlindexbuilder.db")
+	r.NoError(err)
+	defer testutil.CleanupPath(t, testPath)
+	indexer, err := NewSQLIndexer(sql.NewSQLite3(config.SQLITE3{SQLite3File: filepath.Join(testPath, "missing", "db")}))
+	r.NoError(err)
+	ib := NewSQLIndexBuilder(indexer)
+	_, err = ib.Height()
+	r.Error(err)
+	r.Error(ib.Start(ctx))
+	ib.SetBlockDAO(dao)
+	r.Error(ib.catchUp())
+	// the block DAO does not wait for the SQL database
+	height, err := ib.Height()
+	r.NoError(err)
+	r.Equal(tip, height)
+	r.NoError(ib.PutBlock(ctx, blks[0]))
+	r.NoError(ib.DeleteTipBlock(blks[0]))
+	r.NoError(indexer.Stop(ctx))
+
+	// the SQL indexer catches up from its own height
+	indexer, err = NewSQLIndexer(sql.NewSQLite3(config.SQLITE3{SQLite3File: testPath}))
+	r.NoError(err)
+	ib = NewSQLIndexBuilder(indexer)
+	ib.SetBlockDAO(dao)
+	r.NoError(ib.catchUp())
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.Equal(tip, height)
+	tip = 3
+	r.NoError(ib.catchUp())
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.Equal(tip, height)
+
+	// the blocks above the common ancestor are rolled back, including when the SQL indexer is above the tip
+	fork := buildBlocks(3, 28)
+	blks = []*block.Block{blks[0], fork[1]}
+	tip = 2
+	r.NoError(ib.catchUp())
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.Equal(tip, height)
+	for i, blk := range blks {
+		h, err := indexer.GetBlockHash(uint64(i + 1))
+		r.NoError(err)
+		r.Equal(blk.HashBlock(), h)
+	}
+
+	r.NoError(ib.Start(ctx))
+	r.NoError(ib.Stop(ctx))
+}
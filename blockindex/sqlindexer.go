@@ -0,0 +1,406 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"math/big"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding/rewardingpb"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/db"
+	sqldb "github.com/iotexproject/iotex-core/db/sql"
+)
+
+// the tables written by the SQL indexer. The rows of a block are keyed by the block height first, so that a tip block
+// is deleted by the primary keys. The ? placeholders of the statements are rebound to the bind variables of the driver
+var sqlIndexerTables = []string{
+	"CREATE TABLE IF NOT EXISTS blocks (" +
+		"height BIGINT NOT NULL, hash VARCHAR(64) NOT NULL, prev_hash VARCHAR(64) NOT NULL, " +
+		"producer VARCHAR(41) NOT NULL, timestamp BIGINT NOT NULL, num_actions INTEGER NOT NULL, " +
+		"PRIMARY KEY (height))",
+	"CREATE TABLE IF NOT EXISTS actions (" +
+		"block_height BIGINT NOT NULL, action_index INTEGER NOT NULL, action_hash VARCHAR(64) NOT NULL UNIQUE, " +
+		"action_type VARCHAR(32) NOT NULL, sender VARCHAR(41) NOT NULL, recipient VARCHAR(41) NOT NULL, " +
+		"nonce BIGINT NOT NULL, gas_limit BIGINT NOT NULL, gas_price VARCHAR(78) NOT NULL, amount VARCHAR(78) NOT NULL, " +
+		"PRIMARY KEY (block_height, action_index))",
+	"CREATE TABLE IF NOT EXISTS receipts (" +
+		"block_height BIGINT NOT NULL, action_index INTEGER NOT NULL, action_hash VARCHAR(64) NOT NULL, " +
+		"status BIGINT NOT NULL, gas_consumed BIGINT NOT NULL, contract_address VARCHAR(41) NOT NULL, " +
+		"PRIMARY KEY (block_height, action_index))",
+	"CREATE TABLE IF NOT EXISTS transfers (" +
+		"block_height BIGINT NOT NULL, action_index INTEGER NOT NULL, action_hash VARCHAR(64) NOT NULL, " +
+		"sender VARCHAR(41) NOT NULL, recipient VARCHAR(41) NOT NULL, amount VARCHAR(78) NOT NULL, " +
+		"PRIMARY KEY (block_height, action_index))",
+	"CREATE TABLE IF NOT EXISTS staking_buckets (" +
+		"block_height BIGINT NOT NULL, action_index INTEGER NOT NULL, action_hash VARCHAR(64) NOT NULL, " +
+		"bucket_index BIGINT NOT NULL, event VARCHAR(32) NOT NULL, candidate VARCHAR(41) NOT NULL, " +
+		"owner VARCHAR(41) NOT NULL, amount VARCHAR(78) NOT NULL, duration BIGINT NOT NULL, auto_stake BOOLEAN NOT NULL, " +
+		"PRIMARY KEY (block_height, action_index))",
+	"CREATE TABLE IF NOT EXISTS reward_grants (" +
+		"block_height BIGINT NOT NULL, action_index INTEGER NOT NULL, log_index INTEGER NOT NULL, " +
+		"action_hash VARCHAR(64) NOT NULL, reward_type VARCHAR(32) NOT NULL, recipient VARCHAR(41) NOT NULL, " +
+		"amount VARCHAR(78) NOT NULL, PRIMARY KEY (block_height, action_index, log_index))",
+}
+
+// the tables with the rows of a block and their height columns, in the order the rows are deleted
+var sqlIndexerBlockTables = [][2]string{
+	{"reward_grants", "block_height"},
+	{"staking_buckets", "block_height"},
+	{"transfers", "block_height"},
+	{"receipts", "block_height"},
+	{"actions", "block_height"},
+	{"blocks", "height"},
+}
+
+type (
+	// SQLIndexer is the indexer writing the blocks into a SQL database
+	SQLIndexer interface {
+		blockdao.BlockIndexer
+		// GetBlockHash returns the hash of the block at the height in the SQL database
+		GetBlockHash(height uint64) (hash.Hash256, error)
+		// DeleteBlocksAbove deletes the rows of the blocks above the height, which have been rolled back
+		DeleteBlocksAbove(height uint64) error
+	}
+
+	// sqlIndexer writes blocks, actions, receipts, transfers, staking bucket changes and reward grants into the
+	// normalised tables of a SQL database
+	sqlIndexer struct {
+		mutex sync.Mutex
+		store sqldb.Store
+	}
+)
+
+// NewSQLIndexer creates a new SQL indexer by given SQL store
+func NewSQLIndexer(store sqldb.Store) (SQLIndexer, error) {
+	if store == nil {
+		return nil, errors.New("empty SQL store")
+	}
+	return &sqlIndexer{store: store}, nil
+}
+
+// Start starts the SQL indexer and creates the tables if they don't exist
+func (x *sqlIndexer) Start(ctx context.Context) error {
+	if err := x.store.Start(ctx); err != nil {
+		return err
+	}
+	return x.transact(func(tx *sqlTx) error {
+		for _, table := range sqlIndexerTables {
+			if _, err := tx.Exec(table); err != nil {
+				return errors.Wrap(err, "failed to create table")
+			}
+		}
+		return nil
+	})
+}
+
+// Stop stops the SQL indexer
+func (x *sqlIndexer) Stop(ctx context.Context) error {
+	return x.store.Stop(ctx)
+}
+
+// Height returns the height of the tip block in the SQL database
+func (x *sqlIndexer) Height() (uint64, error) {
+	var height sql.NullInt64
+	if err := x.store.GetDB().QueryRow("SELECT MAX(height) FROM blocks").Scan(&height); err != nil {
+		return 0, errors.Wrap(db.ErrIO, err.Error())
+	}
+	return uint64(height.Int64), nil
+}
+
+// PutBlock writes the block and its actions and receipts in a transaction
+func (x *sqlIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	receipts := make(map[string]*action.Receipt, len(blk.Receipts))
+	for _, r := range blk.Receipts {
+		receipts[hex.EncodeToString(r.ActionHash[:])] = r
+	}
+	return x.transact(func(tx *sqlTx) error {
+		if err := putBlockRow(tx, blk); err != nil {
+			return err
+		}
+		for i, selp := range blk.Actions {
+			actHash := selp.Hash()
+			r := receipts[hex.EncodeToString(actHash[:])]
+			if err := putActionRows(tx, blk.Height(), i, selp, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetBlockHash returns the hash of the block at the height
+func (x *sqlIndexer) GetBlockHash(height uint64) (hash.Hash256, error) {
+	var h string
+	query := sqldb.Rebind(x.store.DriverName(), "SELECT hash FROM blocks WHERE height = ?")
+	err := x.store.GetDB().QueryRow(query, height).Scan(&h)
+	switch {
+	case err == sql.ErrNoRows:
+		return hash.ZeroHash256, errors.Wrapf(db.ErrNotExist, "block %d doesn't exist", height)
+	case err != nil:
+		return hash.ZeroHash256, errors.Wrap(db.ErrIO, err.Error())
+	}
+	return hash.HexStringToHash256(h)
+}
+
+// DeleteTipBlock deletes the rows of the tip block
+func (x *sqlIndexer) DeleteTipBlock(blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	tip, err := x.Height()
+	if err != nil {
+		return err
+	}
+	height := blk.Height()
+	if height != tip {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, tip)
+	}
+	return x.deleteBlocksAbove(height - 1)
+}
+
+// DeleteBlocksAbove deletes the rows of the blocks above the height
+func (x *sqlIndexer) DeleteBlocksAbove(height uint64) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	return x.deleteBlocksAbove(height)
+}
+
+func (x *sqlIndexer) deleteBlocksAbove(height uint64) error {
+	return x.transact(func(tx *sqlTx) error {
+		for _, table := range sqlIndexerBlockTables {
+			if _, err := tx.Exec("DELETE FROM "+table[0]+" WHERE "+table[1]+" > ?", height); err != nil {
+				return errors.Wrapf(err, "failed to delete blocks above %d from table %s", height, table[0])
+			}
+		}
+		return nil
+	})
+}
+
+// sqlTx is a transaction whose statements are rebound to the bind variables of the driver
+type sqlTx struct {
+	*sql.Tx
+	driver string
+}
+
+// Exec executes the statement after rebinding its placeholders
+func (tx *sqlTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return tx.Tx.Exec(sqldb.Rebind(tx.driver, query), args...)
+}
+
+func (x *sqlIndexer) transact(txFunc func(*sqlTx) error) error {
+	return x.store.Transact(func(tx *sql.Tx) error {
+		return txFunc(&sqlTx{Tx: tx, driver: x.store.DriverName()})
+	})
+}
+
+func putBlockRow(tx *sqlTx, blk *block.Block) error {
+	blkHash := blk.HashBlock()
+	prevHash := blk.PrevHash()
+	producer, err := address.FromBytes(blk.PublicKey().Hash())
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(
+		"INSERT INTO blocks (height, hash, prev_hash, producer, timestamp, num_actions) VALUES (?, ?, ?, ?, ?, ?)",
+		blk.Height(),
+		hex.EncodeToString(blkHash[:]),
+		hex.EncodeToString(prevHash[:]),
+		producer.String(),
+		blk.Timestamp().Unix(),
+		len(blk.Actions),
+	); err != nil {
+		return errors.Wrapf(err, "failed to insert block %d", blk.Height())
+	}
+	return nil
+}
+
+func putActionRows(tx *sqlTx, height uint64, index int, selp action.SealedEnvelope, r *action.Receipt) error {
+	actHash := selp.Hash()
+	hashStr := hex.EncodeToString(actHash[:])
+	sender, err := address.FromBytes(selp.SrcPubkey().Hash())
+	if err != nil {
+		return err
+	}
+	var (
+		actType   string
+		recipient string
+		amount    *big.Int
+	)
+	switch act := selp.Action().(type) {
+	case *action.Transfer:
+		actType, recipient, amount = "transfer", act.Recipient(), act.Amount()
+	case *action.Execution:
+		actType, recipient, amount = "execution", act.Contract(), act.Amount()
+	case *action.GrantReward:
+		actType = "grantReward"
+	case *action.ClaimFromRewardingFund:
+		actType, amount = "claimFromRewardingFund", act.Amount()
+	case *action.DepositToRewardingFund:
+		actType, amount = "depositToRewardingFund", act.Amount()
+	case *action.PutPollResult:
+		actType = "putPollResult"
+	case *action.SubmitEvidence:
+		actType = "submitEvidence"
+	case *action.CreateStake:
+		actType, recipient, amount = staking.HandleCreateStake, act.Candidate(), act.Amount()
+	case *action.Unstake:
+		actType = staking.HandleUnstake
+	case *action.WithdrawStake:
+		actType = staking.HandleWithdrawStake
+	case *action.ChangeCandidate:
+		actType, recipient = staking.HandleChangeCandidate, act.Candidate()
+	case *action.TransferStake:
+		actType, recipient = staking.HandleTransferStake, act.VoterAddress().String()
+	case *action.DepositToStake:
+		actType, amount = staking.HandleDepositToStake, act.Amount()
+	case *action.Restake:
+		actType = staking.HandleRestake
+	case *action.CandidateRegister:
+		actType, recipient, amount = staking.HandleCandidateRegister, act.Name(), act.Amount()
+	case *action.CandidateUpdate:
+		actType, recipient = staking.HandleCandidateUpdate, act.Name()
+	default:
+		actType = "unknown"
+	}
+	if _, err := tx.Exec(
+		"INSERT INTO actions (block_height, action_index, action_hash, action_type, sender, recipient, nonce, "+
+			"gas_limit, gas_price, amount) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		height, index, hashStr, actType, sender.String(), recipient,
+		selp.Nonce(), selp.GasLimit(), bigIntString(selp.GasPrice()), bigIntString(amount),
+	); err != nil {
+		return errors.Wrapf(err, "failed to insert action %s", hashStr)
+	}
+	if r == nil {
+		return nil
+	}
+	if _, err := tx.Exec(
+		"INSERT INTO receipts (block_height, action_index, action_hash, status, gas_consumed, contract_address) "+
+			"VALUES (?, ?, ?, ?, ?, ?)",
+		height, index, hashStr, r.Status, r.GasConsumed, r.ContractAddress,
+	); err != nil {
+		return errors.Wrapf(err, "failed to insert receipt of action %s", hashStr)
+	}
+	if r.Status != uint64(iotextypes.ReceiptStatus_Success) {
+		return nil
+	}
+
+	switch act := selp.Action().(type) {
+	case *action.Transfer, *action.Execution:
+		if amount == nil || amount.Sign() == 0 {
+			return nil
+		}
+		if _, err := tx.Exec(
+			"INSERT INTO transfers (block_height, action_index, action_hash, sender, recipient, amount) "+
+				"VALUES (?, ?, ?, ?, ?, ?)",
+			height, index, hashStr, sender.String(), recipient, amount.String(),
+		); err != nil {
+			return errors.Wrapf(err, "failed to insert transfer of action %s", hashStr)
+		}
+	case *action.GrantReward:
+		return putRewardRows(tx, height, index, hashStr, r)
+	case *action.CreateStake, *action.Unstake, *action.WithdrawStake, *action.ChangeCandidate, *action.TransferStake,
+		*action.DepositToStake, *action.Restake, *action.CandidateRegister:
+		bucketIndex, ok := bucketIndexFromReceipt(r)
+		if !ok {
+			return nil
+		}
+		var (
+			duration  uint32
+			autoStake bool
+			candidate = recipient
+			owner     = sender.String()
+		)
+		switch act := act.(type) {
+		case *action.CreateStake:
+			duration, autoStake = act.Duration(), act.AutoStake()
+		case *action.Restake:
+			duration, autoStake = act.Duration(), act.AutoStake()
+		case *action.CandidateRegister:
+			duration, autoStake = act.Duration(), act.AutoStake()
+			if act.OwnerAddress() != nil {
+				owner = act.OwnerAddress().String()
+			}
+		case *action.TransferStake:
+			candidate, owner = "", recipient
+		}
+		if _, err := tx.Exec(
+			"INSERT INTO staking_buckets (block_height, action_index, action_hash, bucket_index, event, candidate, "+
+				"owner, amount, duration, auto_stake) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			height, index, hashStr, bucketIndex, actType, candidate, owner, bigIntString(amount), duration, autoStake,
+		); err != nil {
+			return errors.Wrapf(err, "failed to insert staking bucket change of action %s", hashStr)
+		}
+	}
+	return nil
+}
+
+func putRewardRows(tx *sqlTx, height uint64, index int, hashStr string, r *action.Receipt) error {
+	rewardingAddr, err := address.FromBytes(address.RewardingProtocolAddrHash[:])
+	if err != nil {
+		return err
+	}
+	for i, l := range r.Logs() {
+		if l.Address != rewardingAddr.String() {
+			continue
+		}
+		var rewardLog rewardingpb.RewardLog
+		if err := proto.Unmarshal(l.Data, &rewardLog); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal reward log of action %s", hashStr)
+		}
+		var rewardType string
+		switch rewardLog.Type {
+		case rewardingpb.RewardLog_BLOCK_REWARD:
+			rewardType = "blockReward"
+		case rewardingpb.RewardLog_EPOCH_REWARD:
+			rewardType = "epochReward"
+		case rewardingpb.RewardLog_FOUNDATION_BONUS:
+			rewardType = "foundationBonus"
+		}
+		if _, err := tx.Exec(
+			"INSERT INTO reward_grants (block_height, action_index, log_index, action_hash, reward_type, recipient, "+
+				"amount) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			height, index, i, hashStr, rewardType, rewardLog.Addr, rewardLog.Amount,
+		); err != nil {
+			return errors.Wrapf(err, "failed to insert reward grant of action %s", hashStr)
+		}
+	}
+	return nil
+}
+
+// bucketIndexFromReceipt returns the index of the bucket changed by the staking action of the receipt
+func bucketIndexFromReceipt(r *action.Receipt) (uint64, bool) {
+	for _, l := range r.Logs() {
+		if index, ok := staking.BucketIndexFromReceiptLog(l.ConvertToLogPb()); ok {
+			return index, true
+		}
+	}
+	return 0, false
+}
+
+func bigIntString(v *big.Int) string {
+	if v == nil {
+		return "0"
+	}
+	return v.String()
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding/rewardingpb"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/sql"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestSQLIndexer(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	success := uint64(iotextypes.ReceiptStatus_Success)
+	tsf, err := testutil.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), 1, big.NewInt(10), nil, testutil.TestGasLimit, big.NewInt(1))
+	r.NoError(err)
+	failed, err := testutil.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), 2, big.NewInt(20), nil, testutil.TestGasLimit, big.NewInt(1))
+	r.NoError(err)
+	cs, err := testutil.SignedCreateStake(3, "cand", "100", 7, true, nil, testutil.TestGasLimit, big.NewInt(1), identityset.PrivateKey(27))
+	r.NoError(err)
+	gb := action.GrantRewardBuilder{}
+	grant := gb.SetRewardType(action.BlockReward).SetHeight(1).Build()
+	eb := action.EnvelopeBuilder{}
+	grantSelp, err := action.Sign(eb.SetNonce(0).SetGasPrice(big.NewInt(0)).SetAction(&grant).Build(), identityset.PrivateKey(27))
+	r.NoError(err)
+
+	stakingAddr, err := address.FromBytes(address.StakingProtocolAddrHash[:])
+	r.NoError(err)
+	rewardingAddr, err := address.FromBytes(address.RewardingProtocolAddrHash[:])
+	r.NoError(err)
+	rewardLog, err := proto.Marshal(&rewardingpb.RewardLog{
+		Type:   rewardingpb.RewardLog_BLOCK_REWARD,
+		Addr:   identityset.Address(27).String(),
+		Amount: "16",
+	})
+	r.NoError(err)
+	receipts := []*action.Receipt{
+		{Status: success, ActionHash: tsf.Hash(), GasConsumed: 10000},
+		{Status: uint64(iotextypes.ReceiptStatus_Failure), ActionHash: failed.Hash(), GasConsumed: 10000},
+		(&action.Receipt{Status: success, ActionHash: cs.Hash(), GasConsumed: 10000}).AddLogs(&action.Log{
+			Address: stakingAddr.String(),
+			Topics: action.Topics{
+				hash.BytesToHash256([]byte(staking.HandleCreateStake)),
+				hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(5)),
+			},
+		}),
+		(&action.Receipt{Status: success, ActionHash: grantSelp.Hash()}).AddLogs(&action.Log{
+			Address: rewardingAddr.String(),
+			Data:    rewardLog,
+		}),
+	}
+	blk1, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(tsf, failed, cs, grantSelp).
+		SetReceipts(receipts).
+		SignAndBuild(identityset.PrivateKey(27))
+	r.NoError(err)
+	blk2, err := block.NewTestingBuilder().
+		SetHeight(2).
+		SetPrevBlockHash(blk1.HashBlock()).
+		SetTimeStamp(testutil.TimestampNow()).
+		SignAndBuild(identityset.PrivateKey(27))
+	r.NoError(err)
+
+	testPath, err := testutil.PathOfTempFile("sqlindexer.db")
+	r.NoError(err)
+	defer testutil.CleanupPath(t, testPath)
+	store := sql.NewSQLite3(config.SQLITE3{SQLite3File: testPath})
+	indexer, err := NewSQLIndexer(store)
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	defer func() {
+		r.NoError(indexer.Stop(ctx))
+	}()
+
+	height, err := indexer.Height()
+	r.NoError(err)
+	r.Zero(height)
+	r.NoError(indexer.PutBlock(ctx, &blk1))
+	r.NoError(indexer.PutBlock(ctx, &blk2))
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.EqualValues(2, height)
+
+	count := func(table string) int {
+		var n int
+		r.NoError(store.GetDB().QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n))
+		return n
+	}
+	for table, n := range map[string]int{
+		"blocks":          2,
+		"actions":         4,
+		"receipts":        4,
+		"transfers":       1,
+		"staking_buckets": 1,
+		"reward_grants":   1,
+	} {
+		r.Equal(n, count(table), table)
+	}
+
+	var (
+		sender, recipient, amount string
+		bucketIndex               uint64
+		candidate                 string
+		autoStake                 bool
+	)
+	tsfHash := tsf.Hash()
+	r.NoError(store.GetDB().QueryRow("SELECT sender, recipient, amount FROM transfers WHERE action_hash = ?",
+		hex.EncodeToString(tsfHash[:])).Scan(&sender, &recipient, &amount))
+	r.Equal(identityset.Address(27).String(), sender)
+	r.Equal(identityset.Address(28).String(), recipient)
+	r.Equal("10", amount)
+	r.NoError(store.GetDB().QueryRow("SELECT bucket_index, candidate, amount, auto_stake FROM staking_buckets").
+		Scan(&bucketIndex, &candidate, &amount, &autoStake))
+	r.EqualValues(5, bucketIndex)
+	r.Equal("cand", candidate)
+	r.Equal("100", amount)
+	r.True(autoStake)
+	r.NoError(store.GetDB().QueryRow("SELECT recipient, amount FROM reward_grants WHERE reward_type = 'blockReward'").
+		Scan(&recipient, &amount))
+	r.Equal(identityset.Address(27).String(), recipient)
+	r.Equal("16", amount)
+
+	// only the tip block can be deleted
+	r.Equal(db.ErrInvalid, errors.Cause(indexer.DeleteTipBlock(&blk1)))
+	r.NoError(indexer.DeleteTipBlock(&blk2))
+	r.NoError(indexer.DeleteTipBlock(&blk1))
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.Zero(height)
+	for _, table := range sqlIndexerBlockTables {
+		r.Zero(count(table[0]), table[0])
+	}
+	r.NoError(indexer.PutBlock(ctx, &blk1))
+	r.Equal(4, count("actions"))
+	h, err := indexer.GetBlockHash(1)
+	r.NoError(err)
+	r.Equal(blk1.HashBlock(), h)
+	_, err = indexer.GetBlockHash(2)
+	r.Equal(db.ErrNotExist, errors.Cause(err))
+
+	// the rolled back blocks are deleted
+	r.NoError(indexer.PutBlock(ctx, &blk2))
+	r.NoError(indexer.DeleteBlocksAbove(0))
+	for _, table := range sqlIndexerBlockTables {
+		r.Zero(count(table[0]), table[0])
+	}
+}
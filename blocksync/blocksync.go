@@ -12,17 +12,26 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blocksync/blocksyncpb"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
 	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
 )
 
+// maxHeadersPerResponse is the maximal number of headers served in a response
+const maxHeadersPerResponse = 1000
+
+// ErrBlockMismatchHeader indicates the block does not match the tx root in its header
+var ErrBlockMismatchHeader = errors.New("block does not match header")
+
 type (
 	// UnicastOutbound sends a unicast message to the given address
 	UnicastOutbound func(ctx context.Context, peer peerstore.PeerInfo, msg proto.Message) error
@@ -39,6 +48,7 @@ type BlockDAO interface {
 type Config struct {
 	unicastHandler   UnicastOutbound
 	neighborsHandler Neighbors
+	snapshotStore    SnapshotStore
 }
 
 // Option is the option to override the blocksync config
@@ -66,8 +76,13 @@ type BlockSync interface {
 
 	TargetHeight() uint64
 	ProcessSyncRequest(ctx context.Context, peer peerstore.PeerInfo, sync *iotexrpc.BlockSync) error
+	ProcessHeadersRequest(ctx context.Context, peer peerstore.PeerInfo, req *blocksyncpb.HeadersRequest) error
+	ProcessHeaders(ctx context.Context, peer peerstore.PeerInfo, resp *blocksyncpb.HeadersResponse) error
 	ProcessBlock(ctx context.Context, blk *block.Block) error
 	ProcessBlockSync(ctx context.Context, blk *block.Block) error
+	ProcessSnapshotRequest(ctx context.Context, peer peerstore.PeerInfo, req *snapshotpb.Request) error
+	ProcessSnapshotResponse(ctx context.Context, peer peerstore.PeerInfo, msg proto.Message) error
+	SyncSnapshot(ctx context.Context) (*block.Block, error)
 }
 
 // blockSyncer implements BlockSync interface
@@ -80,6 +95,11 @@ type blockSyncer struct {
 	dao                   BlockDAO
 	unicastHandler        UnicastOutbound
 	neighborsHandler      Neighbors
+	snapshotStore         SnapshotStore
+	snapshotResps         chan *snapshotResponse
+	snapshotCheckpoint    *snapshotCheckpoint
+	snapshotInterval      time.Duration
+	maxRepeat             int
 }
 
 // NewBlockSyncer returns a new block syncer instance
@@ -103,6 +123,10 @@ func NewBlockSyncer(
 			return nil, err
 		}
 	}
+	checkpoint, err := newSnapshotCheckpoint(cfg.BlockSync.SnapshotCheckpoint)
+	if err != nil {
+		return nil, err
+	}
 	bs := &blockSyncer{
 		bc:                    chain,
 		dao:                   dao,
@@ -111,6 +135,11 @@ func NewBlockSyncer(
 		neighborsHandler:      bsCfg.neighborsHandler,
 		worker:                newSyncWorker(chain.ChainID(), cfg, bsCfg.unicastHandler, bsCfg.neighborsHandler, buf),
 		processSyncRequestTTL: cfg.BlockSync.ProcessSyncRequestTTL,
+		snapshotStore:         bsCfg.snapshotStore,
+		snapshotResps:         make(chan *snapshotResponse, 64),
+		snapshotCheckpoint:    checkpoint,
+		snapshotInterval:      cfg.BlockSync.Interval,
+		maxRepeat:             cfg.BlockSync.MaxRepeat,
 	}
 	return bs, nil
 }
@@ -136,7 +165,10 @@ func (bs *blockSyncer) Stop(ctx context.Context) error {
 }
 
 // ProcessBlock processes an incoming latest committed block
-func (bs *blockSyncer) ProcessBlock(_ context.Context, blk *block.Block) error {
+func (bs *blockSyncer) ProcessBlock(ctx context.Context, blk *block.Block) error {
+	if err := bs.checkBlock(ctx, blk); err != nil {
+		return err
+	}
 	var needSync bool
 	moved, re := bs.buf.Flush(blk)
 	switch re {
@@ -158,7 +190,10 @@ func (bs *blockSyncer) ProcessBlock(_ context.Context, blk *block.Block) error {
 	return nil
 }
 
-func (bs *blockSyncer) ProcessBlockSync(_ context.Context, blk *block.Block) error {
+func (bs *blockSyncer) ProcessBlockSync(ctx context.Context, blk *block.Block) error {
+	if err := bs.checkBlock(ctx, blk); err != nil {
+		return err
+	}
 	bs.buf.Flush(blk)
 	if bs.bc.TipHeight() == bs.TargetHeight() {
 		bs.worker.SetTargetHeight(bs.TargetHeight() + bs.buf.bufSize())
@@ -195,3 +230,51 @@ func (bs *blockSyncer) ProcessSyncRequest(ctx context.Context, peer peerstore.Pe
 	}
 	return nil
 }
+
+// ProcessHeadersRequest processes a block headers request
+func (bs *blockSyncer) ProcessHeadersRequest(ctx context.Context, peer peerstore.PeerInfo, req *blocksyncpb.HeadersRequest) error {
+	end := bs.bc.TipHeight()
+	if req.End < end {
+		end = req.End
+	}
+	if req.Start+maxHeadersPerResponse-1 < end {
+		end = req.Start + maxHeadersPerResponse - 1
+	}
+	resp := &blocksyncpb.HeadersResponse{}
+	for i := req.Start; i <= end; i++ {
+		header, err := bs.bc.BlockHeaderByHeight(i)
+		if err != nil {
+			return err
+		}
+		resp.Headers = append(resp.Headers, header.BlockHeaderProto())
+	}
+	syncCtx, cancel := context.WithTimeout(ctx, bs.processSyncRequestTTL)
+	defer cancel()
+	return bs.unicastHandler(syncCtx, peer, resp)
+}
+
+// ProcessHeaders processes the block headers served by a peer
+func (bs *blockSyncer) ProcessHeaders(_ context.Context, peer peerstore.PeerInfo, resp *blocksyncpb.HeadersResponse) error {
+	headers := make([]*block.Header, 0, len(resp.GetHeaders()))
+	for _, pb := range resp.GetHeaders() {
+		header := &block.Header{}
+		if err := header.LoadFromBlockHeaderProto(pb); err != nil {
+			bs.worker.scorer.OnInvalid(peer.ID.Pretty())
+			return err
+		}
+		headers = append(headers, header)
+	}
+	return bs.worker.onHeaders(peer.ID.Pretty(), headers)
+}
+
+// checkBlock checks the block against the tx root in its header
+func (bs *blockSyncer) checkBlock(ctx context.Context, blk *block.Block) error {
+	if blk == nil {
+		return nil
+	}
+	peer, _ := GetPeer(ctx)
+	if !bs.worker.onBlock(peer, blk) {
+		return errors.Wrapf(ErrBlockMismatchHeader, "height %d, peer %s", blk.Height(), peer)
+	}
+	return nil
+}
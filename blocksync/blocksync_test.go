@@ -30,6 +30,7 @@ import (
 	bc "github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/blocksync/blocksyncpb"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-core/test/identityset"
@@ -137,6 +138,40 @@ func TestBlockSyncerProcessSyncRequest(t *testing.T) {
 	assert.NoError(bs.ProcessSyncRequest(context.Background(), peerstore.PeerInfo{}, pbBs))
 }
 
+func TestBlockSyncerProcessHeadersRequest(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blks := newTestChain(t, hash.ZeroHash256, 3, 27)
+	mBc := mock_blockchain.NewMockBlockchain(ctrl)
+	mBc.EXPECT().ChainID().AnyTimes().Return(config.Default.Chain.ID)
+	mBc.EXPECT().TipHeight().AnyTimes().Return(uint64(3))
+	mBc.EXPECT().BlockHeaderByHeight(gomock.Any()).AnyTimes().DoAndReturn(func(h uint64) (*block.Header, error) {
+		return &blks[h-1].Header, nil
+	})
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	var resp *blocksyncpb.HeadersResponse
+	bs, err := NewBlockSyncer(cfg, mBc, mock_blockdao.NewMockBlockDAO(ctrl), mock_consensus.NewMockConsensus(ctrl),
+		WithUnicastOutBound(func(_ context.Context, _ peerstore.PeerInfo, msg proto.Message) error {
+			resp = msg.(*blocksyncpb.HeadersResponse)
+			return nil
+		}),
+	)
+	require.NoError(err)
+
+	// the headers are served up to the tip
+	require.NoError(bs.ProcessHeadersRequest(context.Background(), peerstore.PeerInfo{}, &blocksyncpb.HeadersRequest{
+		Start: 2,
+		End:   10,
+	}))
+	require.Len(resp.Headers, 2)
+	for i, pb := range resp.Headers {
+		require.True(proto.Equal(blks[i+1].Header.BlockHeaderProto(), pb))
+	}
+}
+
 func TestBlockSyncerProcessSyncRequestError(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
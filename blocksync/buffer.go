@@ -152,6 +152,14 @@ func (b *blockBuffer) GetBlocksIntervalsToSync(targetHeight uint64) []syncBlocks
 	return bi
 }
 
+// blockExists returns true if the block at height is in the buffer
+func (b *blockBuffer) blockExists(height uint64) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, ok := b.blocks[height]
+	return ok
+}
+
 // bufSize return the bufferSize of buffer
 func (b *blockBuffer) bufSize() uint64 {
 	return b.bufferSize
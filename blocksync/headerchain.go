@@ -0,0 +1,129 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+)
+
+// ErrInvalidHeaders indicates the headers do not link to the chain, or are not signed by the delegates
+var ErrInvalidHeaders = errors.New("invalid block headers")
+
+// headerChain keeps the validated headers above the chain tip. Every header links to the previous one by hash, and the
+// lowest one links to the chain tip, so that the blocks are requested by header from any peer. The headers are not
+// confirmed until the blocks are committed, so a block conflicting with its header replaces the header
+type headerChain struct {
+	mu               sync.RWMutex
+	headers          map[uint64]*block.Header
+	tip              uint64       // height of the highest header
+	tipHash          hash.Hash256 // hash of the highest header
+	validateProducer func(*block.Header) error
+}
+
+func newHeaderChain(validateProducer func(*block.Header) error) *headerChain {
+	return &headerChain{
+		headers:          make(map[uint64]*block.Header),
+		validateProducer: validateProducer,
+	}
+}
+
+// Tip returns the height of the highest header
+func (c *headerChain) Tip() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tip
+}
+
+// Hash returns the hash of the header at height
+func (c *headerChain) Hash(height uint64) (hash.Hash256, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	h, ok := c.headers[height]
+	if !ok {
+		return hash.ZeroHash256, false
+	}
+	return h.HashBlock(), true
+}
+
+// Sync drops the headers which have been committed to the chain. All the headers are dropped if they do not link to
+// the chain tip
+func (c *headerChain) Sync(tipHeight uint64, tipHash hash.Hash256) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for h := range c.headers {
+		if h <= tipHeight {
+			delete(c.headers, h)
+		}
+	}
+	if next, ok := c.headers[tipHeight+1]; ok && next.PrevHash() == tipHash {
+		return
+	}
+	c.headers = make(map[uint64]*block.Header)
+	c.tip = tipHeight
+	c.tipHash = tipHash
+}
+
+// Drop drops the header at height and the ones above it, and falls back to the chain tip if no header remains
+func (c *headerChain) Drop(height uint64, tipHeight uint64, tipHash hash.Hash256) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if height > c.tip {
+		return
+	}
+	for h := range c.headers {
+		if h >= height {
+			delete(c.headers, h)
+		}
+	}
+	if prev, ok := c.headers[height-1]; ok {
+		c.tip = height - 1
+		c.tipHash = prev.HashBlock()
+		return
+	}
+	c.headers = make(map[uint64]*block.Header)
+	c.tip = tipHeight
+	c.tipHash = tipHash
+}
+
+// Add appends the consecutive headers to the chain, and returns the number of headers added. The headers at or below
+// the tip are skipped, and so are the headers beyond the heights of which the delegates are known
+func (c *headerChain) Add(headers []*block.Header) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	added := 0
+	for _, h := range headers {
+		if h.Height() <= c.tip {
+			continue
+		}
+		if h.Height() != c.tip+1 {
+			return added, errors.Wrapf(ErrInvalidHeaders, "expecting height %d, got %d", c.tip+1, h.Height())
+		}
+		if h.PrevHash() != c.tipHash {
+			return added, errors.Wrapf(ErrInvalidHeaders, "header at height %d does not link to the previous one", h.Height())
+		}
+		if !h.VerifySignature() {
+			return added, errors.Wrapf(ErrInvalidHeaders, "failed to verify signature of header at height %d", h.Height())
+		}
+		if err := c.validateProducer(h); err != nil {
+			if errors.Cause(err) == scheme.ErrUnknownDelegates {
+				return added, nil
+			}
+			return added, errors.Wrapf(ErrInvalidHeaders, "header at height %d: %v", h.Height(), err)
+		}
+		c.headers[h.Height()] = h
+		c.tip = h.Height()
+		c.tipHash = h.HashBlock()
+		added++
+	}
+	return added, nil
+}
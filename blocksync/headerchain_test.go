@@ -0,0 +1,137 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// newTestChain returns the blocks from height 1 to n linked to prevHash
+func newTestChain(t *testing.T, prevHash hash.Hash256, n uint64, producer int) []*block.Block {
+	var blks []*block.Block
+	for i := uint64(1); i <= n; i++ {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(i).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			SignAndBuild(identityset.PrivateKey(producer))
+		require.NoError(t, err)
+		blks = append(blks, &blk)
+		prevHash = blk.HashBlock()
+	}
+	return blks
+}
+
+func testHeaders(blks []*block.Block) []*block.Header {
+	var headers []*block.Header
+	for _, blk := range blks {
+		h := blk.Header
+		headers = append(headers, &h)
+	}
+	return headers
+}
+
+func TestHeaderChain(t *testing.T) {
+	require := require.New(t)
+
+	genesis := hash.Hash256b([]byte("genesis"))
+	blks := newTestChain(t, genesis, 6, 27)
+	headers := testHeaders(blks)
+	c := newHeaderChain(func(h *block.Header) error {
+		if h.ProducerAddress() != identityset.Address(27).String() {
+			return errors.New("not a delegate")
+		}
+		return nil
+	})
+	c.Sync(0, genesis)
+	require.Zero(c.Tip())
+
+	// headers have to link to the tip
+	_, err := c.Add(headers[1:3])
+	require.Equal(ErrInvalidHeaders, errors.Cause(err))
+	_, err = c.Add(testHeaders(newTestChain(t, hash.ZeroHash256, 2, 27)))
+	require.Equal(ErrInvalidHeaders, errors.Cause(err))
+	n, err := c.Add(headers[:3])
+	require.NoError(err)
+	require.Equal(3, n)
+	require.EqualValues(3, c.Tip())
+	// the overlapped headers are skipped
+	n, err = c.Add(headers[1:4])
+	require.NoError(err)
+	require.Equal(1, n)
+	for i, blk := range blks[:4] {
+		h, ok := c.Hash(uint64(i + 1))
+		require.True(ok)
+		require.Equal(blk.HashBlock(), h)
+	}
+	_, ok := c.Hash(5)
+	require.False(ok)
+
+	// headers from a different producer are not linked
+	forged := testHeaders(newTestChain(t, genesis, 6, 28))
+	_, err = c.Add(forged[4:])
+	require.Equal(ErrInvalidHeaders, errors.Cause(err))
+
+	// headers produced by a non-delegate are rejected
+	c2 := newHeaderChain(c.validateProducer)
+	c2.Sync(0, genesis)
+	_, err = c2.Add(forged)
+	require.Equal(ErrInvalidHeaders, errors.Cause(err))
+	require.Zero(c2.Tip())
+	// headers beyond the known delegates are not added yet
+	c2 = newHeaderChain(func(h *block.Header) error {
+		if h.Height() > 2 {
+			return scheme.ErrUnknownDelegates
+		}
+		return nil
+	})
+	c2.Sync(0, genesis)
+	n, err = c2.Add(headers)
+	require.NoError(err)
+	require.Equal(2, n)
+	require.EqualValues(2, c2.Tip())
+
+	// the committed headers are dropped
+	c.Sync(2, blks[1].HashBlock())
+	_, ok = c.Hash(2)
+	require.False(ok)
+	require.EqualValues(4, c.Tip())
+	// all the headers are dropped if they do not link to the chain tip
+	c.Sync(2, hash.ZeroHash256)
+	_, ok = c.Hash(3)
+	require.False(ok)
+	require.EqualValues(2, c.Tip())
+
+	// the headers conflicting with a block are dropped
+	c.Sync(0, genesis)
+	_, err = c.Add(headers)
+	require.NoError(err)
+	c.Drop(7, 0, genesis)
+	require.EqualValues(6, c.Tip())
+	c.Drop(4, 0, genesis)
+	require.EqualValues(3, c.Tip())
+	_, ok = c.Hash(4)
+	require.False(ok)
+	n, err = c.Add(headers[3:])
+	require.NoError(err)
+	require.Equal(3, n)
+	// fall back to the chain tip if no header remains
+	c.Drop(1, 0, genesis)
+	require.Zero(c.Tip())
+	n, err = c.Add(headers)
+	require.NoError(err)
+	require.Equal(6, n)
+}
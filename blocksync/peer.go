@@ -0,0 +1,146 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+type peerContextKey struct{}
+
+// WithPeer adds the id of the peer which sends the block into context
+func WithPeer(ctx context.Context, peer string) context.Context {
+	return context.WithValue(ctx, peerContextKey{}, peer)
+}
+
+// GetPeer gets the id of the peer which sends the block from context
+func GetPeer(ctx context.Context) (string, bool) {
+	peer, ok := ctx.Value(peerContextKey{}).(string)
+	return peer, ok
+}
+
+type (
+	// peerStat is the record of how a peer serves the sync requests
+	peerStat struct {
+		latency      time.Duration // moving average of the response latency
+		failures     int           // consecutive timed out requests
+		invalids     int           // invalid headers or blocks served
+		demotedUntil time.Time
+	}
+
+	// peerScorer scores the peers by their latency and failures, and demotes the peers which keep timing out or serve
+	// invalid data, so that they are not asked for blocks for a while
+	peerScorer struct {
+		mu          sync.Mutex
+		stats       map[string]*peerStat
+		maxFailures int
+		demotion    time.Duration
+	}
+)
+
+func newPeerScorer(maxFailures int, demotion time.Duration) *peerScorer {
+	if maxFailures <= 0 {
+		maxFailures = 1
+	}
+	return &peerScorer{
+		stats:       make(map[string]*peerStat),
+		maxFailures: maxFailures,
+		demotion:    demotion,
+	}
+}
+
+// OnResponse records a response of the peer in time
+func (s *peerScorer) OnResponse(peer string, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stat := s.stat(peer)
+	if stat.latency == 0 {
+		stat.latency = latency
+	} else {
+		stat.latency = (stat.latency*3 + latency) / 4
+	}
+	stat.failures = 0
+}
+
+// OnTimeout records a timed out request to the peer
+func (s *peerScorer) OnTimeout(peer string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stat := s.stat(peer)
+	if stat.failures++; stat.failures >= s.maxFailures {
+		s.demote(peer, stat)
+	}
+}
+
+// OnInvalid records an invalid header or block served by the peer, which demotes the peer right away
+func (s *peerScorer) OnInvalid(peer string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stat := s.stat(peer)
+	stat.invalids++
+	s.demote(peer, stat)
+}
+
+// Demoted returns true if the peer is demoted
+func (s *peerScorer) Demoted(peer string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stat, ok := s.stats[peer]
+	return ok && time.Now().Before(stat.demotedUntil)
+}
+
+// Rank returns the peers which are not demoted, the best first. All the peers are returned if all of them are demoted
+func (s *peerScorer) Rank(peers []peerstore.PeerInfo) []peerstore.PeerInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	ranked := make([]peerstore.PeerInfo, 0, len(peers))
+	for _, p := range peers {
+		if stat, ok := s.stats[p.ID.Pretty()]; !ok || !now.Before(stat.demotedUntil) {
+			ranked = append(ranked, p)
+		}
+	}
+	if len(ranked) == 0 {
+		ranked = append(ranked, peers...)
+	}
+	// the peers not asked yet come first, so that every peer gets scored
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return s.score(ranked[i].ID.Pretty()) < s.score(ranked[j].ID.Pretty())
+	})
+	return ranked
+}
+
+func (s *peerScorer) score(peer string) time.Duration {
+	stat, ok := s.stats[peer]
+	if !ok {
+		return 0
+	}
+	return stat.latency * time.Duration(1+stat.failures+stat.invalids)
+}
+
+func (s *peerScorer) stat(peer string) *peerStat {
+	stat, ok := s.stats[peer]
+	if !ok {
+		stat = &peerStat{}
+		s.stats[peer] = stat
+	}
+	return stat
+}
+
+func (s *peerScorer) demote(peer string, stat *peerStat) {
+	stat.demotedUntil = time.Now().Add(s.demotion)
+	stat.failures = 0
+	log.L().Info("Demote peer.", zap.String("peer", peer), zap.Int("invalids", stat.invalids))
+}
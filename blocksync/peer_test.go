@@ -0,0 +1,59 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerScorer(t *testing.T) {
+	require := require.New(t)
+
+	peers := []peerstore.PeerInfo{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	a, b, c := peers[0].ID.Pretty(), peers[1].ID.Pretty(), peers[2].ID.Pretty()
+	s := newPeerScorer(2, time.Hour)
+	// the peers not asked yet come first
+	s.OnResponse(a, 3*time.Second)
+	s.OnResponse(b, time.Second)
+	require.Equal([]peerstore.PeerInfo{peers[2], peers[1], peers[0]}, s.Rank(peers))
+	s.OnResponse(c, 2*time.Second)
+	require.Equal([]peerstore.PeerInfo{peers[1], peers[2], peers[0]}, s.Rank(peers))
+
+	// a timed out request is penalized, and the peer is demoted after consecutive timeouts
+	s.OnTimeout(b)
+	require.False(s.Demoted(b))
+	require.Equal([]peerstore.PeerInfo{peers[1], peers[2], peers[0]}, s.Rank(peers))
+	s.OnTimeout(b)
+	require.True(s.Demoted(b))
+	require.Equal([]peerstore.PeerInfo{peers[2], peers[0]}, s.Rank(peers))
+
+	// a peer serving invalid data is demoted right away
+	s.OnInvalid(c)
+	require.True(s.Demoted(c))
+	require.Equal([]peerstore.PeerInfo{peers[0]}, s.Rank(peers))
+
+	// all the peers are returned if all of them are demoted
+	s.OnInvalid(a)
+	require.Len(s.Rank(peers), 3)
+
+	// a demoted peer is asked again after the demotion
+	s = newPeerScorer(1, 0)
+	s.OnTimeout(a)
+	require.False(s.Demoted(a))
+	require.Len(s.Rank(peers), 3)
+
+	peer, ok := GetPeer(WithPeer(context.Background(), a))
+	require.True(ok)
+	require.Equal(a, peer)
+	_, ok = GetPeer(context.Background())
+	require.False(ok)
+}
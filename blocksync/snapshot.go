@@ -0,0 +1,325 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/hash"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
+)
+
+// snapshotManifestRounds is the number of rounds to ask the neighbors for the manifest of the state snapshot
+const snapshotManifestRounds = 10
+
+var (
+	// ErrSnapshotNotSupported indicates the state snapshot is not served
+	ErrSnapshotNotSupported = errors.New("state snapshot is not supported")
+	// ErrSnapshotNotAvailable indicates no neighbor serves the state snapshot at the trusted checkpoint
+	ErrSnapshotNotAvailable = errors.New("trusted state snapshot is not available")
+)
+
+type (
+	// SnapshotStore serves and imports the state snapshot
+	SnapshotStore interface {
+		SnapshotManifest() (*snapshotpb.Manifest, error)
+		SnapshotChunk(uint64, uint32) ([]byte, error)
+		ImportSnapshot(context.Context, *snapshotpb.Manifest, func(uint32) ([]byte, error)) error
+		VerifySnapshot(context.Context, *snapshotpb.Manifest) error
+	}
+
+	snapshotResponse struct {
+		peer peerstore.PeerInfo
+		msg  proto.Message
+	}
+
+	// snapshotCheckpoint is the decoded config.SnapshotCheckpoint
+	snapshotCheckpoint struct {
+		height    uint64
+		blockHash []byte
+		stateRoot []byte
+	}
+
+	// snapshotCandidate is a manifest served by the neighbors
+	snapshotCandidate struct {
+		manifest *snapshotpb.Manifest
+		block    *block.Block
+		peers    []peerstore.PeerInfo
+	}
+)
+
+// WithSnapshotStore is the option to serve and import the state snapshot
+func WithSnapshotStore(store SnapshotStore) Option {
+	return func(cfg *Config) error {
+		cfg.snapshotStore = store
+		return nil
+	}
+}
+
+func newSnapshotCheckpoint(cfg config.SnapshotCheckpoint) (*snapshotCheckpoint, error) {
+	if cfg.Height == 0 {
+		return nil, nil
+	}
+	blkHash, err := hex.DecodeString(cfg.BlockHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid checkpoint block hash")
+	}
+	stateRoot, err := hex.DecodeString(cfg.StateRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid checkpoint state root")
+	}
+	return &snapshotCheckpoint{
+		height:    cfg.Height,
+		blockHash: blkHash,
+		stateRoot: stateRoot,
+	}, nil
+}
+
+// match returns true if the manifest is the snapshot at the checkpoint
+func (c *snapshotCheckpoint) match(manifest *snapshotpb.Manifest) bool {
+	return manifest.GetHeight() == c.height &&
+		bytes.Equal(manifest.GetBlockHash(), c.blockHash) &&
+		bytes.Equal(manifest.GetStateRoot(), c.stateRoot)
+}
+
+// ProcessSnapshotRequest processes a request for the manifest or a chunk of the state snapshot
+func (bs *blockSyncer) ProcessSnapshotRequest(ctx context.Context, peer peerstore.PeerInfo, req *snapshotpb.Request) error {
+	if bs.snapshotStore == nil {
+		return ErrSnapshotNotSupported
+	}
+	var resp proto.Message
+	if req.GetManifest() {
+		manifest, err := bs.snapshotStore.SnapshotManifest()
+		if err != nil {
+			return err
+		}
+		blk, err := bs.dao.GetBlockByHeight(manifest.GetHeight())
+		if err != nil {
+			return err
+		}
+		resp = &snapshotpb.ManifestResponse{
+			Manifest: manifest,
+			Block:    blk.ConvertToBlockPb(),
+		}
+	} else {
+		data, err := bs.snapshotStore.SnapshotChunk(req.GetHeight(), req.GetChunk())
+		if err != nil {
+			return err
+		}
+		resp = &snapshotpb.ChunkResponse{
+			Height: req.GetHeight(),
+			Chunk:  req.GetChunk(),
+			Data:   data,
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, bs.processSyncRequestTTL)
+	defer cancel()
+	return bs.unicastHandler(ctx, peer, resp)
+}
+
+// ProcessSnapshotResponse processes a response with the manifest or a chunk of the state snapshot
+func (bs *blockSyncer) ProcessSnapshotResponse(_ context.Context, peer peerstore.PeerInfo, msg proto.Message) error {
+	select {
+	case bs.snapshotResps <- &snapshotResponse{peer: peer, msg: msg}:
+	default:
+		log.L().Debug("Drop snapshot response.", zap.String("peerID", peer.ID.Pretty()))
+	}
+	return nil
+}
+
+// SyncSnapshot imports the state snapshot at cfg.BlockSync.SnapshotCheckpoint from the neighbors serving it, and
+// returns the block at the height of the snapshot
+func (bs *blockSyncer) SyncSnapshot(ctx context.Context) (*block.Block, error) {
+	if bs.snapshotStore == nil {
+		return nil, ErrSnapshotNotSupported
+	}
+	if bs.snapshotCheckpoint == nil {
+		return nil, errors.New("snapshot sync requires a trusted checkpoint")
+	}
+	var candidate *snapshotCandidate
+	for i := 0; candidate == nil; i++ {
+		if i == snapshotManifestRounds {
+			return nil, ErrSnapshotNotAvailable
+		}
+		if i > 0 {
+			// the neighbors are usually not discovered yet right after the p2p agent starts, so back off before
+			// asking again
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(bs.snapshotInterval):
+			}
+		}
+		var err error
+		if candidate, err = bs.requestManifest(ctx); err != nil {
+			return nil, err
+		}
+	}
+	manifest := candidate.manifest
+	log.L().Info("Importing state snapshot.",
+		zap.Uint64("height", manifest.GetHeight()),
+		zap.Int("chunks", len(manifest.GetChunkHashes())),
+		zap.Int("peers", len(candidate.peers)))
+	if err := bs.snapshotStore.ImportSnapshot(ctx, manifest, func(index uint32) ([]byte, error) {
+		return bs.requestChunk(ctx, candidate, index)
+	}); err != nil {
+		return nil, err
+	}
+	// the state trie is rebuilt from the imported states, and its root has to match the trusted state root
+	if err := bs.snapshotStore.VerifySnapshot(ctx, manifest); err != nil {
+		return nil, err
+	}
+	return candidate.block, nil
+}
+
+// requestManifest asks the neighbors for the manifest, and returns the snapshot at the checkpoint with the peers
+// serving it
+func (bs *blockSyncer) requestManifest(ctx context.Context) (*snapshotCandidate, error) {
+	peers, err := bs.neighborsHandler(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bs.drainSnapshotResponses()
+	// only the peers supporting the snapshot are asked, so the others are not waited for
+	requested := 0
+	for _, peer := range peers {
+		if err := bs.unicastHandler(ctx, peer, &snapshotpb.Request{Manifest: true}); err != nil {
+			log.L().Debug("Failed to request snapshot manifest.", zap.Error(err))
+			continue
+		}
+		requested++
+	}
+	var (
+		candidate *snapshotCandidate
+		served    = map[string]bool{}
+		timer     = time.NewTimer(bs.snapshotInterval)
+	)
+	defer timer.Stop()
+	for len(served) < requested {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return candidate, nil
+		case resp := <-bs.snapshotResps:
+			msg, ok := resp.msg.(*snapshotpb.ManifestResponse)
+			if !ok || served[resp.peer.ID.Pretty()] {
+				continue
+			}
+			served[resp.peer.ID.Pretty()] = true
+			if !bs.snapshotCheckpoint.match(msg.GetManifest()) {
+				log.L().Debug("Snapshot manifest does not match the checkpoint.", zap.String("peerID", resp.peer.ID.Pretty()))
+				continue
+			}
+			blk, err := verifySnapshotBlock(msg)
+			if err != nil {
+				log.L().Warn("Invalid snapshot manifest.", zap.String("peerID", resp.peer.ID.Pretty()), zap.Error(err))
+				continue
+			}
+			if candidate == nil {
+				candidate = &snapshotCandidate{manifest: msg.GetManifest(), block: blk}
+			} else if !proto.Equal(candidate.manifest, msg.GetManifest()) {
+				// the chunk hashes differ, so the peer serves the states under another chunking
+				continue
+			}
+			candidate.peers = append(candidate.peers, resp.peer)
+		}
+	}
+	return candidate, nil
+}
+
+// requestChunk fetches the chunk from the peers serving the snapshot in turn, until a peer returns the valid chunk
+func (bs *blockSyncer) requestChunk(ctx context.Context, candidate *snapshotCandidate, index uint32) ([]byte, error) {
+	height := candidate.manifest.GetHeight()
+	if int(index) >= len(candidate.manifest.GetChunkHashes()) {
+		return nil, errors.Errorf("chunk %d does not exist", index)
+	}
+	chunkHash := candidate.manifest.GetChunkHashes()[index]
+	attempts := len(candidate.peers) * bs.maxRepeat
+	if attempts < len(candidate.peers) {
+		attempts = len(candidate.peers)
+	}
+	for i := 0; i < attempts; i++ {
+		peer := candidate.peers[(int(index)+i)%len(candidate.peers)]
+		if err := bs.unicastHandler(ctx, peer, &snapshotpb.Request{Height: height, Chunk: index}); err != nil {
+			log.L().Debug("Failed to request snapshot chunk.", zap.Error(err))
+			continue
+		}
+		data, err := bs.waitChunk(ctx, peer, height, index)
+		if err != nil {
+			return nil, err
+		}
+		if h := hash.Hash256b(data); data != nil && bytes.Equal(h[:], chunkHash) {
+			return data, nil
+		}
+	}
+	return nil, errors.Errorf("failed to fetch chunk %d of snapshot at height %d", index, height)
+}
+
+// waitChunk waits for the chunk from the peer, and returns nil on timeout
+func (bs *blockSyncer) waitChunk(ctx context.Context, peer peerstore.PeerInfo, height uint64, index uint32) ([]byte, error) {
+	timer := time.NewTimer(bs.processSyncRequestTTL)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return nil, nil
+		case resp := <-bs.snapshotResps:
+			msg, ok := resp.msg.(*snapshotpb.ChunkResponse)
+			if ok && resp.peer.ID == peer.ID && msg.GetHeight() == height && msg.GetChunk() == index {
+				return msg.GetData(), nil
+			}
+		}
+	}
+}
+
+func (bs *blockSyncer) drainSnapshotResponses() {
+	for {
+		select {
+		case <-bs.snapshotResps:
+		default:
+			return
+		}
+	}
+}
+
+// verifySnapshotBlock verifies the block at the height of the snapshot is signed and matches the manifest. The manifest
+// has been matched against the trusted checkpoint, so the block hash pins the block
+func verifySnapshotBlock(resp *snapshotpb.ManifestResponse) (*block.Block, error) {
+	manifest := resp.GetManifest()
+	if manifest == nil || resp.GetBlock() == nil {
+		return nil, errors.New("missing manifest or block")
+	}
+	blk := &block.Block{}
+	if err := blk.ConvertFromBlockPb(resp.GetBlock()); err != nil {
+		return nil, err
+	}
+	blkHash := blk.HashBlock()
+	if blk.Height() != manifest.GetHeight() || !bytes.Equal(blkHash[:], manifest.GetBlockHash()) {
+		return nil, errors.Errorf("block %x at height %d does not match the manifest", blkHash, blk.Height())
+	}
+	if !blk.VerifySignature() {
+		return nil, errors.New("failed to verify block signature")
+	}
+	if err := blk.VerifyTxRoot(blk.CalculateTxRoot()); err != nil {
+		return nil, err
+	}
+	return blk, nil
+}
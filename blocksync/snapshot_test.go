@@ -0,0 +1,238 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/hash"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockdao"
+	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+type testSnapshotStore struct {
+	manifest *snapshotpb.Manifest
+	chunks   [][]byte
+	imported [][]byte
+	invalid  bool
+}
+
+func (s *testSnapshotStore) SnapshotManifest() (*snapshotpb.Manifest, error) {
+	return s.manifest, nil
+}
+
+func (s *testSnapshotStore) SnapshotChunk(height uint64, index uint32) ([]byte, error) {
+	if height != s.manifest.GetHeight() || int(index) >= len(s.chunks) {
+		return nil, errors.New("chunk does not exist")
+	}
+	return s.chunks[index], nil
+}
+
+func (s *testSnapshotStore) ImportSnapshot(
+	_ context.Context,
+	manifest *snapshotpb.Manifest,
+	chunk func(uint32) ([]byte, error),
+) error {
+	for i, chunkHash := range manifest.GetChunkHashes() {
+		data, err := chunk(uint32(i))
+		if err != nil {
+			return err
+		}
+		if h := hash.Hash256b(data); !bytes.Equal(h[:], chunkHash) {
+			return errors.New("invalid chunk")
+		}
+		s.imported = append(s.imported, data)
+	}
+	return nil
+}
+
+func (s *testSnapshotStore) VerifySnapshot(context.Context, *snapshotpb.Manifest) error {
+	if s.invalid {
+		return errors.New("invalid snapshot")
+	}
+	return nil
+}
+
+func TestSyncSnapshot(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	cfg.BlockSync.Interval = 10 * time.Millisecond
+	cfg.BlockSync.ProcessSyncRequestTTL = 10 * time.Millisecond
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().ChainID().AnyTimes().Return(config.Default.Chain.ID)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+
+	blk, err := block.NewTestingBuilder().
+		SetHeight(4).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	dao := mock_blockdao.NewMockBlockDAO(ctrl)
+	dao.EXPECT().GetBlockByHeight(gomock.Any()).AnyTimes().Return(&blk, nil)
+	blkHash := blk.HashBlock()
+	chunks := [][]byte{{1, 2}, {3, 4}, {5, 6}}
+	manifest := &snapshotpb.Manifest{
+		Height:    4,
+		BlockHash: blkHash[:],
+		StateRoot: hash.ZeroHash256[:],
+	}
+	for _, c := range chunks {
+		h := hash.Hash256b(c)
+		manifest.ChunkHashes = append(manifest.ChunkHashes, h[:])
+	}
+
+	var (
+		client      BlockSync
+		clientStore = &testSnapshotStore{}
+		servers     = map[string]BlockSync{}
+		peers       = []peerstore.PeerInfo{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+		honest      = &testSnapshotStore{manifest: manifest, chunks: chunks}
+		stores      = []SnapshotStore{
+			honest,
+			// serves a corrupted chunk
+			&testSnapshotStore{manifest: manifest, chunks: [][]byte{{1, 2}, {0, 0}, {5, 6}}},
+			honest,
+		}
+	)
+	for i, p := range peers {
+		p := p
+		bs, err := NewBlockSyncer(cfg, chain, dao, cs,
+			WithUnicastOutBound(func(ctx context.Context, _ peerstore.PeerInfo, msg proto.Message) error {
+				return client.ProcessSnapshotResponse(ctx, p, msg)
+			}),
+			WithSnapshotStore(stores[i]),
+		)
+		require.NoError(err)
+		servers[p.ID.Pretty()] = bs
+	}
+	neighbors := func() []peerstore.PeerInfo { return peers }
+	newClient := func(checkpoint config.SnapshotCheckpoint) BlockSync {
+		cfg.BlockSync.SnapshotCheckpoint = checkpoint
+		bs, err := NewBlockSyncer(cfg, chain, dao, cs,
+			WithUnicastOutBound(func(ctx context.Context, p peerstore.PeerInfo, msg proto.Message) error {
+				return servers[p.ID.Pretty()].ProcessSnapshotRequest(ctx, peerstore.PeerInfo{ID: "client"}, msg.(*snapshotpb.Request))
+			}),
+			WithNeighbors(func(context.Context) ([]peerstore.PeerInfo, error) { return neighbors(), nil }),
+			WithSnapshotStore(clientStore),
+		)
+		require.NoError(err)
+		return bs
+	}
+
+	checkpoint := config.SnapshotCheckpoint{
+		Height:    4,
+		BlockHash: hex.EncodeToString(blkHash[:]),
+		StateRoot: hex.EncodeToString(hash.ZeroHash256[:]),
+	}
+	ctx := context.Background()
+	client = newClient(checkpoint)
+	synced, err := client.SyncSnapshot(ctx)
+	require.NoError(err)
+	require.Equal(blkHash, synced.HashBlock())
+	require.Equal(chunks, clientStore.imported)
+
+	// the imported states do not match the manifest
+	clientStore.invalid = true
+	clientStore.imported = nil
+	client = newClient(checkpoint)
+	_, err = client.SyncSnapshot(ctx)
+	require.Error(err)
+	clientStore.invalid = false
+
+	// no peer serves the snapshot at the checkpoint
+	untrusted := checkpoint
+	untrusted.StateRoot = hex.EncodeToString(blkHash[:])
+	client = newClient(untrusted)
+	_, err = client.SyncSnapshot(ctx)
+	require.Equal(ErrSnapshotNotAvailable, errors.Cause(err))
+
+	// the neighbors are discovered after a few rounds
+	rounds := 0
+	neighbors = func() []peerstore.PeerInfo {
+		if rounds++; rounds < 3 {
+			return nil
+		}
+		return peers
+	}
+	clientStore.imported = nil
+	client = newClient(checkpoint)
+	synced, err = client.SyncSnapshot(ctx)
+	require.NoError(err)
+	require.Equal(blkHash, synced.HashBlock())
+	require.Equal(chunks, clientStore.imported)
+
+	// no neighbor is discovered, the rounds back off before failing
+	neighbors = func() []peerstore.PeerInfo { return nil }
+	client = newClient(checkpoint)
+	start := time.Now()
+	_, err = client.SyncSnapshot(ctx)
+	require.Equal(ErrSnapshotNotAvailable, errors.Cause(err))
+	require.True(time.Since(start) >= (snapshotManifestRounds-1)*cfg.BlockSync.Interval)
+	neighbors = func() []peerstore.PeerInfo { return peers }
+
+	// no checkpoint is configured
+	client = newClient(config.SnapshotCheckpoint{})
+	_, err = client.SyncSnapshot(ctx)
+	require.Error(err)
+
+	// serving is not enabled
+	bs, err := NewBlockSyncer(cfg, chain, dao, cs, opts...)
+	require.NoError(err)
+	require.Equal(ErrSnapshotNotSupported, bs.ProcessSnapshotRequest(ctx, peers[0], &snapshotpb.Request{Manifest: true}))
+	_, err = bs.SyncSnapshot(ctx)
+	require.Equal(ErrSnapshotNotSupported, err)
+}
+
+func TestVerifySnapshotBlock(t *testing.T) {
+	require := require.New(t)
+
+	blk, err := block.NewTestingBuilder().
+		SetHeight(4).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	blkHash := blk.HashBlock()
+	resp := &snapshotpb.ManifestResponse{
+		Manifest: &snapshotpb.Manifest{Height: 4, BlockHash: blkHash[:]},
+		Block:    blk.ConvertToBlockPb(),
+	}
+	_, err = verifySnapshotBlock(resp)
+	require.NoError(err)
+
+	// the block does not match the manifest
+	resp.Manifest.Height = 5
+	_, err = verifySnapshotBlock(resp)
+	require.Error(err)
+	resp.Manifest.Height = 4
+
+	// the block is not signed by the producer
+	resp.Block.Header.Signature = nil
+	_, err = verifySnapshotBlock(resp)
+	require.Error(err)
+}
@@ -10,9 +10,14 @@ import (
 	"context"
 	"math/rand"
 	"sync"
+	"time"
 
+	"github.com/iotexproject/go-pkgs/hash"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blocksync/blocksyncpb"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/routine"
@@ -24,6 +29,13 @@ type syncBlocksInterval struct {
 	End   uint64
 }
 
+// syncRequest is a request sent to a peer, which is expired if not responded in time
+type syncRequest struct {
+	peer    string
+	sent    time.Time
+	expired bool
+}
+
 type syncWorker struct {
 	chainID          uint32
 	mu               sync.RWMutex
@@ -34,6 +46,15 @@ type syncWorker struct {
 	task             *routine.RecurringTask
 	maxRepeat        int
 	repeatDecayStep  int
+	requestTTL       time.Duration
+	headers          *headerChain
+	scorer           *peerScorer
+	reqMu            sync.Mutex
+	inflight         map[uint64]*syncRequest // pending block requests by height
+	headerReq        *syncRequest            // pending headers request
+	headerFailures   int                     // consecutive timed out headers requests
+	headerPeerIdx    int                     // rank of the peer to ask for headers
+	genesisHash      hash.Hash256
 }
 
 func newSyncWorker(
@@ -51,6 +72,11 @@ func newSyncWorker(
 		targetHeight:     0,
 		maxRepeat:        cfg.BlockSync.MaxRepeat,
 		repeatDecayStep:  cfg.BlockSync.RepeatDecayStep,
+		requestTTL:       cfg.BlockSync.ProcessSyncRequestTTL,
+		headers:          newHeaderChain(buf.cs.ValidateBlockProducer),
+		scorer:           newPeerScorer(cfg.BlockSync.MaxPeerFailures, cfg.BlockSync.PeerDemotionDuration),
+		inflight:         make(map[uint64]*syncRequest),
+		genesisHash:      cfg.Genesis.Hash(),
 	}
 	if cfg.BlockSync.Interval != 0 {
 		w.task = routine.NewRecurringTask(w.Sync, cfg.BlockSync.Interval)
@@ -80,7 +106,8 @@ func (w *syncWorker) SetTargetHeight(h uint64) {
 	}
 }
 
-// Sync checks the sliding window and send more sync request if needed
+// Sync fetches the headers up to the target height from the best peer, and then the blocks of the headers from all the
+// peers in parallel. It falls back to fetching the blocks without headers if the peers do not serve headers
 func (w *syncWorker) Sync() {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -95,12 +122,82 @@ func (w *syncWorker) Sync() {
 		log.L().Warn("Error when get neighbor peers.", zap.Error(err))
 		return
 	}
-	intervals := w.buf.GetBlocksIntervalsToSync(w.targetHeight)
+	tipHeight, tipHash := w.chainTip()
+	w.headers.Sync(tipHeight, tipHash)
+	targetHeight := w.syncTargetHeight(tipHeight)
+	ranked := w.scorer.Rank(peers)
+
+	w.reqMu.Lock()
+	w.expireRequests(tipHeight)
+	if w.headers.Tip() <= tipHeight && w.headerFailures > 0 {
+		w.reqMu.Unlock()
+		w.syncLegacy(ctx, peers)
+		w.reqMu.Lock()
+	}
+	var (
+		headersReq *blocksyncpb.HeadersRequest
+		headerPeer = ranked[w.headerPeerIdx%len(ranked)]
+		bodyPeers  []peerstore.PeerInfo
+	)
+	if headerTip := w.headers.Tip(); headerTip < targetHeight && w.headerReq == nil {
+		headersReq = &blocksyncpb.HeadersRequest{Start: headerTip + 1, End: targetHeight}
+		w.headerReq = &syncRequest{peer: headerPeer.ID.Pretty(), sent: time.Now()}
+	}
+	intervals := w.bodyIntervals(tipHeight)
+	for i, interval := range intervals {
+		p := ranked[i%len(ranked)]
+		req := &syncRequest{peer: p.ID.Pretty(), sent: time.Now()}
+		for h := interval.Start; h <= interval.End; h++ {
+			w.inflight[h] = req
+		}
+		bodyPeers = append(bodyPeers, p)
+	}
+	w.reqMu.Unlock()
+
+	if headersReq != nil {
+		log.L().Debug("block sync headers.",
+			zap.Uint64("start", headersReq.Start),
+			zap.Uint64("targetHeight", targetHeight))
+		if err := w.unicastHandler(ctx, headerPeer, headersReq); err != nil {
+			// the peer may not serve headers, so the request fails at once to fall back to the blocks without headers
+			log.L().Debug("Failed to sync headers.", zap.Error(err))
+			w.reqMu.Lock()
+			w.failHeaders(headerPeer.ID.Pretty())
+			w.reqMu.Unlock()
+		}
+	}
 	if intervals != nil {
 		log.L().Info("block sync intervals.",
 			zap.Any("intervals", intervals),
 			zap.Uint64("targetHeight", w.targetHeight))
 	}
+	// the intervals are downloaded from different peers in parallel
+	for i, interval := range intervals {
+		if err := w.unicastHandler(ctx, bodyPeers[i], &iotexrpc.BlockSync{
+			Start: interval.Start, End: interval.End,
+		}); err != nil {
+			log.L().Debug("Failed to sync block.", zap.Error(err))
+		}
+	}
+}
+
+// chainTip returns the height and the hash of the chain tip
+func (w *syncWorker) chainTip() (uint64, hash.Hash256) {
+	tipHeight := w.buf.bc.TipHeight()
+	if tipHeight == 0 {
+		return 0, w.genesisHash
+	}
+	return tipHeight, w.buf.bc.TipHash()
+}
+
+// syncLegacy asks random peers for the missing blocks up to the target height, without headers
+func (w *syncWorker) syncLegacy(ctx context.Context, peers []peerstore.PeerInfo) {
+	intervals := w.buf.GetBlocksIntervalsToSync(w.targetHeight)
+	if intervals != nil {
+		log.L().Info("block sync intervals without headers.",
+			zap.Any("intervals", intervals),
+			zap.Uint64("targetHeight", w.targetHeight))
+	}
 
 	for i, interval := range intervals {
 		repeat := w.maxRepeat - i/w.repeatDecayStep
@@ -118,3 +215,118 @@ func (w *syncWorker) Sync() {
 		}
 	}
 }
+
+// syncTargetHeight returns the height to sync to, which is bounded by the buffer size
+func (w *syncWorker) syncTargetHeight(tipHeight uint64) uint64 {
+	targetHeight := w.targetHeight
+	if targetHeight > tipHeight+w.buf.bufferSize {
+		targetHeight = tipHeight + w.buf.bufferSize
+	}
+	// speculatively fetch the headers of at least one interval
+	if targetHeight < tipHeight+w.buf.intervalSize {
+		targetHeight = tipHeight + w.buf.intervalSize
+	}
+	return targetHeight
+}
+
+// expireRequests drops the requests which have been responded or timed out, and penalizes the peers of the timed out
+func (w *syncWorker) expireRequests(tipHeight uint64) {
+	now := time.Now()
+	expire := func(req *syncRequest) bool {
+		if now.Sub(req.sent) < w.requestTTL {
+			return false
+		}
+		if !req.expired {
+			req.expired = true
+			w.scorer.OnTimeout(req.peer)
+		}
+		return true
+	}
+	for h, req := range w.inflight {
+		if h <= tipHeight || w.buf.blockExists(h) || expire(req) {
+			delete(w.inflight, h)
+		}
+	}
+	if w.headerReq != nil && expire(w.headerReq) {
+		w.failHeaders(w.headerReq.peer)
+	}
+}
+
+// failHeaders drops the pending headers request to the peer, and moves on to the next peer
+func (w *syncWorker) failHeaders(peer string) {
+	if w.headerReq == nil || w.headerReq.peer != peer {
+		return
+	}
+	w.headerReq = nil
+	w.headerFailures++
+	w.headerPeerIdx++
+}
+
+// bodyIntervals returns the intervals of the blocks which have headers but are neither buffered nor requested
+func (w *syncWorker) bodyIntervals(tipHeight uint64) []syncBlocksInterval {
+	var (
+		intervals []syncBlocksInterval
+		start     uint64
+	)
+	for h := tipHeight + 1; h <= w.headers.Tip()+1; h++ {
+		_, requested := w.inflight[h]
+		missing := h <= w.headers.Tip() && !requested && !w.buf.blockExists(h)
+		switch {
+		case missing && start == 0:
+			start = h
+		case start != 0 && (!missing || h-start == w.buf.intervalSize):
+			intervals = append(intervals, syncBlocksInterval{Start: start, End: h - 1})
+			start = 0
+			if missing {
+				start = h
+			}
+		}
+	}
+	return intervals
+}
+
+// onHeaders adds the headers served by the peer to the header chain
+func (w *syncWorker) onHeaders(peer string, headers []*block.Header) error {
+	w.reqMu.Lock()
+	defer w.reqMu.Unlock()
+	if w.headerReq != nil && w.headerReq.peer == peer {
+		w.scorer.OnResponse(peer, time.Since(w.headerReq.sent))
+		w.headerReq = nil
+		w.headerFailures = 0
+	}
+	n, err := w.headers.Add(headers)
+	if err != nil {
+		w.scorer.OnInvalid(peer)
+	}
+	if n == 0 {
+		// the peer is not ahead, ask the next one
+		w.headerPeerIdx++
+	}
+	return err
+}
+
+// onBlock checks the block served by the peer, and returns false if the block does not match its own tx root. The
+// headers are not confirmed, so the block is passed on to be validated by the chain even if it conflicts with the
+// header at its height, and the header and the ones above it are dropped instead
+func (w *syncWorker) onBlock(peer string, blk *block.Block) bool {
+	if blk.VerifyTxRoot(blk.CalculateTxRoot()) != nil {
+		if peer != "" {
+			w.scorer.OnInvalid(peer)
+		}
+		return false
+	}
+	if expected, ok := w.headers.Hash(blk.Height()); ok && blk.HashBlock() != expected {
+		log.L().Info("Drop block headers conflicting with block.",
+			zap.Uint64("height", blk.Height()),
+			zap.String("peer", peer))
+		tipHeight, tipHash := w.chainTip()
+		w.headers.Drop(blk.Height(), tipHeight, tipHash)
+	}
+	w.reqMu.Lock()
+	defer w.reqMu.Unlock()
+	if req, ok := w.inflight[blk.Height()]; ok && req.peer == peer {
+		w.scorer.OnResponse(peer, time.Since(req.sent))
+		delete(w.inflight, blk.Height())
+	}
+	return true
+}
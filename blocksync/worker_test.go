@@ -0,0 +1,175 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blocksync/blocksyncpb"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestSyncWorkerHeaderFirst(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	cfg.BlockSync.Interval = 0
+	cfg.BlockSync.IntervalSize = 2
+	cfg.BlockSync.ProcessSyncRequestTTL = time.Hour
+	genesis := cfg.Genesis.Hash()
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().Return(uint64(0)).AnyTimes()
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().ValidateBlockProducer(gomock.Any()).Return(nil).AnyTimes()
+	blks := newTestChain(t, genesis, 6, 27)
+
+	type sent struct {
+		peer string
+		msg  proto.Message
+	}
+	var (
+		msgs []sent
+		// noHeaders makes the headers requests fail, as the peers not supporting them do
+		noHeaders bool
+	)
+	peers := []peerstore.PeerInfo{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	newWorker := func() *syncWorker {
+		msgs = nil
+		return newSyncWorker(
+			cfg.Chain.ID,
+			cfg,
+			func(_ context.Context, p peerstore.PeerInfo, msg proto.Message) error {
+				msgs = append(msgs, sent{p.ID.Pretty(), msg})
+				if _, ok := msg.(*blocksyncpb.HeadersRequest); ok && noHeaders {
+					return errors.New("headers are not supported")
+				}
+				return nil
+			},
+			func(context.Context) ([]peerstore.PeerInfo, error) { return peers, nil },
+			&blockBuffer{
+				blocks:       make(map[uint64]*block.Block),
+				bc:           chain,
+				cs:           cs,
+				bufferSize:   cfg.BlockSync.BufferSize,
+				intervalSize: cfg.BlockSync.IntervalSize,
+			},
+		)
+	}
+
+	// the headers are fetched first
+	w := newWorker()
+	w.SetTargetHeight(6)
+	w.Sync()
+	require.Len(msgs, 1)
+	require.True(proto.Equal(&blocksyncpb.HeadersRequest{Start: 1, End: 6}, msgs[0].msg))
+	headerPeer := msgs[0].peer
+	require.NoError(w.onHeaders(headerPeer, testHeaders(blks)))
+	require.EqualValues(6, w.headers.Tip())
+
+	// the blocks are fetched from all the peers in parallel
+	msgs = nil
+	w.Sync()
+	require.Len(msgs, 3)
+	bodyPeers := map[string]uint64{}
+	for i, m := range msgs {
+		start := uint64(2*i + 1)
+		require.True(proto.Equal(&iotexrpc.BlockSync{Start: start, End: start + 1}, m.msg))
+		bodyPeers[m.peer] = start
+	}
+	require.Len(bodyPeers, 3)
+	// requested blocks are not requested again
+	msgs = nil
+	w.Sync()
+	require.Empty(msgs)
+
+	// a block not matching its tx root demotes the peer
+	tsf, err := testutil.SignedTransfer(identityset.Address(2).String(), identityset.PrivateKey(1), 1, big.NewInt(1), nil, 10000, big.NewInt(0))
+	require.NoError(err)
+	tampered := *blks[3]
+	tampered.Actions = append(tampered.Actions, tsf)
+	require.False(w.onBlock(peerOf(bodyPeers, 3), &tampered))
+	require.True(w.scorer.Demoted(peerOf(bodyPeers, 3)))
+	require.EqualValues(6, w.headers.Tip())
+	require.True(w.onBlock(peerOf(bodyPeers, 1), blks[0]))
+	require.False(w.scorer.Demoted(peerOf(bodyPeers, 1)))
+	// blocks above the headers are not checked
+	require.True(w.onBlock("", newTestChain(t, genesis, 7, 28)[6]))
+	// a block conflicting with its header is passed on, and the headers from its height are dropped
+	forged := newTestChain(t, genesis, 6, 28)
+	require.True(w.onBlock(peerOf(bodyPeers, 5), forged[4]))
+	require.False(w.scorer.Demoted(peerOf(bodyPeers, 5)))
+	require.EqualValues(4, w.headers.Tip())
+
+	// invalid headers demote the peer
+	w = newWorker()
+	require.Error(w.onHeaders(headerPeer, testHeaders(forged[1:])))
+	require.True(w.scorer.Demoted(headerPeer))
+
+	// fall back to syncing blocks without headers if the peers do not serve headers
+	cfg.BlockSync.ProcessSyncRequestTTL = 0
+	w = newWorker()
+	w.SetTargetHeight(4)
+	w.Sync()
+	require.Len(msgs, 1)
+	msgs = nil
+	w.Sync()
+	var legacy int
+	for _, m := range msgs {
+		if _, ok := m.msg.(*iotexrpc.BlockSync); ok {
+			legacy++
+		}
+	}
+	require.True(legacy > 0)
+
+	// the headers request failing to be sent falls back at once, and the next peer is asked for the headers
+	cfg.BlockSync.ProcessSyncRequestTTL = time.Hour
+	noHeaders = true
+	w = newWorker()
+	w.SetTargetHeight(4)
+	w.Sync()
+	require.Len(msgs, 1)
+	first := msgs[0].peer
+	require.Nil(w.headerReq)
+	msgs = nil
+	w.Sync()
+	legacy = 0
+	for _, m := range msgs {
+		switch m.msg.(type) {
+		case *iotexrpc.BlockSync:
+			legacy++
+		case *blocksyncpb.HeadersRequest:
+			require.NotEqual(first, m.peer)
+		}
+	}
+	require.True(legacy > 0)
+}
+
+func peerOf(peers map[string]uint64, start uint64) string {
+	for p, s := range peers {
+		if s == start {
+			return p
+		}
+	}
+	return ""
+}
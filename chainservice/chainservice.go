@@ -8,7 +8,10 @@ package chainservice
 
 import (
 	"context"
+	"io"
 	"math/big"
+	"os"
+	"runtime"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -36,15 +39,22 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
 	"github.com/iotexproject/iotex-core/blockindex"
 	"github.com/iotexproject/iotex-core/blocksync"
+	"github.com/iotexproject/iotex-core/blocksync/blocksyncpb"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus"
 	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/sql"
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/p2p"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
+	"github.com/iotexproject/iotex-core/web3"
 )
 
 // ChainService is a blockchain service with all blockchain components.
@@ -58,11 +68,19 @@ type ChainService struct {
 	electionCommittee committee.Committee
 	// TODO: explorer dependency deleted at #1085, need to api related params
 	api                *api.Server
+	web3               *web3.Server
 	indexBuilder       *blockindex.IndexBuilder
 	bfIndexer          blockindex.BloomFilterIndexer
 	candidateIndexer   *poll.CandidateIndexer
 	candBucketsIndexer *staking.CandidatesBucketsIndexer
 	registry           *protocol.Registry
+	signer             signer.Signer
+	// snapshotSync is true if the new node bootstraps from the state snapshot served by the peers
+	snapshotSync bool
+	chainDBCfg   config.DB
+	trieDBPath   string
+	// stateRebuildCfg is set if the candidate or staking indexer is rebuilt as the blocks are replayed at start
+	stateRebuildCfg *config.Config
 }
 
 type optionParams struct {
@@ -112,6 +130,9 @@ func New(
 		}
 	}
 	registry := protocol.NewRegistry()
+	// a new node without any db file bootstraps from the state snapshot
+	snapshotSync := cfg.BlockSync.EnableSnapshotSync && !ops.isTesting &&
+		!fileutil.FileExists(cfg.Chain.ChainDBPath) && !fileutil.FileExists(cfg.Chain.TrieDBPath)
 	// create state factory
 	var sf factory.Factory
 	if ops.isTesting {
@@ -127,7 +148,13 @@ func New(
 				sf, err = factory.NewStateDB(cfg, factory.DefaultStateDBOption(), factory.RegistryStateDBOption(registry))
 			}
 		} else {
-			sf, err = factory.NewFactory(cfg, factory.DefaultTrieOption(), factory.RegistryOption(registry))
+			sfOpts := []factory.Option{factory.DefaultTrieOption(), factory.RegistryOption(registry)}
+			if cfg.Chain.SnapshotInterval > 0 {
+				snapshotDBCfg := cfg.DB
+				snapshotDBCfg.DbPath = cfg.Chain.SnapshotDBPath
+				sfOpts = append(sfOpts, factory.SnapshotOption(db.NewOnDiskDB(snapshotDBCfg)))
+			}
+			sf, err = factory.NewFactory(cfg, sfOpts...)
 		}
 		if err != nil {
 			return nil, errors.Wrapf(err, "Failed to create state factory")
@@ -164,9 +191,19 @@ func New(
 		}
 	}
 	_, gateway := cfg.Plugins[config.GatewayPlugin]
+	var stateRebuildCfg *config.Config
+	if !ops.isTesting {
+		stateRebuild, err := rebuildIndexers(cfg, gateway)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to rebuild indexers")
+		}
+		if stateRebuild {
+			stateRebuildCfg = &cfg
+		}
+	}
 	if gateway {
 		cfg.DB.DbPath = cfg.Chain.IndexDBPath
-		indexer, err = blockindex.NewIndexer(db.NewBoltDB(cfg.DB), cfg.Genesis.Hash())
+		indexer, err = blockindex.NewIndexer(db.NewOnDiskDB(cfg.DB), cfg.Genesis.Hash())
 		if err != nil {
 			return nil, err
 		}
@@ -176,7 +213,7 @@ func New(
 
 		// create bloomfilter indexer
 		cfg.DB.DbPath = cfg.Chain.BloomfilterIndexDBPath
-		bfIndexer, err = blockindex.NewBloomfilterIndexer(db.NewBoltDB(cfg.DB), cfg.Chain.RangeBloomFilterSize)
+		bfIndexer, err = blockindex.NewBloomfilterIndexer(db.NewOnDiskDB(cfg.DB), cfg.Chain.RangeBloomFilterSize)
 		if err != nil {
 			return nil, err
 		}
@@ -184,31 +221,62 @@ func New(
 
 		// create candidate indexer
 		cfg.DB.DbPath = cfg.Chain.CandidateIndexDBPath
-		candidateIndexer, err = poll.NewCandidateIndexer(db.NewBoltDB(cfg.DB))
+		candidateIndexer, err = poll.NewCandidateIndexer(db.NewOnDiskDB(cfg.DB))
 		if err != nil {
 			return nil, err
 		}
 		if cfg.Chain.EnableStakingIndexer {
 			cfg.DB.DbPath = cfg.Chain.StakingIndexDBPath
-			candBucketsIndexer, err = staking.NewStakingCandidatesBucketsIndexer(db.NewBoltDB(cfg.DB))
+			candBucketsIndexer, err = staking.NewStakingCandidatesBucketsIndexer(db.NewOnDiskDB(cfg.DB))
 			if err != nil {
 				return nil, err
 			}
 		}
 	}
 
+	var sqlIndexBuilder *blockindex.SQLIndexBuilder
+	if cfg.Chain.EnableSQLIndexer {
+		var store sql.Store
+		switch {
+		case cfg.DB.SQL.Driver != "":
+			store = sql.NewSQLStore(cfg.DB.SQL)
+		case cfg.API.UseRDS:
+			store = sql.NewAwsRDS(cfg.DB.RDS)
+		default:
+			store = sql.NewSQLite3(cfg.DB.SQLITE3)
+		}
+		sqlIndexer, err := blockindex.NewSQLIndexer(store)
+		if err != nil {
+			return nil, err
+		}
+		// the blocks are written into the SQL database in the background, so that its outage does not stop the chain
+		sqlIndexBuilder = blockindex.NewSQLIndexBuilder(sqlIndexer)
+		indexers = append(indexers, sqlIndexBuilder)
+	}
+
 	// create BlockDAO
-	var dao blockdao.BlockDAO
+	var (
+		dao        blockdao.BlockDAO
+		chainDBCfg config.DB
+	)
 	if ops.isTesting {
 		dao = blockdao.NewBlockDAOInMemForTest(indexers)
 	} else {
 		cfg.DB.DbPath = cfg.Chain.ChainDBPath
 		cfg.DB.CompressLegacy = cfg.Chain.CompressBlock
+		chainDBCfg = cfg.DB
 		dao = blockdao.NewBlockDAO(indexers, cfg.DB)
 	}
+	if sqlIndexBuilder != nil {
+		sqlIndexBuilder.SetBlockDAO(dao)
+	}
 
 	// Create ActPool
 	actOpts := make([]actpool.Option, 0)
+	if cfg.ActPool.JournalPath != "" && !ops.isTesting {
+		cfg.DB.DbPath = cfg.ActPool.JournalPath
+		actOpts = append(actOpts, actpool.WithJournal(db.NewOnDiskDB(cfg.DB)))
+	}
 	actPool, err := actpool.NewActPool(sf, cfg.ActPool, actOpts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create actpool")
@@ -216,7 +284,11 @@ func New(
 
 	// Add action validators
 	actPool.AddActionEnvelopeValidators(
-		protocol.NewGenericValidator(sf, accountutil.AccountState),
+		protocol.NewGenericValidator(
+			sf,
+			accountutil.AccountState,
+			protocol.EthTxOption(cfg.Genesis.IcelandBlockHeight, cfg.Chain.EVMNetworkID),
+		),
 	)
 	if !ops.isSubchain {
 		chainOpts = append(chainOpts, blockchain.BlockValidatorOption(block.NewValidator(sf, actPool)))
@@ -224,6 +296,12 @@ func New(
 		chainOpts = append(chainOpts, blockchain.BlockValidatorOption(sf))
 	}
 
+	producerSigner, err := signer.NewSigner(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create producer signer")
+	}
+	chainOpts = append(chainOpts, blockchain.SignerOption(producerSigner))
+
 	// create Blockchain
 	chain := blockchain.NewBlockchain(cfg, dao, factory.NewMinter(sf, actPool), chainOpts...)
 	if chain == nil {
@@ -246,6 +324,7 @@ func New(
 		consensus.WithBroadcast(func(msg proto.Message) error {
 			return p2pAgent.BroadcastOutbound(p2p.WitContext(context.Background(), p2p.Context{ChainID: chain.ChainID()}), msg)
 		}),
+		consensus.WithSigner(producerSigner),
 	}
 	var (
 		rDPoSProtocol   *rolldpos.Protocol
@@ -311,6 +390,7 @@ func New(
 				return blockchain.Productivity(chain, start, end)
 			},
 			dao.GetBlockHash,
+			rewarding.DepositGas,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to generate poll protocol")
@@ -339,11 +419,23 @@ func New(
 			return p2pAgent.UnicastOutbound(ctx, peer, msg)
 		}),
 		blocksync.WithNeighbors(p2pAgent.Neighbors),
+		blocksync.WithSnapshotStore(sf),
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create blockSyncer")
 	}
 
+	apiOpts := []api.Option{
+		api.WithBroadcastOutbound(func(ctx context.Context, chainID uint32, msg proto.Message) error {
+			ctx = p2p.WitContext(ctx, p2p.Context{ChainID: chainID})
+			return p2pAgent.BroadcastOutbound(ctx, msg)
+		}),
+		api.WithNativeElection(electionCommittee),
+		api.WithEvidenceReader(consensus),
+	}
+	if cfg.API.EnableConsensusRoundAPI {
+		apiOpts = append(apiOpts, api.WithRoundInspector(consensus))
+	}
 	var apiSvr *api.Server
 	apiSvr, err = api.NewServer(
 		cfg,
@@ -354,15 +446,15 @@ func New(
 		bfIndexer,
 		actPool,
 		registry,
-		api.WithBroadcastOutbound(func(ctx context.Context, chainID uint32, msg proto.Message) error {
-			ctx = p2p.WitContext(ctx, p2p.Context{ChainID: chainID})
-			return p2pAgent.BroadcastOutbound(ctx, msg)
-		}),
-		api.WithNativeElection(electionCommittee),
+		apiOpts...,
 	)
 	if err != nil {
 		return nil, err
 	}
+	var web3Svr *web3.Server
+	if cfg.API.Web3Port != 0 {
+		web3Svr = web3.NewServer(cfg, apiSvr)
+	}
 	accountProtocol := account.NewProtocol(rewarding.DepositGas)
 	if accountProtocol != nil {
 		if err = accountProtocol.Register(registry); err != nil {
@@ -404,10 +496,65 @@ func New(
 		candidateIndexer:   candidateIndexer,
 		candBucketsIndexer: candBucketsIndexer,
 		api:                apiSvr,
+		web3:               web3Svr,
 		registry:           registry,
+		signer:             producerSigner,
+		snapshotSync:       snapshotSync,
+		chainDBCfg:         chainDBCfg,
+		trieDBPath:         cfg.Chain.TrieDBPath,
+		stateRebuildCfg:    stateRebuildCfg,
 	}, nil
 }
 
+// rebuildIndexers drops and rebuilds the indexers asked by the rebuild-indexer flag from the chain db, and resumes
+// the rebuilds interrupted at the last startup. It returns true if an indexer built from the states is rebuilt, which
+// is done as the state factory replays the blocks at start
+func rebuildIndexers(cfg config.Config, gateway bool) (bool, error) {
+	if !gateway {
+		if len(cfg.Chain.RebuildIndexers) > 0 {
+			return false, errors.New("indexers are only built by the gateway plugin")
+		}
+		return false, nil
+	}
+	names := blockindex.PendingRebuilds(cfg)
+	for _, name := range cfg.Chain.RebuildIndexers {
+		pending := false
+		for _, n := range names {
+			pending = pending || n == name
+		}
+		if !pending {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return false, nil
+	}
+
+	cfg.DB.DbPath = cfg.Chain.ChainDBPath
+	cfg.DB.CompressLegacy = cfg.Chain.CompressBlock
+	dao, err := filedao.NewFileDAO(cfg.DB)
+	if err != nil {
+		return false, err
+	}
+	ctx := context.Background()
+	if err := dao.Start(ctx); err != nil {
+		return false, err
+	}
+	defer dao.Stop(ctx)
+	rebuilder, err := blockindex.NewRebuilder(dao, cfg, blockindex.WithRebuildWorkers(runtime.NumCPU()))
+	if err != nil {
+		return false, err
+	}
+	var stateRebuild bool
+	for _, name := range names {
+		if err := rebuilder.Rebuild(ctx, name); err != nil {
+			return false, errors.Wrapf(err, "failed to rebuild indexer %s", name)
+		}
+		stateRebuild = stateRebuild || name == blockindex.IndexerCandidate || name == blockindex.IndexerStaking
+	}
+	return stateRebuild, nil
+}
+
 // Start starts the server
 func (cs *ChainService) Start(ctx context.Context) error {
 	if cs.electionCommittee != nil {
@@ -425,9 +572,23 @@ func (cs *ChainService) Start(ctx context.Context) error {
 			return errors.Wrap(err, "error when starting staking candidates indexer")
 		}
 	}
+	if cs.snapshotSync {
+		if err := cs.syncSnapshot(ctx); err != nil {
+			return errors.Wrap(err, "error when syncing state snapshot")
+		}
+	}
 	if err := cs.chain.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting blockchain")
 	}
+	if cs.stateRebuildCfg != nil {
+		// the state factory has replayed the blocks and rebuilt the indexers on start
+		if err := blockindex.FinishStateRebuilds(*cs.stateRebuildCfg); err != nil {
+			return err
+		}
+	}
+	if err := cs.actpool.Start(protocol.WithRegistry(ctx, cs.registry)); err != nil {
+		return errors.Wrap(err, "error when starting actpool")
+	}
 	if err := cs.consensus.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting consensus")
 	}
@@ -445,10 +606,39 @@ func (cs *ChainService) Start(ctx context.Context) error {
 			return errors.Wrap(err, "err when starting API server")
 		}
 	}
+	if cs.web3 != nil {
+		if err := cs.web3.Start(); err != nil {
+			return errors.Wrap(err, "err when starting web3 server")
+		}
+	}
 
 	return nil
 }
 
+// syncSnapshot imports the state snapshot at the trusted checkpoint and the block at its height, from which the chain
+// continues. The node fails to start if no peer serves the snapshot, rather than silently syncing from genesis
+func (cs *ChainService) syncSnapshot(ctx context.Context) error {
+	blk, err := cs.blocksync.SyncSnapshot(ctx)
+	if err == nil {
+		err = filedao.ImportBottomBlock(cs.chainDBCfg, blk)
+	}
+	if err != nil {
+		cs.removeSnapshot()
+		return err
+	}
+	log.L().Info("Synced state snapshot.", zap.Uint64("height", blk.Height()))
+	return nil
+}
+
+// removeSnapshot removes the imported data, so that the node bootstraps again on the next start
+func (cs *ChainService) removeSnapshot() {
+	for _, path := range []string{cs.trieDBPath, cs.chainDBCfg.DbPath} {
+		if e := os.RemoveAll(path); e != nil {
+			log.L().Error("Failed to remove db file.", zap.String("path", path), zap.Error(e))
+		}
+	}
+}
+
 // Stop stops the server
 func (cs *ChainService) Stop(ctx context.Context) error {
 	if cs.indexBuilder != nil {
@@ -459,6 +649,11 @@ func (cs *ChainService) Stop(ctx context.Context) error {
 			return errors.Wrap(err, "error when stopping index builder")
 		}
 	}
+	if cs.web3 != nil {
+		if err := cs.web3.Stop(); err != nil {
+			return errors.Wrap(err, "error when stopping web3 server")
+		}
+	}
 	// TODO: explorer dependency deleted at #1085, need to revive by migrating to api
 	if cs.api != nil {
 		if err := cs.api.Stop(); err != nil {
@@ -471,9 +666,17 @@ func (cs *ChainService) Stop(ctx context.Context) error {
 	if err := cs.blocksync.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping blocksync")
 	}
+	if err := cs.actpool.Stop(ctx); err != nil {
+		return errors.Wrap(err, "error when stopping actpool")
+	}
 	if err := cs.chain.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping blockchain")
 	}
+	if closer, ok := cs.signer.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return errors.Wrap(err, "error when closing producer signer")
+		}
+	}
 	if cs.candidateIndexer != nil {
 		if err := cs.candidateIndexer.Stop(ctx); err != nil {
 			return errors.Wrap(err, "error when stopping candidate indexer")
@@ -527,6 +730,26 @@ func (cs *ChainService) HandleSyncRequest(ctx context.Context, peer peerstore.Pe
 	return cs.blocksync.ProcessSyncRequest(ctx, peer, sync)
 }
 
+// HandleHeadersRequest handles incoming block headers request.
+func (cs *ChainService) HandleHeadersRequest(ctx context.Context, peer peerstore.PeerInfo, req *blocksyncpb.HeadersRequest) error {
+	return cs.blocksync.ProcessHeadersRequest(ctx, peer, req)
+}
+
+// HandleHeaders handles incoming block headers.
+func (cs *ChainService) HandleHeaders(ctx context.Context, peer peerstore.PeerInfo, resp *blocksyncpb.HeadersResponse) error {
+	return cs.blocksync.ProcessHeaders(ctx, peer, resp)
+}
+
+// HandleSnapshotRequest handles incoming state snapshot request.
+func (cs *ChainService) HandleSnapshotRequest(ctx context.Context, peer peerstore.PeerInfo, req *snapshotpb.Request) error {
+	return cs.blocksync.ProcessSnapshotRequest(ctx, peer, req)
+}
+
+// HandleSnapshotResponse handles incoming state snapshot response.
+func (cs *ChainService) HandleSnapshotResponse(ctx context.Context, peer peerstore.PeerInfo, msg proto.Message) error {
+	return cs.blocksync.ProcessSnapshotResponse(ctx, peer, msg)
+}
+
 // HandleConsensusMsg handles incoming consensus message.
 func (cs *ChainService) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 	return cs.consensus.HandleConsensusMsg(msg)
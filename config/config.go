@@ -8,6 +8,7 @@ package config
 
 import (
 	"crypto/ecdsa"
+	"encoding/hex"
 	"flag"
 	"math/big"
 	"os"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/iotexproject/go-p2p"
 	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-election/committee"
 	"github.com/pkg/errors"
 	uconfig "go.uber.org/config"
@@ -23,6 +25,7 @@ import (
 
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/compress"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/unit"
 )
@@ -35,6 +38,10 @@ func init() {
 	flag.StringVar(&_secretPath, "secret-path", "", "Secret path")
 	flag.StringVar(&_subChainPath, "sub-config-path", "", "Sub chain Config path")
 	flag.Var(&_plugins, "plugin", "Plugin of the node")
+	flag.Var(&_rebuildIndexers, "rebuild-indexer", "Indexer to drop and rebuild from the blocks at startup. The "+
+		"candidate and staking indexers are rebuilt from the states, which requires -drop-state-db")
+	flag.BoolVar(&_dropStateDB, "drop-state-db", false, "Drop the state DB (trie.db) to rebuild the candidate and "+
+		"staking indexers, and replay all the blocks from genesis at startup")
 }
 
 var (
@@ -44,6 +51,10 @@ var (
 	_secretPath   string
 	_subChainPath string
 	_plugins      strs
+	// rebuildIndexers is the indexers to drop and rebuild at startup
+	_rebuildIndexers strs
+	// dropStateDB allows dropping the state DB to rebuild the indexers built from the states
+	_dropStateDB bool
 )
 
 const (
@@ -55,6 +66,22 @@ const (
 	NOOPScheme = "NOOP"
 )
 
+const (
+	// BoltDBEngine is the B+tree KV engine of the on-disk DBs
+	BoltDBEngine = "bolt"
+	// LevelDBEngine is the LSM-tree KV engine of the on-disk DBs
+	LevelDBEngine = "leveldb"
+)
+
+const (
+	// LocalSigner signs with the producer private key of the chain config
+	LocalSigner = "local"
+	// KeystoreSigner signs with the producer private key decrypted from a keystore file
+	KeystoreSigner = "keystore"
+	// RemoteSigner signs through a remote signer, which holds the producer private key
+	RemoteSigner = "remote"
+)
+
 const (
 	// GatewayPlugin is the plugin of accepting user API requests and serving blockchain data to users
 	GatewayPlugin = iota
@@ -110,20 +137,26 @@ var (
 			CandidateIndexDBPath:   "/var/data/candidate.index.db",
 			StakingIndexDBPath:     "/var/data/staking.index.db",
 			ID:                     1,
+			EVMNetworkID:           4689,
 			Address:                "",
 			ProducerPrivKey:        generateRandomKey(SigP256k1),
 			SignatureScheme:        []string{SigP256k1},
 			EmptyGenesis:           false,
-			GravityChainDB:         DB{DbPath: "/var/data/poll.db", NumRetries: 10},
+			GravityChainDB:         DB{DbPath: "/var/data/poll.db", NumRetries: 10, KVEngineByPath: map[string]string{}},
 			Committee: committee.Config{
 				GravityChainAPIs: []string{},
 			},
+			ProducerSigner: Signer{
+				Type:    LocalSigner,
+				Timeout: 2 * time.Second,
+			},
 			EnableTrielessStateDB:         true,
 			EnableStateDBCaching:          false,
 			EnableAsyncIndexWrite:         true,
 			EnableSystemLogIndexer:        false,
 			EnableStakingProtocol:         true,
 			EnableStakingIndexer:          false,
+			EnableSQLIndexer:              false,
 			CompressBlock:                 false,
 			AllowedBlockGasResidue:        10000,
 			MaxCacheSize:                  0,
@@ -132,14 +165,33 @@ var (
 			WorkingSetCacheSize:           20,
 			EnableArchiveMode:             false,
 			RangeBloomFilterSize:          4096,
+			SnapshotDBPath:                "/var/data/snapshot.db",
+			SnapshotInterval:              0,
+			SnapshotChunkSize:             1 << 20,
 		},
 		ActPool: ActPool{
-			MaxNumActsPerPool:  32000,
-			MaxGasLimitPerPool: 320000000,
-			MaxNumActsPerAcct:  2000,
-			ActionExpiry:       10 * time.Minute,
-			MinGasPriceStr:     big.NewInt(unit.Qev).String(),
-			BlackList:          []string{},
+			MaxNumActsPerPool:    32000,
+			MaxGasLimitPerPool:   320000000,
+			MaxNumActsPerAcct:    2000,
+			ActionExpiry:         10 * time.Minute,
+			MinGasPriceStr:       big.NewInt(unit.Qev).String(),
+			BlackList:            []string{},
+			ReplaceGasPriceBump:  10,
+			EnableEviction:       false,
+			JournalFlushInterval: time.Second,
+			PackingPolicy:        "gasPrice",
+			RateLimit: ActPoolRateLimit{
+				Enabled:          false,
+				SenderBurst:      100,
+				SenderRefillRate: 10,
+				PeerBurst:        1000,
+				PeerRefillRate:   100,
+				GlobalBurst:      0,
+				GlobalRefillRate: 0,
+				CacheSize:        10000,
+				BanThreshold:     3,
+				BanDuration:      15 * time.Minute,
+			},
 		},
 		Consensus: Consensus{
 			Scheme: StandaloneScheme,
@@ -153,9 +205,11 @@ var (
 					CommitTTL:                    2 * time.Second,
 					EventChanSize:                10000,
 				},
-				ToleratedOvertime: 2 * time.Second,
-				Delay:             5 * time.Second,
-				ConsensusDBPath:   "/var/data/consensus.db",
+				ToleratedOvertime:     2 * time.Second,
+				Delay:                 5 * time.Second,
+				ConsensusDBPath:       "/var/data/consensus.db",
+				FlightRecorderMaxSize: 64 * 1024 * 1024,
+				RoundHistorySize:      100,
 			},
 		},
 		BlockSync: BlockSync{
@@ -165,6 +219,9 @@ var (
 			IntervalSize:          20,
 			MaxRepeat:             3,
 			RepeatDecayStep:       1,
+			MaxPeerFailures:       3,
+			PeerDemotionDuration:  time.Minute,
+			EnableSnapshotSync:    false,
 		},
 		Dispatcher: Dispatcher{
 			EventChanSize: 10000,
@@ -177,8 +234,15 @@ var (
 				SuggestBlockWindow: 20,
 				DefaultGas:         uint64(unit.Qev),
 				Percentile:         60,
+				SlowPercentile:     20,
+				FastPercentile:     90,
+				FeeHistoryWindow:   1024,
 			},
-			RangeQueryLimit: 1000,
+			RangeQueryLimit:         1000,
+			EnableConsensusRoundAPI: false,
+			EnableDebugAPI:          false,
+			TraceLimit:              10000,
+			TraceGasLimit:           5000000,
 		},
 		System: System{
 			Active:                true,
@@ -195,6 +259,8 @@ var (
 			V2BlocksToSplitDB:   1000000,
 			Compressor:          "Snappy",
 			CompressLegacy:      false,
+			KVEngine:            BoltDBEngine,
+			KVEngineByPath:      map[string]string{},
 			SQLITE3: SQLITE3{
 				SQLite3File: "./explorer.db",
 			},
@@ -212,10 +278,15 @@ var (
 	Validates = []Validate{
 		ValidateRollDPoS,
 		ValidateArchiveMode,
+		ValidateSnapshotSync,
+		ValidateBlockDataRetention,
+		ValidateCompressor,
+		ValidateKVEngine,
 		ValidateDispatcher,
 		ValidateAPI,
 		ValidateActPool,
 		ValidateForkHeights,
+		ValidateSigner,
 	}
 )
 
@@ -247,11 +318,15 @@ type (
 		ID                     uint32           `yaml:"id"`
 		Address                string           `yaml:"address"`
 		ProducerPrivKey        string           `yaml:"producerPrivKey"`
+		ProducerSigner         Signer           `yaml:"producerSigner"`
 		SignatureScheme        []string         `yaml:"signatureScheme"`
 		EmptyGenesis           bool             `yaml:"emptyGenesis"`
 		GravityChainDB         DB               `yaml:"gravityChainDB"`
 		Committee              committee.Config `yaml:"committee"`
 
+		// EVMNetworkID is the chain ID presented to Ethereum tools through the web3 JSON-RPC gateway
+		EVMNetworkID uint32 `yaml:"evmNetworkID"`
+
 		EnableTrielessStateDB bool `yaml:"enableTrielessStateDB"`
 		// EnableStateDBCaching enables cachedStateDBOption
 		EnableStateDBCaching bool `yaml:"enableStateDBCaching"`
@@ -265,6 +340,10 @@ type (
 		EnableStakingProtocol bool `yaml:"enableStakingProtocol"`
 		// EnableStakingIndexer enables staking indexer
 		EnableStakingIndexer bool `yaml:"enableStakingIndexer"`
+		// EnableSQLIndexer enables writing blocks, actions, receipts, transfers, staking bucket changes and reward
+		// grants into the SQL database of DB.SQL, or DB.RDS if API.UseRDS is set, or else DB.SQLITE3. The blocks are
+		// written in the background after they are committed, and caught up once the database is available again
+		EnableSQLIndexer bool `yaml:"enableSQLIndexer"`
 		// deprecated by DB.CompressBlock
 		CompressBlock bool `yaml:"compressBlock"`
 		// AllowedBlockGasResidue is the amount of gas remained when block producer could stop processing more actions
@@ -279,6 +358,43 @@ type (
 		WorkingSetCacheSize uint64 `yaml:"workingSetCacheSize"`
 		// RangeBloomFilterSize is the number of blocks that rangeBloomfilter will store in bloomfilterIndexer
 		RangeBloomFilterSize uint64 `yaml:"rangeBloomFilterSize"`
+		// SnapshotDBPath is the path of the db storing the latest state snapshot served to the peers
+		SnapshotDBPath string `yaml:"snapshotDBPath"`
+		// SnapshotInterval is the number of epochs between two state snapshots served to the peers, the snapshot is
+		// taken at the last block of the epoch. 0 means disabled. It is only meaningful when EnableTrielessStateDB is false
+		SnapshotInterval uint64 `yaml:"snapshotInterval"`
+		// SnapshotChunkSize is the max size in bytes of a chunk of the state snapshot
+		SnapshotChunkSize int `yaml:"snapshotChunkSize"`
+		// RebuildIndexers is the indexers to drop and rebuild from the blocks at startup, which is set by the
+		// rebuild-indexer flag only, so the indexers are not dropped again at the next startup
+		RebuildIndexers []string `yaml:"-"`
+		// DropStateDB allows the rebuild of the candidate and staking indexers to drop the state DB and replay all
+		// the blocks from genesis, which is set by the drop-state-db flag only
+		DropStateDB bool `yaml:"-"`
+	}
+
+	// Signer is the config struct of the signer of the block producer, which signs the blocks, the consensus
+	// endorsements and the system actions
+	Signer struct {
+		// Type is the type of the signer, which is LocalSigner, KeystoreSigner, or RemoteSigner
+		Type string `yaml:"type"`
+		// PublicKey is the hex encoded public key of the producer, which is required if the private key is not in
+		// the chain config
+		PublicKey string `yaml:"publicKey"`
+		// KeystorePath is the encrypted keystore file of the producer private key
+		KeystorePath string `yaml:"keystorePath"`
+		// KeystorePassword is the password of the keystore file, which should be set in the secret config, or
+		// expanded from an environment variable
+		KeystorePassword string `yaml:"keystorePassword"`
+		// Endpoint is the gRPC endpoint of the remote signer
+		Endpoint string `yaml:"endpoint"`
+		// CertPath and KeyPath are the client certificate and key authenticating the node to the remote signer
+		CertPath string `yaml:"certPath"`
+		KeyPath  string `yaml:"keyPath"`
+		// CACertPath is the CA certificate verifying the certificate of the remote signer
+		CACertPath string `yaml:"caCertPath"`
+		// Timeout is the timeout of a request to the remote signer
+		Timeout time.Duration `yaml:"timeout"`
 	}
 
 	// Consensus is the config struct for consensus package
@@ -298,6 +414,25 @@ type (
 		MaxRepeat int `yaml:"maxRepeat"`
 		// RepeatDecayStep is the step for repeat number decreasing by 1
 		RepeatDecayStep int `yaml:"repeatDecayStep"`
+		// MaxPeerFailures is the number of timed out requests after which a peer is demoted
+		MaxPeerFailures int `yaml:"maxPeerFailures"`
+		// PeerDemotionDuration is the duration in which a demoted peer is not asked for blocks
+		PeerDemotionDuration time.Duration `yaml:"peerDemotionDuration"`
+		// EnableSnapshotSync makes a new node bootstrap from the state snapshot at the trusted checkpoint served by the
+		// peers, instead of replaying the blocks from genesis
+		EnableSnapshotSync bool `yaml:"enableSnapshotSync"`
+		// SnapshotCheckpoint is the snapshot trusted by the operator, which is the only one a new node imports
+		SnapshotCheckpoint SnapshotCheckpoint `yaml:"snapshotCheckpoint"`
+	}
+
+	// SnapshotCheckpoint identifies a state snapshot by the block at its height and the root hash of the state trie.
+	// Neither the peers nor the block header commit to the state root, so it has to be obtained from a trusted source
+	SnapshotCheckpoint struct {
+		Height uint64 `yaml:"height"`
+		// BlockHash is the hex encoded hash of the block at the height
+		BlockHash string `yaml:"blockHash"`
+		// StateRoot is the hex encoded root hash of the state trie at the height
+		StateRoot string `yaml:"stateRoot"`
 	}
 
 	// RollDPoS is the config struct for RollDPoS consensus package
@@ -306,6 +441,16 @@ type (
 		ToleratedOvertime time.Duration   `yaml:"toleratedOvertime"`
 		Delay             time.Duration   `yaml:"delay"`
 		ConsensusDBPath   string          `yaml:"consensusDBPath"`
+		// FlightRecorderPath is the file to record the consensus events handled, which is disabled if empty
+		FlightRecorderPath string `yaml:"flightRecorderPath"`
+		// FlightRecorderMaxSize is the max size in bytes of the flight recorder file, which is rotated to the file with
+		// suffix .1 once the size is reached
+		FlightRecorderMaxSize int64 `yaml:"flightRecorderMaxSize"`
+		// RoundHistorySize is the number of the last finished rounds kept for inspection
+		RoundHistorySize int `yaml:"roundHistorySize"`
+		// EvidenceDBPath is the db to store the evidence of the delegates signing conflicting consensus messages,
+		// which are only logged if it is empty
+		EvidenceDBPath string `yaml:"evidenceDBPath"`
 	}
 
 	// ConsensusTiming defines a set of time durations used in fsm and event queue size
@@ -332,6 +477,16 @@ type (
 		TpsWindow       int        `yaml:"tpsWindow"`
 		GasStation      GasStation `yaml:"gasStation"`
 		RangeQueryLimit uint64     `yaml:"rangeQueryLimit"`
+		// Web3Port is the port of the Ethereum-compatible JSON-RPC gateway serving HTTP and WebSocket. 0 means disabled
+		Web3Port int `yaml:"web3Port"`
+		// EnableConsensusRoundAPI enables the live consensus rounds on the API, which are always served on the admin port
+		EnableConsensusRoundAPI bool `yaml:"enableConsensusRoundAPI"`
+		// EnableDebugAPI enables the debug API tracing the executions, which re-executes the contracts on the node
+		EnableDebugAPI bool `yaml:"enableDebugAPI"`
+		// TraceLimit is the max number of struct logs of a trace
+		TraceLimit uint64 `yaml:"traceLimit"`
+		// TraceGasLimit is the max gas of a traced call
+		TraceGasLimit uint64 `yaml:"traceGasLimit"`
 	}
 
 	// GasStation is the gas station config
@@ -339,6 +494,12 @@ type (
 		SuggestBlockWindow int    `yaml:"suggestBlockWindow"`
 		DefaultGas         uint64 `yaml:"defaultGas"`
 		Percentile         int    `yaml:"Percentile"`
+		// SlowPercentile is the percentile of the suggested gas price for slow tier, and Percentile is for standard tier
+		SlowPercentile int `yaml:"slowPercentile"`
+		// FastPercentile is the percentile of the suggested gas price for fast tier
+		FastPercentile int `yaml:"fastPercentile"`
+		// FeeHistoryWindow is the number of recent blocks whose gas price summaries are indexed for fee history
+		FeeHistoryWindow int `yaml:"feeHistoryWindow"`
 	}
 
 	// System is the system config
@@ -352,6 +513,9 @@ type (
 		HTTPStatsPort         int           `yaml:"httpStatsPort"`
 		StartSubChainInterval time.Duration `yaml:"startSubChainInterval"`
 		SystemLogDBPath       string        `yaml:"systemLogDBPath"`
+		// HTTPAdminToken is the bearer token required by the admin requests changing the node state, such as lifting
+		// actpool bans. Such requests are rejected if it is empty
+		HTTPAdminToken string `yaml:"httpAdminToken"`
 	}
 
 	// ActPool is the actpool config
@@ -368,6 +532,52 @@ type (
 		MinGasPriceStr string `yaml:"minGasPrice"`
 		// BlackList lists the account address that are banned from initiating actions
 		BlackList []string `yaml:"blackList"`
+		// ReplaceGasPriceBump is the minimal percentage by which the gas price of an action must exceed that of the
+		// pending action with the same nonce to replace it. 0 means replacement is disabled
+		ReplaceGasPriceBump uint64 `yaml:"replaceGasPriceBump"`
+		// EnableEviction enables evicting the lowest-priced actions to make room for new ones when the pool is full
+		EnableEviction bool `yaml:"enableEviction"`
+		// JournalPath is the path of the db recording accepted actions, which are replayed upon restart. Empty means
+		// journal is disabled
+		JournalPath string `yaml:"journalPath"`
+		// JournalFlushInterval is the interval of writing the actions staged in journal to disk. 0 means the actions
+		// are only written when the pool stops
+		JournalFlushInterval time.Duration `yaml:"journalFlushInterval"`
+		// PackingPolicy decides the order in which pending actions are packed into a block, which is one of
+		// "gasPrice", "feePerGas" and "firstSeen"
+		PackingPolicy string `yaml:"packingPolicy"`
+		// MaxActsPerSenderInBlock caps the number of actions of each sender packed into a block by "feePerGas" policy.
+		// 0 means no cap
+		MaxActsPerSenderInBlock uint64 `yaml:"maxActsPerSenderInBlock"`
+		// ReservedSystemActionGas is the gas of a block kept for the system actions created by the block producer,
+		// which the pending actions cannot use. 0 means no reservation beyond the intrinsic gas of the system actions
+		ReservedSystemActionGas uint64 `yaml:"reservedSystemActionGas"`
+		// RateLimit is the config of rate limiting the actions added into actpool
+		RateLimit ActPoolRateLimit `yaml:"rateLimit"`
+	}
+
+	// ActPoolRateLimit is the config of the token buckets limiting the actions from each sender, each peer and in total
+	ActPoolRateLimit struct {
+		// Enabled enables rate limiting
+		Enabled bool `yaml:"enabled"`
+		// SenderBurst is the maximum number of actions a sender can submit at once. 0 means no limit per sender
+		SenderBurst uint64 `yaml:"senderBurst"`
+		// SenderRefillRate is the number of actions refilled into a sender's bucket per second
+		SenderRefillRate float64 `yaml:"senderRefillRate"`
+		// PeerBurst is the maximum number of actions a peer can relay at once. 0 means no limit per peer
+		PeerBurst uint64 `yaml:"peerBurst"`
+		// PeerRefillRate is the number of actions refilled into a peer's bucket per second
+		PeerRefillRate float64 `yaml:"peerRefillRate"`
+		// GlobalBurst is the maximum number of actions the pool accepts at once. 0 means no global limit
+		GlobalBurst uint64 `yaml:"globalBurst"`
+		// GlobalRefillRate is the number of actions refilled into the global bucket per second
+		GlobalRefillRate float64 `yaml:"globalRefillRate"`
+		// CacheSize is the maximum number of senders and peers tracked respectively
+		CacheSize int `yaml:"cacheSize"`
+		// BanThreshold is the number of times a sender or peer exceeds its limit before being banned
+		BanThreshold int `yaml:"banThreshold"`
+		// BanDuration is how long a sender or peer is banned
+		BanDuration time.Duration `yaml:"banDuration"`
 	}
 
 	// DB is the config for database
@@ -383,18 +593,34 @@ type (
 		V2BlocksToSplitDB uint64 `yaml:"v2BlocksToSplitDB"`
 		// Compressor is the compression used on block data, used by new DB file after v1.1.2
 		Compressor string `yaml:"compressor"`
+		// CompressorDict is the path of the zstd dictionary trained on block data, used by new DB file with Zstd
+		// compressor. The dictionary is kept in the header of the DB file
+		CompressorDict string `yaml:"compressorDict"`
 		// CompressLegacy enables gzip compression on block data, used by legacy DB file before v1.1.2
 		CompressLegacy bool `yaml:"compressLegacy"`
 		// RDS is the config for rds
 		RDS RDS `yaml:"RDS"`
 		// SQLite3 is the config for SQLITE3
 		SQLITE3 SQLITE3 `yaml:"SQLITE3"`
+		// SQL is the config for a SQL database of any registered database/sql driver
+		SQL SQL `yaml:"SQL"`
 		// SplitDBSize is the config for DB's split file size
 		SplitDBSizeMB uint64 `yaml:"splitDBSizeMB"`
 		// SplitDBHeight is the config for DB's split start height
 		SplitDBHeight uint64 `yaml:"splitDBHeight"`
 		// HistoryStateRetention is the number of blocks account/contract state will be retained
 		HistoryStateRetention uint64 `yaml:"historyStateRetention"`
+		// BlockDataRetention is the number of blocks whose bodies, receipts and transaction logs will be retained. The
+		// v2 split files and legacy segments below them are pruned, while the headers and footers are kept. 0 means all
+		// the blocks are retained
+		BlockDataRetention uint64 `yaml:"blockDataRetention"`
+		// ReadOnly opens the DB files in read-only mode. The bolt DB files of a running node are locked, so they have
+		// to be read from a filesystem snapshot of the data directory, e.g. an LVM or ZFS snapshot
+		ReadOnly bool `yaml:"readOnly"`
+		// KVEngine is the engine of the on-disk KV stores, except the chain db files which are always bolt DB
+		KVEngine string `yaml:"kvEngine"`
+		// KVEngineByPath overrides KVEngine for the KV store at the given db path
+		KVEngineByPath map[string]string `yaml:"kvEngineByPath"`
 	}
 
 	// RDS is the cloud rds config
@@ -417,6 +643,14 @@ type (
 		SQLite3File string `yaml:"sqlite3File"`
 	}
 
+	// SQL is the config of a SQL database accessed by a registered database/sql driver
+	SQL struct {
+		// Driver is the name of the database/sql driver, such as mysql. Empty means disabled
+		Driver string `yaml:"driver"`
+		// DataSource is the driver specific data source name
+		DataSource string `yaml:"dataSource"`
+	}
+
 	// Config is the root config struct, each package's config should be put as its sub struct
 	Config struct {
 		Plugins    map[int]interface{}         `ymal:"plugins"`
@@ -443,6 +677,17 @@ func (db DB) SplitDBSize() uint64 {
 	return db.SplitDBSizeMB * 1024 * 1024
 }
 
+// KVEngineOfPath returns the KV engine selected for DbPath
+func (db DB) KVEngineOfPath() string {
+	if engine, ok := db.KVEngineByPath[db.DbPath]; ok {
+		return engine
+	}
+	if db.KVEngine == "" {
+		return BoltDBEngine
+	}
+	return db.KVEngine
+}
+
 // New creates a config instance. It first loads the default configs. If the config path is not empty, it will read from
 // the file and override the default configs. By default, it will apply all validation functions. To bypass validation,
 // use DoNotValidate instead.
@@ -481,6 +726,9 @@ func New(validates ...Validate) (Config, error) {
 		}
 	}
 
+	cfg.Chain.RebuildIndexers = _rebuildIndexers
+	cfg.Chain.DropStateDB = _dropStateDB
+
 	// By default, the config needs to pass all the validation
 	if len(validates) == 0 {
 		validates = Validates
@@ -515,6 +763,9 @@ func NewSub(validates ...Validate) (Config, error) {
 		return Config{}, errors.Wrap(err, "failed to unmarshal YAML config to struct")
 	}
 
+	cfg.Chain.RebuildIndexers = _rebuildIndexers
+	cfg.Chain.DropStateDB = _dropStateDB
+
 	// By default, the config needs to pass all the validation
 	if len(validates) == 0 {
 		validates = Validates
@@ -529,8 +780,7 @@ func NewSub(validates ...Validate) (Config, error) {
 
 // ProducerAddress returns the configured producer address derived from key
 func (cfg Config) ProducerAddress() address.Address {
-	sk := cfg.ProducerPrivateKey()
-	addr, err := address.FromBytes(sk.PublicKey().Hash())
+	addr, err := address.FromBytes(cfg.ProducerPublicKey().Hash())
 	if err != nil {
 		log.L().Panic(
 			"Error when constructing producer address",
@@ -540,6 +790,22 @@ func (cfg Config) ProducerAddress() address.Address {
 	return addr
 }
 
+// ProducerPublicKey returns the configured public key, which is derived from the private key for the local signer
+func (cfg Config) ProducerPublicKey() crypto.PublicKey {
+	switch cfg.Chain.ProducerSigner.Type {
+	case "", LocalSigner:
+		return cfg.ProducerPrivateKey().PublicKey()
+	}
+	pk, err := crypto.HexStringToPublicKey(cfg.Chain.ProducerSigner.PublicKey)
+	if err != nil {
+		log.L().Panic(
+			"Error when decoding public key",
+			zap.Error(err),
+		)
+	}
+	return pk
+}
+
 // ProducerPrivateKey returns the configured private key
 func (cfg Config) ProducerPrivateKey() crypto.PrivateKey {
 	sk, err := crypto.HexStringToPrivateKey(cfg.Chain.ProducerPrivKey)
@@ -621,6 +887,35 @@ func ValidateRollDPoS(cfg Config) error {
 	return nil
 }
 
+// ValidateSigner validates the producer signer configs
+func ValidateSigner(cfg Config) error {
+	sc := cfg.Chain.ProducerSigner
+	switch sc.Type {
+	case "", LocalSigner:
+		return nil
+	case KeystoreSigner:
+		if sc.KeystorePath == "" {
+			return errors.Wrap(ErrInvalidCfg, "keystore path of the keystore signer is empty")
+		}
+	case RemoteSigner:
+		if sc.Endpoint == "" {
+			return errors.Wrap(ErrInvalidCfg, "endpoint of the remote signer is empty")
+		}
+		if sc.Timeout <= 0 {
+			return errors.Wrap(ErrInvalidCfg, "timeout of the remote signer should be greater than 0")
+		}
+		if sc.CertPath == "" || sc.KeyPath == "" || sc.CACertPath == "" {
+			return errors.Wrap(ErrInvalidCfg, "certificates of the remote signer are not set")
+		}
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unsupported signer type %s", sc.Type)
+	}
+	if _, err := crypto.HexStringToPublicKey(sc.PublicKey); err != nil {
+		return errors.Wrapf(ErrInvalidCfg, "invalid public key of the %s signer", sc.Type)
+	}
+	return nil
+}
+
 // ValidateArchiveMode validates the state factory setting
 func ValidateArchiveMode(cfg Config) error {
 	if !cfg.Chain.EnableArchiveMode || !cfg.Chain.EnableTrielessStateDB {
@@ -630,11 +925,76 @@ func ValidateArchiveMode(cfg Config) error {
 	return errors.Wrap(ErrInvalidCfg, "Archive mode is incompatible with trieless state DB")
 }
 
+// ValidateSnapshotSync validates the state snapshot sync configs
+func ValidateSnapshotSync(cfg Config) error {
+	if !cfg.BlockSync.EnableSnapshotSync {
+		return nil
+	}
+	if cfg.Chain.EnableTrielessStateDB {
+		return errors.Wrap(ErrInvalidCfg, "snapshot sync is incompatible with trieless state DB")
+	}
+	// the indexers of the gateway need all the blocks from genesis
+	if _, ok := cfg.Plugins[GatewayPlugin]; ok {
+		return errors.Wrap(ErrInvalidCfg, "snapshot sync is incompatible with gateway plugin")
+	}
+	checkpoint := cfg.BlockSync.SnapshotCheckpoint
+	if checkpoint.Height == 0 {
+		return errors.Wrap(ErrInvalidCfg, "snapshot sync requires a trusted checkpoint")
+	}
+	for _, h := range []string{checkpoint.BlockHash, checkpoint.StateRoot} {
+		if b, err := hex.DecodeString(h); err != nil || len(b) != len(hash.ZeroHash256) {
+			return errors.Wrapf(ErrInvalidCfg, "invalid hash %s of snapshot checkpoint", h)
+		}
+	}
+	return nil
+}
+
+// ValidateBlockDataRetention validates the block data pruning configs
+func ValidateBlockDataRetention(cfg Config) error {
+	if cfg.DB.BlockDataRetention > 0 && cfg.Chain.EnableArchiveMode {
+		return errors.Wrap(ErrInvalidCfg, "block data pruning is incompatible with archive mode")
+	}
+	return nil
+}
+
+// ValidateCompressor validates the block data compressor configs
+func ValidateCompressor(cfg Config) error {
+	if cfg.DB.Compressor != "" && !compress.IsSupported(cfg.DB.Compressor) {
+		return errors.Wrapf(ErrInvalidCfg, "unsupported compressor %s", cfg.DB.Compressor)
+	}
+	if cfg.DB.CompressorDict != "" && cfg.DB.Compressor != compress.Zstd {
+		return errors.Wrap(ErrInvalidCfg, "compressor dictionary is only supported by Zstd")
+	}
+	return nil
+}
+
+// ValidateKVEngine validates the KV engines of the on-disk DBs
+func ValidateKVEngine(cfg Config) error {
+	engines := []string{cfg.DB.KVEngine}
+	for _, engine := range cfg.DB.KVEngineByPath {
+		engines = append(engines, engine)
+	}
+	for _, engine := range engines {
+		switch engine {
+		case "", BoltDBEngine, LevelDBEngine:
+		default:
+			return errors.Wrapf(ErrInvalidCfg, "unsupported KV engine %s", engine)
+		}
+	}
+	if engine, ok := cfg.DB.KVEngineByPath[cfg.Chain.ChainDBPath]; ok && engine != BoltDBEngine {
+		return errors.Wrap(ErrInvalidCfg, "chain db files only support bolt DB")
+	}
+	return nil
+}
+
 // ValidateAPI validates the api configs
 func ValidateAPI(cfg Config) error {
 	if cfg.API.TpsWindow <= 0 {
 		return errors.Wrap(ErrInvalidCfg, "tps window is not a positive integer when the api is enabled")
 	}
+	if cfg.API.GasStation.FeeHistoryWindow <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "fee history window should be greater than 0")
+	}
 	return nil
 }
 
@@ -679,6 +1039,8 @@ func ValidateForkHeights(cfg Config) error {
 		return errors.Wrap(ErrInvalidCfg, "FairbankMigration is heigher than Fairbank")
 	case hu.FairbankBlockHeight() > hu.GreenlandBlockHeight():
 		return errors.Wrap(ErrInvalidCfg, "Fairbank is heigher than Greenland")
+	case hu.HawaiiBlockHeight() > hu.IcelandBlockHeight():
+		return errors.Wrap(ErrInvalidCfg, "Hawaii is heigher than Iceland")
 	}
 	return nil
 }
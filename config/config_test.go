@@ -253,6 +253,100 @@ func TestValidateArchiveMode(t *testing.T) {
 	require.NoError(t, errors.Cause(ValidateArchiveMode(cfg)))
 }
 
+func TestValidateAPI(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateAPI(cfg))
+	cfg.API.GasStation.FeeHistoryWindow = 0
+	require.EqualError(t, ValidateAPI(cfg), "fee history window should be greater than 0: invalid config value")
+}
+
+func TestValidateSigner(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateSigner(cfg))
+	cfg.Chain.ProducerSigner.Type = KeystoreSigner
+	require.EqualError(t, ValidateSigner(cfg), "keystore path of the keystore signer is empty: invalid config value")
+	cfg.Chain.ProducerSigner.KeystorePath = "producer.json"
+	require.EqualError(t, ValidateSigner(cfg), "invalid public key of the keystore signer: invalid config value")
+	sk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	cfg.Chain.ProducerSigner.PublicKey = sk.PublicKey().HexString()
+	require.NoError(t, ValidateSigner(cfg))
+	require.Equal(t, sk.PublicKey().Bytes(), cfg.ProducerPublicKey().Bytes())
+
+	cfg.Chain.ProducerSigner.Type = RemoteSigner
+	require.EqualError(t, ValidateSigner(cfg), "endpoint of the remote signer is empty: invalid config value")
+	cfg.Chain.ProducerSigner.Endpoint = "127.0.0.1:14016"
+	cfg.Chain.ProducerSigner.Timeout = 0
+	require.Equal(t, ErrInvalidCfg, errors.Cause(ValidateSigner(cfg)))
+	cfg.Chain.ProducerSigner.Timeout = Default.Chain.ProducerSigner.Timeout
+	require.EqualError(t, ValidateSigner(cfg), "certificates of the remote signer are not set: invalid config value")
+	cfg.Chain.ProducerSigner.CertPath = "node.crt"
+	cfg.Chain.ProducerSigner.KeyPath = "node.key"
+	cfg.Chain.ProducerSigner.CACertPath = "ca.crt"
+	require.NoError(t, ValidateSigner(cfg))
+
+	cfg.Chain.ProducerSigner.Type = "hsm"
+	require.EqualError(t, ValidateSigner(cfg), "unsupported signer type hsm: invalid config value")
+}
+
+func TestValidateSnapshotSync(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateSnapshotSync(cfg))
+	cfg.BlockSync.EnableSnapshotSync = true
+	require.EqualError(t, ValidateSnapshotSync(cfg), "snapshot sync is incompatible with trieless state DB: invalid config value")
+	cfg.Chain.EnableTrielessStateDB = false
+	require.EqualError(t, ValidateSnapshotSync(cfg), "snapshot sync requires a trusted checkpoint: invalid config value")
+	cfg.BlockSync.SnapshotCheckpoint = SnapshotCheckpoint{
+		Height:    100,
+		BlockHash: "9d2ce5b540faa0b473d1efca579498739e5f1bee7af7951d94e91c5c8ab8f601",
+		StateRoot: "256ba50f2120f533971874a19811fa72b6b73374a50daed0fbd8cfb8344c98d0",
+	}
+	require.NoError(t, ValidateSnapshotSync(cfg))
+	cfg.Plugins = map[int]interface{}{GatewayPlugin: nil}
+	require.EqualError(t, ValidateSnapshotSync(cfg), "snapshot sync is incompatible with gateway plugin: invalid config value")
+	cfg.Plugins = nil
+	cfg.BlockSync.SnapshotCheckpoint.StateRoot = "256ba50f"
+	require.Equal(t, ErrInvalidCfg, errors.Cause(ValidateSnapshotSync(cfg)))
+}
+
+func TestValidateBlockDataRetention(t *testing.T) {
+	cfg := Default
+	cfg.DB.BlockDataRetention = 1000
+	require.NoError(t, ValidateBlockDataRetention(cfg))
+	cfg.Chain.EnableArchiveMode = true
+	require.EqualError(t, ValidateBlockDataRetention(cfg), "block data pruning is incompatible with archive mode: invalid config value")
+	cfg.DB.BlockDataRetention = 0
+	require.NoError(t, ValidateBlockDataRetention(cfg))
+}
+
+func TestValidateCompressor(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateCompressor(cfg))
+	cfg.DB.Compressor = "Zstd"
+	cfg.DB.CompressorDict = "./blockstore.dict"
+	require.NoError(t, ValidateCompressor(cfg))
+	cfg.DB.Compressor = "Lz4"
+	require.EqualError(t, ValidateCompressor(cfg), "compressor dictionary is only supported by Zstd: invalid config value")
+	cfg.DB.Compressor = "Brotli"
+	require.EqualError(t, ValidateCompressor(cfg), "unsupported compressor Brotli: invalid config value")
+}
+
+func TestValidateKVEngine(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateKVEngine(cfg))
+	cfg.DB.KVEngine = LevelDBEngine
+	cfg.DB.KVEngineByPath = map[string]string{cfg.Chain.TrieDBPath: BoltDBEngine}
+	require.NoError(t, ValidateKVEngine(cfg))
+	cfg.DB.DbPath = cfg.Chain.TrieDBPath
+	require.Equal(t, BoltDBEngine, cfg.DB.KVEngineOfPath())
+	cfg.DB.DbPath = cfg.Chain.IndexDBPath
+	require.Equal(t, LevelDBEngine, cfg.DB.KVEngineOfPath())
+	cfg.DB.KVEngineByPath[cfg.Chain.ChainDBPath] = LevelDBEngine
+	require.EqualError(t, ValidateKVEngine(cfg), "chain db files only support bolt DB: invalid config value")
+	cfg.DB.KVEngine = "rocksdb"
+	require.EqualError(t, ValidateKVEngine(cfg), "unsupported KV engine rocksdb: invalid config value")
+}
+
 func TestValidateActPool(t *testing.T) {
 	cfg := Default
 	cfg.ActPool.MaxNumActsPerAcct = 0
@@ -19,10 +19,12 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
 	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
@@ -35,9 +37,12 @@ type Consensus interface {
 	HandleConsensusMsg(*iotextypes.ConsensusMessage) error
 	Calibrate(uint64)
 	ValidateBlockFooter(*block.Block) error
+	ValidateBlockProducer(*block.Header) error
 	Metrics() (scheme.ConsensusMetrics, error)
 	Activate(bool)
 	Active() bool
+	scheme.RoundInspector
+	scheme.EvidenceReader
 }
 
 // IotxConsensus implements Consensus
@@ -50,6 +55,7 @@ type optionParams struct {
 	broadcastHandler scheme.Broadcast
 	pp               poll.Protocol
 	rp               *rp.Protocol
+	signer           signer.Signer
 }
 
 // Option sets Consensus construction parameter.
@@ -79,6 +85,14 @@ func WithPollProtocol(pp poll.Protocol) Option {
 	}
 }
 
+// WithSigner is an option to sign the blocks and the consensus messages with the signer
+func WithSigner(s signer.Signer) Option {
+	return func(ops *optionParams) error {
+		ops.signer = s
+		return nil
+	}
+}
+
 // NewConsensus creates a IotxConsensus struct.
 func NewConsensus(
 	cfg config.Config,
@@ -98,9 +112,12 @@ func NewConsensus(
 	var err error
 	switch cfg.Consensus.Scheme {
 	case config.RollDPoSScheme:
+		if ops.signer == nil {
+			ops.signer = signer.NewLocalSigner(cfg.ProducerPrivateKey())
+		}
 		bd := rolldpos.NewRollDPoSBuilder().
 			SetAddr(cfg.ProducerAddress().String()).
-			SetPriKey(cfg.ProducerPrivateKey()).
+			SetSigner(ops.signer).
 			SetConfig(cfg).
 			SetChainManager(bc).
 			SetClock(clock).
@@ -211,6 +228,21 @@ func (c *IotxConsensus) Metrics() (scheme.ConsensusMetrics, error) {
 	return c.scheme.Metrics()
 }
 
+// CurrentRound returns the snapshot of the live consensus round
+func (c *IotxConsensus) CurrentRound() (*scheme.ConsensusRound, error) {
+	return c.scheme.CurrentRound()
+}
+
+// RoundHistory returns the snapshots of the last n finished consensus rounds
+func (c *IotxConsensus) RoundHistory(n int) ([]*scheme.ConsensusRound, error) {
+	return c.scheme.RoundHistory(n)
+}
+
+// Evidence returns the evidence of the endorsers signing conflicting consensus messages
+func (c *IotxConsensus) Evidence(startHeight uint64, count uint64) ([]*evidence.Evidence, error) {
+	return c.scheme.Evidence(startHeight, count)
+}
+
 // HandleConsensusMsg handles consensus messages
 func (c *IotxConsensus) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 	return c.scheme.HandleConsensusMsg(msg)
@@ -226,6 +258,11 @@ func (c *IotxConsensus) ValidateBlockFooter(blk *block.Block) error {
 	return c.scheme.ValidateBlockFooter(blk)
 }
 
+// ValidateBlockProducer validates the producer of the block is a delegate at its height
+func (c *IotxConsensus) ValidateBlockProducer(header *block.Header) error {
+	return c.scheme.ValidateBlockProducer(header)
+}
+
 // Scheme returns the scheme instance
 func (c *IotxConsensus) Scheme() scheme.Scheme {
 	return c.scheme
@@ -88,12 +88,13 @@ var (
 
 // ConsensusFSM wraps over the general purpose FSM and implements the consensus logic
 type ConsensusFSM struct {
-	fsm   fsm.FSM
-	evtq  chan *ConsensusEvent
-	close chan interface{}
-	clock clock.Clock
-	ctx   Context
-	wg    sync.WaitGroup
+	fsm      fsm.FSM
+	evtq     chan *ConsensusEvent
+	close    chan interface{}
+	clock    clock.Clock
+	ctx      Context
+	recorder *FlightRecorder
+	wg       sync.WaitGroup
 }
 
 // NewConsensusFSM returns a new fsm
@@ -215,6 +216,13 @@ func NewConsensusFSM(ctx Context, clock clock.Clock) (*ConsensusFSM, error) {
 	return cm, nil
 }
 
+// SetFlightRecorder sets the flight recorder to record the events handled by the fsm, which should be called before
+// the fsm starts
+func (m *ConsensusFSM) SetFlightRecorder(recorder *FlightRecorder) {
+	m.recorder = recorder
+	m.ctx = &recordingContext{Context: m.ctx, recorder: recorder}
+}
+
 // Start starts the fsm and get in initial state
 func (m *ConsensusFSM) Start(c context.Context) error {
 	m.wg.Add(1)
@@ -315,17 +323,29 @@ func (m *ConsensusFSM) produce(evt *ConsensusEvent, delay time.Duration) {
 }
 
 func (m *ConsensusFSM) handle(evt *ConsensusEvent) error {
+	if m.recorder == nil {
+		_, err := m.handleEvent(evt)
+		return err
+	}
+	m.recorder.begin(evt, m.clock.Now(), m.fsm.CurrentState())
+	status, err := m.handleEvent(evt)
+	m.recorder.end(m.fsm.CurrentState(), status, err)
+	return err
+}
+
+// handleEvent handles the event, and returns the status of the event
+func (m *ConsensusFSM) handleEvent(evt *ConsensusEvent) (string, error) {
 	if m.ctx.IsStaleEvent(evt) {
 		m.ctx.Logger().Debug("stale event", zap.Any("event", evt.Type()))
-		consensusEvtsMtc.WithLabelValues(string(evt.Type()), "stale").Inc()
-		return nil
+		consensusEvtsMtc.WithLabelValues(string(evt.Type()), statusStale).Inc()
+		return statusStale, nil
 	}
 	if m.ctx.IsFutureEvent(evt) {
 		m.ctx.Logger().Debug("future event", zap.Any("event", evt.Type()))
 		// TODO: find a more appropriate delay
 		m.produce(evt, m.ctx.UnmatchedEventInterval(evt.Height()))
-		consensusEvtsMtc.WithLabelValues(string(evt.Type()), "backoff").Inc()
-		return nil
+		consensusEvtsMtc.WithLabelValues(string(evt.Type()), statusBackoff).Inc()
+		return statusBackoff, nil
 	}
 	src := m.fsm.CurrentState()
 	err := m.fsm.Handle(evt)
@@ -337,11 +357,12 @@ func (m *ConsensusFSM) handle(evt *ConsensusEvent) error {
 			zap.String("dst", string(m.fsm.CurrentState())),
 			zap.String("evt", string(evt.Type())),
 		)
-		consensusEvtsMtc.WithLabelValues(string(evt.Type()), "consumed").Inc()
+		consensusEvtsMtc.WithLabelValues(string(evt.Type()), statusConsumed).Inc()
+		return statusConsumed, nil
 	case fsm.ErrTransitionNotFound:
 		if m.ctx.IsStaleUnmatchedEvent(evt) {
-			consensusEvtsMtc.WithLabelValues(string(evt.Type()), "stale").Inc()
-			return nil
+			consensusEvtsMtc.WithLabelValues(string(evt.Type()), statusStale).Inc()
+			return statusStale, nil
 		}
 		m.produce(evt, m.ctx.UnmatchedEventInterval(evt.Height()))
 		m.ctx.Logger().Debug(
@@ -350,21 +371,22 @@ func (m *ConsensusFSM) handle(evt *ConsensusEvent) error {
 			zap.String("evt", string(evt.Type())),
 			zap.Error(err),
 		)
-		consensusEvtsMtc.WithLabelValues(string(evt.Type()), "backoff").Inc()
+		consensusEvtsMtc.WithLabelValues(string(evt.Type()), statusBackoff).Inc()
+		return statusBackoff, nil
 	case ErrOldCalibrateEvt:
 		m.ctx.Logger().Debug(
 			"failed to handle eCalibrate, event height is less than current height",
 			zap.Error(err),
 		)
+		return statusIgnored, nil
 	default:
-		return errors.Wrapf(
+		return statusFailed, errors.Wrapf(
 			err,
 			"failed to handle event %s with src %s",
 			string(evt.Type()),
 			string(src),
 		)
 	}
-	return nil
 }
 
 func (m *ConsensusFSM) calibrate(evt fsm.Event) (fsm.State, error) {
@@ -0,0 +1,327 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensusfsm
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	fsm "github.com/iotexproject/go-fsm"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/consensus/consensusfsm/flightrecorderpb"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// status of the events handled by the consensus fsm
+const (
+	statusConsumed = "consumed"
+	statusStale    = "stale"
+	statusBackoff  = "backoff"
+	statusIgnored  = "ignored"
+	statusFailed   = "failed"
+)
+
+type (
+	// DataEncoder encodes the data of the consensus events, and the messages created by the consensus context
+	DataEncoder func(interface{}) ([]byte, error)
+
+	// FlightRecorder records every event handled by the consensus fsm, with the state transition it causes and the
+	// decisions the consensus context makes on the way, so that the decision path of the rounds could be replayed.
+	// The entries are appended to the file as length-prefixed protobuf messages. Once the file reaches the max size,
+	// it is rotated to the file with suffix .1, which replaces the previous one.
+	FlightRecorder struct {
+		mutex   sync.Mutex
+		path    string
+		maxSize int64
+		size    int64
+		file    *os.File
+		encode  DataEncoder
+		entry   *flightrecorderpb.Entry
+	}
+
+	// recordingContext records the decisions of the consensus context into the flight recorder
+	recordingContext struct {
+		Context
+		recorder *FlightRecorder
+	}
+)
+
+// NewFlightRecorder creates a flight recorder appending to the file at path, which is rotated at maxSize bytes. 0 means
+// the file is never rotated
+func NewFlightRecorder(path string, maxSize int64, encode DataEncoder) (*FlightRecorder, error) {
+	r := &FlightRecorder{
+		path:    path,
+		maxSize: maxSize,
+		encode:  encode,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *FlightRecorder) open() error {
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open flight recorder file %s", r.path)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return errors.Wrapf(err, "failed to stat flight recorder file %s", r.path)
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// rotate renames the file to the one with suffix .1, and opens a new file
+func (r *FlightRecorder) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(r.path, rotatedPath(r.path)); err != nil {
+		return err
+	}
+	return r.open()
+}
+
+func rotatedPath(path string) string {
+	return path + ".1"
+}
+
+// Close closes the file of the flight recorder
+func (r *FlightRecorder) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.file.Close()
+}
+
+// begin starts the entry of the event to be handled
+func (r *FlightRecorder) begin(evt *ConsensusEvent, handleTime time.Time, src fsm.State) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.entry = &flightrecorderpb.Entry{
+		Height:       evt.Height(),
+		Round:        evt.Round(),
+		Type:         string(evt.Type()),
+		CreationTime: evt.Timestamp().UnixNano(),
+		HandleTime:   handleTime.UnixNano(),
+		Data:         r.encodeData(evt.Data()),
+		Src:          string(src),
+	}
+}
+
+// record adds a decision of the consensus context into the entry of the event being handled
+func (r *FlightRecorder) record(call *flightrecorderpb.Call, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.entry == nil {
+		// the decision is not made for an event
+		return
+	}
+	if err != nil {
+		call.Error = err.Error()
+	}
+	r.entry.Calls = append(r.entry.Calls, call)
+}
+
+// end completes the entry of the event handled, and writes it into the file
+func (r *FlightRecorder) end(dst fsm.State, status string, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	entry := r.entry
+	r.entry = nil
+	entry.Dst = string(dst)
+	entry.Status = status
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	b, err := proto.Marshal(entry)
+	if err != nil {
+		log.L().Error("failed to marshal flight recorder entry", zap.Error(err))
+		return
+	}
+	buf := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(b))
+	buf = append(buf[:binary.PutUvarint(buf, uint64(len(b)))], b...)
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(buf)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			log.L().Error("failed to rotate flight recorder file", zap.Error(err))
+			return
+		}
+	}
+	n, err := r.file.Write(buf)
+	r.size += int64(n)
+	if err != nil {
+		log.L().Error("failed to write flight recorder entry", zap.Error(err))
+	}
+}
+
+func (r *FlightRecorder) encodeData(data interface{}) []byte {
+	switch d := data.(type) {
+	case nil:
+		return nil
+	case uint64:
+		return byteutil.Uint64ToBytesBigEndian(d)
+	case fsm.State:
+		return []byte(d)
+	}
+	if r.encode == nil {
+		return nil
+	}
+	b, err := r.encode(data)
+	if err != nil {
+		log.L().Warn("failed to encode consensus data for flight recorder", zap.Error(err))
+		return nil
+	}
+	return b
+}
+
+// ReadFlightRecording reads the entries recorded by the flight recorder in the file at path, after the ones in the
+// rotated file if it exists
+func ReadFlightRecording(path string) ([]*flightrecorderpb.Entry, error) {
+	var entries []*flightrecorderpb.Entry
+	if _, err := os.Stat(rotatedPath(path)); err == nil {
+		if entries, err = readFlightRecordingFile(rotatedPath(path)); err != nil {
+			return nil, err
+		}
+	}
+	rest, err := readFlightRecordingFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return append(entries, rest...), nil
+}
+
+func readFlightRecordingFile(path string) ([]*flightrecorderpb.Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open flight recorder file %s", path)
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	var entries []*flightrecorderpb.Entry
+	for {
+		size, err := binary.ReadUvarint(reader)
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read size of entry %d", len(entries))
+		}
+		b := make([]byte, size)
+		if _, err := io.ReadFull(reader, b); err != nil {
+			return nil, errors.Wrapf(err, "failed to read entry %d", len(entries))
+		}
+		entry := &flightrecorderpb.Entry{}
+		if err := proto.Unmarshal(b, entry); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal entry %d", len(entries))
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func (c *recordingContext) Active() bool {
+	active := c.Context.Active()
+	c.recorder.record(&flightrecorderpb.Call{Method: "Active", Ok: active}, nil)
+	return active
+}
+
+func (c *recordingContext) IsStaleEvent(evt *ConsensusEvent) bool {
+	stale := c.Context.IsStaleEvent(evt)
+	c.recorder.record(&flightrecorderpb.Call{Method: "IsStaleEvent", Ok: stale}, nil)
+	return stale
+}
+
+func (c *recordingContext) IsFutureEvent(evt *ConsensusEvent) bool {
+	future := c.Context.IsFutureEvent(evt)
+	c.recorder.record(&flightrecorderpb.Call{Method: "IsFutureEvent", Ok: future}, nil)
+	return future
+}
+
+func (c *recordingContext) IsStaleUnmatchedEvent(evt *ConsensusEvent) bool {
+	stale := c.Context.IsStaleUnmatchedEvent(evt)
+	c.recorder.record(&flightrecorderpb.Call{Method: "IsStaleUnmatchedEvent", Ok: stale}, nil)
+	return stale
+}
+
+func (c *recordingContext) Height() uint64 {
+	height := c.Context.Height()
+	c.recorder.record(&flightrecorderpb.Call{Method: "Height", Value: int64(height)}, nil)
+	return height
+}
+
+func (c *recordingContext) Prepare() error {
+	err := c.Context.Prepare()
+	c.recorder.record(&flightrecorderpb.Call{Method: "Prepare"}, err)
+	return err
+}
+
+func (c *recordingContext) IsDelegate() bool {
+	isDelegate := c.Context.IsDelegate()
+	c.recorder.record(&flightrecorderpb.Call{Method: "IsDelegate", Ok: isDelegate}, nil)
+	return isDelegate
+}
+
+func (c *recordingContext) Proposal() (interface{}, error) {
+	proposal, err := c.Context.Proposal()
+	c.recordData("Proposal", proposal, err)
+	return proposal, err
+}
+
+func (c *recordingContext) WaitUntilRoundStart() time.Duration {
+	overtime := c.Context.WaitUntilRoundStart()
+	c.recorder.record(&flightrecorderpb.Call{Method: "WaitUntilRoundStart", Value: int64(overtime)}, nil)
+	return overtime
+}
+
+func (c *recordingContext) PreCommitEndorsement() interface{} {
+	en := c.Context.PreCommitEndorsement()
+	c.recordData("PreCommitEndorsement", en, nil)
+	return en
+}
+
+func (c *recordingContext) NewProposalEndorsement(block interface{}) (interface{}, error) {
+	en, err := c.Context.NewProposalEndorsement(block)
+	c.recordData("NewProposalEndorsement", en, err)
+	return en, err
+}
+
+func (c *recordingContext) NewLockEndorsement(vote interface{}) (interface{}, error) {
+	en, err := c.Context.NewLockEndorsement(vote)
+	c.recordData("NewLockEndorsement", en, err)
+	return en, err
+}
+
+func (c *recordingContext) NewPreCommitEndorsement(vote interface{}) (interface{}, error) {
+	en, err := c.Context.NewPreCommitEndorsement(vote)
+	c.recordData("NewPreCommitEndorsement", en, err)
+	return en, err
+}
+
+func (c *recordingContext) Commit(msg interface{}) (bool, error) {
+	committed, err := c.Context.Commit(msg)
+	c.recorder.record(&flightrecorderpb.Call{Method: "Commit", Ok: committed}, err)
+	return committed, err
+}
+
+func (c *recordingContext) recordData(method string, data interface{}, err error) {
+	call := &flightrecorderpb.Call{Method: method}
+	if data != nil {
+		call.Ok = true
+		call.Data = c.recorder.encodeData(data)
+	}
+	c.recorder.record(call, err)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensusfsm
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/mock/gomock"
+	fsm "github.com/iotexproject/go-fsm"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestFlightRecorder(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClock := clock.NewMock()
+	mockCtx := NewMockContext(ctrl)
+	mockCtx.EXPECT().Logger().Return(log.Logger("consensus")).AnyTimes()
+	mockCtx.EXPECT().EventChanSize().Return(uint(10)).AnyTimes()
+	mockCtx.EXPECT().AcceptBlockTTL(gomock.Any()).Return(4 * time.Second).AnyTimes()
+	mockCtx.EXPECT().AcceptProposalEndorsementTTL(gomock.Any()).Return(2 * time.Second).AnyTimes()
+	mockCtx.EXPECT().AcceptLockEndorsementTTL(gomock.Any()).Return(2 * time.Second).AnyTimes()
+	mockCtx.EXPECT().CommitTTL(gomock.Any()).Return(2 * time.Second).AnyTimes()
+	mockCtx.EXPECT().UnmatchedEventInterval(gomock.Any()).Return(100 * time.Millisecond).AnyTimes()
+	mockCtx.EXPECT().Broadcast(gomock.Any()).AnyTimes()
+	mockCtx.EXPECT().NewConsensusEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(eventType fsm.EventType, data interface{}) *ConsensusEvent {
+			return NewConsensusEvent(eventType, data, 2, 0, mockClock.Now())
+		}).AnyTimes()
+	mockCtx.EXPECT().IsStaleEvent(gomock.Any()).DoAndReturn(func(evt *ConsensusEvent) bool {
+		return evt.Height() < 2
+	}).AnyTimes()
+	mockCtx.EXPECT().IsFutureEvent(gomock.Any()).Return(false).AnyTimes()
+	mockCtx.EXPECT().Prepare().Return(nil).Times(1)
+	mockCtx.EXPECT().Proposal().Return([]byte("proposal"), nil).Times(1)
+	mockCtx.EXPECT().WaitUntilRoundStart().Return(time.Second).Times(1)
+	mockCtx.EXPECT().IsDelegate().Return(true).Times(1)
+	mockCtx.EXPECT().PreCommitEndorsement().Return(nil).Times(1)
+	mockCtx.EXPECT().NewProposalEndorsement(gomock.Any()).Return([]byte("proposal endorsement"), nil).Times(1)
+	gomock.InOrder(
+		mockCtx.EXPECT().NewLockEndorsement(gomock.Any()).Return(nil, nil).Times(1),
+		mockCtx.EXPECT().NewLockEndorsement(gomock.Any()).Return([]byte("lock endorsement"), nil).Times(1),
+	)
+	mockCtx.EXPECT().Active().Return(false).Times(1)
+
+	path, err := testutil.PathOfTempFile("flightrecorder")
+	require.NoError(err)
+	defer testutil.CleanupPath(t, path)
+	recorder, err := NewFlightRecorder(path, 0, func(data interface{}) ([]byte, error) {
+		return data.([]byte), nil
+	})
+	require.NoError(err)
+	cfsm, err := NewConsensusFSM(mockCtx, mockClock)
+	require.NoError(err)
+	cfsm.SetFlightRecorder(recorder)
+
+	events := []*ConsensusEvent{
+		NewConsensusEvent(ePrepare, nil, 2, 0, mockClock.Now()),
+		NewConsensusEvent(eReceiveBlock, []byte("block"), 2, 0, mockClock.Now()),
+		NewConsensusEvent(eReceiveProposalEndorsement, []byte("vote"), 1, 0, mockClock.Now()),
+		NewConsensusEvent(eReceiveProposalEndorsement, []byte("vote 1"), 2, 0, mockClock.Now()),
+		NewConsensusEvent(eReceiveProposalEndorsement, []byte("vote 2"), 2, 0, mockClock.Now()),
+		NewConsensusEvent(eStopReceivingLockEndorsement, nil, 2, 0, mockClock.Now()),
+	}
+	for _, evt := range events {
+		mockClock.Add(time.Second)
+		require.NoError(cfsm.handle(evt))
+	}
+	require.Equal(sPrepare, cfsm.CurrentState())
+	require.NoError(cfsm.Stop(context.Background()))
+	require.NoError(recorder.Close())
+
+	entries, err := ReadFlightRecording(path)
+	require.NoError(err)
+	require.Len(entries, len(events))
+	expected := []struct {
+		src, dst fsm.State
+		status   string
+		calls    int
+	}{
+		{sPrepare, sAcceptBlockProposal, statusConsumed, 7},
+		{sAcceptBlockProposal, sAcceptProposalEndorsement, statusConsumed, 3},
+		{sAcceptProposalEndorsement, sAcceptProposalEndorsement, statusStale, 1},
+		{sAcceptProposalEndorsement, sAcceptProposalEndorsement, statusConsumed, 3},
+		{sAcceptProposalEndorsement, sAcceptLockEndorsement, statusConsumed, 3},
+		{sAcceptLockEndorsement, sPrepare, statusConsumed, 3},
+	}
+	for i, entry := range entries {
+		require.Equal(string(events[i].Type()), entry.GetType())
+		require.Equal(events[i].Height(), entry.GetHeight())
+		require.Equal(string(expected[i].src), entry.GetSrc())
+		require.Equal(string(expected[i].dst), entry.GetDst())
+		require.Equal(expected[i].status, entry.GetStatus())
+		require.Len(entry.GetCalls(), expected[i].calls)
+		require.Equal(time.Unix(int64(i+1), 0).UnixNano(), entry.GetHandleTime())
+	}
+	require.Equal([]byte("block"), entries[1].GetData())
+	proposal := entries[0].GetCalls()[3]
+	require.Equal("Proposal", proposal.GetMethod())
+	require.True(proposal.GetOk())
+	require.Equal([]byte("proposal"), proposal.GetData())
+	overtime := entries[0].GetCalls()[4]
+	require.Equal("WaitUntilRoundStart", overtime.GetMethod())
+	require.Equal(int64(time.Second), overtime.GetValue())
+
+	t.Run("replay", func(t *testing.T) {
+		steps, err := Replay(entries, mockCtx)
+		require.NoError(err)
+		require.Len(steps, len(entries))
+		for i, step := range steps {
+			require.False(step.Diverged())
+			require.Equal(expected[i].src, step.Src)
+			require.Equal(expected[i].dst, step.Dst)
+		}
+	})
+	t.Run("replay-diverged", func(t *testing.T) {
+		// the lock endorsement is not created as recorded
+		entries[4].GetCalls()[2].Ok = false
+		steps, err := Replay(entries, mockCtx)
+		require.NoError(err)
+		require.Len(steps, len(entries))
+		for i, step := range steps {
+			require.Equal(i == 4, step.Diverged())
+			require.Equal(expected[i].src, step.Src)
+		}
+		require.Equal(sAcceptProposalEndorsement, steps[4].Dst)
+	})
+	t.Run("rotate", func(t *testing.T) {
+		rotated := rotatedPath(path)
+		defer testutil.CleanupPath(t, rotated)
+		info, err := os.Stat(path)
+		require.NoError(err)
+		// the file is rotated before the entry exceeding the max size is written
+		recorder, err := NewFlightRecorder(path, info.Size()+1, nil)
+		require.NoError(err)
+		for i := 0; i < 2; i++ {
+			recorder.begin(events[0], mockClock.Now(), sPrepare)
+			recorder.end(sPrepare, statusIgnored, nil)
+		}
+		require.NoError(recorder.Close())
+		rotatedEntries, err := readFlightRecordingFile(rotated)
+		require.NoError(err)
+		require.Len(rotatedEntries, len(events))
+		entries, err := ReadFlightRecording(path)
+		require.NoError(err)
+		require.Len(entries, len(events)+2)
+		require.Equal(statusIgnored, entries[len(events)].GetStatus())
+	})
+	t.Run("read-error", func(t *testing.T) {
+		_, err := ReadFlightRecording(path + ".nonexist")
+		require.Error(err)
+	})
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensusfsm
+
+import (
+	"time"
+
+	"github.com/facebookgo/clock"
+	fsm "github.com/iotexproject/go-fsm"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/consensus/consensusfsm/flightrecorderpb"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+type (
+	// ReplayStep is a recorded event handled again by the replay, with the state transition it causes
+	ReplayStep struct {
+		Entry  *flightrecorderpb.Entry
+		Src    fsm.State
+		Dst    fsm.State
+		Status string
+		Err    error
+		// Missing are the decisions asked by the fsm in the replay, but not found in the recording
+		Missing []string
+	}
+
+	// replayContext answers the decisions of the consensus context from the recording
+	replayContext struct {
+		ConsensusConfig
+		clock  clock.Clock
+		logger *zap.Logger
+		entry  *flightrecorderpb.Entry
+		used   []bool
+		missed []string
+	}
+)
+
+// Diverged returns true if the replay does not reproduce the recorded decision path at the step
+func (s *ReplayStep) Diverged() bool {
+	return string(s.Dst) != s.Entry.GetDst() || s.Status != s.Entry.GetStatus() || len(s.Missing) > 0
+}
+
+// Replay feeds the recorded events one by one into a consensus fsm driven by a mock clock set to the recorded time,
+// with the decisions of the consensus context answered from the recording, and returns the steps of the decision path
+func Replay(entries []*flightrecorderpb.Entry, cfg ConsensusConfig) ([]*ReplayStep, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	clk := clock.NewMock()
+	clk.Add(time.Unix(0, entries[0].GetHandleTime()).Sub(clk.Now()))
+	ctx := &replayContext{
+		ConsensusConfig: cfg,
+		clock:           clk,
+		logger:          zap.NewNop(),
+	}
+	m, err := NewConsensusFSM(ctx, clk)
+	if err != nil {
+		return nil, err
+	}
+	// the events produced by the fsm are dropped, since all the events handled are taken from the recording
+	done := make(chan interface{})
+	go func() {
+		for {
+			select {
+			case <-m.evtq:
+			case <-done:
+				return
+			}
+		}
+	}()
+	defer func() {
+		close(m.close)
+		m.wg.Wait()
+		close(done)
+	}()
+
+	steps := make([]*ReplayStep, 0, len(entries))
+	for _, entry := range entries {
+		if handleTime := time.Unix(0, entry.GetHandleTime()); handleTime.After(clk.Now()) {
+			clk.Add(handleTime.Sub(clk.Now()))
+		}
+		// move the fsm to the recorded state, in case the replay diverged in the previous step
+		if src := fsm.State(entry.GetSrc()); m.CurrentState() != src {
+			if err := m.fsm.Handle(ctx.NewBackdoorEvt(src)); err != nil {
+				return nil, errors.Wrapf(err, "failed to move to recorded state %s", src)
+			}
+		}
+		ctx.load(entry)
+		evt, err := recordedEvent(entry)
+		if err != nil {
+			return nil, err
+		}
+		src := m.CurrentState()
+		status, err := m.handleEvent(evt)
+		steps = append(steps, &ReplayStep{
+			Entry:   entry,
+			Src:     src,
+			Dst:     m.CurrentState(),
+			Status:  status,
+			Err:     err,
+			Missing: ctx.missed,
+		})
+	}
+	return steps, nil
+}
+
+// recordedEvent restores the consensus event from the entry, of which the data is left encoded except the calibrated
+// height and the backdoor state
+func recordedEvent(entry *flightrecorderpb.Entry) (*ConsensusEvent, error) {
+	var data interface{}
+	switch eventType := fsm.EventType(entry.GetType()); {
+	case eventType == eCalibrate:
+		if len(entry.GetData()) != 8 {
+			return nil, errors.Wrapf(ErrEvtConvert, "invalid calibrated height of event at %d", entry.GetHandleTime())
+		}
+		data = byteutil.BytesToUint64BigEndian(entry.GetData())
+	case eventType == BackdoorEvent:
+		data = fsm.State(entry.GetData())
+	case len(entry.GetData()) > 0:
+		data = entry.GetData()
+	}
+	return NewConsensusEvent(
+		fsm.EventType(entry.GetType()),
+		data,
+		entry.GetHeight(),
+		entry.GetRound(),
+		time.Unix(0, entry.GetCreationTime()),
+	), nil
+}
+
+// load loads the decisions recorded for the entry
+func (c *replayContext) load(entry *flightrecorderpb.Entry) {
+	c.entry = entry
+	c.used = make([]bool, len(entry.GetCalls()))
+	c.missed = nil
+}
+
+// answer returns the first unused decision of the method, in the order they are recorded
+func (c *replayContext) answer(method string) *flightrecorderpb.Call {
+	for i, call := range c.entry.GetCalls() {
+		if !c.used[i] && call.GetMethod() == method {
+			c.used[i] = true
+			return call
+		}
+	}
+	c.missed = append(c.missed, method)
+	return &flightrecorderpb.Call{Method: method}
+}
+
+func (c *replayContext) data(method string) (interface{}, error) {
+	call := c.answer(method)
+	if call.GetError() != "" {
+		return nil, errors.New(call.GetError())
+	}
+	if !call.GetOk() {
+		return nil, nil
+	}
+	return call.GetData(), nil
+}
+
+func (c *replayContext) err(method string) error {
+	if call := c.answer(method); call.GetError() != "" {
+		return errors.New(call.GetError())
+	}
+	return nil
+}
+
+func (c *replayContext) Activate(bool) {}
+
+func (c *replayContext) Active() bool {
+	return c.answer("Active").GetOk()
+}
+
+func (c *replayContext) IsStaleEvent(*ConsensusEvent) bool {
+	return c.answer("IsStaleEvent").GetOk()
+}
+
+func (c *replayContext) IsFutureEvent(*ConsensusEvent) bool {
+	return c.answer("IsFutureEvent").GetOk()
+}
+
+func (c *replayContext) IsStaleUnmatchedEvent(*ConsensusEvent) bool {
+	return c.answer("IsStaleUnmatchedEvent").GetOk()
+}
+
+func (c *replayContext) Logger() *zap.Logger {
+	return c.logger
+}
+
+func (c *replayContext) Height() uint64 {
+	return uint64(c.answer("Height").GetValue())
+}
+
+func (c *replayContext) NewConsensusEvent(eventType fsm.EventType, data interface{}) *ConsensusEvent {
+	return NewConsensusEvent(eventType, data, c.entry.GetHeight(), c.entry.GetRound(), c.clock.Now())
+}
+
+func (c *replayContext) NewBackdoorEvt(dst fsm.State) *ConsensusEvent {
+	return NewConsensusEvent(BackdoorEvent, dst, c.entry.GetHeight(), c.entry.GetRound(), c.clock.Now())
+}
+
+func (c *replayContext) Broadcast(interface{}) {}
+
+func (c *replayContext) Prepare() error {
+	return c.err("Prepare")
+}
+
+func (c *replayContext) IsDelegate() bool {
+	return c.answer("IsDelegate").GetOk()
+}
+
+func (c *replayContext) Proposal() (interface{}, error) {
+	return c.data("Proposal")
+}
+
+func (c *replayContext) WaitUntilRoundStart() time.Duration {
+	return time.Duration(c.answer("WaitUntilRoundStart").GetValue())
+}
+
+func (c *replayContext) PreCommitEndorsement() interface{} {
+	en, _ := c.data("PreCommitEndorsement")
+	return en
+}
+
+func (c *replayContext) NewProposalEndorsement(interface{}) (interface{}, error) {
+	return c.data("NewProposalEndorsement")
+}
+
+func (c *replayContext) NewLockEndorsement(interface{}) (interface{}, error) {
+	return c.data("NewLockEndorsement")
+}
+
+func (c *replayContext) NewPreCommitEndorsement(interface{}) (interface{}, error) {
+	return c.data("NewPreCommitEndorsement")
+}
+
+func (c *replayContext) Commit(interface{}) (bool, error) {
+	call := c.answer("Commit")
+	if call.GetError() != "" {
+		return false, errors.New(call.GetError())
+	}
+	return call.GetOk(), nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+)
+
+type (
+	// Detector detects the endorsers signing conflicting consensus messages, among the messages received
+	Detector struct {
+		mutex  sync.Mutex
+		store  *Store
+		seen   map[hash.Hash256]*seenMsg
+		height uint64
+	}
+
+	// seenMsg is the first message received of an offence key
+	seenMsg struct {
+		msg      *iotextypes.ConsensusMessage
+		height   uint64
+		blkHash  []byte
+		reported bool
+	}
+)
+
+// NewDetector creates a detector, which persists the evidence detected into the store if it is not nil
+func NewDetector(store *Store) *Detector {
+	return &Detector{
+		store: store,
+		seen:  map[hash.Hash256]*seenMsg{},
+	}
+}
+
+// Detect checks the consensus message against the ones received before, and returns the evidence if the endorser
+// has signed a different block at the same stage of the round. The evidence of an offence is returned only once
+func (d *Detector) Detect(msg *iotextypes.ConsensusMessage) (*Evidence, error) {
+	doc, err := decode(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(doc.blkHash) == 0 {
+		return nil, nil
+	}
+	key := offence(doc.en.Endorser(), doc.topic, doc.en.Timestamp())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	seen, ok := d.seen[key]
+	if !ok {
+		d.seen[key] = &seenMsg{
+			msg:     msg,
+			height:  doc.height,
+			blkHash: doc.blkHash,
+		}
+		return nil, nil
+	}
+	if seen.reported || bytes.Equal(seen.blkHash, doc.blkHash) {
+		return nil, nil
+	}
+	e, err := New(seen.msg, msg)
+	if err != nil {
+		return nil, err
+	}
+	if d.store != nil {
+		if err := d.store.Put(e); err != nil {
+			return nil, err
+		}
+	}
+	seen.reported = true
+	return e, nil
+}
+
+// Prune drops the messages received below the height
+func (d *Detector) Prune(height uint64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if height <= d.height {
+		return
+	}
+	d.height = height
+	for key, seen := range d.seen {
+		if seen.height < height {
+			delete(d.seen, key)
+		}
+	}
+}
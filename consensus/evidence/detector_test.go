@@ -0,0 +1,96 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestDetector(t *testing.T) {
+	require := require.New(t)
+	path, err := testutil.PathOfTempFile("evidence")
+	require.NoError(err)
+	defer testutil.CleanupPath(t, path)
+	cfg := config.Default.DB
+	cfg.DbPath = path
+	store := NewStore(db.NewBoltDB(cfg))
+	ctx := context.Background()
+	require.NoError(store.Start(ctx))
+	defer func() {
+		require.NoError(store.Stop(ctx))
+	}()
+
+	list, err := store.List(0, 10)
+	require.NoError(err)
+	require.Empty(list)
+
+	d := NewDetector(store)
+	sk1, sk2 := identityset.PrivateKey(1), identityset.PrivateKey(2)
+	ts := time.Unix(1600000000, 0)
+	commit := iotextypes.ConsensusVote_COMMIT
+	hash1, hash2 := []byte("block hash 1"), []byte("block hash 2")
+
+	for _, msg := range []*iotextypes.ConsensusMessage{
+		voteMsg(t, sk1, 10, commit, hash1, ts),
+		voteMsg(t, sk1, 10, commit, hash1, ts),
+		voteMsg(t, sk1, 10, commit, nil, ts),
+		voteMsg(t, sk2, 10, commit, hash2, ts),
+		voteMsg(t, sk1, 10, commit, hash2, ts.Add(time.Second)),
+		voteMsg(t, sk1, 11, commit, hash1, ts.Add(time.Minute)),
+	} {
+		e, err := d.Detect(msg)
+		require.NoError(err)
+		require.Nil(e)
+	}
+	e, err := d.Detect(voteMsg(t, sk1, 10, commit, hash2, ts))
+	require.NoError(err)
+	require.NotNil(e)
+	require.Equal(uint64(10), e.Height())
+	require.Equal([][]byte{hash1, hash2}, e.BlockHashes())
+	// the offence is reported once
+	e, err = d.Detect(voteMsg(t, sk1, 10, commit, []byte("block hash 3"), ts))
+	require.NoError(err)
+	require.Nil(e)
+	// a message with invalid signature is rejected
+	msg := voteMsg(t, sk2, 10, commit, hash1, ts)
+	msg.GetVote().BlockHash = hash2
+	_, err = d.Detect(msg)
+	require.Error(err)
+
+	// the messages below the height are pruned
+	d.Prune(11)
+	e, err = d.Detect(voteMsg(t, sk2, 10, commit, hash1, ts))
+	require.NoError(err)
+	require.Nil(e)
+	e, err = d.Detect(voteMsg(t, sk1, 11, commit, hash2, ts.Add(time.Minute)))
+	require.NoError(err)
+	require.NotNil(e)
+
+	list, err = store.List(0, 10)
+	require.NoError(err)
+	require.Len(list, 2)
+	require.Equal(uint64(10), list[0].Height())
+	require.Equal(uint64(11), list[1].Height())
+	list, err = store.List(11, 10)
+	require.NoError(err)
+	require.Len(list, 1)
+	require.Equal(uint64(11), list[0].Height())
+	list, err = store.List(0, 1)
+	require.NoError(err)
+	require.Len(list, 1)
+	require.Equal(uint64(10), list[0].Height())
+}
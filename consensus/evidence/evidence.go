@@ -0,0 +1,261 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/evidence/evidencepb"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// BlockProposalTopic is the topic of the evidence on conflicting block proposals
+const BlockProposalTopic = "BLOCK_PROPOSAL"
+
+// ErrInvalidEvidence indicates the consensus messages do not prove an equivocation
+var ErrInvalidEvidence = errors.New("invalid evidence")
+
+type (
+	// Evidence is the proof of an endorser signing two different blocks at the same stage of a consensus round.
+	// Since the round start time is unique along the chain, and the endorsement time of a proposal or a vote is
+	// derived from it, two messages of the same topic endorsed at the same time by the same endorser are
+	// conflicting if they are on different blocks
+	Evidence struct {
+		msgs      [2]*iotextypes.ConsensusMessage
+		endorser  crypto.PublicKey
+		topic     string
+		ts        time.Time
+		height    uint64
+		blkHashes [2][]byte
+	}
+
+	// signedDoc is a document with the endorsement on it
+	signedDoc struct {
+		en      *endorsement.Endorsement
+		topic   string
+		height  uint64
+		blkHash []byte
+		hash    []byte
+	}
+)
+
+// Hash returns the hash of the document signed
+func (d *signedDoc) Hash() ([]byte, error) {
+	return d.hash, nil
+}
+
+// New creates an evidence from two consensus messages, which are verified to be conflicting
+func New(first, second *iotextypes.ConsensusMessage) (*Evidence, error) {
+	e := &Evidence{}
+	if err := e.load(first, second); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Endorser returns the public key of the endorser who signed the conflicting messages
+func (e *Evidence) Endorser() crypto.PublicKey {
+	return e.endorser
+}
+
+// EndorserAddress returns the address of the endorser
+func (e *Evidence) EndorserAddress() (address.Address, error) {
+	return address.FromBytes(e.endorser.Hash())
+}
+
+// Height returns the consensus height of the messages
+func (e *Evidence) Height() uint64 {
+	return e.height
+}
+
+// Topic returns the topic of the messages, which is either a vote topic or BlockProposalTopic
+func (e *Evidence) Topic() string {
+	return e.topic
+}
+
+// Timestamp returns the endorsement time of the messages
+func (e *Evidence) Timestamp() time.Time {
+	return e.ts
+}
+
+// BlockHashes returns the hashes of the conflicting blocks
+func (e *Evidence) BlockHashes() [][]byte {
+	return [][]byte{e.blkHashes[0], e.blkHashes[1]}
+}
+
+// Messages returns the conflicting consensus messages
+func (e *Evidence) Messages() []*iotextypes.ConsensusMessage {
+	return []*iotextypes.ConsensusMessage{e.msgs[0], e.msgs[1]}
+}
+
+// Offence returns the key of the offence, which identifies the endorser, the topic and the endorsement time,
+// regardless of the blocks in the messages
+func (e *Evidence) Offence() hash.Hash256 {
+	return offence(e.endorser, e.topic, e.ts)
+}
+
+// Hash returns the hash of the evidence
+func (e *Evidence) Hash() (hash.Hash256, error) {
+	ser, err := e.Serialize()
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return hash.Hash256b(ser), nil
+}
+
+// Proto converts the evidence to protobuf message
+func (e *Evidence) Proto() *evidencepb.Evidence {
+	return &evidencepb.Evidence{
+		First:  e.msgs[0],
+		Second: e.msgs[1],
+	}
+}
+
+// LoadProto loads and verifies the evidence from protobuf message
+func (e *Evidence) LoadProto(pb *evidencepb.Evidence) error {
+	return e.load(pb.GetFirst(), pb.GetSecond())
+}
+
+// Serialize returns the serialized bytes of the evidence
+func (e *Evidence) Serialize() ([]byte, error) {
+	return proto.Marshal(e.Proto())
+}
+
+// Deserialize loads and verifies the evidence from bytes
+func (e *Evidence) Deserialize(buf []byte) error {
+	pb := &evidencepb.Evidence{}
+	if err := proto.Unmarshal(buf, pb); err != nil {
+		return errors.Wrap(err, "failed to unmarshal evidence")
+	}
+	return e.LoadProto(pb)
+}
+
+func (e *Evidence) load(first, second *iotextypes.ConsensusMessage) error {
+	if first == nil || second == nil {
+		return errors.Wrap(ErrInvalidEvidence, "missing consensus message")
+	}
+	d1, err := decode(first)
+	if err != nil {
+		return errors.Wrap(ErrInvalidEvidence, err.Error())
+	}
+	d2, err := decode(second)
+	if err != nil {
+		return errors.Wrap(ErrInvalidEvidence, err.Error())
+	}
+	switch {
+	case !bytes.Equal(d1.en.Endorser().Bytes(), d2.en.Endorser().Bytes()):
+		return errors.Wrap(ErrInvalidEvidence, "messages are endorsed by different endorsers")
+	case d1.topic != d2.topic:
+		return errors.Wrapf(ErrInvalidEvidence, "messages are of different topics %s and %s", d1.topic, d2.topic)
+	case !d1.en.Timestamp().Equal(d2.en.Timestamp()):
+		return errors.Wrap(ErrInvalidEvidence, "messages are endorsed at different time")
+	case len(d1.blkHash) == 0 || len(d2.blkHash) == 0:
+		return errors.Wrap(ErrInvalidEvidence, "message is not on a block")
+	case bytes.Equal(d1.blkHash, d2.blkHash):
+		return errors.Wrap(ErrInvalidEvidence, "messages are on the same block")
+	}
+	// the messages are sorted by block hash, such that the same offence always yields the same evidence
+	if bytes.Compare(d1.blkHash, d2.blkHash) > 0 {
+		first, second = second, first
+		d1, d2 = d2, d1
+	}
+	e.msgs = [2]*iotextypes.ConsensusMessage{first, second}
+	e.endorser = d1.en.Endorser()
+	e.topic = d1.topic
+	e.ts = d1.en.Timestamp()
+	e.height = d1.height
+	e.blkHashes = [2][]byte{d1.blkHash, d2.blkHash}
+	return nil
+}
+
+// decode decodes the consensus message, and verifies the signature on it
+func decode(msg *iotextypes.ConsensusMessage) (*signedDoc, error) {
+	if msg.GetEndorsement() == nil {
+		return nil, errors.New("missing endorsement")
+	}
+	en := &endorsement.Endorsement{}
+	if err := en.LoadProto(msg.GetEndorsement()); err != nil {
+		return nil, errors.Wrap(err, "failed to decode endorsement")
+	}
+	doc := &signedDoc{
+		en:     en,
+		height: msg.GetHeight(),
+	}
+	switch {
+	case msg.GetVote() != nil:
+		vote := msg.GetVote()
+		if _, ok := iotextypes.ConsensusVote_Topic_name[int32(vote.GetTopic())]; !ok {
+			return nil, errors.Errorf("invalid topic %d", vote.GetTopic())
+		}
+		// the document is re-encoded the same way as it is signed
+		ser, err := proto.Marshal(&iotextypes.ConsensusVote{
+			BlockHash: vote.GetBlockHash(),
+			Topic:     vote.GetTopic(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		h := blake2b.Sum256(ser)
+		doc.hash = h[:]
+		doc.topic = vote.GetTopic().String()
+		doc.blkHash = vote.GetBlockHash()
+	case msg.GetBlockProposal() != nil:
+		proposal := msg.GetBlockProposal()
+		blk := &block.Block{}
+		if err := blk.ConvertFromBlockPb(proposal.GetBlock()); err != nil {
+			return nil, errors.Wrap(err, "failed to decode block proposal")
+		}
+		pb := &iotextypes.BlockProposal{Block: blk.ConvertToBlockPb()}
+		for _, ePb := range proposal.GetEndorsements() {
+			en := &endorsement.Endorsement{}
+			if err := en.LoadProto(ePb); err != nil {
+				return nil, errors.Wrap(err, "failed to decode proof of lock")
+			}
+			ePb, err := en.Proto()
+			if err != nil {
+				return nil, err
+			}
+			pb.Endorsements = append(pb.Endorsements, ePb)
+		}
+		ser, err := proto.Marshal(pb)
+		if err != nil {
+			return nil, err
+		}
+		h := hash.Hash256b(ser)
+		blkHash := blk.HashBlock()
+		doc.hash = h[:]
+		doc.topic = BlockProposalTopic
+		// unlike the one of the message, the height of the block is signed by the proposer
+		doc.height = blk.Height()
+		doc.blkHash = blkHash[:]
+	default:
+		return nil, errors.New("unsupported consensus message")
+	}
+	if !endorsement.VerifyEndorsement(doc, en) {
+		return nil, errors.New("failed to verify signature in endorsement")
+	}
+	return doc, nil
+}
+
+// offence returns the key of the stage of a consensus round signed by the endorser
+func offence(endorser crypto.PublicKey, topic string, ts time.Time) hash.Hash256 {
+	b := append(endorser.Bytes(), []byte(topic)...)
+	b = append(b, byteutil.Uint64ToBytesBigEndian(uint64(ts.Unix()))...)
+	b = append(b, byteutil.Uint32ToBytesBigEndian(uint32(ts.Nanosecond()))...)
+	return hash.Hash256b(b)
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/signer"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func voteMsg(
+	t *testing.T,
+	sk crypto.PrivateKey,
+	height uint64,
+	topic iotextypes.ConsensusVote_Topic,
+	blkHash []byte,
+	ts time.Time,
+) *iotextypes.ConsensusMessage {
+	vote := &iotextypes.ConsensusVote{
+		BlockHash: blkHash,
+		Topic:     topic,
+	}
+	h := blake2b.Sum256(mustMarshal(t, vote))
+	msg := endorse(t, sk, height, h[:], ts)
+	msg.Msg = &iotextypes.ConsensusMessage_Vote{Vote: vote}
+	return msg
+}
+
+func proposalMsg(
+	t *testing.T,
+	sk crypto.PrivateKey,
+	height uint64,
+	blkTime time.Time,
+	ts time.Time,
+) *iotextypes.ConsensusMessage {
+	blk, err := block.NewTestingBuilder().
+		SetHeight(height).
+		SetTimeStamp(blkTime).
+		SignAndBuild(sk)
+	require.NoError(t, err)
+	proposal := &iotextypes.BlockProposal{Block: blk.ConvertToBlockPb()}
+	h := hash.Hash256b(mustMarshal(t, proposal))
+	msg := endorse(t, sk, height, h[:], ts)
+	msg.Msg = &iotextypes.ConsensusMessage_BlockProposal{BlockProposal: proposal}
+	return msg
+}
+
+func endorse(
+	t *testing.T,
+	sk crypto.PrivateKey,
+	height uint64,
+	docHash []byte,
+	ts time.Time,
+) *iotextypes.ConsensusMessage {
+	en, err := endorsement.Endorse(signer.NewLocalSigner(sk), &signedDoc{hash: docHash}, ts)
+	require.NoError(t, err)
+	enPb, err := en.Proto()
+	require.NoError(t, err)
+	return &iotextypes.ConsensusMessage{
+		Height:      height,
+		Endorsement: enPb,
+	}
+}
+
+func mustMarshal(t *testing.T, msg proto.Message) []byte {
+	ser, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	return ser
+}
+
+func TestEvidence(t *testing.T) {
+	require := require.New(t)
+	sk := identityset.PrivateKey(1)
+	ts := time.Unix(1600000000, 500)
+	hash1, hash2 := []byte("block hash 1"), []byte("block hash 2")
+	lock := iotextypes.ConsensusVote_LOCK
+
+	t.Run("conflicting-votes", func(t *testing.T) {
+		r := require
+		m1 := voteMsg(t, sk, 10, lock, hash2, ts)
+		m2 := voteMsg(t, sk, 10, lock, hash1, ts)
+		e, err := New(m1, m2)
+		r.NoError(err)
+		r.Equal(sk.PublicKey().Bytes(), e.Endorser().Bytes())
+		addr, err := e.EndorserAddress()
+		r.NoError(err)
+		r.Equal(identityset.Address(1).String(), addr.String())
+		r.Equal(uint64(10), e.Height())
+		r.Equal("LOCK", e.Topic())
+		r.True(ts.Equal(e.Timestamp()))
+		// the messages are sorted by block hash
+		r.Equal([][]byte{hash1, hash2}, e.BlockHashes())
+		r.Equal([]*iotextypes.ConsensusMessage{m2, m1}, e.Messages())
+
+		swapped, err := New(m2, m1)
+		r.NoError(err)
+		r.Equal(e.Offence(), swapped.Offence())
+		h1, err := e.Hash()
+		r.NoError(err)
+		h2, err := swapped.Hash()
+		r.NoError(err)
+		r.Equal(h1, h2)
+
+		ser, err := e.Serialize()
+		r.NoError(err)
+		loaded := &Evidence{}
+		r.NoError(loaded.Deserialize(ser))
+		r.Equal(e.Offence(), loaded.Offence())
+		r.Equal(e.BlockHashes(), loaded.BlockHashes())
+		r.Error(loaded.Deserialize([]byte("invalid")))
+	})
+	t.Run("conflicting-proposals", func(t *testing.T) {
+		r := require
+		m1 := proposalMsg(t, sk, 10, ts, ts)
+		m2 := proposalMsg(t, sk, 10, ts.Add(time.Second), ts)
+		e, err := New(m1, m2)
+		r.NoError(err)
+		r.Equal(BlockProposalTopic, e.Topic())
+		r.Equal(uint64(10), e.Height())
+		hashes := e.BlockHashes()
+		r.Equal(-1, bytes.Compare(hashes[0], hashes[1]))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		r := require
+		m := voteMsg(t, sk, 10, lock, hash1, ts)
+		tampered := voteMsg(t, sk, 10, lock, hash2, ts)
+		tampered.GetVote().BlockHash = []byte("block hash 3")
+		tests := []struct {
+			name  string
+			other *iotextypes.ConsensusMessage
+		}{
+			{"nil", nil},
+			{"same-block", voteMsg(t, sk, 10, lock, hash1, ts)},
+			{"other-endorser", voteMsg(t, identityset.PrivateKey(2), 10, lock, hash2, ts)},
+			{"other-topic", voteMsg(t, sk, 10, iotextypes.ConsensusVote_COMMIT, hash2, ts)},
+			{"other-time", voteMsg(t, sk, 10, lock, hash2, ts.Add(time.Second))},
+			{"nil-vote", voteMsg(t, sk, 10, lock, nil, ts)},
+			{"bad-signature", tampered},
+			{"proposal", proposalMsg(t, sk, 10, ts, ts)},
+		}
+		for _, test := range tests {
+			_, err := New(m, test.other)
+			r.Equal(ErrInvalidEvidence, errors.Cause(err), test.name)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// evidenceNS is the namespace of the evidence, keyed by height and offence
+const evidenceNS = "Evidence"
+
+// Store persists the evidence detected
+type Store struct {
+	kvStore db.KVStore
+}
+
+// NewStore creates an evidence store on the kv store
+func NewStore(kvStore db.KVStore) *Store {
+	return &Store{kvStore: kvStore}
+}
+
+// Start starts the store
+func (s *Store) Start(ctx context.Context) error {
+	return s.kvStore.Start(ctx)
+}
+
+// Stop stops the store
+func (s *Store) Stop(ctx context.Context) error {
+	return s.kvStore.Stop(ctx)
+}
+
+// Put stores the evidence, which overwrites the one of the same offence
+func (s *Store) Put(e *Evidence) error {
+	ser, err := e.Serialize()
+	if err != nil {
+		return err
+	}
+	return s.kvStore.Put(evidenceNS, evidenceKey(e), ser)
+}
+
+// List returns at most count evidence from the start height, in the order of height
+func (s *Store) List(startHeight uint64, count uint64) ([]*Evidence, error) {
+	if count == 0 {
+		return nil, nil
+	}
+	_, values, err := s.kvStore.Filter(evidenceNS, func(k, v []byte) bool {
+		return true
+	}, byteutil.Uint64ToBytesBigEndian(startHeight), nil)
+	if err != nil {
+		if cause := errors.Cause(err); cause == db.ErrNotExist || cause == db.ErrBucketNotExist {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if uint64(len(values)) > count {
+		values = values[:count]
+	}
+	list := make([]*Evidence, 0, len(values))
+	for _, v := range values {
+		e := &Evidence{}
+		if err := e.Deserialize(v); err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+	return list, nil
+}
+
+func evidenceKey(e *Evidence) []byte {
+	h := e.Offence()
+	return append(byteutil.Uint64ToBytesBigEndian(e.Height()), h[:]...)
+}
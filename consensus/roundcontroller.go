@@ -0,0 +1,108 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// RoundController inspects the consensus rounds
+type RoundController struct {
+	ri scheme.RoundInspector
+}
+
+// NewRoundController constructs a round controller instance
+func NewRoundController(ri scheme.RoundInspector) *RoundController {
+	return &RoundController{
+		ri: ri,
+	}
+}
+
+// Handle handles admin request, which returns the live consensus round, and the last finished rounds of the number
+// given by "history"
+func (rc *RoundController) Handle(w http.ResponseWriter, r *http.Request) {
+	n := 0
+	if val := r.URL.Query().Get("history"); val != "" {
+		var err error
+		if n, err = strconv.Atoi(val); err != nil || n < 0 {
+			http.Error(w, "invalid history", http.StatusBadRequest)
+			return
+		}
+	}
+	round, err := rc.ri.CurrentRound()
+	if err != nil {
+		writeRoundError(w, err)
+		return
+	}
+	history, err := rc.ri.RoundHistory(n)
+	if err != nil {
+		writeRoundError(w, err)
+		return
+	}
+	type payload struct {
+		Round   *scheme.ConsensusRound   `json:"round"`
+		History []*scheme.ConsensusRound `json:"history"`
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&payload{Round: round, History: history}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// HandleStream handles admin request, which streams the live consensus round as a JSON line whenever it changes,
+// polling at the interval given by "interval", which cannot be less than scheme.MinRoundWatchInterval
+func (rc *RoundController) HandleStream(w http.ResponseWriter, r *http.Request) {
+	interval := scheme.MinRoundWatchInterval
+	if val := r.URL.Query().Get("interval"); val != "" {
+		var err error
+		if interval, err = time.ParseDuration(val); err != nil {
+			http.Error(w, "invalid interval", http.StatusBadRequest)
+			return
+		}
+		if interval < scheme.MinRoundWatchInterval {
+			http.Error(w, "interval is less than "+scheme.MinRoundWatchInterval.String(), http.StatusBadRequest)
+			return
+		}
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	if _, err := rc.ri.CurrentRound(); err != nil {
+		writeRoundError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	if err := scheme.WatchRound(r.Context(), rc.ri, interval, func(round *scheme.ConsensusRound) error {
+		if err := enc.Encode(round); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}); err != nil {
+		log.L().Debug("Stopped streaming consensus round.", zap.Error(err))
+	}
+}
+
+func writeRoundError(w http.ResponseWriter, err error) {
+	if errors.Cause(err) == scheme.ErrNotImplemented {
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusServiceUnavailable)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+)
+
+type testRoundInspector struct {
+	mutex   sync.Mutex
+	round   *scheme.ConsensusRound
+	history []*scheme.ConsensusRound
+	err     error
+}
+
+func (ri *testRoundInspector) CurrentRound() (*scheme.ConsensusRound, error) {
+	ri.mutex.Lock()
+	defer ri.mutex.Unlock()
+	if ri.err != nil {
+		return nil, ri.err
+	}
+	round := *ri.round
+	return &round, nil
+}
+
+func (ri *testRoundInspector) RoundHistory(n int) ([]*scheme.ConsensusRound, error) {
+	if ri.err != nil {
+		return nil, ri.err
+	}
+	if n > len(ri.history) {
+		n = len(ri.history)
+	}
+	return ri.history[:n], nil
+}
+
+func (ri *testRoundInspector) setRound(round *scheme.ConsensusRound) {
+	ri.mutex.Lock()
+	defer ri.mutex.Unlock()
+	ri.round = round
+}
+
+func TestRoundController(t *testing.T) {
+	require := require.New(t)
+	ri := &testRoundInspector{
+		round: &scheme.ConsensusRound{Height: 3, Round: 1, State: "S_PREPARE"},
+		history: []*scheme.ConsensusRound{
+			{Height: 2, Committed: true},
+			{Height: 1, Committed: true},
+		},
+	}
+	ctl := NewRoundController(ri)
+
+	rec := httptest.NewRecorder()
+	ctl.Handle(rec, httptest.NewRequest(http.MethodGet, "/consensus/round?history=1", nil))
+	require.Equal(http.StatusOK, rec.Code)
+	var res struct {
+		Round   *scheme.ConsensusRound   `json:"round"`
+		History []*scheme.ConsensusRound `json:"history"`
+	}
+	require.NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Equal(uint64(3), res.Round.Height)
+	require.Equal("S_PREPARE", res.Round.State)
+	require.Len(res.History, 1)
+	require.Equal(uint64(2), res.History[0].Height)
+
+	rec = httptest.NewRecorder()
+	ctl.Handle(rec, httptest.NewRequest(http.MethodGet, "/consensus/round?history=x", nil))
+	require.Equal(http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	NewRoundController(&testRoundInspector{err: scheme.ErrNotImplemented}).Handle(
+		rec,
+		httptest.NewRequest(http.MethodGet, "/consensus/round", nil),
+	)
+	require.Equal(http.StatusNotImplemented, rec.Code)
+
+	t.Run("stream", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		ctl.HandleStream(rec, httptest.NewRequest(http.MethodGet, "/consensus/round/stream?interval=100ms", nil))
+		require.Equal(http.StatusBadRequest, rec.Code)
+
+		svr := httptest.NewServer(http.HandlerFunc(ctl.HandleStream))
+		defer svr.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, svr.URL+"?interval=1s", nil)
+		require.NoError(err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(err)
+		defer resp.Body.Close()
+		require.Equal(http.StatusOK, resp.StatusCode)
+
+		scanner := bufio.NewScanner(resp.Body)
+		round := &scheme.ConsensusRound{}
+		require.True(scanner.Scan())
+		require.NoError(json.Unmarshal(scanner.Bytes(), round))
+		require.Equal(uint64(3), round.Height)
+		ri.setRound(&scheme.ConsensusRound{Height: 4, State: "S_ACCEPT_BLOCK_PROPOSAL"})
+		require.True(scanner.Scan())
+		require.NoError(json.Unmarshal(scanner.Bytes(), round))
+		require.Equal(uint64(4), round.Height)
+		require.Equal("S_ACCEPT_BLOCK_PROPOSAL", round.State)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
@@ -46,6 +47,11 @@ func (n *Noop) ValidateBlockFooter(*block.Block) error {
 	return nil
 }
 
+// ValidateBlockProducer validates the block producer
+func (n *Noop) ValidateBlockProducer(*block.Header) error {
+	return nil
+}
+
 // Metrics is not implemented for noop scheme
 func (n *Noop) Metrics() (ConsensusMetrics, error) {
 	return ConsensusMetrics{}, errors.Wrapf(
@@ -54,6 +60,30 @@ func (n *Noop) Metrics() (ConsensusMetrics, error) {
 	)
 }
 
+// CurrentRound is not implemented for noop scheme
+func (n *Noop) CurrentRound() (*ConsensusRound, error) {
+	return nil, errors.Wrapf(
+		ErrNotImplemented,
+		"noop scheme does not supported round inspection yet",
+	)
+}
+
+// RoundHistory is not implemented for noop scheme
+func (n *Noop) RoundHistory(int) ([]*ConsensusRound, error) {
+	return nil, errors.Wrapf(
+		ErrNotImplemented,
+		"noop scheme does not supported round inspection yet",
+	)
+}
+
+// Evidence is not implemented for noop scheme
+func (n *Noop) Evidence(uint64, uint64) ([]*evidence.Evidence, error) {
+	return nil, errors.Wrapf(
+		ErrNotImplemented,
+		"noop scheme does not supported evidence detection yet",
+	)
+}
+
 // Activate is not implemented for noop scheme
 func (n *Noop) Activate(_ bool) {
 	log.S().Warn("Noop scheme could not support activate")
@@ -12,6 +12,7 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
 
@@ -57,10 +58,32 @@ func (bp *blockProposal) Hash() ([]byte, error) {
 	return h[:], nil
 }
 
+func (bp *blockProposal) Describe(msg *signer.Message) error {
+	pb, err := bp.Proto()
+	if err != nil {
+		return err
+	}
+	if msg.Document, err = proto.Marshal(pb); err != nil {
+		return err
+	}
+	msg.Kind = signer.ProposalKind
+	return nil
+}
+
 func (bp *blockProposal) ProposerAddress() string {
 	return bp.block.ProducerAddress()
 }
 
+// LoadBlockProposal loads a block proposal, and returns the document endorsed by the proposer along with the block
+// proposed
+func LoadBlockProposal(msg *iotextypes.BlockProposal) (endorsement.Document, *block.Block, error) {
+	bp := &blockProposal{}
+	if err := bp.LoadProto(msg); err != nil {
+		return nil, nil, err
+	}
+	return bp, bp.block, nil
+}
+
 func (bp *blockProposal) LoadProto(msg *iotextypes.BlockProposal) error {
 	bp.block = &block.Block{}
 	if err := bp.block.ConvertFromBlockPb(msg.Block); err != nil {
@@ -12,6 +12,8 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 // ConsensusVoteTopic defines the topic of an consensus vote
@@ -26,6 +28,20 @@ const (
 	COMMIT ConsensusVoteTopic = 2
 )
 
+// String returns the name of the topic
+func (t ConsensusVoteTopic) String() string {
+	switch t {
+	case PROPOSAL:
+		return "PROPOSAL"
+	case LOCK:
+		return "LOCK"
+	case COMMIT:
+		return "COMMIT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
 // ConsensusVote is a vote on a given topic for a block on a specific height
 type ConsensusVote struct {
 	blkHash []byte
@@ -56,6 +72,19 @@ func (v *ConsensusVote) Topic() ConsensusVoteTopic {
 	return v.topic
 }
 
+// Describe describes the serialized vote endorsed, from which a remote signer derives the block and the topic
+func (v *ConsensusVote) Describe(msg *signer.Message) error {
+	pb, err := v.Proto()
+	if err != nil {
+		return err
+	}
+	if msg.Document, err = proto.Marshal(pb); err != nil {
+		return err
+	}
+	msg.Kind = signer.VoteKind
+	return nil
+}
+
 // Proto converts to a protobuf message
 func (v *ConsensusVote) Proto() (*iotextypes.ConsensusVote, error) {
 	var topic iotextypes.ConsensusVote_Topic
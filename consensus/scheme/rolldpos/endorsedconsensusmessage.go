@@ -7,6 +7,7 @@
 package rolldpos
 
 import (
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/endorsement"
@@ -101,3 +102,21 @@ func (ecm *EndorsedConsensusMessage) LoadProto(msg *iotextypes.ConsensusMessage)
 
 	return ecm.endorsement.LoadProto(msg.GetEndorsement())
 }
+
+// EncodeConsensusData encodes the endorsed consensus message carried by the consensus events, for the flight recorder.
+// A block proposal is encoded as a PROPOSAL vote of the block hash, instead of the full block
+func EncodeConsensusData(data interface{}) ([]byte, error) {
+	ecm, ok := data.(*EndorsedConsensusMessage)
+	if !ok {
+		return nil, errors.Errorf("unexpected consensus data type %T", data)
+	}
+	if proposal, ok := ecm.Document().(*blockProposal); ok {
+		blkHash := proposal.block.HashBlock()
+		ecm = NewEndorsedConsensusMessage(ecm.Height(), NewConsensusVote(blkHash[:], PROPOSAL), ecm.Endorsement())
+	}
+	msg, err := ecm.Proto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
@@ -11,9 +11,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
 	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-core/test/identityset"
 )
 
@@ -44,3 +49,65 @@ func TestEndorsedConsensusMessage(t *testing.T) {
 	require.True(now.Equal(cen.Timestamp()))
 	require.Equal(priKey.PublicKey().HexString(), en.Endorser().HexString())
 }
+
+func TestEncodeConsensusData(t *testing.T) {
+	require := require.New(t)
+	vote := NewConsensusVote([]byte("abcdefg"), LOCK)
+	en := endorsement.NewEndorsement(time.Now(), identityset.PrivateKey(0).PublicKey(), []byte("signature"))
+	data, err := EncodeConsensusData(NewEndorsedConsensusMessage(10, vote, en))
+	require.NoError(err)
+	pb := &iotextypes.ConsensusMessage{}
+	require.NoError(proto.Unmarshal(data, pb))
+	cem := &EndorsedConsensusMessage{}
+	require.NoError(cem.LoadProto(pb))
+	require.Equal(uint64(10), cem.Height())
+	require.Equal(LOCK, cem.Document().(*ConsensusVote).Topic())
+
+	// a block proposal is encoded as a proposal vote of the block hash
+	blk := getBlockforctx(t, 0, false)
+	data, err = EncodeConsensusData(NewEndorsedConsensusMessage(blk.Height(), newBlockProposal(&blk, nil), en))
+	require.NoError(err)
+	require.NoError(proto.Unmarshal(data, pb))
+	require.NoError(cem.LoadProto(pb))
+	blkHash := blk.HashBlock()
+	require.Equal(PROPOSAL, cem.Document().(*ConsensusVote).Topic())
+	require.Equal(blkHash[:], cem.Document().(*ConsensusVote).BlockHash())
+
+	_, err = EncodeConsensusData(uint64(10))
+	require.Error(err)
+}
+
+func TestConflictingMessagesEvidence(t *testing.T) {
+	require := require.New(t)
+	priKey := identityset.PrivateKey(0)
+	ts := time.Unix(1600000000, 0)
+	endorse := func(doc endorsement.Document) *iotextypes.ConsensusMessage {
+		en, err := endorsement.Endorse(signer.NewLocalSigner(priKey), doc, ts)
+		require.NoError(err)
+		pb, err := NewEndorsedConsensusMessage(10, doc, en).Proto()
+		require.NoError(err)
+		return pb
+	}
+	e, err := evidence.New(
+		endorse(NewConsensusVote([]byte("block 1"), COMMIT)),
+		endorse(NewConsensusVote([]byte("block 2"), COMMIT)),
+	)
+	require.NoError(err)
+	require.Equal(COMMIT.String(), e.Topic())
+
+	lock, err := endorsement.Endorse(signer.NewLocalSigner(identityset.PrivateKey(1)), NewConsensusVote([]byte("block 1"), LOCK), ts)
+	require.NoError(err)
+	proposals := make([]*iotextypes.ConsensusMessage, 0, 2)
+	for i := 0; i < 2; i++ {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(10).
+			SetTimeStamp(ts.Add(time.Duration(i) * time.Second)).
+			SignAndBuild(priKey)
+		require.NoError(err)
+		proposals = append(proposals, endorse(newBlockProposal(&blk, []*endorsement.Endorsement{lock})))
+	}
+	e, err = evidence.New(proposals[0], proposals[1])
+	require.NoError(err)
+	require.Equal(evidence.BlockProposalTopic, e.Topic())
+	require.Equal(uint64(10), e.Height())
+}
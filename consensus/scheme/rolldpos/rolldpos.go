@@ -22,9 +22,12 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 var (
@@ -61,6 +64,9 @@ type ChainManager interface {
 type RollDPoS struct {
 	cfsm       *consensusfsm.ConsensusFSM
 	ctx        *rollDPoSCtx
+	recorder   *consensusfsm.FlightRecorder
+	detector   *evidence.Detector
+	evidences  *evidence.Store
 	startDelay time.Duration
 	ready      chan interface{}
 }
@@ -70,6 +76,11 @@ func (r *RollDPoS) Start(ctx context.Context) error {
 	if err := r.ctx.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting the roll dpos context")
 	}
+	if r.evidences != nil {
+		if err := r.evidences.Start(ctx); err != nil {
+			return errors.Wrap(err, "error when starting the evidence store")
+		}
+	}
 	if err := r.cfsm.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting the consensus FSM")
 	}
@@ -85,6 +96,16 @@ func (r *RollDPoS) Stop(ctx context.Context) error {
 	if err := r.cfsm.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping the consensus FSM")
 	}
+	if r.recorder != nil {
+		if err := r.recorder.Close(); err != nil {
+			return errors.Wrap(err, "error when closing the flight recorder")
+		}
+	}
+	if r.evidences != nil {
+		if err := r.evidences.Stop(ctx); err != nil {
+			return errors.Wrap(err, "error when stopping the evidence store")
+		}
+	}
 	return errors.Wrap(r.ctx.Stop(ctx), "error when stopping the roll dpos context")
 }
 
@@ -128,12 +149,14 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 		if err := r.ctx.CheckBlockProposer(endorsedMessage.Height(), consensusMessage, en); err != nil {
 			return errors.Wrap(err, "failed to verify block proposal")
 		}
+		r.detectEquivocation(consensusHeight, msg)
 		r.cfsm.ProduceReceiveBlockEvent(endorsedMessage)
 		return nil
 	case *ConsensusVote:
 		if err := r.ctx.CheckVoteEndorser(endorsedMessage.Height(), consensusMessage, en); err != nil {
 			return errors.Wrapf(err, "failed to verify vote")
 		}
+		r.detectEquivocation(consensusHeight, msg)
 		switch consensusMessage.Topic() {
 		case PROPOSAL:
 			r.cfsm.ProduceReceiveProposalEndorsementEvent(endorsedMessage)
@@ -149,6 +172,32 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 	}
 }
 
+// detectEquivocation checks whether the endorser of the message has signed a different block at the same stage of the
+// round, and logs the evidence detected
+func (r *RollDPoS) detectEquivocation(consensusHeight uint64, msg *iotextypes.ConsensusMessage) {
+	r.detector.Prune(consensusHeight)
+	e, err := r.detector.Detect(msg)
+	if err != nil {
+		log.Logger("consensus").Error("failed to detect equivocation", zap.Error(err))
+		return
+	}
+	if e == nil {
+		return
+	}
+	addr, err := e.EndorserAddress()
+	if err != nil {
+		log.Logger("consensus").Error("failed to get endorser address of evidence", zap.Error(err))
+		return
+	}
+	log.Logger("consensus").Warn(
+		"endorser signed conflicting consensus messages",
+		zap.String("endorser", addr.String()),
+		zap.Uint64("height", e.Height()),
+		zap.String("topic", e.Topic()),
+		zap.Time("timestamp", e.Timestamp()),
+	)
+}
+
 // Calibrate called on receive a new block not via consensus
 func (r *RollDPoS) Calibrate(height uint64) {
 	r.cfsm.Calibrate(height)
@@ -186,6 +235,22 @@ func (r *RollDPoS) ValidateBlockFooter(blk *block.Block) error {
 	return nil
 }
 
+// ValidateBlockProducer validates the producer of the block is a delegate at its height. The delegates are only known
+// up to the next epoch of the chain tip, and scheme.ErrUnknownDelegates is returned for the blocks beyond
+func (r *RollDPoS) ValidateBlockProducer(header *block.Header) error {
+	delegates, err := r.ctx.roundCalc.Delegates(header.Height())
+	if err != nil {
+		return errors.Wrapf(scheme.ErrUnknownDelegates, "height %d: %v", header.Height(), err)
+	}
+	producer := header.ProducerAddress()
+	for _, d := range delegates {
+		if d == producer {
+			return nil
+		}
+	}
+	return errors.Errorf("block proposer %s is not a valid delegate", producer)
+}
+
 // Metrics returns RollDPoS consensus metrics
 func (r *RollDPoS) Metrics() (scheme.ConsensusMetrics, error) {
 	var metrics scheme.ConsensusMetrics
@@ -203,6 +268,29 @@ func (r *RollDPoS) Metrics() (scheme.ConsensusMetrics, error) {
 	}, nil
 }
 
+// CurrentRound returns the snapshot of the live consensus round
+func (r *RollDPoS) CurrentRound() (*scheme.ConsensusRound, error) {
+	round, err := r.ctx.CurrentRound()
+	if err != nil {
+		return nil, err
+	}
+	round.State = string(r.cfsm.CurrentState())
+	return round, nil
+}
+
+// RoundHistory returns the snapshots of the last n finished consensus rounds, from the latest to the earliest
+func (r *RollDPoS) RoundHistory(n int) ([]*scheme.ConsensusRound, error) {
+	return r.ctx.RoundHistory(n), nil
+}
+
+// Evidence returns at most count evidence detected from the start height, in the order of height
+func (r *RollDPoS) Evidence(startHeight uint64, count uint64) ([]*evidence.Evidence, error) {
+	if r.evidences == nil {
+		return nil, errors.Wrap(scheme.ErrNotImplemented, "evidence store is disabled")
+	}
+	return r.evidences.List(startHeight, count)
+}
+
 // NumPendingEvts returns the number of pending events
 func (r *RollDPoS) NumPendingEvts() int {
 	return r.cfsm.NumPendingEvents()
@@ -230,10 +318,9 @@ func (r *RollDPoS) Active() bool {
 
 // Builder is the builder for RollDPoS
 type Builder struct {
-	cfg config.Config
-	// TODO: we should use keystore in the future
+	cfg              config.Config
 	encodedAddr      string
-	priKey           crypto.PrivateKey
+	signer           signer.Signer
 	chain            ChainManager
 	broadcastHandler scheme.Broadcast
 	clock            clock.Clock
@@ -259,9 +346,15 @@ func (b *Builder) SetAddr(encodedAddr string) *Builder {
 	return b
 }
 
-// SetPriKey sets the private key
+// SetPriKey sets the private key, which signs as a local signer
 func (b *Builder) SetPriKey(priKey crypto.PrivateKey) *Builder {
-	b.priKey = priKey
+	b.signer = signer.NewLocalSigner(priKey)
+	return b
+}
+
+// SetSigner sets the signer of the proposals and the endorsements
+func (b *Builder) SetSigner(s signer.Signer) *Builder {
+	b.signer = s
 	return b
 }
 
@@ -320,20 +413,41 @@ func (b *Builder) Build() (*RollDPoS, error) {
 		b.broadcastHandler,
 		b.delegatesByEpochFunc,
 		b.encodedAddr,
-		b.priKey,
+		b.signer,
 		b.clock,
 		b.cfg.Genesis.BeringBlockHeight,
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "error when constructing consensus context")
 	}
+	ctx.historySize = b.cfg.Consensus.RollDPoS.RoundHistorySize
 	cfsm, err := consensusfsm.NewConsensusFSM(ctx, b.clock)
 	if err != nil {
 		return nil, errors.Wrap(err, "error when constructing the consensus FSM")
 	}
+	var recorder *consensusfsm.FlightRecorder
+	if path := b.cfg.Consensus.RollDPoS.FlightRecorderPath; path != "" {
+		recorder, err = consensusfsm.NewFlightRecorder(
+			path,
+			b.cfg.Consensus.RollDPoS.FlightRecorderMaxSize,
+			EncodeConsensusData,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error when constructing the flight recorder")
+		}
+		cfsm.SetFlightRecorder(recorder)
+	}
+	var evidences *evidence.Store
+	if path := b.cfg.Consensus.RollDPoS.EvidenceDBPath; path != "" {
+		b.cfg.DB.DbPath = path
+		evidences = evidence.NewStore(db.NewBoltDB(b.cfg.DB))
+	}
 	return &RollDPoS{
 		cfsm:       cfsm,
 		ctx:        ctx,
+		recorder:   recorder,
+		detector:   evidence.NewDetector(evidences),
+		evidences:  evidences,
 		startDelay: b.cfg.Consensus.RollDPoS.Delay,
 		ready:      make(chan interface{}),
 	}, nil
@@ -35,9 +35,11 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
 	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/p2p/node"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
@@ -163,7 +165,7 @@ func makeBlock(t *testing.T, accountIndex, numOfEndosements int, makeInvalidEndo
 		} else {
 			consensusVote = NewConsensusVote(hs[:], COMMIT)
 		}
-		en, err := endorsement.Endorse(identityset.PrivateKey(i), consensusVote, timeTime)
+		en, err := endorsement.Endorse(signer.NewLocalSigner(identityset.PrivateKey(i)), consensusVote, timeTime)
 		require.NoError(t, err)
 		enProto, err := en.Proto()
 		require.NoError(t, err)
@@ -252,6 +254,59 @@ func TestValidateBlockFooter(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestValidateBlockProducer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.Default
+	cfg.Genesis.NumDelegates = 4
+	cfg.Genesis.NumSubEpochs = 1
+	blockchain := mock_blockchain.NewMockBlockchain(ctrl)
+	blockchain.EXPECT().Genesis().Return(cfg.Genesis).AnyTimes()
+	rp := rolldpos.NewProtocol(
+		cfg.Genesis.NumCandidateDelegates,
+		cfg.Genesis.NumDelegates,
+		cfg.Genesis.NumSubEpochs,
+	)
+	r, err := NewRollDPoSBuilder().
+		SetConfig(cfg).
+		SetAddr(identityset.Address(1).String()).
+		SetPriKey(identityset.PrivateKey(1)).
+		SetChainManager(blockchain).
+		SetBroadcast(func(_ proto.Message) error {
+			return nil
+		}).
+		SetDelegatesByEpochFunc(func(epochNum uint64) ([]string, error) {
+			if epochNum > 1 {
+				return nil, errors.New("invalid epoch number")
+			}
+			return []string{
+				identityset.Address(0).String(),
+				identityset.Address(1).String(),
+				identityset.Address(2).String(),
+				identityset.Address(3).String(),
+			}, nil
+		}).
+		SetClock(clock.NewMock()).
+		RegisterProtocol(rp).
+		Build()
+	require.NoError(err)
+
+	header := func(height uint64, producer int) *block.Header {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetPrevBlockHash(hash.ZeroHash256).
+			SetTimeStamp(time.Now()).
+			SignAndBuild(identityset.PrivateKey(producer))
+		require.NoError(err)
+		return &blk.Header
+	}
+	require.NoError(r.ValidateBlockProducer(header(2, 1)))
+	require.Error(r.ValidateBlockProducer(header(2, 4)))
+	require.Equal(scheme.ErrUnknownDelegates, errors.Cause(r.ValidateBlockProducer(header(5, 1))))
+}
+
 func TestRollDPoS_Metrics(t *testing.T) {
 	t.Parallel()
 
@@ -272,7 +327,10 @@ func TestRollDPoS_Metrics(t *testing.T) {
 
 	sk1 := identityset.PrivateKey(1)
 	cfg := config.Default
-	cfg.Consensus.RollDPoS.ConsensusDBPath = "consensus.db"
+	dbPath, err := testutil.PathOfTempFile("consensus.db")
+	require.NoError(t, err)
+	defer testutil.CleanupPath(t, dbPath)
+	cfg.Consensus.RollDPoS.ConsensusDBPath = dbPath
 	cfg.Genesis.NumDelegates = 4
 	cfg.Genesis.NumSubEpochs = 1
 	cfg.Genesis.BlockInterval = 10 * time.Second
@@ -306,6 +364,7 @@ func TestRollDPoS_Metrics(t *testing.T) {
 	require.NotNil(t, r)
 	clock.Add(r.ctx.BlockInterval(blockHeight))
 	require.NoError(t, r.ctx.Start(context.Background()))
+	defer r.ctx.Stop(context.Background())
 	r.ctx.round, err = r.ctx.roundCalc.UpdateRound(r.ctx.round, blockHeight+1, r.ctx.BlockInterval(blockHeight+1), clock.Now(), 2*time.Second)
 	require.NoError(t, err)
 
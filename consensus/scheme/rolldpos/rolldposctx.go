@@ -13,7 +13,6 @@ import (
 
 	"github.com/facebookgo/clock"
 	fsm "github.com/iotexproject/go-fsm"
-	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -27,6 +26,7 @@ import (
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 var (
@@ -83,11 +83,14 @@ type rollDPoSCtx struct {
 	toleratedOvertime time.Duration
 
 	encodedAddr string
-	priKey      crypto.PrivateKey
+	signer      signer.Signer
 	round       *roundCtx
 	clock       clock.Clock
 	active      bool
 	mutex       sync.RWMutex
+	// history is the snapshots of the last finished rounds, from the earliest to the latest
+	history     []*scheme.ConsensusRound
+	historySize int
 }
 
 func newRollDPoSCtx(
@@ -101,7 +104,7 @@ func newRollDPoSCtx(
 	broadcastHandler scheme.Broadcast,
 	delegatesByEpochFunc DelegatesByEpochFunc,
 	encodedAddr string,
-	priKey crypto.PrivateKey,
+	signer signer.Signer,
 	clock clock.Clock,
 	beringHeight uint64,
 ) (*rollDPoSCtx, error) {
@@ -129,7 +132,7 @@ func newRollDPoSCtx(
 	}
 	var eManagerDB db.KVStore
 	if len(consensusDBConfig.DbPath) > 0 {
-		eManagerDB = db.NewBoltDB(consensusDBConfig)
+		eManagerDB = db.NewOnDiskDB(consensusDBConfig)
 	}
 	roundCalc := &roundCalculator{
 		delegatesByEpochFunc: delegatesByEpochFunc,
@@ -142,7 +145,7 @@ func newRollDPoSCtx(
 		ConsensusConfig:   cfg,
 		active:            active,
 		encodedAddr:       encodedAddr,
-		priKey:            priKey,
+		signer:            signer,
 		chain:             chain,
 		broadcastHandler:  broadcastHandler,
 		clock:             clock,
@@ -292,10 +295,19 @@ func (ctx *rollDPoSCtx) Prepare() error {
 	ctx.mutex.Lock()
 	defer ctx.mutex.Unlock()
 	height := ctx.chain.TipHeight() + 1
+	// the endorsements of the round are cleaned up in updating the round, so take the snapshot in advance
+	var snapshot *scheme.ConsensusRound
+	if ctx.historySize > 0 && ctx.round != nil {
+		snapshot = ctx.round.Snapshot(ctx.clock.Now())
+	}
 	newRound, err := ctx.roundCalc.UpdateRound(ctx.round, height, ctx.BlockInterval(height), ctx.clock.Now(), ctx.toleratedOvertime)
 	if err != nil {
 		return err
 	}
+	if snapshot != nil && (newRound.Height() != snapshot.Height || newRound.Number() != snapshot.Round) {
+		snapshot.Committed = newRound.Height() > snapshot.Height
+		ctx.addHistory(snapshot)
+	}
 	ctx.logger().Debug(
 		"new round",
 		zap.Uint64("height", newRound.height),
@@ -578,10 +590,41 @@ func (ctx *rollDPoSCtx) Active() bool {
 	return ctx.active
 }
 
+// CurrentRound returns the snapshot of the current round
+func (ctx *rollDPoSCtx) CurrentRound() (*scheme.ConsensusRound, error) {
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+	if ctx.round == nil {
+		return nil, errors.New("consensus round is not started yet")
+	}
+	return ctx.round.Snapshot(ctx.clock.Now()), nil
+}
+
+// RoundHistory returns the snapshots of the last n finished rounds, from the latest to the earliest
+func (ctx *rollDPoSCtx) RoundHistory(n int) []*scheme.ConsensusRound {
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+	if n > len(ctx.history) || n < 0 {
+		n = len(ctx.history)
+	}
+	rounds := make([]*scheme.ConsensusRound, 0, n)
+	for i := len(ctx.history) - 1; i >= len(ctx.history)-n; i-- {
+		rounds = append(rounds, ctx.history[i])
+	}
+	return rounds
+}
+
 ///////////////////////////////////////////
 // private functions
 ///////////////////////////////////////////
 
+func (ctx *rollDPoSCtx) addHistory(round *scheme.ConsensusRound) {
+	if len(ctx.history) >= ctx.historySize {
+		ctx.history = ctx.history[len(ctx.history)-ctx.historySize+1:]
+	}
+	ctx.history = append(ctx.history, round)
+}
+
 func (ctx *rollDPoSCtx) mintNewBlock() (*EndorsedConsensusMessage, error) {
 	var err error
 	blk := ctx.round.CachedMintedBlock()
@@ -612,7 +655,7 @@ func (ctx *rollDPoSCtx) isDelegate() bool {
 }
 
 func (ctx *rollDPoSCtx) endorseBlockProposal(proposal *blockProposal) (*EndorsedConsensusMessage, error) {
-	en, err := endorsement.Endorse(ctx.priKey, proposal, ctx.round.StartTime())
+	en, err := endorsement.Endorse(ctx.signer, proposal, ctx.round.StartTime())
 	if err != nil {
 		return nil, err
 	}
@@ -702,7 +745,7 @@ func (ctx *rollDPoSCtx) newEndorsement(
 		blkHash,
 		topic,
 	)
-	en, err := endorsement.Endorse(ctx.priKey, vote, timestamp)
+	en, err := endorsement.Endorse(ctx.signer, vote, timestamp)
 	if err != nil {
 		return nil, err
 	}
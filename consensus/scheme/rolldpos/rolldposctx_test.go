@@ -22,7 +22,9 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
 	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/identityset"
 )
@@ -249,7 +251,7 @@ func TestCheckBlockProposer(t *testing.T) {
 	block = getBlockforctx(t, 1, true)
 	hash := block.HashBlock()
 	vote := NewConsensusVote(hash[:], COMMIT)
-	en2, err = endorsement.Endorse(identityset.PrivateKey(7), vote, time.Unix(1562382592, 0))
+	en2, err = endorsement.Endorse(signer.NewLocalSigner(identityset.PrivateKey(7)), vote, time.Unix(1562382592, 0))
 	require.NoError(err)
 	bp = newBlockProposal(&block, []*endorsement.Endorsement{en2})
 	require.Error(rctx.CheckBlockProposer(51, bp, en2))
@@ -311,7 +313,7 @@ func TestNotProducingMultipleBlocks(t *testing.T) {
 			return addrs, nil
 		},
 		"",
-		identityset.PrivateKey(10),
+		signer.NewLocalSigner(identityset.PrivateKey(10)),
 		c,
 		config.Default.Genesis.BeringBlockHeight,
 	)
@@ -339,6 +341,25 @@ func TestNotProducingMultipleBlocks(t *testing.T) {
 	require.Equal(height1, height2)
 }
 
+func TestRoundHistory(t *testing.T) {
+	require := require.New(t)
+	ctx := &rollDPoSCtx{historySize: 2}
+	_, err := ctx.CurrentRound()
+	require.Error(err)
+	require.Empty(ctx.RoundHistory(3))
+	for i := 1; i <= 3; i++ {
+		ctx.addHistory(&scheme.ConsensusRound{Height: uint64(i)})
+	}
+	rounds := ctx.RoundHistory(3)
+	require.Len(rounds, 2)
+	require.Equal(uint64(3), rounds[0].Height)
+	require.Equal(uint64(2), rounds[1].Height)
+	rounds = ctx.RoundHistory(1)
+	require.Len(rounds, 1)
+	require.Equal(uint64(3), rounds[0].Height)
+	require.Empty(ctx.RoundHistory(0))
+}
+
 func getBlockforctx(t *testing.T, i int, sign bool) block.Block {
 	require := require.New(t)
 	ts := &timestamp.Timestamp{Seconds: 1596329600, Nanos: 10}
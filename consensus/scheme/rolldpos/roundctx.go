@@ -7,12 +7,15 @@
 package rolldpos
 
 import (
+	"encoding/hex"
 	"time"
 
+	"github.com/iotexproject/iotex-address/address"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
 	"github.com/iotexproject/iotex-core/endorsement"
 )
 
@@ -238,8 +241,66 @@ func (ctx *roundCtx) CachedMintedBlock() *block.Block {
 	return ctx.eManager.CachedMintedBlock()
 }
 
+// Snapshot returns the snapshot of the round at the given time
+func (ctx *roundCtx) Snapshot(now time.Time) *scheme.ConsensusRound {
+	blkHash := ctx.blockUnderVote()
+	round := &scheme.ConsensusRound{
+		Height:       ctx.height,
+		Epoch:        ctx.epochNum,
+		Round:        ctx.roundNum,
+		Proposer:     ctx.proposer,
+		Delegates:    append([]string{}, ctx.delegates...),
+		BlockHash:    hex.EncodeToString(blkHash),
+		Locked:       ctx.IsLocked(),
+		Endorsements: []*scheme.StageEndorsements{},
+		StartTime:    ctx.roundStartTime,
+		EndTime:      ctx.nextRoundStartTime,
+		Timestamp:    now,
+	}
+	if now.Before(ctx.nextRoundStartTime) {
+		round.Remaining = ctx.nextRoundStartTime.Sub(now)
+	}
+	for _, topic := range []ConsensusVoteTopic{PROPOSAL, LOCK, COMMIT} {
+		endorsed := map[string]bool{}
+		for _, en := range ctx.endorsements(blkHash, []ConsensusVoteTopic{topic}) {
+			if addr, err := address.FromBytes(en.Endorser().Hash()); err == nil {
+				endorsed[addr.String()] = true
+			}
+		}
+		stage := &scheme.StageEndorsements{
+			Topic:       topic.String(),
+			Endorsed:    []string{},
+			NotEndorsed: []string{},
+		}
+		for _, delegate := range ctx.delegates {
+			if endorsed[delegate] {
+				stage.Endorsed = append(stage.Endorsed, delegate)
+			} else {
+				stage.NotEndorsed = append(stage.NotEndorsed, delegate)
+			}
+		}
+		round.Endorsements = append(round.Endorsements, stage)
+	}
+	return round
+}
+
 // private functions
 
+// blockUnderVote returns the hash of the block in lock, or the block proposed by the proposer of the round, or nil if
+// there is no such block, on which the delegates vote for no block
+func (ctx *roundCtx) blockUnderVote() []byte {
+	if ctx.status != open {
+		return ctx.blockInLock
+	}
+	for _, c := range ctx.eManager.collections {
+		if blk := c.Block(); blk != nil && blk.Height() == ctx.height && blk.ProducerAddress() == ctx.proposer {
+			blkHash := blk.HashBlock()
+			return blkHash[:]
+		}
+	}
+	return nil
+}
+
 func (ctx *roundCtx) endorsements(blkHash []byte, topics []ConsensusVoteTopic) []*endorsement.Endorsement {
 	c := ctx.eManager.CollectionByBlockHash(blkHash)
 	if c == nil {
@@ -7,6 +7,7 @@
 package rolldpos
 
 import (
+	"encoding/hex"
 	"testing"
 	"time"
 
@@ -14,6 +15,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/signer"
+	"github.com/iotexproject/iotex-core/test/identityset"
 )
 
 func TestRoundCtx(t *testing.T) {
@@ -94,3 +97,61 @@ func TestRoundCtx(t *testing.T) {
 	})
 	// TODO: add more unit tests
 }
+
+func TestRoundCtxSnapshot(t *testing.T) {
+	require := require.New(t)
+	now := time.Now()
+	eManager, err := newEndorsementManager(nil)
+	require.NoError(err)
+	delegates := make([]string, 4)
+	for i := range delegates {
+		delegates[i] = identityset.Address(i).String()
+	}
+	round := &roundCtx{
+		height:             51,
+		roundNum:           1,
+		proposer:           delegates[0],
+		roundStartTime:     now.Add(-2 * time.Second),
+		nextRoundStartTime: now.Add(2 * time.Second),
+		epochNum:           2,
+		delegates:          delegates,
+		eManager:           eManager,
+		status:             open,
+	}
+
+	snapshot := round.Snapshot(now)
+	require.Equal(uint64(51), snapshot.Height)
+	require.Equal(uint64(2), snapshot.Epoch)
+	require.Equal(uint32(1), snapshot.Round)
+	require.Equal(delegates[0], snapshot.Proposer)
+	require.Equal(delegates, snapshot.Delegates)
+	require.Empty(snapshot.BlockHash)
+	require.False(snapshot.Locked)
+	require.Equal(2*time.Second, snapshot.Remaining)
+	require.Len(snapshot.Endorsements, 3)
+	for _, stage := range snapshot.Endorsements {
+		require.Empty(stage.Endorsed)
+		require.Equal(delegates, stage.NotEndorsed)
+	}
+
+	// endorsements of the block proposed by the proposer
+	blk := getBlockforctx(t, 0, true)
+	require.NoError(eManager.RegisterBlock(&blk))
+	blkHash := blk.HashBlock()
+	for _, i := range []int{0, 2} {
+		vote := NewConsensusVote(blkHash[:], PROPOSAL)
+		en, err := endorsement.Endorse(signer.NewLocalSigner(identityset.PrivateKey(i)), vote, now)
+		require.NoError(err)
+		require.NoError(eManager.AddVoteEndorsement(vote, en))
+	}
+	snapshot = round.Snapshot(now.Add(3 * time.Second))
+	require.Equal(hex.EncodeToString(blkHash[:]), snapshot.BlockHash)
+	require.Zero(snapshot.Remaining)
+	require.Equal("PROPOSAL", snapshot.Endorsements[0].Topic)
+	require.Equal([]string{delegates[0], delegates[2]}, snapshot.Endorsements[0].Endorsed)
+	require.Equal([]string{delegates[1], delegates[3]}, snapshot.Endorsements[0].NotEndorsed)
+	require.Equal("LOCK", snapshot.Endorsements[1].Topic)
+	require.Empty(snapshot.Endorsements[1].Endorsed)
+	require.Equal("COMMIT", snapshot.Endorsements[2].Topic)
+	require.Empty(snapshot.Endorsements[2].Endorsed)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package scheme
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// MinRoundWatchInterval is the minimal interval to poll the live consensus round, which is shared by the admin and the
+// API streams
+const MinRoundWatchInterval = time.Second
+
+// WatchRound polls the live consensus round at the interval, and calls f with the snapshot whenever the round changes,
+// until the context is done or f returns an error
+func WatchRound(ctx context.Context, ri RoundInspector, interval time.Duration, f func(*ConsensusRound) error) error {
+	if interval < MinRoundWatchInterval {
+		interval = MinRoundWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var last *ConsensusRound
+	for {
+		round, err := ri.CurrentRound()
+		if err != nil {
+			return err
+		}
+		if last == nil || roundChanged(last, round) {
+			if err := f(round); err != nil {
+				return err
+			}
+			last = round
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// roundChanged returns true if the round differs besides the time the snapshots are taken
+func roundChanged(a, b *ConsensusRound) bool {
+	x, y := *a, *b
+	x.Timestamp, y.Timestamp = time.Time{}, time.Time{}
+	x.Remaining, y.Remaining = 0, 0
+	return !reflect.DeepEqual(x, y)
+}
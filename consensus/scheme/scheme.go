@@ -7,13 +7,20 @@
 package scheme
 
 import (
+	"time"
+
 	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
 
+// ErrUnknownDelegates indicates the delegates at the height cannot be determined yet
+var ErrUnknownDelegates = errors.New("unknown delegates")
+
 // CreateBlockCB defines the callback to create a new block
 type CreateBlockCB func() (*block.Block, error)
 
@@ -36,9 +43,26 @@ type Scheme interface {
 	HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error
 	Calibrate(uint64)
 	ValidateBlockFooter(*block.Block) error
+	ValidateBlockProducer(*block.Header) error
 	Metrics() (ConsensusMetrics, error)
 	Activate(bool)
 	Active() bool
+	RoundInspector
+	EvidenceReader
+}
+
+// RoundInspector inspects the consensus rounds
+type RoundInspector interface {
+	// CurrentRound returns the snapshot of the live consensus round
+	CurrentRound() (*ConsensusRound, error)
+	// RoundHistory returns the snapshots of the last n finished rounds, from the latest to the earliest
+	RoundHistory(n int) ([]*ConsensusRound, error)
+}
+
+// EvidenceReader reads the evidence of the endorsers signing conflicting consensus messages
+type EvidenceReader interface {
+	// Evidence returns at most count evidence detected from the start height, in the order of height
+	Evidence(startHeight uint64, count uint64) ([]*evidence.Evidence, error)
 }
 
 // ConsensusMetrics contains consensus metrics to expose
@@ -48,3 +72,32 @@ type ConsensusMetrics struct {
 	LatestDelegates     []string
 	LatestBlockProducer string
 }
+
+// ConsensusRound is the snapshot of a consensus round
+type ConsensusRound struct {
+	Height    uint64   `json:"height"`
+	Epoch     uint64   `json:"epoch"`
+	Round     uint32   `json:"round"`
+	Proposer  string   `json:"proposer"`
+	Delegates []string `json:"delegates"`
+	// State is the state of the consensus fsm, which is empty for the finished rounds
+	State string `json:"state,omitempty"`
+	// BlockHash is the hex encoded hash of the block under vote, or empty if no block is under vote
+	BlockHash    string               `json:"blockHash"`
+	Locked       bool                 `json:"locked"`
+	Committed    bool                 `json:"committed"`
+	Endorsements []*StageEndorsements `json:"endorsements"`
+	StartTime    time.Time            `json:"startTime"`
+	EndTime      time.Time            `json:"endTime"`
+	// Remaining is the time remaining in the round when the snapshot is taken
+	Remaining time.Duration `json:"remaining"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+// StageEndorsements lists the delegates who have and have not endorsed the block under vote at a consensus stage
+type StageEndorsements struct {
+	// Topic is one of PROPOSAL, LOCK and COMMIT
+	Topic       string   `json:"topic"`
+	Endorsed    []string `json:"endorsed"`
+	NotEndorsed []string `json:"notEndorsed"`
+}
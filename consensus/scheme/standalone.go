@@ -15,6 +15,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/routine"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
@@ -87,6 +88,11 @@ func (s *Standalone) ValidateBlockFooter(*block.Block) error {
 	return nil
 }
 
+// ValidateBlockProducer validates the block producer
+func (s *Standalone) ValidateBlockProducer(*block.Header) error {
+	return nil
+}
+
 // Metrics is not implemented for standalone scheme
 func (s *Standalone) Metrics() (ConsensusMetrics, error) {
 	return ConsensusMetrics{}, errors.Wrapf(
@@ -95,6 +101,30 @@ func (s *Standalone) Metrics() (ConsensusMetrics, error) {
 	)
 }
 
+// CurrentRound is not implemented for standalone scheme
+func (s *Standalone) CurrentRound() (*ConsensusRound, error) {
+	return nil, errors.Wrapf(
+		ErrNotImplemented,
+		"standalone scheme does not supported round inspection yet",
+	)
+}
+
+// RoundHistory is not implemented for standalone scheme
+func (s *Standalone) RoundHistory(int) ([]*ConsensusRound, error) {
+	return nil, errors.Wrapf(
+		ErrNotImplemented,
+		"standalone scheme does not supported round inspection yet",
+	)
+}
+
+// Evidence is not implemented for standalone scheme
+func (s *Standalone) Evidence(uint64, uint64) ([]*evidence.Evidence, error) {
+	return nil, errors.Wrapf(
+		ErrNotImplemented,
+		"standalone scheme does not supported evidence detection yet",
+	)
+}
+
 // Activate is not implemented for standalone scheme
 func (s *Standalone) Activate(_ bool) {
 	log.S().Warn("Standalone scheme could not support activate")
@@ -157,9 +157,17 @@ func TestCountingIndex(t *testing.T) {
 	cfg := config.Default.DB
 	cfg.DbPath = testPath
 
+	levelPath, err := testutil.PathOfTempFile(path + ".leveldb")
+	require.NoError(t, err)
+	testutil.CleanupPath(t, levelPath)
+	defer testutil.CleanupPath(t, levelPath)
+	levelCfg := cfg
+	levelCfg.DbPath = levelPath
+
 	for _, v := range []KVStore{
 		NewMemKVStore(),
 		NewBoltDB(cfg),
+		NewLevelDB(levelCfg),
 	} {
 		t.Run("test counting index", func(t *testing.T) {
 			testFunc(v, t)
@@ -9,17 +9,30 @@ package db
 import (
 	"bytes"
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/pkg/errors"
 	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
 const fileMode = 0600
 
+// readOnlyTimeout is the time to wait for the file lock in read-only mode, instead of waiting forever
+const readOnlyTimeout = time.Second
+
+// ErrLocked indicates the DB file is locked by a running node. Bolt DB does not support reading a file while another
+// process writes it, so the DB files of a running node must be opened from a filesystem snapshot of them
+var ErrLocked = errors.New("DB file is locked by another process")
+
 // BoltDB is KVStore implementation based bolt DB
 type BoltDB struct {
 	db     *bolt.DB
@@ -38,7 +51,20 @@ func NewBoltDB(cfg config.DB) *BoltDB {
 
 // Start opens the BoltDB (creates new file if not existing yet)
 func (b *BoltDB) Start(_ context.Context) error {
-	db, err := bolt.Open(b.path, fileMode, nil)
+	var opts *bolt.Options
+	if b.config.ReadOnly {
+		opts = &bolt.Options{ReadOnly: true, Timeout: readOnlyTimeout}
+	} else {
+		// remove the copies of the read views left by the last run
+		views, _ := filepath.Glob(b.path + ".view*")
+		for _, view := range views {
+			os.Remove(view)
+		}
+	}
+	db, err := bolt.Open(b.path, fileMode, opts)
+	if err == bolt.ErrTimeout && b.config.ReadOnly {
+		return errors.Wrapf(ErrLocked, "file = %s", b.path)
+	}
 	if err != nil {
 		return errors.Wrap(ErrIO, err.Error())
 	}
@@ -311,6 +337,90 @@ func (b *BoltDB) BucketExists(namespace string) bool {
 	return exist
 }
 
+// ForEach calls the function on every record of every bucket in a read-only transaction
+func (b *BoltDB) ForEach(fn func(string, []byte, []byte) error) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		return forEachInTx(tx, fn)
+	})
+}
+
+// ReadView returns a view of the DB on a point-in-time copy of the file. The copy is taken in a read-only transaction
+// as fast as the file is read, rather than holding the transaction while the view is read, since a long-lived
+// transaction blocks the writes which remap the growing file
+func (b *BoltDB) ReadView() (ReadView, error) {
+	f, err := ioutil.TempFile(filepath.Dir(b.path), filepath.Base(b.path)+".view")
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	path := f.Name()
+	if err := f.Close(); err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	if err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(path, fileMode)
+	}); err != nil {
+		os.Remove(path)
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	db, err := bolt.Open(path, fileMode, &bolt.Options{ReadOnly: true, Timeout: readOnlyTimeout})
+	if err != nil {
+		os.Remove(path)
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return &boltReadView{db: db, path: path}, nil
+}
+
+// boltReadView is a view of bolt DB on a copy of the file, which is removed once the view is released
+type boltReadView struct {
+	db   *bolt.DB
+	path string
+}
+
+func (v *boltReadView) Get(namespace string, key []byte) ([]byte, error) {
+	var value []byte
+	err := v.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(namespace))
+		if bucket == nil {
+			return errors.Wrapf(ErrNotExist, "bucket = %x doesn't exist", []byte(namespace))
+		}
+		v := bucket.Get(key)
+		if v == nil {
+			return errors.Wrapf(ErrNotExist, "key = %x doesn't exist", key)
+		}
+		value = copyBytes(v)
+		return nil
+	})
+	return value, err
+}
+
+func (v *boltReadView) ForEach(fn func(string, []byte, []byte) error) error {
+	return v.db.View(func(tx *bolt.Tx) error {
+		return forEachInTx(tx, fn)
+	})
+}
+
+func (v *boltReadView) Release() {
+	if err := v.db.Close(); err != nil {
+		log.L().Error("Failed to close read view.", zap.Error(err))
+	}
+	if err := os.Remove(v.path); err != nil {
+		log.L().Error("Failed to remove read view.", zap.String("path", v.path), zap.Error(err))
+	}
+}
+
+func forEachInTx(tx *bolt.Tx, fn func(string, []byte, []byte) error) error {
+	return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+		namespace := string(name)
+		return bucket.ForEach(func(k, v []byte) error {
+			if v == nil {
+				// nested bucket
+				return nil
+			}
+			return fn(namespace, copyBytes(k), copyBytes(v))
+		})
+	})
+}
+
 // ======================================
 // below functions used by RangeIndex
 // ======================================
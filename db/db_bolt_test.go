@@ -11,6 +11,7 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/config"
@@ -36,6 +37,36 @@ func TestBucketExists(t *testing.T) {
 	r.True(kv.BucketExists("name"))
 }
 
+func TestBoltDBReadOnly(t *testing.T) {
+	r := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-readonly")
+	r.NoError(err)
+	testutil.CleanupPath(t, testPath)
+	defer testutil.CleanupPath(t, testPath)
+
+	cfg := config.Default.DB
+	cfg.DbPath = testPath
+	ctx := context.Background()
+	readOnly := cfg
+	readOnly.ReadOnly = true
+	r.Error(NewBoltDB(readOnly).Start(ctx))
+
+	// the file locked by the writer cannot be opened
+	kv := NewBoltDB(cfg)
+	r.NoError(kv.Start(ctx))
+	r.NoError(kv.Put("ns", []byte("key"), []byte("value")))
+	r.Equal(ErrLocked, errors.Cause(NewBoltDB(readOnly).Start(ctx)))
+	r.NoError(kv.Stop(ctx))
+
+	kv = NewBoltDB(readOnly)
+	r.NoError(kv.Start(ctx))
+	defer kv.Stop(ctx)
+	v, err := kv.Get("ns", []byte("key"))
+	r.NoError(err)
+	r.Equal([]byte("value"), v)
+	r.Error(kv.Put("ns", []byte("key"), []byte("new value")))
+}
+
 func BenchmarkBoltDB_Get(b *testing.B) {
 	runBenchmark := func(b *testing.B, size int) {
 		path, err := testutil.PathOfTempFile("boltdb")
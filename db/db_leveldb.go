@@ -0,0 +1,563 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// errKeyRequired indicates an empty key is written, which bolt DB does not support either
+var errKeyRequired = errors.New("key required")
+
+// the namespace of a record is encoded into the prefix of the leveldb key, where byte 0x00 of the namespace is escaped
+// into {0x00, 0xff}, and the namespace is terminated by {0x00, 0x01}. So the records are ordered by namespace first,
+// and the namespaces sharing a prefix are adjacent. The key of the bare prefix marks the existence of the namespace
+var (
+	nsEscape     = []byte{0x00, 0xff}
+	nsTerminator = []byte{0x00, 0x01}
+	// nsUpperBound is greater than the terminator and the escaped byte 0x00, and less than any other escaped byte
+	nsUpperBound = []byte{0x00, 0x02}
+)
+
+// LevelDB is KVStore implementation based on leveldb, a LSM-tree which suits large write batches better than bolt DB
+type LevelDB struct {
+	// mutex serializes the writes, so the read-modify-writes of range index are atomic
+	mutex  sync.Mutex
+	db     *leveldb.DB
+	path   string
+	config config.DB
+}
+
+// NewLevelDB instantiates a LevelDB which implements KVStore
+func NewLevelDB(cfg config.DB) *LevelDB {
+	return &LevelDB{
+		db:     nil,
+		path:   cfg.DbPath,
+		config: cfg,
+	}
+}
+
+// NewOnDiskDB instantiates the KVStore of the engine selected for cfg.DbPath
+func NewOnDiskDB(cfg config.DB) KVStoreForRangeIndex {
+	if cfg.KVEngineOfPath() == config.LevelDBEngine {
+		return NewLevelDB(cfg)
+	}
+	return NewBoltDB(cfg)
+}
+
+// Start opens the LevelDB (creates new directory if not existing yet)
+func (l *LevelDB) Start(_ context.Context) error {
+	opts := &opt.Options{}
+	if l.config.ReadOnly {
+		opts.ReadOnly = true
+		opts.ErrorIfMissing = true
+	}
+	db, err := leveldb.OpenFile(l.path, opts)
+	if err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	l.db = db
+	return nil
+}
+
+// Stop closes the LevelDB
+func (l *LevelDB) Stop(_ context.Context) error {
+	if l.db != nil {
+		if err := l.db.Close(); err != nil {
+			return errors.Wrap(ErrIO, err.Error())
+		}
+	}
+	return nil
+}
+
+// Put inserts a <key, value> record
+func (l *LevelDB) Put(namespace string, key, value []byte) error {
+	if len(key) == 0 {
+		return errors.Wrap(ErrIO, errKeyRequired.Error())
+	}
+	b := new(leveldb.Batch)
+	b.Put(nsPrefix([]byte(namespace)), nil)
+	b.Put(nsKey([]byte(namespace), key), value)
+	return l.write(b)
+}
+
+// Get retrieves a record
+func (l *LevelDB) Get(namespace string, key []byte) ([]byte, error) {
+	value, err := l.db.Get(nsKey([]byte(namespace), key), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, errors.Wrapf(ErrNotExist, "key = %x doesn't exist", key)
+	}
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return value, nil
+}
+
+// Filter returns <k, v> pair in a bucket that meet the condition
+func (l *LevelDB) Filter(namespace string, cond Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
+	snapshot, iter, err := l.bucketIterator([]byte(namespace))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer snapshot.Release()
+	defer iter.Release()
+
+	var (
+		fk, fv   [][]byte
+		ok       bool
+		prefix   = nsPrefix([]byte(namespace))
+		checkMax = len(maxKey) > 0
+	)
+	if len(minKey) > 0 {
+		ok = iter.Seek(nsKey([]byte(namespace), minKey))
+	} else {
+		ok = iter.First()
+	}
+	for ; ok; ok = iter.Next() {
+		k := iter.Key()[len(prefix):]
+		if len(k) == 0 {
+			// the marker of the namespace
+			continue
+		}
+		if checkMax && bytes.Compare(k, maxKey) == 1 {
+			break
+		}
+		if cond(k, iter.Value()) {
+			fk = append(fk, copyBytes(k))
+			fv = append(fv, copyBytes(iter.Value()))
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, nil, errors.Wrap(ErrIO, err.Error())
+	}
+
+	if len(fk) == 0 {
+		return nil, nil, errors.Wrap(ErrNotExist, "filter returns no match")
+	}
+	return fk, fv, nil
+}
+
+// Range retrieves values for a range of keys
+func (l *LevelDB) Range(namespace string, key []byte, count uint64) ([][]byte, error) {
+	snapshot, iter, err := l.bucketIterator([]byte(namespace))
+	if err != nil {
+		if errors.Cause(err) == ErrBucketNotExist {
+			return nil, errors.Wrapf(ErrNotExist, "bucket = %s doesn't exist", namespace)
+		}
+		return nil, err
+	}
+	defer snapshot.Release()
+	defer iter.Release()
+
+	ok := iter.Seek(nsKey([]byte(namespace), key))
+	if ok && len(iter.Key()) == len(nsPrefix([]byte(namespace))) {
+		// skip the marker of the namespace
+		ok = iter.Next()
+	}
+	if !ok {
+		return nil, errors.Wrapf(ErrNotExist, "entry for key 0x%x doesn't exist", key)
+	}
+	value := make([][]byte, count)
+	for i := uint64(0); i < count; i++ {
+		if i > 0 && !iter.Next() {
+			return nil, errors.Wrapf(ErrNotExist, "entry for key 0x%x doesn't exist", key)
+		}
+		value[i] = copyBytes(iter.Value())
+	}
+	return value, nil
+}
+
+// GetBucketByPrefix retrieves all bucket those with const namespace prefix
+func (l *LevelDB) GetBucketByPrefix(namespace []byte) ([][]byte, error) {
+	allKey := make([][]byte, 0)
+	iter := l.db.NewIterator(util.BytesPrefix(escapeNamespace(namespace)), nil)
+	defer iter.Release()
+	for ok := iter.First(); ok; {
+		name, _, err := splitNamespace(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(name, namespace) {
+			allKey = append(allKey, name)
+		}
+		// skip the rest records of the namespace
+		ok = iter.Seek(append(escapeNamespace(name), nsUpperBound...))
+	}
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return allKey, nil
+}
+
+// GetKeyByPrefix retrieves all keys those with const prefix
+func (l *LevelDB) GetKeyByPrefix(namespace, prefix []byte) ([][]byte, error) {
+	snapshot, err := l.db.GetSnapshot()
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	defer snapshot.Release()
+	if exist, err := snapshot.Has(nsPrefix(namespace), nil); err != nil || !exist {
+		return nil, ErrNotExist
+	}
+
+	allKey := make([][]byte, 0)
+	iter := snapshot.NewIterator(util.BytesPrefix(nsKey(namespace, prefix)), nil)
+	defer iter.Release()
+	bucketPrefix := nsPrefix(namespace)
+	for iter.Next() {
+		if k := iter.Key()[len(bucketPrefix):]; len(k) > 0 {
+			allKey = append(allKey, copyBytes(k))
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return allKey, nil
+}
+
+// Delete deletes a record,if key is nil,this will delete the whole bucket
+func (l *LevelDB) Delete(namespace string, key []byte) error {
+	if key != nil {
+		b := new(leveldb.Batch)
+		b.Delete(nsKey([]byte(namespace), key))
+		return l.write(b)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	b := new(leveldb.Batch)
+	iter := l.db.NewIterator(util.BytesPrefix(nsPrefix([]byte(namespace))), nil)
+	for iter.Next() {
+		b.Delete(copyBytes(iter.Key()))
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	return l.writeLocked(b)
+}
+
+// WriteBatch commits a batch
+func (l *LevelDB) WriteBatch(kvsb batch.KVStoreBatch) error {
+	kvsb.Lock()
+	defer kvsb.Unlock()
+
+	var (
+		b       = new(leveldb.Batch)
+		buckets = make(map[string]bool)
+	)
+	for i := 0; i < kvsb.Size(); i++ {
+		write, e := kvsb.Entry(i)
+		if e != nil {
+			return errors.Wrap(ErrIO, e.Error())
+		}
+		ns := write.Namespace()
+		switch write.WriteType() {
+		case batch.Put:
+			if len(write.Key()) == 0 {
+				return errors.Wrap(ErrIO, errors.Wrapf(errKeyRequired, write.ErrorFormat(), write.ErrorArgs()).Error())
+			}
+			if !buckets[ns] {
+				b.Put(nsPrefix([]byte(ns)), nil)
+				buckets[ns] = true
+			}
+			b.Put(nsKey([]byte(ns), write.Key()), write.Value())
+		case batch.Delete:
+			b.Delete(nsKey([]byte(ns), write.Key()))
+		}
+	}
+	return l.write(b)
+}
+
+// BucketExists returns true if bucket exists
+func (l *LevelDB) BucketExists(namespace string) bool {
+	exist, err := l.db.Has(nsPrefix([]byte(namespace)), nil)
+	return err == nil && exist
+}
+
+// ForEach calls the function on every record of every bucket from a snapshot
+func (l *LevelDB) ForEach(fn func(string, []byte, []byte) error) error {
+	snapshot, err := l.db.GetSnapshot()
+	if err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	defer snapshot.Release()
+	return forEachInSnapshot(snapshot, fn)
+}
+
+// ReadView returns a view of the DB from a snapshot
+func (l *LevelDB) ReadView() (ReadView, error) {
+	snapshot, err := l.db.GetSnapshot()
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return &levelReadView{snapshot: snapshot}, nil
+}
+
+// levelReadView is a view of leveldb from a snapshot
+type levelReadView struct {
+	snapshot *leveldb.Snapshot
+}
+
+func (v *levelReadView) Get(namespace string, key []byte) ([]byte, error) {
+	value, err := v.snapshot.Get(nsKey([]byte(namespace), key), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, errors.Wrapf(ErrNotExist, "key = %x doesn't exist", key)
+	}
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return value, nil
+}
+
+func (v *levelReadView) ForEach(fn func(string, []byte, []byte) error) error {
+	return forEachInSnapshot(v.snapshot, fn)
+}
+
+func (v *levelReadView) Release() {
+	v.snapshot.Release()
+}
+
+func forEachInSnapshot(snapshot *leveldb.Snapshot, fn func(string, []byte, []byte) error) error {
+	iter := snapshot.NewIterator(nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		name, key, err := splitNamespace(iter.Key())
+		if err != nil {
+			return err
+		}
+		if len(key) == 0 {
+			// the marker of the namespace
+			continue
+		}
+		if err := fn(string(name), copyBytes(key), copyBytes(iter.Value())); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	return nil
+}
+
+// ======================================
+// below functions used by RangeIndex
+// ======================================
+
+// Insert inserts a value into the index
+func (l *LevelDB) Insert(name []byte, key uint64, value []byte) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	snapshot, iter, err := l.bucketIterator(name)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+	defer iter.Release()
+
+	var (
+		b      = new(leveldb.Batch)
+		prefix = nsPrefix(name)
+		ak     = byteutil.Uint64ToBytesBigEndian(key - 1)
+		k, v   []byte
+	)
+	if iter.Seek(nsKey(name, ak)) {
+		k, v = copyBytes(iter.Key()[len(prefix):]), copyBytes(iter.Value())
+	}
+	if !bytes.Equal(k, ak) {
+		// insert new key
+		b.Put(nsKey(name, ak), v)
+	} else {
+		// update an existing key
+		k = nil
+		if iter.Next() {
+			k = copyBytes(iter.Key()[len(prefix):])
+		}
+	}
+	if k != nil {
+		b.Put(nsKey(name, k), value)
+	}
+	return l.writeLocked(b)
+}
+
+// Seek returns value by the key
+func (l *LevelDB) Seek(name []byte, key uint64) ([]byte, error) {
+	snapshot, iter, err := l.bucketIterator(name)
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Release()
+	defer iter.Release()
+
+	value := []byte{}
+	if iter.Seek(nsKey(name, byteutil.Uint64ToBytesBigEndian(key))) {
+		value = copyBytes(iter.Value())
+	}
+	return value, nil
+}
+
+// Remove removes an existing key
+func (l *LevelDB) Remove(name []byte, key uint64) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	snapshot, iter, err := l.bucketIterator(name)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+	defer iter.Release()
+
+	prefix := nsPrefix(name)
+	ak := byteutil.Uint64ToBytesBigEndian(key - 1)
+	if !iter.Seek(nsKey(name, ak)) || !bytes.Equal(iter.Key()[len(prefix):], ak) {
+		// return nil if the key does not exist
+		return nil
+	}
+	v := copyBytes(iter.Value())
+	b := new(leveldb.Batch)
+	b.Delete(nsKey(name, ak))
+	// write the corresponding value to next key
+	if iter.Next() {
+		b.Put(copyBytes(iter.Key()), v)
+	}
+	return l.writeLocked(b)
+}
+
+// Purge deletes an existing key and all keys before it
+func (l *LevelDB) Purge(name []byte, key uint64) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	snapshot, iter, err := l.bucketIterator(name)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+	defer iter.Release()
+
+	var (
+		b      = new(leveldb.Batch)
+		prefix = nsPrefix(name)
+		nk     []byte
+		ok     bool
+	)
+	if iter.Seek(nsKey(name, byteutil.Uint64ToBytesBigEndian(key))) {
+		nk = copyBytes(iter.Key())
+		ok = iter.Prev()
+	} else {
+		ok = iter.Last()
+	}
+	// delete all keys before this key
+	for ; ok && len(iter.Key()) > len(prefix); ok = iter.Prev() {
+		b.Delete(copyBytes(iter.Key()))
+	}
+	// write not exist value to next key
+	if nk != nil {
+		b.Put(nk, NotExist)
+	}
+	return l.writeLocked(b)
+}
+
+// ======================================
+// private functions
+// ======================================
+
+// bucketIterator returns the iterator of the bucket from a snapshot, or ErrBucketNotExist
+func (l *LevelDB) bucketIterator(name []byte) (*leveldb.Snapshot, iterator.Iterator, error) {
+	snapshot, err := l.db.GetSnapshot()
+	if err != nil {
+		return nil, nil, errors.Wrap(ErrIO, err.Error())
+	}
+	prefix := nsPrefix(name)
+	exist, err := snapshot.Has(prefix, nil)
+	if err != nil {
+		snapshot.Release()
+		return nil, nil, errors.Wrap(ErrIO, err.Error())
+	}
+	if !exist {
+		snapshot.Release()
+		return nil, nil, errors.Wrapf(ErrBucketNotExist, "bucket = %x doesn't exist", name)
+	}
+	return snapshot, snapshot.NewIterator(util.BytesPrefix(prefix), nil), nil
+}
+
+func (l *LevelDB) write(b *leveldb.Batch) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.writeLocked(b)
+}
+
+func (l *LevelDB) writeLocked(b *leveldb.Batch) error {
+	if b.Len() == 0 {
+		return nil
+	}
+	if err := l.db.Write(b, &opt.WriteOptions{Sync: true}); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	return nil
+}
+
+func escapeNamespace(namespace []byte) []byte {
+	escaped := make([]byte, 0, len(namespace)+len(nsTerminator))
+	for _, c := range namespace {
+		if c == 0x00 {
+			escaped = append(escaped, nsEscape...)
+		} else {
+			escaped = append(escaped, c)
+		}
+	}
+	return escaped
+}
+
+func nsPrefix(namespace []byte) []byte {
+	return append(escapeNamespace(namespace), nsTerminator...)
+}
+
+func nsKey(namespace, key []byte) []byte {
+	return append(nsPrefix(namespace), key...)
+}
+
+// splitNamespace splits a leveldb key into the namespace and the key in the namespace
+func splitNamespace(k []byte) ([]byte, []byte, error) {
+	var namespace []byte
+	for i := 0; i < len(k); i++ {
+		if k[i] != 0x00 {
+			namespace = append(namespace, k[i])
+			continue
+		}
+		if i+1 == len(k) {
+			break
+		}
+		switch k[i+1] {
+		case nsEscape[1]:
+			namespace = append(namespace, 0x00)
+			i++
+		case nsTerminator[1]:
+			return namespace, k[i+2:], nil
+		default:
+			return nil, nil, errors.Wrapf(ErrInvalid, "invalid key %x", k)
+		}
+	}
+	return nil, nil, errors.Wrapf(ErrInvalid, "invalid key %x", k)
+}
+
+func copyBytes(b []byte) []byte {
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
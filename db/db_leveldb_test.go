@@ -0,0 +1,86 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestLevelDBNamespace(t *testing.T) {
+	r := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-leveldb")
+	r.NoError(err)
+	testutil.CleanupPath(t, testPath)
+	defer testutil.CleanupPath(t, testPath)
+
+	cfg := config.Default.DB
+	cfg.DbPath = testPath
+	kv := NewLevelDB(cfg)
+	ctx := context.Background()
+	r.NoError(kv.Start(ctx))
+	defer kv.Stop(ctx)
+
+	r.False(kv.BucketExists("name"))
+	r.NoError(kv.Put("name", []byte("key"), []byte{}))
+	r.True(kv.BucketExists("name"))
+	r.Error(kv.Put("name", nil, []byte("value")))
+
+	// namespaces with byte 0x00 and sharing a prefix do not overlap
+	namespaces := []string{"name\x00", "name\x00\x01", "name\x01", "name2", "other"}
+	for _, ns := range namespaces {
+		r.NoError(kv.Put(ns, []byte("key"), []byte(ns)))
+	}
+	for _, ns := range namespaces {
+		v, err := kv.Get(ns, []byte("key"))
+		r.NoError(err)
+		r.Equal([]byte(ns), v)
+	}
+	buckets, err := kv.GetBucketByPrefix([]byte("name"))
+	r.NoError(err)
+	r.Equal([][]byte{[]byte("name\x00"), []byte("name\x00\x01"), []byte("name\x01"), []byte("name2")}, buckets)
+
+	var ns []string
+	r.NoError(kv.ForEach(func(n string, k, v []byte) error {
+		ns = append(ns, n)
+		r.Equal([]byte("key"), k)
+		return nil
+	}))
+	r.Equal(append([]string{"name"}, namespaces...), ns)
+
+	r.NoError(kv.Put("name", []byte("key2"), []byte{}))
+	r.NoError(kv.Put("name", []byte("other"), []byte{}))
+	keys, err := kv.GetKeyByPrefix([]byte("name"), []byte("key"))
+	r.NoError(err)
+	r.Equal([][]byte{[]byte("key"), []byte("key2")}, keys)
+	_, err = kv.GetKeyByPrefix([]byte("none"), nil)
+	r.Equal(ErrNotExist, errors.Cause(err))
+
+	// delete the bucket
+	r.NoError(kv.Delete("name\x00", nil))
+	r.False(kv.BucketExists("name\x00"))
+	r.True(kv.BucketExists("name\x00\x01"))
+	_, err = kv.Get("name\x00", []byte("key"))
+	r.Equal(ErrNotExist, errors.Cause(err))
+}
+
+func TestNewOnDiskDB(t *testing.T) {
+	r := require.New(t)
+	cfg := config.Default.DB
+	cfg.DbPath = "trie.db"
+	_, ok := NewOnDiskDB(cfg).(*BoltDB)
+	r.True(ok)
+	cfg.KVEngineByPath = map[string]string{"trie.db": config.LevelDBEngine}
+	_, ok = NewOnDiskDB(cfg).(*LevelDB)
+	r.True(ok)
+}
@@ -63,9 +63,17 @@ func TestKVStorePutGet(t *testing.T) {
 	cfg := config.Default.DB
 	cfg.DbPath = testPath
 
+	levelPath, err := testutil.PathOfTempFile(path + ".leveldb")
+	require.NoError(t, err)
+	testutil.CleanupPath(t, levelPath)
+	defer testutil.CleanupPath(t, levelPath)
+	levelCfg := cfg
+	levelCfg.DbPath = levelPath
+
 	for _, v := range []KVStore{
 		NewMemKVStore(),
 		NewBoltDB(cfg),
+		NewLevelDB(levelCfg),
 	} {
 		t.Run("test put get", func(t *testing.T) {
 			testKVStorePutGet(v, t)
@@ -245,9 +253,17 @@ func TestDBBatch(t *testing.T) {
 	cfg := config.Default.DB
 	cfg.DbPath = testPath
 
+	levelPath, err := testutil.PathOfTempFile(path + ".leveldb")
+	require.NoError(t, err)
+	testutil.CleanupPath(t, levelPath)
+	defer testutil.CleanupPath(t, levelPath)
+	levelCfg := cfg
+	levelCfg.DbPath = levelPath
+
 	for _, v := range []KVStore{
 		NewMemKVStore(),
 		NewBoltDB(cfg),
+		NewLevelDB(levelCfg),
 	} {
 		t.Run("test batch", func(t *testing.T) {
 			testBatchRollback(v, t)
@@ -298,9 +314,17 @@ func TestCacheKV(t *testing.T) {
 	cfg := config.Default.DB
 	cfg.DbPath = testPath
 
+	levelPath, err := testutil.PathOfTempFile(path + ".leveldb")
+	require.NoError(t, err)
+	testutil.CleanupPath(t, levelPath)
+	defer testutil.CleanupPath(t, levelPath)
+	levelCfg := cfg
+	levelCfg.DbPath = levelPath
+
 	for _, v := range []KVStore{
 		NewMemKVStore(),
 		NewBoltDB(cfg),
+		NewLevelDB(levelCfg),
 	} {
 		t.Run("test cache kv", func(t *testing.T) {
 			testFunc(v, t)
@@ -347,6 +371,15 @@ func TestDeleteBucket(t *testing.T) {
 	t.Run("test delete bucket", func(t *testing.T) {
 		testFunc(NewBoltDB(cfg), t)
 	})
+
+	levelPath, err := testutil.PathOfTempFile(path + ".leveldb")
+	require.NoError(t, err)
+	testutil.CleanupPath(t, levelPath)
+	defer testutil.CleanupPath(t, levelPath)
+	cfg.DbPath = levelPath
+	t.Run("test delete bucket in leveldb", func(t *testing.T) {
+		testFunc(NewLevelDB(cfg), t)
+	})
 }
 
 func TestFilter(t *testing.T) {
@@ -449,4 +482,134 @@ func TestFilter(t *testing.T) {
 	t.Run("test filter", func(t *testing.T) {
 		testFunc(NewBoltDB(cfg), t)
 	})
+
+	levelPath, err := testutil.PathOfTempFile(path + ".leveldb")
+	require.NoError(err)
+	testutil.CleanupPath(t, levelPath)
+	defer testutil.CleanupPath(t, levelPath)
+	cfg.DbPath = levelPath
+	t.Run("test filter in leveldb", func(t *testing.T) {
+		testFunc(NewLevelDB(cfg), t)
+	})
+}
+
+func TestForEach(t *testing.T) {
+	require := require.New(t)
+
+	testFunc := func(kv KVStoreWithForEach, t *testing.T) {
+		require.NoError(kv.Start(context.Background()))
+		defer func() {
+			require.NoError(kv.Stop(context.Background()))
+		}()
+
+		b := batch.NewBatch()
+		for i := 2; i >= 0; i-- {
+			b.Put(bucket2, testK2[i], testV2[i], "")
+			b.Put(bucket1, testK1[i], testV1[i], "")
+		}
+		b.Delete(bucket2, testK2[1], "")
+		require.NoError(kv.WriteBatch(b))
+
+		var ns []string
+		var keys, values [][]byte
+		require.NoError(kv.ForEach(func(n string, k, v []byte) error {
+			ns = append(ns, n)
+			keys = append(keys, k)
+			values = append(values, v)
+			return nil
+		}))
+		require.Equal([]string{bucket1, bucket1, bucket1, bucket2, bucket2}, ns)
+		require.Equal([][]byte{testK1[0], testK1[1], testK1[2], testK2[0], testK2[2]}, keys)
+		require.Equal([][]byte{testV1[0], testV1[1], testV1[2], testV2[0], testV2[2]}, values)
+
+		// stop at the first error
+		count := 0
+		err := kv.ForEach(func(string, []byte, []byte) error {
+			count++
+			return ErrIO
+		})
+		require.Equal(ErrIO, errors.Cause(err))
+		require.Equal(1, count)
+	}
+
+	path := "test-foreach.bolt"
+	testPath, err := testutil.PathOfTempFile(path)
+	require.NoError(err)
+	testutil.CleanupPath(t, testPath)
+	defer testutil.CleanupPath(t, testPath)
+	cfg := config.Default.DB
+	cfg.DbPath = testPath
+
+	t.Run("bolt db", func(t *testing.T) {
+		testFunc(NewBoltDB(cfg), t)
+	})
+	t.Run("in-memory kv", func(t *testing.T) {
+		testFunc(NewMemKVStore().(KVStoreWithForEach), t)
+	})
+
+	levelPath, err := testutil.PathOfTempFile(path + ".leveldb")
+	require.NoError(err)
+	testutil.CleanupPath(t, levelPath)
+	defer testutil.CleanupPath(t, levelPath)
+	cfg.DbPath = levelPath
+	t.Run("leveldb", func(t *testing.T) {
+		testFunc(NewLevelDB(cfg), t)
+	})
+}
+
+func TestReadView(t *testing.T) {
+	require := require.New(t)
+
+	testFunc := func(kv KVStoreWithReadView, t *testing.T) {
+		require.NoError(kv.Start(context.Background()))
+		defer func() {
+			require.NoError(kv.Stop(context.Background()))
+		}()
+
+		require.NoError(kv.Put(bucket1, testK1[0], testV1[0]))
+		view, err := kv.ReadView()
+		require.NoError(err)
+		// the writes after the view is taken are not visible, and do not wait for the view to be released
+		require.NoError(kv.Put(bucket1, testK1[0], testV1[1]))
+		require.NoError(kv.Put(bucket1, testK1[1], testV1[1]))
+		v, err := view.Get(bucket1, testK1[0])
+		require.NoError(err)
+		require.Equal(testV1[0], v)
+		_, err = view.Get(bucket1, testK1[1])
+		require.Equal(ErrNotExist, errors.Cause(err))
+		count := 0
+		require.NoError(view.ForEach(func(string, []byte, []byte) error {
+			count++
+			return nil
+		}))
+		require.Equal(1, count)
+		view.Release()
+		v, err = kv.Get(bucket1, testK1[1])
+		require.NoError(err)
+		require.Equal(testV1[1], v)
+	}
+
+	path := "test-readview.bolt"
+	testPath, err := testutil.PathOfTempFile(path)
+	require.NoError(err)
+	testutil.CleanupPath(t, testPath)
+	defer testutil.CleanupPath(t, testPath)
+	cfg := config.Default.DB
+	cfg.DbPath = testPath
+
+	t.Run("bolt db", func(t *testing.T) {
+		testFunc(NewBoltDB(cfg), t)
+	})
+	t.Run("in-memory kv", func(t *testing.T) {
+		testFunc(NewMemKVStore().(KVStoreWithReadView), t)
+	})
+
+	levelPath, err := testutil.PathOfTempFile(path + ".leveldb")
+	require.NoError(err)
+	testutil.CleanupPath(t, levelPath)
+	defer testutil.CleanupPath(t, levelPath)
+	cfg.DbPath = levelPath
+	t.Run("leveldb", func(t *testing.T) {
+		testFunc(NewLevelDB(cfg), t)
+	})
 }
@@ -44,6 +44,32 @@ type (
 		Range(string, []byte, uint64) ([][]byte, error)
 	}
 
+	// KVStoreWithForEach is KVStore with ForEach() API
+	KVStoreWithForEach interface {
+		KVStore
+		// ForEach calls the function on every <namespace, k, v> record in the order of namespaces and keys, and stops
+		// at the first error returned by the function. The records are read from a consistent view of the store
+		ForEach(func(string, []byte, []byte) error) error
+	}
+
+	// KVStoreWithReadView is KVStore with ReadView() API
+	KVStoreWithReadView interface {
+		KVStoreWithForEach
+		// ReadView returns a consistent read-only view of the store at the time of the call, which is not affected by
+		// the later writes. The view has to be released once done
+		ReadView() (ReadView, error)
+	}
+
+	// ReadView is a consistent read-only view of a KVStore
+	ReadView interface {
+		// Get gets a record by (namespace, key)
+		Get(string, []byte) ([]byte, error)
+		// ForEach calls the function on every <namespace, k, v> record in the order of namespaces and keys
+		ForEach(func(string, []byte, []byte) error) error
+		// Release releases the view
+		Release()
+	}
+
 	// KVStoreForRangeIndex is KVStore for range index
 	KVStoreForRangeIndex interface {
 		KVStore
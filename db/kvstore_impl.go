@@ -8,6 +8,8 @@ package db
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -97,6 +99,53 @@ func (m *memKVStore) Filter(namespace string, c Condition, minKey, maxKey []byte
 	return nil, nil, errors.New("in-memory KVStore does not support Filter()")
 }
 
+// ForEach calls the function on every record, the records written during the iteration may not be visited
+func (m *memKVStore) ForEach(fn func(string, []byte, []byte) error) error {
+	var namespaces []string
+	m.bucket.Range(func(k, _ interface{}) bool {
+		namespaces = append(namespaces, k.(string))
+		return true
+	})
+	sort.Strings(namespaces)
+	for _, ns := range namespaces {
+		prefix := ns + keyDelimiter
+		var keys []string
+		m.data.Range(func(k, _ interface{}) bool {
+			if key := k.(string); strings.HasPrefix(key, prefix) {
+				keys = append(keys, key[len(prefix):])
+			}
+			return true
+		})
+		sort.Strings(keys)
+		for _, key := range keys {
+			v, ok := m.data.Load(prefix + key)
+			if !ok {
+				continue
+			}
+			if err := fn(ns, []byte(key), v.([]byte)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ReadView returns a view on a copy of the records, the records written during the copy may not be included
+func (m *memKVStore) ReadView() (ReadView, error) {
+	view := &memReadView{NewMemKVStore().(*memKVStore)}
+	if err := m.ForEach(view.Put); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// memReadView is a view on a copy of memKVStore
+type memReadView struct {
+	*memKVStore
+}
+
+func (v *memReadView) Release() {}
+
 // WriteBatch commits a batch
 func (m *memKVStore) WriteBatch(b batch.KVStoreBatch) (e error) {
 	succeed := false
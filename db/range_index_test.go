@@ -18,6 +18,30 @@ import (
 )
 
 func TestRangeIndex(t *testing.T) {
+	testKVStores(t, "test-indexer", testRangeIndex)
+}
+
+func TestRangeIndex2(t *testing.T) {
+	testKVStores(t, "test-ranger", testRangeIndex2)
+}
+
+// testKVStores runs the test on each on-disk KV store
+func testKVStores(t *testing.T, path string, testFunc func(*testing.T, KVStore)) {
+	for _, engine := range []string{config.BoltDBEngine, config.LevelDBEngine} {
+		testPath, err := testutil.PathOfTempFile(path + "." + engine)
+		require.NoError(t, err)
+		testutil.CleanupPath(t, testPath)
+		cfg := config.Default.DB
+		cfg.DbPath = testPath
+		cfg.KVEngine = engine
+		t.Run(engine, func(t *testing.T) {
+			defer testutil.CleanupPath(t, testPath)
+			testFunc(t, NewOnDiskDB(cfg))
+		})
+	}
+}
+
+func testRangeIndex(t *testing.T, kv KVStore) {
 	require := require.New(t)
 
 	rangeTests := []struct {
@@ -31,17 +55,6 @@ func TestRangeIndex(t *testing.T) {
 		{999, []byte("nine-nine-nine")},
 	}
 
-	path := "test-indexer"
-	testPath, err := testutil.PathOfTempFile(path)
-	require.NoError(err)
-	cfg := config.Default.DB
-	cfg.DbPath = testPath
-	testutil.CleanupPath(t, testPath)
-	defer testutil.CleanupPath(t, testPath)
-
-	kv := NewBoltDB(cfg)
-	require.NotNil(kv)
-
 	require.NoError(kv.Start(context.Background()))
 	defer func() {
 		require.NoError(kv.Stop(context.Background()))
@@ -154,20 +167,9 @@ func TestRangeIndex(t *testing.T) {
 	require.Equal(rangeTests[4].v, v)
 }
 
-func TestRangeIndex2(t *testing.T) {
+func testRangeIndex2(t *testing.T, kv KVStore) {
 	require := require.New(t)
 
-	path := "test-ranger"
-	testPath, err := testutil.PathOfTempFile(path)
-	require.NoError(err)
-	cfg := config.Default.DB
-	cfg.DbPath = testPath
-	testutil.CleanupPath(t, testPath)
-	defer testutil.CleanupPath(t, testPath)
-
-	kv := NewBoltDB(cfg)
-	require.NotNil(kv)
-
 	require.NoError(kv.Start(context.Background()))
 	defer func() {
 		require.NoError(kv.Stop(context.Background()))
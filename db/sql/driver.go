@@ -0,0 +1,17 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package sql
+
+import (
+	"github.com/iotexproject/iotex-core/config"
+)
+
+// NewSQLStore instantiates a store of the SQL database by the database/sql driver, which must be registered by the
+// binary
+func NewSQLStore(cfg config.SQL) Store {
+	return newStoreBase(cfg.Driver, cfg.DataSource)
+}
@@ -25,6 +25,9 @@ type Store interface {
 	// Get DB instance
 	GetDB() *sql.DB
 
+	// DriverName returns the name of the database/sql driver
+	DriverName() string
+
 	// Transact wrap the transaction
 	Transact(txFunc func(*sql.Tx) error) (err error)
 }
@@ -84,6 +87,11 @@ func (s *storeBase) GetDB() *sql.DB {
 	return s.db
 }
 
+// DriverName returns the name of the database/sql driver
+func (s *storeBase) DriverName() string {
+	return s.driverName
+}
+
 // Transact wrap the transaction
 func (s *storeBase) Transact(txFunc func(*sql.Tx) error) (err error) {
 	tx, err := s.db.Begin()
@@ -105,6 +113,7 @@ func (s *storeBase) Transact(txFunc func(*sql.Tx) error) (err error) {
 			// err is nil; if Commit returns error update err
 			if commitErr := tx.Commit(); commitErr != nil {
 				logger.Error().Err(commitErr)
+				err = commitErr
 			}
 		}
 	}()
@@ -8,10 +8,38 @@ package sql
 
 import (
 	"reflect"
+	"strconv"
+	"strings"
 
 	"database/sql"
 )
 
+// Rebind replaces the ? placeholders of the query by the bind variables of the driver, which are $1, $2, ... for
+// postgres. The query must not have ? in its string literals
+func Rebind(driver, query string) string {
+	switch driver {
+	case "postgres", "pgx":
+	default:
+		return query
+	}
+	var (
+		b strings.Builder
+		n int
+	)
+	for {
+		i := strings.IndexByte(query, '?')
+		if i < 0 {
+			break
+		}
+		n++
+		b.WriteString(query[:i])
+		b.WriteString("$" + strconv.Itoa(n))
+		query = query[i+1:]
+	}
+	b.WriteString(query)
+	return b.String()
+}
+
 // ParseSQLRows will parse the row
 func ParseSQLRows(rows *sql.Rows, schema interface{}) ([]interface{}, error) {
 	var parsedRows []interface{}
@@ -0,0 +1,22 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebind(t *testing.T) {
+	r := require.New(t)
+	query := "INSERT INTO blocks (height, hash) VALUES (?, ?)"
+	r.Equal(query, Rebind("sqlite3", query))
+	r.Equal(query, Rebind("mysql", query))
+	r.Equal("INSERT INTO blocks (height, hash) VALUES ($1, $2)", Rebind("postgres", query))
+	r.Equal("SELECT MAX(height) FROM blocks", Rebind("postgres", "SELECT MAX(height) FROM blocks"))
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/db/trie/triepb"
+)
+
+// Prove returns the serialized nodes on the path from the root to the key. The last node is the leaf of the key if it
+// exists, otherwise it is the node where the path of the key diverges, which proves the absence of the key
+func (mpt *merklePatriciaTrie) Prove(key []byte) ([][]byte, error) {
+	mpt.mutex.RLock()
+	defer mpt.mutex.RUnlock()
+
+	trieMtc.WithLabelValues("root", "Prove").Inc()
+	kt, err := mpt.checkKeyType(key)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		proof  [][]byte
+		n      node = mpt.root
+		offset uint8
+	)
+	for {
+		if hn, ok := n.(*hashNode); ok {
+			if n, err = hn.LoadNode(); err != nil {
+				return nil, err
+			}
+		}
+		sn, ok := n.(serializable)
+		if !ok {
+			return nil, errors.Wrap(trie.ErrInvalidTrie, "unexpected node type")
+		}
+		pb, err := sn.proto(false)
+		if err != nil {
+			return nil, err
+		}
+		ser, err := proto.Marshal(pb)
+		if err != nil {
+			return nil, err
+		}
+		proof = append(proof, ser)
+		switch node := n.(type) {
+		case *branchNode:
+			child, err := node.child(kt[offset])
+			if err != nil {
+				return proof, nil
+			}
+			n = child
+			offset++
+		case *extensionNode:
+			matched := node.commonPrefixLength(kt[offset:])
+			if matched != uint8(len(node.path)) {
+				return proof, nil
+			}
+			n = node.child
+			offset += matched
+		default:
+			return proof, nil
+		}
+	}
+}
+
+// VerifyProof verifies the proof generated by Prove against the root hash
+func (mpt *merklePatriciaTrie) VerifyProof(rootHash []byte, key []byte, proof [][]byte) ([]byte, error) {
+	if _, err := mpt.checkKeyType(key); err != nil {
+		return nil, err
+	}
+	value, n, err := verifyProof(mpt.hashFunc, rootHash, key, proof)
+	if err != nil && errors.Cause(err) != trie.ErrNotExist {
+		return nil, err
+	}
+	if n != len(proof) {
+		return nil, errors.Wrap(trie.ErrInvalidProof, "redundant nodes in proof")
+	}
+	return value, err
+}
+
+// verifyProof walks through the proof from the root hash along the key, and returns the value of the key and the
+// number of nodes used to reach the value or the divergence of the key
+func verifyProof(hashFunc HashFunc, rootHash []byte, key []byte, proof [][]byte) ([]byte, int, error) {
+	expected := rootHash
+	offset := 0
+	for i, ser := range proof {
+		if !bytes.Equal(hashFunc(ser), expected) {
+			return nil, i, errors.Wrapf(trie.ErrInvalidProof, "hash of node %d does not match", i)
+		}
+		pb := triepb.NodePb{}
+		if err := proto.Unmarshal(ser, &pb); err != nil {
+			return nil, i, errors.Wrapf(trie.ErrInvalidProof, "failed to unmarshal node %d", i)
+		}
+		switch {
+		case pb.GetBranch() != nil:
+			if offset >= len(key) {
+				return nil, i, errors.Wrapf(trie.ErrInvalidProof, "branch node %d is beyond the key", i)
+			}
+			expected = nil
+			for _, b := range pb.GetBranch().GetBranches() {
+				if b.GetIndex() == uint32(key[offset]) {
+					expected = b.GetPath()
+					break
+				}
+			}
+			if expected == nil {
+				return nil, i + 1, errors.Wrapf(trie.ErrNotExist, "key %x does not exist", key)
+			}
+			offset++
+		case pb.GetExtend() != nil:
+			path := pb.GetExtend().GetPath()
+			if offset+len(path) > len(key) || !bytes.Equal(path, key[offset:offset+len(path)]) {
+				return nil, i + 1, errors.Wrapf(trie.ErrNotExist, "key %x does not exist", key)
+			}
+			expected = pb.GetExtend().GetValue()
+			offset += len(path)
+		case pb.GetLeaf() != nil:
+			if !bytes.Equal(pb.GetLeaf().GetPath(), key) {
+				return nil, i + 1, errors.Wrapf(trie.ErrNotExist, "key %x does not exist", key)
+			}
+			return pb.GetLeaf().GetValue(), i + 1, nil
+		default:
+			return nil, i, errors.Wrapf(trie.ErrInvalidProof, "invalid type of node %d", i)
+		}
+	}
+	return nil, len(proof), errors.Wrap(trie.ErrInvalidProof, "incomplete proof")
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db/trie"
+)
+
+func TestProof(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		testProof(t, async)
+	}
+}
+
+func testProof(t *testing.T, async bool) {
+	require := require.New(t)
+	opts := []Option{KVStoreOption(trie.NewMemKVStore()), KeyLengthOption(8)}
+	if async {
+		opts = append(opts, AsyncOption())
+	}
+	tr, err := New(opts...)
+	require.NoError(err)
+	require.NoError(tr.Start(context.Background()))
+	defer require.NoError(tr.Stop(context.Background()))
+
+	// absence in an empty trie
+	root, err := tr.RootHash()
+	require.NoError(err)
+	proof, err := tr.Prove(cat)
+	require.NoError(err)
+	require.Len(proof, 1)
+	_, err = tr.VerifyProof(root, cat, proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+
+	keys := [][]byte{ham, car, cat, dog, egg, fox, cow, ant}
+	for i, k := range keys {
+		require.NoError(tr.Upsert(k, testV[i]))
+	}
+	root, err = tr.RootHash()
+	require.NoError(err)
+	for i, k := range keys {
+		proof, err := tr.Prove(k)
+		require.NoError(err)
+		v, err := tr.VerifyProof(root, k, proof)
+		require.NoError(err)
+		require.Equal(testV[i], v)
+	}
+
+	// absence of keys diverging at a branch, an extension and a leaf
+	for _, k := range [][]byte{br1, rat, {1, 2, 3, 4, 2, 3, 4, 6}} {
+		proof, err := tr.Prove(k)
+		require.NoError(err)
+		_, err = tr.VerifyProof(root, k, proof)
+		require.Equal(trie.ErrNotExist, errors.Cause(err))
+	}
+
+	// invalid proofs
+	proof, err = tr.Prove(cat)
+	require.NoError(err)
+	_, err = tr.VerifyProof(emptyTrieRootHash, cat, proof)
+	require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+	_, err = tr.VerifyProof(root, car, proof)
+	require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+	_, err = tr.VerifyProof(root, cat, proof[:len(proof)-1])
+	require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+	_, err = tr.VerifyProof(root, cat, append(proof, proof[0]))
+	require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+	tampered := make([][]byte, len(proof))
+	copy(tampered, proof)
+	tampered[len(proof)-1] = append([]byte{}, proof[len(proof)-1]...)
+	tampered[len(proof)-1][len(tampered[len(proof)-1])-1]++
+	_, err = tr.VerifyProof(root, cat, tampered)
+	require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+	_, err = tr.Prove([]byte{1, 2, 3})
+	require.Error(err)
+}
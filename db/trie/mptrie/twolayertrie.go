@@ -189,3 +189,59 @@ func (tlt *twoLayerTrie) Delete(layerOneKey []byte, layerTwoKey []byte) error {
 
 	return nil
 }
+
+// Prove proves the item against the current layer one root, without changing the trie. The pending changes of layer
+// two tries are not reflected in layer one until the root hash is calculated, so the proof is rejected if any exists
+func (tlt *twoLayerTrie) Prove(layerOneKey []byte, layerTwoKey []byte) ([][]byte, error) {
+	for _, lt := range tlt.layerTwoMap {
+		if lt.dirty {
+			return nil, errors.New("failed to prove with pending changes of layer two tries")
+		}
+	}
+	proof, err := tlt.layerOne.Prove(layerOneKey)
+	if err != nil {
+		return nil, err
+	}
+	value, err := tlt.layerOne.Get(layerOneKey)
+	if err != nil {
+		if errors.Cause(err) == trie.ErrNotExist {
+			// the proof of the absence of layer two trie
+			return proof, nil
+		}
+		return nil, err
+	}
+	lt, err := New(KVStoreOption(tlt.kvStore), KeyLengthOption(len(layerTwoKey)), RootHashOption(value))
+	if err != nil {
+		return nil, err
+	}
+	if err := lt.Start(context.Background()); err != nil {
+		return nil, err
+	}
+	defer lt.Stop(context.Background())
+
+	layerTwoProof, err := lt.Prove(layerTwoKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(proof, layerTwoProof...), nil
+}
+
+func (tlt *twoLayerTrie) VerifyProof(rootHash []byte, layerOneKey []byte, layerTwoKey []byte, proof [][]byte) ([]byte, error) {
+	layerTwoRoot, n, err := verifyProof(DefaultHashFunc, rootHash, layerOneKey, proof)
+	if err != nil {
+		if errors.Cause(err) == trie.ErrNotExist && n != len(proof) {
+			return nil, errors.Wrap(trie.ErrInvalidProof, "redundant nodes in proof")
+		}
+		return nil, err
+	}
+	value, m, err := verifyProof(DefaultHashFunc, layerTwoRoot, layerTwoKey, proof[n:])
+	if err != nil && errors.Cause(err) != trie.ErrNotExist {
+		return nil, err
+	}
+	if n+m != len(proof) {
+		return nil, errors.Wrap(trie.ErrInvalidProof, "redundant nodes in proof")
+	}
+
+	return value, err
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/db/trie"
@@ -33,3 +34,43 @@ func TestTwoLayerTrie(t *testing.T) {
 	value, err = tlt.Get([]byte("layerOneKey111111111"), []byte("layerTwoKey1"))
 	require.Error(t, err)
 }
+
+func TestTwoLayerTrieProof(t *testing.T) {
+	require := require.New(t)
+	tlt := NewTwoLayerTrie(trie.NewMemKVStore(), "rootKey")
+	require.NoError(tlt.Start(context.Background()))
+	defer require.NoError(tlt.Stop(context.Background()))
+
+	layerOneKey1 := []byte("layerOneKey111111111")
+	layerOneKey2 := []byte("layerOneKey222222222")
+	require.NoError(tlt.Upsert(layerOneKey1, []byte("layerTwoKey1"), []byte("value1")))
+	require.NoError(tlt.Upsert(layerOneKey1, []byte("layerTwoKey2"), []byte("value2")))
+	require.NoError(tlt.Upsert(layerOneKey2, []byte("layerTwoKey1"), []byte("value3")))
+	// the pending changes are not proven
+	_, err := tlt.Prove(layerOneKey1, []byte("layerTwoKey2"))
+	require.Error(err)
+	root, err := tlt.RootHash()
+	require.NoError(err)
+
+	proof, err := tlt.Prove(layerOneKey1, []byte("layerTwoKey2"))
+	require.NoError(err)
+	value, err := tlt.VerifyProof(root, layerOneKey1, []byte("layerTwoKey2"), proof)
+	require.NoError(err)
+	require.Equal([]byte("value2"), value)
+	_, err = tlt.VerifyProof(root, layerOneKey2, []byte("layerTwoKey2"), proof)
+	require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+	_, err = tlt.VerifyProof(root, layerOneKey1, []byte("layerTwoKey2"), append(proof, proof[0]))
+	require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+
+	// absence in layer two
+	proof, err = tlt.Prove(layerOneKey2, []byte("layerTwoKey2"))
+	require.NoError(err)
+	_, err = tlt.VerifyProof(root, layerOneKey2, []byte("layerTwoKey2"), proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+	// absence in layer one
+	layerOneKey3 := []byte("layerOneKey333333333")
+	proof, err = tlt.Prove(layerOneKey3, []byte("layerTwoKey1"))
+	require.NoError(err)
+	_, err = tlt.VerifyProof(root, layerOneKey3, []byte("layerTwoKey1"), proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+}
@@ -21,6 +21,9 @@ var (
 
 	// ErrEndOfIterator defines an error which will be returned
 	ErrEndOfIterator = errors.New("hit the end of the iterator, no more item")
+
+	// ErrInvalidProof indicates the proof does not match the root hash or the key
+	ErrInvalidProof = errors.New("invalid proof")
 )
 
 type (
@@ -47,6 +50,12 @@ type (
 		SetRootHash([]byte) error
 		// IsEmpty returns true is this is an empty trie
 		IsEmpty() bool
+		// Prove returns the nodes on the path from the root to an entry, which prove the existence or the absence of
+		// the entry
+		Prove([]byte) ([][]byte, error)
+		// VerifyProof verifies the proof of an entry against a root hash, and returns the value of the entry, or
+		// ErrNotExist if the proof proves the absence of the entry
+		VerifyProof([]byte, []byte, [][]byte) ([]byte, error)
 	}
 	// TwoLayerTrie is a trie data structure with two layers
 	TwoLayerTrie interface {
@@ -64,5 +73,11 @@ type (
 		Upsert([]byte, []byte, []byte) error
 		// Delete deletes an item in layer two
 		Delete([]byte, []byte) error
+		// Prove returns the proof of an item in layer two, which is the proof of the layer two root in layer one
+		// followed by the proof of the item in layer two
+		Prove([]byte, []byte) ([][]byte, error)
+		// VerifyProof verifies the proof of an item in layer two against a layer one root hash, and returns the value
+		// of the item, or ErrNotExist if the proof proves the absence of the item
+		VerifyProof([]byte, []byte, []byte, [][]byte) ([]byte, error)
 	}
 )
@@ -13,14 +13,19 @@ import (
 	"sync/atomic"
 
 	"github.com/golang/protobuf/proto"
+	p2p "github.com/iotexproject/go-p2p"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blocksync"
+	"github.com/iotexproject/iotex-core/blocksync/blocksyncpb"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
 	goproto "github.com/iotexproject/iotex-proto/golang"
 	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
@@ -32,7 +37,11 @@ type Subscriber interface {
 	HandleBlock(context.Context, *iotextypes.Block) error
 	HandleBlockSync(context.Context, *iotextypes.Block) error
 	HandleSyncRequest(context.Context, peerstore.PeerInfo, *iotexrpc.BlockSync) error
+	HandleHeadersRequest(context.Context, peerstore.PeerInfo, *blocksyncpb.HeadersRequest) error
+	HandleHeaders(context.Context, peerstore.PeerInfo, *blocksyncpb.HeadersResponse) error
 	HandleConsensusMsg(*iotextypes.ConsensusMessage) error
+	HandleSnapshotRequest(context.Context, peerstore.PeerInfo, *snapshotpb.Request) error
+	HandleSnapshotResponse(context.Context, peerstore.PeerInfo, proto.Message) error
 }
 
 // Dispatcher is used by peers, handles incoming block and header notifications and relays announcements of new blocks.
@@ -84,6 +93,30 @@ func (m blockSyncMsg) ChainID() uint32 {
 	return m.chainID
 }
 
+// headersRequestMsg packages a block headers request message.
+type headersRequestMsg struct {
+	ctx     context.Context
+	chainID uint32
+	request *blocksyncpb.HeadersRequest
+	peer    peerstore.PeerInfo
+}
+
+func (m headersRequestMsg) ChainID() uint32 {
+	return m.chainID
+}
+
+// snapshotRequestMsg packages a state snapshot request message.
+type snapshotRequestMsg struct {
+	ctx     context.Context
+	chainID uint32
+	request *snapshotpb.Request
+	peer    peerstore.PeerInfo
+}
+
+func (m snapshotRequestMsg) ChainID() uint32 {
+	return m.chainID
+}
+
 // actionMsg packages a proto action message.
 type actionMsg struct {
 	ctx     context.Context
@@ -100,7 +133,7 @@ type IotxDispatcher struct {
 	started        int32
 	shutdown       int32
 	eventChan      chan interface{}
-	syncChan       chan *blockSyncMsg
+	syncChan       chan interface{}
 	eventAudit     map[iotexrpc.MessageType]int
 	eventAuditLock sync.RWMutex
 	wg             sync.WaitGroup
@@ -113,7 +146,7 @@ type IotxDispatcher struct {
 func NewDispatcher(cfg config.Config) (Dispatcher, error) {
 	d := &IotxDispatcher{
 		eventChan:   make(chan interface{}, cfg.Dispatcher.EventChanSize),
-		syncChan:    make(chan *blockSyncMsg, cfg.Dispatcher.EventChanSize),
+		syncChan:    make(chan interface{}, cfg.Dispatcher.EventChanSize),
 		eventAudit:  make(map[iotexrpc.MessageType]int),
 		quit:        make(chan struct{}),
 		subscribers: make(map[uint32]Subscriber),
@@ -203,7 +236,16 @@ loop:
 	for {
 		select {
 		case m := <-d.syncChan:
-			d.handleBlockSyncMsg(m)
+			switch msg := m.(type) {
+			case *blockSyncMsg:
+				d.handleBlockSyncMsg(msg)
+			case *headersRequestMsg:
+				d.handleHeadersRequestMsg(msg)
+			case *snapshotRequestMsg:
+				d.handleSnapshotRequestMsg(msg)
+			default:
+				log.L().Warn("Invalid message type in sync handler.", zap.Any("msg", msg))
+			}
 		case <-d.quit:
 			break loop
 		}
@@ -222,7 +264,12 @@ func (d *IotxDispatcher) handleActionMsg(m *actionMsg) {
 	d.subscribersMU.RUnlock()
 	if ok {
 		d.updateEventAudit(iotexrpc.MessageType_ACTION)
-		if err := subscriber.HandleAction(m.ctx, m.action); err != nil {
+		ctx := m.ctx
+		// tag the relaying peer, so that actpool can rate limit per peer
+		if rawmsg, ok := p2p.GetBroadcastMsg(ctx); ok {
+			ctx = actpool.WithPeer(ctx, rawmsg.GetFrom().Pretty())
+		}
+		if err := subscriber.HandleAction(ctx, m.action); err != nil {
 			requestMtc.WithLabelValues("AddAction", "false").Inc()
 			log.L().Debug("Handle action request error.", zap.Error(err))
 		}
@@ -269,6 +316,45 @@ func (d *IotxDispatcher) handleBlockSyncMsg(m *blockSyncMsg) {
 	}
 }
 
+// handleHeadersRequestMsg handles block headers requests from peers.
+func (d *IotxDispatcher) handleHeadersRequestMsg(m *headersRequestMsg) {
+	log.L().Debug("Receive headersRequestMsg.",
+		zap.String("src", fmt.Sprintf("%v", m.peer)),
+		zap.Uint64("start", m.request.Start),
+		zap.Uint64("end", m.request.End))
+
+	d.subscribersMU.RLock()
+	subscriber, ok := d.subscribers[m.ChainID()]
+	d.subscribersMU.RUnlock()
+	if ok {
+		if err := subscriber.HandleHeadersRequest(m.ctx, m.peer, m.request); err != nil {
+			log.L().Error("Failed to handle headers request.", zap.Error(err))
+		}
+	} else {
+		log.L().Info("No subscriber specified in the dispatcher.", zap.Uint32("chainID", m.ChainID()))
+	}
+}
+
+// handleSnapshotRequestMsg handles state snapshot requests from peers.
+func (d *IotxDispatcher) handleSnapshotRequestMsg(m *snapshotRequestMsg) {
+	log.L().Debug("Receive snapshotRequestMsg.",
+		zap.String("src", fmt.Sprintf("%v", m.peer)),
+		zap.Bool("manifest", m.request.Manifest),
+		zap.Uint64("height", m.request.Height),
+		zap.Uint32("chunk", m.request.Chunk))
+
+	d.subscribersMU.RLock()
+	subscriber, ok := d.subscribers[m.ChainID()]
+	d.subscribersMU.RUnlock()
+	if ok {
+		if err := subscriber.HandleSnapshotRequest(m.ctx, m.peer, m.request); err != nil {
+			log.L().Debug("Failed to handle snapshot request.", zap.Error(err))
+		}
+	} else {
+		log.L().Info("No subscriber specified in the dispatcher.", zap.Uint32("chainID", m.ChainID()))
+	}
+}
+
 // dispatchAction adds the passed action message to the news handling queue.
 func (d *IotxDispatcher) dispatchAction(ctx context.Context, chainID uint32, msg proto.Message) {
 	if atomic.LoadInt32(&d.shutdown) != 0 {
@@ -311,6 +397,42 @@ func (d *IotxDispatcher) dispatchBlockSyncReq(ctx context.Context, chainID uint3
 	}
 }
 
+// dispatchHeadersRequest adds the passed block headers request to the sync handling queue.
+func (d *IotxDispatcher) dispatchHeadersRequest(ctx context.Context, chainID uint32, peer peerstore.PeerInfo, msg *blocksyncpb.HeadersRequest) {
+	if atomic.LoadInt32(&d.shutdown) != 0 {
+		return
+	}
+
+	if len(d.syncChan) == cap(d.syncChan) {
+		log.L().Warn("dispatcher sync chan is full, drop an event.")
+		return
+	}
+	d.syncChan <- &headersRequestMsg{
+		ctx:     ctx,
+		chainID: chainID,
+		peer:    peer,
+		request: msg,
+	}
+}
+
+// dispatchSnapshotRequest adds the passed snapshot request to the sync handling queue.
+func (d *IotxDispatcher) dispatchSnapshotRequest(ctx context.Context, chainID uint32, peer peerstore.PeerInfo, msg *snapshotpb.Request) {
+	if atomic.LoadInt32(&d.shutdown) != 0 {
+		return
+	}
+
+	if len(d.syncChan) == cap(d.syncChan) {
+		log.L().Warn("dispatcher sync chan is full, drop an event.")
+		return
+	}
+	d.syncChan <- &snapshotRequestMsg{
+		ctx:     ctx,
+		chainID: chainID,
+		peer:    peer,
+		request: msg,
+	}
+}
+
 // HandleBroadcast handles incoming broadcast message
 func (d *IotxDispatcher) HandleBroadcast(ctx context.Context, chainID uint32, message proto.Message) {
 	msgType, err := goproto.GetTypeFromRPCMsg(message)
@@ -341,6 +463,39 @@ func (d *IotxDispatcher) HandleBroadcast(ctx context.Context, chainID uint32, me
 
 // HandleTell handles incoming unicast message
 func (d *IotxDispatcher) HandleTell(ctx context.Context, chainID uint32, peer peerstore.PeerInfo, message proto.Message) {
+	// the block headers and state snapshot messages are not defined in iotex-proto
+	switch msg := message.(type) {
+	case *blocksyncpb.HeadersRequest:
+		d.dispatchHeadersRequest(ctx, chainID, peer, msg)
+		return
+	case *blocksyncpb.HeadersResponse:
+		d.subscribersMU.RLock()
+		subscriber, ok := d.subscribers[chainID]
+		d.subscribersMU.RUnlock()
+		if !ok {
+			log.L().Warn("chainID has not been registered in dispatcher.", zap.Uint32("chainID", chainID))
+			return
+		}
+		if err := subscriber.HandleHeaders(ctx, peer, msg); err != nil {
+			log.L().Debug("Failed to handle headers.", zap.Error(err))
+		}
+		return
+	case *snapshotpb.Request:
+		d.dispatchSnapshotRequest(ctx, chainID, peer, msg)
+		return
+	case *snapshotpb.ManifestResponse, *snapshotpb.ChunkResponse:
+		d.subscribersMU.RLock()
+		subscriber, ok := d.subscribers[chainID]
+		d.subscribersMU.RUnlock()
+		if !ok {
+			log.L().Warn("chainID has not been registered in dispatcher.", zap.Uint32("chainID", chainID))
+			return
+		}
+		if err := subscriber.HandleSnapshotResponse(ctx, peer, msg); err != nil {
+			log.L().Debug("Failed to handle snapshot response.", zap.Error(err))
+		}
+		return
+	}
 	msgType, err := goproto.GetTypeFromRPCMsg(message)
 	if err != nil {
 		log.L().Warn("Unexpected message handled by HandleTell.", zap.Error(err))
@@ -349,7 +504,8 @@ func (d *IotxDispatcher) HandleTell(ctx context.Context, chainID uint32, peer pe
 	case iotexrpc.MessageType_BLOCK_REQUEST:
 		d.dispatchBlockSyncReq(ctx, chainID, peer, message)
 	case iotexrpc.MessageType_BLOCK:
-		d.dispatchBlockCommit(ctx, chainID, message)
+		// tag the peer serving the block, so that blocksync scores the peer
+		d.dispatchBlockCommit(blocksync.WithPeer(ctx, peer.ID.Pretty()), chainID, message)
 	default:
 		log.L().Warn("Unexpected msgType handled by HandleTell.", zap.Any("msgType", msgType))
 	}
@@ -15,7 +15,9 @@ import (
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/iotexproject/iotex-core/blocksync/blocksyncpb"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
 	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/iotexproject/iotex-proto/golang/testingpb"
@@ -53,6 +55,11 @@ func setTestCase() []proto.Message {
 		&iotextypes.Block{},
 		&iotexrpc.BlockSync{},
 		&testingpb.TestPayload{},
+		&blocksyncpb.HeadersRequest{},
+		&blocksyncpb.HeadersResponse{},
+		&snapshotpb.Request{},
+		&snapshotpb.ManifestResponse{},
+		&snapshotpb.ChunkResponse{},
 	}
 }
 
@@ -96,6 +103,22 @@ func (s *DummySubscriber) HandleSyncRequest(context.Context, peerstore.PeerInfo,
 	return nil
 }
 
+func (s *DummySubscriber) HandleHeadersRequest(context.Context, peerstore.PeerInfo, *blocksyncpb.HeadersRequest) error {
+	return nil
+}
+
+func (s *DummySubscriber) HandleHeaders(context.Context, peerstore.PeerInfo, *blocksyncpb.HeadersResponse) error {
+	return nil
+}
+
 func (s *DummySubscriber) HandleAction(context.Context, *iotextypes.Action) error { return nil }
 
 func (s *DummySubscriber) HandleConsensusMsg(*iotextypes.ConsensusMessage) error { return nil }
+
+func (s *DummySubscriber) HandleSnapshotRequest(context.Context, peerstore.PeerInfo, *snapshotpb.Request) error {
+	return nil
+}
+
+func (s *DummySubscriber) HandleSnapshotResponse(context.Context, peerstore.PeerInfo, proto.Message) error {
+	return nil
+}
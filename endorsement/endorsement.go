@@ -15,6 +15,7 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 type (
@@ -37,7 +38,8 @@ type (
 	}
 )
 
-func hashDocWithTime(doc Document, ts time.Time) ([]byte, error) {
+// Digest returns the digest of the document endorsed at the time, which is signed by the endorser
+func Digest(doc Document, ts time.Time) ([]byte, error) {
 	h, err := doc.Hash()
 	if err != nil {
 		return nil, err
@@ -63,21 +65,31 @@ func NewEndorsement(
 	}
 }
 
-// Endorse endorses a document
+// Endorse endorses a document, which describes what it commits to if it is a signer.Describer
 func Endorse(
-	signer crypto.PrivateKey,
+	s signer.Signer,
 	doc Document,
 	ts time.Time,
 ) (*Endorsement, error) {
-	hash, err := hashDocWithTime(doc, ts)
+	hash, err := Digest(doc, ts)
 	if err != nil {
 		return nil, err
 	}
-	sig, err := signer.Sign(hash)
+	msg := &signer.Message{
+		Kind:      signer.EndorsementKind,
+		Timestamp: ts,
+		Digest:    hash,
+	}
+	if d, ok := doc.(signer.Describer); ok {
+		if err := d.Describe(msg); err != nil {
+			return nil, err
+		}
+	}
+	sig, err := s.Sign(msg)
 	if err != nil {
 		return nil, err
 	}
-	return NewEndorsement(ts, signer.PublicKey(), sig), nil
+	return NewEndorsement(ts, s.PublicKey(), sig), nil
 }
 
 // VerifyEndorsedDocument checks an endorsed document
@@ -87,7 +99,7 @@ func VerifyEndorsedDocument(endorsedDoc EndorsedDocument) bool {
 
 // VerifyEndorsement checks the signature in an endorsement against a document
 func VerifyEndorsement(doc Document, en *Endorsement) bool {
-	hash, err := hashDocWithTime(doc, en.Timestamp())
+	hash, err := Digest(doc, en.Timestamp())
 	if err != nil {
 		return false
 	}
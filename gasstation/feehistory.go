@@ -0,0 +1,246 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package gasstation
+
+import (
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+)
+
+// ErrInvalidFeeHistoryQuery indicates the fee history query is invalid, or beyond the indexed blocks
+var ErrInvalidFeeHistoryQuery = errors.New("invalid fee history query")
+
+type (
+	// FeeHistory is the history of gas usage and gas prices over a range of blocks
+	FeeHistory struct {
+		// OldestBlock is the height of the first block in the range
+		OldestBlock uint64
+		// GasUsedRatios are the ratios of gas used to block gas limit of each block
+		GasUsedRatios []float64
+		// Rewards are the gas prices at the requested percentiles of each block, weighted by gas used
+		Rewards [][]*big.Int
+	}
+
+	// priceWithGas is the gas price of an action along with the gas it consumes
+	priceWithGas struct {
+		price *big.Int
+		gas   uint64
+	}
+
+	// blockFeeSummary summarizes the gas used in a block and the gas prices paid by its user actions
+	blockFeeSummary struct {
+		gasUsed  uint64
+		gasLimit uint64
+		// prices are sorted ascending
+		prices []priceWithGas
+	}
+
+	// feeIndex indexes the gas price summaries of the recent blocks by height. It is loaded from the stored blocks
+	// once, and then maintained incrementally as new blocks are received
+	feeIndex struct {
+		mu        sync.RWMutex
+		window    uint64
+		loaded    bool
+		tip       uint64
+		summaries map[uint64]*blockFeeSummary
+	}
+)
+
+func newFeeIndex(window uint64) *feeIndex {
+	return &feeIndex{
+		window:    window,
+		summaries: make(map[uint64]*blockFeeSummary),
+	}
+}
+
+// put indexes the summary of the block at height, which replaces the summaries at and above the height of the blocks
+// rolled back, and evicts the summaries falling out of the window
+func (idx *feeIndex) put(height uint64, summary *blockFeeSummary) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if height <= idx.tip {
+		for h := height; h <= idx.tip; h++ {
+			delete(idx.summaries, h)
+		}
+	}
+	idx.summaries[height] = summary
+	idx.tip = height
+	if height > idx.window {
+		for h := range idx.summaries {
+			if h <= height-idx.window {
+				delete(idx.summaries, h)
+			}
+		}
+	}
+}
+
+// get returns the summary of the block at height
+func (idx *feeIndex) get(height uint64) (*blockFeeSummary, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	summary, ok := idx.summaries[height]
+	return summary, ok
+}
+
+// ReceiveBlock adds the gas price summary of the new block into index
+func (gs *GasStation) ReceiveBlock(blk *block.Block) error {
+	gs.index.put(blk.Height(), gs.summarize(blk, blk.Receipts))
+	return nil
+}
+
+// FeeHistory returns the fee history of blockCount blocks up to newestBlock, which is the tip if it is 0. The rewards
+// of each block are the gas prices at the percentiles, which must be ascending and within [0, 100]. Only the blocks
+// within the fee history window below the tip are indexed
+func (gs *GasStation) FeeHistory(newestBlock uint64, blockCount uint64, percentiles []float64) (*FeeHistory, error) {
+	if blockCount == 0 {
+		return nil, errors.Wrap(ErrInvalidFeeHistoryQuery, "block count must be positive")
+	}
+	for i, p := range percentiles {
+		if p < 0 || p > 100 {
+			return nil, errors.Wrapf(ErrInvalidFeeHistoryQuery, "invalid reward percentile %f", p)
+		}
+		if i > 0 && p < percentiles[i-1] {
+			return nil, errors.Wrapf(
+				ErrInvalidFeeHistoryQuery,
+				"reward percentiles are not ascending: %f after %f",
+				p,
+				percentiles[i-1],
+			)
+		}
+	}
+	if err := gs.loadFeeIndex(); err != nil {
+		return nil, err
+	}
+	tip := gs.bc.TipHeight()
+	if newestBlock == 0 || newestBlock > tip {
+		newestBlock = tip
+	}
+	if blockCount > newestBlock {
+		blockCount = newestBlock
+	}
+	fh := &FeeHistory{
+		OldestBlock:   newestBlock - blockCount + 1,
+		GasUsedRatios: make([]float64, 0, blockCount),
+	}
+	if len(percentiles) > 0 {
+		fh.Rewards = make([][]*big.Int, 0, blockCount)
+	}
+	for height := fh.OldestBlock; height <= newestBlock; height++ {
+		summary, ok := gs.index.get(height)
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidFeeHistoryQuery, "block %d is beyond the fee history window", height)
+		}
+		ratio := float64(0)
+		if summary.gasLimit > 0 {
+			ratio = float64(summary.gasUsed) / float64(summary.gasLimit)
+		}
+		fh.GasUsedRatios = append(fh.GasUsedRatios, ratio)
+		if len(percentiles) == 0 {
+			continue
+		}
+		rewards := make([]*big.Int, 0, len(percentiles))
+		for _, p := range percentiles {
+			rewards = append(rewards, summary.reward(p))
+		}
+		fh.Rewards = append(fh.Rewards, rewards)
+	}
+	return fh, nil
+}
+
+// loadFeeIndex summarizes the stored blocks within the window below the tip into index, which happens only once, as
+// the index is maintained by ReceiveBlock afterwards
+func (gs *GasStation) loadFeeIndex() error {
+	gs.index.mu.Lock()
+	defer gs.index.mu.Unlock()
+
+	if gs.index.loaded {
+		return nil
+	}
+	tip := gs.bc.TipHeight()
+	if gs.index.tip > tip {
+		tip = gs.index.tip
+	}
+	start := uint64(1)
+	if tip > gs.index.window {
+		start = tip - gs.index.window + 1
+	}
+	for height := start; height <= tip; height++ {
+		if _, ok := gs.index.summaries[height]; ok {
+			continue
+		}
+		blk, err := gs.dao.GetBlockByHeight(height)
+		if err != nil {
+			return err
+		}
+		receipts, err := gs.dao.GetReceipts(height)
+		if err != nil {
+			return err
+		}
+		gs.index.summaries[height] = gs.summarize(blk, receipts)
+	}
+	if tip > gs.index.tip {
+		gs.index.tip = tip
+	}
+	gs.index.loaded = true
+	return nil
+}
+
+func (gs *GasStation) summarize(blk *block.Block, receipts []*action.Receipt) *blockFeeSummary {
+	gasConsumed := make(map[hash.Hash256]uint64, len(receipts))
+	for _, receipt := range receipts {
+		gasConsumed[receipt.ActionHash] = receipt.GasConsumed
+	}
+	summary := &blockFeeSummary{
+		gasLimit: gs.bc.Genesis().BlockGasLimit,
+		prices:   make([]priceWithGas, 0, len(blk.Actions)),
+	}
+	for _, act := range blk.Actions {
+		gas := gasConsumed[act.Hash()]
+		summary.gasUsed += gas
+		if gs.IsSystemAction(act) {
+			continue
+		}
+		summary.prices = append(summary.prices, priceWithGas{price: act.GasPrice(), gas: gas})
+	}
+	sort.SliceStable(summary.prices, func(i, j int) bool {
+		return summary.prices[i].price.Cmp(summary.prices[j].price) < 0
+	})
+	return summary
+}
+
+// reward returns the gas price at the percentile of the gas consumed by user actions, or 0 if there is no user action
+func (s *blockFeeSummary) reward(percentile float64) *big.Int {
+	if len(s.prices) == 0 {
+		return big.NewInt(0)
+	}
+	var total uint64
+	for _, p := range s.prices {
+		total += p.gas
+	}
+	if total == 0 {
+		// no receipt, fall back to the percentile of actions
+		return new(big.Int).Set(s.prices[int(float64(len(s.prices)-1)*percentile/100)].price)
+	}
+	threshold := uint64(float64(total) * percentile / 100)
+	var sum uint64
+	for _, p := range s.prices {
+		sum += p.gas
+		if sum >= threshold {
+			return new(big.Int).Set(p.price)
+		}
+	}
+	return new(big.Int).Set(s.prices[len(s.prices)-1].price)
+}
@@ -8,11 +8,13 @@ package gasstation
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"sort"
 
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
@@ -26,17 +28,41 @@ import (
 type BlockDAO interface {
 	GetBlockHash(uint64) (hash.Hash256, error)
 	GetBlockByHeight(uint64) (*block.Block, error)
+	GetReceipts(uint64) ([]*action.Receipt, error)
 }
 
 // SimulateFunc is function that simulate execution
 type SimulateFunc func(context.Context, address.Address, *action.Execution, evm.GetBlockHash) ([]byte, *action.Receipt, error)
 
+// ExecutionError is the error when the execution fails with the block gas limit
+type ExecutionError struct {
+	// Status is the receipt status of the failed execution
+	Status uint64
+	// RevertData is the return value of the failed execution, which carries the revert reason
+	RevertData []byte
+}
+
+func (e *ExecutionError) Error() string {
+	msg := fmt.Sprintf("execution simulation gets failure status %s", iotextypes.ReceiptStatus_name[int32(e.Status)])
+	if reason := e.RevertReason(); reason != "" {
+		msg += ": " + reason
+	}
+	return msg
+}
+
+// RevertReason returns the decoded revert reason
+func (e *ExecutionError) RevertReason() string {
+	return evm.DecodeRevertReason(e.RevertData)
+}
+
 // GasStation provide gas related api
 type GasStation struct {
 	bc        blockchain.Blockchain
 	simulator SimulateFunc
 	dao       BlockDAO
 	cfg       config.API
+	// index indexes the gas price summaries of recent blocks by height
+	index *feeIndex
 }
 
 // NewGasStation creates a new gas station
@@ -46,6 +72,7 @@ func NewGasStation(bc blockchain.Blockchain, simulator SimulateFunc, dao BlockDA
 		simulator: simulator,
 		dao:       dao,
 		cfg:       cfg,
+		index:     newFeeIndex(uint64(cfg.GasStation.FeeHistoryWindow)),
 	}
 }
 
@@ -63,6 +90,31 @@ func (gs *GasStation) IsSystemAction(act action.SealedEnvelope) bool {
 
 // SuggestGasPrice suggest gas price
 func (gs *GasStation) SuggestGasPrice() (uint64, error) {
+	smallestPrices, err := gs.smallestPrices()
+	if err != nil {
+		return gs.cfg.GasStation.DefaultGas, err
+	}
+	return gs.percentileOf(smallestPrices, gs.cfg.GasStation.Percentile), nil
+}
+
+// SuggestGasPriceTiers suggests gas prices for slow, standard and fast tiers
+func (gs *GasStation) SuggestGasPriceTiers() (slow uint64, standard uint64, fast uint64, err error) {
+	defaultGas := gs.cfg.GasStation.DefaultGas
+	smallestPrices, err := gs.smallestPrices()
+	if err != nil {
+		return defaultGas, defaultGas, defaultGas, err
+	}
+	return gs.percentileOf(smallestPrices, gs.cfg.GasStation.SlowPercentile),
+		gs.percentileOf(smallestPrices, gs.cfg.GasStation.Percentile),
+		gs.percentileOf(smallestPrices, gs.cfg.GasStation.FastPercentile),
+		nil
+}
+
+// smallestPrices returns the smallest gas price of user actions in each of the recent blocks, sorted ascending
+func (gs *GasStation) smallestPrices() ([]*big.Int, error) {
+	if err := gs.loadFeeIndex(); err != nil {
+		return nil, err
+	}
 	var smallestPrices []*big.Int
 	tip := gs.bc.TipHeight()
 
@@ -72,38 +124,30 @@ func (gs *GasStation) SuggestGasPrice() (uint64, error) {
 	}
 
 	for height := tip; height > endBlockHeight; height-- {
-		blk, err := gs.dao.GetBlockByHeight(height)
-		if err != nil {
-			return gs.cfg.GasStation.DefaultGas, err
+		summary, ok := gs.index.get(height)
+		if !ok {
+			// the blocks below are beyond the fee history window
+			break
 		}
-		if len(blk.Actions) == 0 {
+		if len(summary.prices) == 0 {
 			continue
 		}
-		if len(blk.Actions) == 1 && gs.IsSystemAction(blk.Actions[0]) {
-			continue
-		}
-		smallestPrice := blk.Actions[0].GasPrice()
-		for _, act := range blk.Actions {
-			if gs.IsSystemAction(act) {
-				continue
-			}
-			if smallestPrice.Cmp(act.GasPrice()) == 1 {
-				smallestPrice = act.GasPrice()
-			}
-		}
-		smallestPrices = append(smallestPrices, smallestPrice)
+		smallestPrices = append(smallestPrices, summary.prices[0].price)
 	}
+	sort.Sort(bigIntArray(smallestPrices))
+	return smallestPrices, nil
+}
 
+func (gs *GasStation) percentileOf(smallestPrices []*big.Int, percentile int) uint64 {
 	if len(smallestPrices) == 0 {
 		// return default price
-		return gs.cfg.GasStation.DefaultGas, nil
+		return gs.cfg.GasStation.DefaultGas
 	}
-	sort.Sort(bigIntArray(smallestPrices))
-	gasPrice := smallestPrices[(len(smallestPrices)-1)*gs.cfg.GasStation.Percentile/100].Uint64()
+	gasPrice := smallestPrices[(len(smallestPrices)-1)*percentile/100].Uint64()
 	if gasPrice < gs.cfg.GasStation.DefaultGas {
 		gasPrice = gs.cfg.GasStation.DefaultGas
 	}
-	return gasPrice, nil
+	return gasPrice
 }
 
 // EstimateGasForAction estimate gas for action
@@ -118,21 +162,77 @@ func (gs *GasStation) EstimateGasForAction(actPb *iotextypes.Action) (uint64, er
 		if err != nil {
 			return 0, err
 		}
-		ctx, err := gs.bc.Context()
+		return gs.EstimateExecutionGas(callerAddr, sc, sc.Nonce())
+	}
+	gas, err := selp.IntrinsicGas()
+	if err != nil {
+		return 0, err
+	}
+	return gas, nil
+}
+
+// EstimateExecutionGas returns the minimal gas limit with which the execution succeeds. The execution is first
+// simulated with the block gas limit, and then the gas limit is binary searched between the gas consumed and the block
+// gas limit, as contracts with gas dependent logic (e.g., the 63/64 rule of EIP-150) need more gas than they consume
+func (gs *GasStation) EstimateExecutionGas(caller address.Address, sc *action.Execution, nonce uint64) (uint64, error) {
+	ctx, err := gs.bc.Context()
+	if err != nil {
+		return 0, err
+	}
+	simulate := func(gasLimit uint64) ([]byte, *action.Receipt, error) {
+		ex, err := action.NewExecution(sc.Contract(), nonce, sc.Amount(), gasLimit, big.NewInt(0), sc.Data())
 		if err != nil {
-			return 0, err
+			return nil, nil, err
 		}
-		_, receipt, err := gs.simulator(ctx, callerAddr, sc, gs.dao.GetBlockHash)
-		if err != nil {
-			return 0, err
+		return gs.simulator(ctx, caller, ex, gs.dao.GetBlockHash)
+	}
+	enough := func(gasLimit uint64) (bool, error) {
+		_, receipt, err := simulate(gasLimit)
+		switch errors.Cause(err) {
+		case nil:
+			return receipt.Status == uint64(iotextypes.ReceiptStatus_Success), nil
+		case action.ErrOutOfGas:
+			// gas limit is lower than intrinsic gas
+			return false, nil
+		default:
+			return false, err
 		}
-		return receipt.GasConsumed, nil
 	}
-	gas, err := selp.IntrinsicGas()
+
+	high := gs.bc.Genesis().BlockGasLimit
+	ret, receipt, err := simulate(high)
 	if err != nil {
 		return 0, err
 	}
-	return gas, nil
+	if receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+		return 0, &ExecutionError{Status: receipt.Status, RevertData: ret}
+	}
+	// the gas consumed is the lower bound, and suffices unless the contract has gas dependent logic
+	low := receipt.GasConsumed
+	if low >= high {
+		return high, nil
+	}
+	ok, err := enough(low)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		return low, nil
+	}
+	// the execution fails with low and succeeds with high
+	for low+1 < high {
+		mid := low + (high-low)/2
+		ok, err := enough(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	return high, nil
 }
 
 type bigIntArray []*big.Int
@@ -8,10 +8,15 @@ package gasstation
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/pkg/unit"
 
@@ -22,6 +27,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/action/protocol/execution"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/blockchain"
@@ -31,6 +37,8 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/version"
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockdao"
 	"github.com/iotexproject/iotex-core/testutil"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
@@ -113,6 +121,76 @@ func TestSuggestGasPriceForUserAction(t *testing.T) {
 	require.NoError(t, err)
 	// i from 10 to 29,gasprice for 20 to 39,60%*20+20=31
 	require.Equal(t, big.NewInt(1).Mul(big.NewInt(int64(31)), big.NewInt(unit.Qev)).Uint64(), gp)
+
+	slow, standard, fast, err := gs.SuggestGasPriceTiers()
+	require.NoError(t, err)
+	// 20%*19+20=23, 60%*19+20=31, 90%*19+20=37
+	require.Equal(t, big.NewInt(1).Mul(big.NewInt(int64(23)), big.NewInt(unit.Qev)).Uint64(), slow)
+	require.Equal(t, gp, standard)
+	require.Equal(t, big.NewInt(1).Mul(big.NewInt(int64(37)), big.NewInt(unit.Qev)).Uint64(), fast)
+
+	fh, err := gs.FeeHistory(0, 3, []float64{0, 50, 100})
+	require.NoError(t, err)
+	require.Equal(t, height-2, fh.OldestBlock)
+	require.Equal(t, 3, len(fh.GasUsedRatios))
+	require.Equal(t, 3, len(fh.Rewards))
+	for i, rewards := range fh.Rewards {
+		require.True(t, fh.GasUsedRatios[i] > 0 && fh.GasUsedRatios[i] <= 1)
+		// block at height h has a single transfer priced at h+9
+		price := big.NewInt(1).Mul(big.NewInt(int64(fh.OldestBlock)+int64(i)+9), big.NewInt(unit.Qev))
+		for _, reward := range rewards {
+			require.Equal(t, price, reward)
+		}
+	}
+
+	// block count is capped by the newest block
+	fh, err = gs.FeeHistory(2, 10, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), fh.OldestBlock)
+	require.Equal(t, 2, len(fh.GasUsedRatios))
+	require.Nil(t, fh.Rewards)
+
+	// the summary of a new block is indexed on receipt
+	blk, err := blkMemDao.GetBlockByHeight(height)
+	require.NoError(t, err)
+	gs = NewGasStation(bc, sf.SimulateExecution, blkMemDao, cfg.API)
+	require.NoError(t, gs.ReceiveBlock(blk))
+	_, ok := gs.index.get(height)
+	require.True(t, ok)
+	_, ok = gs.index.get(height - 1)
+	require.False(t, ok)
+	// the stored blocks are indexed once upon first query
+	fh, err = gs.FeeHistory(0, 3, nil)
+	require.NoError(t, err)
+	require.Equal(t, height-2, fh.OldestBlock)
+	_, ok = gs.index.get(height - 1)
+	require.True(t, ok)
+
+	// the summaries of the blocks rolled back are replaced
+	require.NoError(t, gs.ReceiveBlock(blk))
+	gs.index.put(height-1, &blockFeeSummary{gasUsed: 1, gasLimit: 1})
+	_, ok = gs.index.get(height)
+	require.False(t, ok)
+	fh, err = gs.FeeHistory(height-1, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, float64(1), fh.GasUsedRatios[0])
+
+	// the blocks beyond the window are not indexed
+	apiCfg := cfg.API
+	apiCfg.GasStation.FeeHistoryWindow = 2
+	gs = NewGasStation(bc, sf.SimulateExecution, blkMemDao, apiCfg)
+	_, err = gs.FeeHistory(0, 2, nil)
+	require.NoError(t, err)
+	_, err = gs.FeeHistory(0, 3, nil)
+	require.Equal(t, ErrInvalidFeeHistoryQuery, errors.Cause(err))
+	require.NoError(t, gs.ReceiveBlock(blk))
+
+	_, err = gs.FeeHistory(0, 0, nil)
+	require.Equal(t, ErrInvalidFeeHistoryQuery, errors.Cause(err))
+	_, err = gs.FeeHistory(0, 1, []float64{50, 10})
+	require.Equal(t, ErrInvalidFeeHistoryQuery, errors.Cause(err))
+	_, err = gs.FeeHistory(0, 1, []float64{101})
+	require.Equal(t, ErrInvalidFeeHistoryQuery, errors.Cause(err))
 }
 
 func TestSuggestGasPriceForSystemAction(t *testing.T) {
@@ -235,3 +313,63 @@ func getActionWithPayload() (act *iotextypes.Action) {
 	}
 	return
 }
+
+func TestEstimateExecutionGas(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	g := config.Default.Genesis
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().Context().Return(context.Background(), nil).AnyTimes()
+	bc.EXPECT().Genesis().Return(g).AnyTimes()
+	dao := mock_blockdao.NewMockBlockDAO(ctrl)
+
+	var (
+		consumed   = uint64(50000)
+		required   = uint64(53000)
+		revertData []byte
+		numCalls   int
+	)
+	// the execution consumes less gas than it requires to succeed, e.g., due to the 63/64 rule of EIP-150
+	simulator := func(_ context.Context, _ address.Address, ex *action.Execution, _ evm.GetBlockHash) ([]byte, *action.Receipt, error) {
+		numCalls++
+		if ex.GasLimit() < 21000 {
+			return nil, nil, action.ErrOutOfGas
+		}
+		receipt := &action.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success), GasConsumed: consumed}
+		if revertData != nil || ex.GasLimit() < required {
+			receipt.Status = uint64(iotextypes.ReceiptStatus_ErrExecutionReverted)
+			return revertData, receipt, nil
+		}
+		return nil, receipt, nil
+	}
+	gs := NewGasStation(bc, simulator, dao, config.Default.API)
+	sc, err := action.NewExecution(identityset.Address(29).String(), 1, big.NewInt(0), 0, big.NewInt(0), nil)
+	require.NoError(err)
+
+	gas, err := gs.EstimateExecutionGas(identityset.Address(28), sc, 1)
+	require.NoError(err)
+	require.Equal(required, gas)
+
+	// gas consumed suffices
+	required = consumed
+	numCalls = 0
+	gas, err = gs.EstimateExecutionGas(identityset.Address(28), sc, 1)
+	require.NoError(err)
+	require.Equal(consumed, gas)
+	require.Equal(2, numCalls)
+
+	// the execution is reverted with any gas limit
+	revertData, err = hex.DecodeString("08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000005" +
+		"6e6f706521000000000000000000000000000000000000000000000000000000")
+	require.NoError(err)
+	_, err = gs.EstimateExecutionGas(identityset.Address(28), sc, 1)
+	execErr, ok := errors.Cause(err).(*ExecutionError)
+	require.True(ok)
+	require.Equal(uint64(iotextypes.ReceiptStatus_ErrExecutionReverted), execErr.Status)
+	require.Equal("nope!", execErr.RevertReason())
+	require.Contains(err.Error(), "ErrExecutionReverted: nope!")
+}
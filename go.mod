@@ -24,6 +24,7 @@ require (
 	github.com/iotexproject/iotex-antenna-go/v2 v2.4.0
 	github.com/iotexproject/iotex-election v0.3.5-0.20201031050050-c3ab4f339a54
 	github.com/iotexproject/iotex-proto v0.4.4-0.20201029172022-a8466422b0f1
+	github.com/klauspost/compress v1.11.3
 	github.com/libp2p/go-libp2p v0.0.21 // indirect
 	github.com/libp2p/go-libp2p-peerstore v0.0.5
 	github.com/mattn/go-sqlite3 v1.11.0
@@ -31,13 +32,15 @@ require (
 	github.com/minio/blake2b-simd v0.0.0-20160723061019-3f5f724cb5b1
 	github.com/minio/sha256-simd v0.1.1 // indirect
 	github.com/multiformats/go-multiaddr v0.0.2
+	github.com/multiformats/go-multistream v0.0.2
+	github.com/pierrec/lz4/v4 v4.1.1
 	github.com/pkg/errors v0.9.1
 	github.com/prometheus/client_golang v1.3.0
 	github.com/rs/zerolog v1.18.0
 	github.com/schollz/progressbar/v2 v2.15.0
 	github.com/spf13/cobra v1.1.1
 	github.com/stretchr/testify v1.5.1
-	github.com/syndtr/goleveldb v1.0.1-0.20190923125748-758128399b1d // indirect
+	github.com/syndtr/goleveldb v1.0.1-0.20190923125748-758128399b1d
 	github.com/tyler-smith/go-bip39 v1.0.2
 	go.etcd.io/bbolt v1.3.5
 	go.uber.org/automaxprocs v1.2.0
@@ -128,10 +128,10 @@ mkdir -p ./test/mock/mock_apiresponder
 mockgen -destination=./test/mock/mock_apiresponder/mock_apiresponder.go  \
         -source=./api/responder.go \
         -package=mock_apiresponder \
-        Responder
+        Responder,ActionResponder
 
 mkdir -p ./test/mock/mock_apiserver
 mockgen -destination=./test/mock/mock_apiserver/mock_apiserver.go  \
-        -source=./api/apitestserver.go \
         -package=mock_apiserver \
-        StreamBlocksServer
+        github.com/iotexproject/iotex-core/api \
+        StreamBlocksServer,StreamPendingActionsServer,StreamConsensusRoundServer
@@ -21,6 +21,7 @@ import (
 	p2p "github.com/iotexproject/go-p2p"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	multiaddr "github.com/multiformats/go-multiaddr"
+	multistream "github.com/multiformats/go-multistream"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -37,6 +38,9 @@ const (
 )
 
 var (
+	// ErrUnsupportedMessage indicates the peer does not support the message, which is not sent
+	ErrUnsupportedMessage = errors.New("message is not supported by the peer")
+
 	p2pMsgCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "iotex_p2p_message_counter",
@@ -63,6 +67,7 @@ const (
 	// TODO: the topic could be fine tuned
 	broadcastTopic    = "broadcast"
 	unicastTopic      = "unicast"
+	syncTopic         = "sync"
 	numDialRetries    = 8
 	dialRetryInterval = 2 * time.Second
 )
@@ -176,50 +181,58 @@ func (p *Agent) Start(ctx context.Context) error {
 		return errors.Wrap(err, "error when adding broadcast pubsub")
 	}
 
-	if err := host.AddUnicastPubSub(unicastTopic+p.topicSuffix, func(ctx context.Context, _ io.Writer, data []byte) (err error) {
-		// Blocking handling the unicast message until the agent is started
-		<-ready
-		var (
-			unicast iotexrpc.UnicastMsg
-			peerID  string
-			latency int64
-		)
-		defer func() {
-			status := successStr
+	unicastHandler := func(typify func(iotexrpc.MessageType, []byte) (proto.Message, error)) p2p.HandleUnicast {
+		return func(ctx context.Context, _ io.Writer, data []byte) (err error) {
+			// Blocking handling the unicast message until the agent is started
+			<-ready
+			var (
+				unicast iotexrpc.UnicastMsg
+				peerID  string
+				latency int64
+			)
+			defer func() {
+				status := successStr
+				if err != nil {
+					status = failureStr
+				}
+				p2pMsgCounter.WithLabelValues("unicast", strconv.Itoa(int(unicast.MsgType)), "in", peerID, status).Inc()
+				p2pMsgLatency.WithLabelValues("unicast", strconv.Itoa(int(unicast.MsgType)), status).Observe(float64(latency))
+			}()
+			if err = proto.Unmarshal(data, &unicast); err != nil {
+				err = errors.Wrap(err, "error when marshaling unicast message")
+				return
+			}
+			msg, err := typify(unicast.MsgType, unicast.MsgBody)
 			if err != nil {
-				status = failureStr
+				err = errors.Wrap(err, "error when typifying unicast message")
+				return
 			}
-			p2pMsgCounter.WithLabelValues("unicast", strconv.Itoa(int(unicast.MsgType)), "in", peerID, status).Inc()
-			p2pMsgLatency.WithLabelValues("unicast", strconv.Itoa(int(unicast.MsgType)), status).Observe(float64(latency))
-		}()
-		if err = proto.Unmarshal(data, &unicast); err != nil {
-			err = errors.Wrap(err, "error when marshaling unicast message")
-			return
-		}
-		msg, err := goproto.TypifyRPCMsg(unicast.MsgType, unicast.MsgBody)
-		if err != nil {
-			err = errors.Wrap(err, "error when typifying unicast message")
-			return
-		}
 
-		t, _ := ptypes.Timestamp(unicast.GetTimestamp())
-		latency = time.Since(t).Nanoseconds() / time.Millisecond.Nanoseconds()
+			t, _ := ptypes.Timestamp(unicast.GetTimestamp())
+			latency = time.Since(t).Nanoseconds() / time.Millisecond.Nanoseconds()
 
-		stream, ok := p2p.GetUnicastStream(ctx)
-		if !ok {
-			err = errors.Wrap(err, "error when typifying unicast message")
+			stream, ok := p2p.GetUnicastStream(ctx)
+			if !ok {
+				err = errors.Wrap(err, "error when typifying unicast message")
+				return
+			}
+			peerID = stream.Conn().RemotePeer().Pretty()
+			peerInfo := peerstore.PeerInfo{
+				ID:    stream.Conn().RemotePeer(),
+				Addrs: []multiaddr.Multiaddr{stream.Conn().RemoteMultiaddr()},
+			}
+			p.unicastInboundAsyncHandler(ctx, unicast.ChainId, peerInfo, msg)
 			return
 		}
-		peerID = stream.Conn().RemotePeer().Pretty()
-		peerInfo := peerstore.PeerInfo{
-			ID:    stream.Conn().RemotePeer(),
-			Addrs: []multiaddr.Multiaddr{stream.Conn().RemoteMultiaddr()},
-		}
-		p.unicastInboundAsyncHandler(ctx, unicast.ChainId, peerInfo, msg)
-		return
-	}); err != nil {
+	}
+	if err := host.AddUnicastPubSub(unicastTopic+p.topicSuffix, unicastHandler(goproto.TypifyRPCMsg)); err != nil {
 		return errors.Wrap(err, "error when adding unicast pubsub")
 	}
+	// the messages of the state snapshot and the block headers are sent on a topic of their own, which the peers not
+	// supporting them do not register, so the topic is negotiated when opening the stream of any such message
+	if err := host.AddUnicastPubSub(syncTopic+p.topicSuffix, unicastHandler(typifySyncMsg)); err != nil {
+		return errors.Wrap(err, "error when adding sync pubsub")
+	}
 
 	if len(p.cfg.BootstrapNodes) > 0 {
 		var tryNum, errNum, connNum, desiredConnNum int
@@ -305,6 +318,10 @@ func (p *Agent) BroadcastOutbound(ctx context.Context, msg proto.Message) (err e
 			status,
 		).Inc()
 	}()
+	if _, ok := getTypeFromSyncMsg(msg); ok {
+		err = errors.Errorf("message %T cannot be broadcast", msg)
+		return
+	}
 	msgType, msgBody, err = convertAppMsg(msg)
 	if err != nil {
 		return
@@ -375,7 +392,16 @@ func (p *Agent) UnicastOutbound(ctx context.Context, peer peerstore.PeerInfo, ms
 		return
 	}
 
-	if err = p.host.Unicast(ctx, peer, unicastTopic+p.topicSuffix, data); err != nil {
+	topic := unicastTopic
+	if _, ok := getTypeFromSyncMsg(msg); ok {
+		topic = syncTopic
+	}
+	if err = p.host.Unicast(ctx, peer, topic+p.topicSuffix, data); err != nil {
+		if errors.Cause(err) == multistream.ErrNotSupported {
+			// the peer is reachable, but does not register the topic
+			err = errors.Wrapf(ErrUnsupportedMessage, "peer does not support topic %s", topic)
+			return
+		}
 		err = errors.Wrap(err, "error when sending unicast message")
 		p.unicastBlocklist.Add(peerName, time.Now())
 		return
@@ -410,9 +436,12 @@ func (p *Agent) Neighbors(ctx context.Context) ([]peerstore.PeerInfo, error) {
 }
 
 func convertAppMsg(msg proto.Message) (iotexrpc.MessageType, []byte, error) {
-	msgType, err := goproto.GetTypeFromRPCMsg(msg)
-	if err != nil {
-		return 0, nil, errors.Wrap(err, "error when converting application message to proto")
+	msgType, ok := getTypeFromSyncMsg(msg)
+	if !ok {
+		var err error
+		if msgType, err = goproto.GetTypeFromRPCMsg(msg); err != nil {
+			return 0, nil, errors.Wrap(err, "error when converting application message to proto")
+		}
 	}
 	msgBody, err := proto.Marshal(msg)
 	if err != nil {
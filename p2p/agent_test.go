@@ -8,16 +8,20 @@ package p2p
 
 import (
 	"context"
+	"io"
 	"net"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	p2p "github.com/iotexproject/go-p2p"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
 	"github.com/iotexproject/iotex-core/testutil"
 	"github.com/iotexproject/iotex-proto/golang/testingpb"
 )
@@ -162,3 +166,62 @@ func TestUnicast(t *testing.T) {
 		}))
 	}
 }
+
+func TestUnicastSyncMsg(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	p2pCtx := WitContext(ctx, Context{ChainID: 1})
+	var (
+		mutex    sync.RWMutex
+		received []proto.Message
+	)
+	b := func(_ context.Context, _ uint32, _ proto.Message) {}
+	u := func(_ context.Context, _ uint32, _ peerstore.PeerInfo, msg proto.Message) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		received = append(received, msg)
+	}
+	newAgent := func() *Agent {
+		agent := NewAgent(config.Config{
+			Network: config.Network{Host: "127.0.0.1", Port: testutil.RandomPort()},
+		}, b, u)
+		require.NoError(agent.Start(ctx))
+		return agent
+	}
+	agent := newAgent()
+	defer func() { require.NoError(agent.Stop(ctx)) }()
+	peer := newAgent()
+	defer func() { require.NoError(peer.Stop(ctx)) }()
+
+	// the sync messages are sent to the peers supporting them
+	req := &snapshotpb.Request{Manifest: true}
+	require.NoError(agent.UnicastOutbound(p2pCtx, peer.Info(), req))
+	require.NoError(testutil.WaitUntil(100*time.Millisecond, 10*time.Second, func() (bool, error) {
+		mutex.RLock()
+		defer mutex.RUnlock()
+		return len(received) == 1 && proto.Equal(req, received[0]), nil
+	}))
+	require.Error(agent.BroadcastOutbound(p2pCtx, req))
+
+	// the peer not supporting the sync messages only registers the unicast topic
+	var oldReceived int
+	old, err := p2p.NewHost(ctx, p2p.HostName("127.0.0.1"), p2p.Port(testutil.RandomPort()), p2p.SecureIO())
+	require.NoError(err)
+	defer func() { require.NoError(old.Close()) }()
+	require.NoError(old.AddUnicastPubSub(unicastTopic+agent.topicSuffix, func(_ context.Context, _ io.Writer, _ []byte) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		oldReceived++
+		return nil
+	}))
+	err = agent.UnicastOutbound(p2pCtx, old.Info(), req)
+	require.Equal(ErrUnsupportedMessage, errors.Cause(err))
+	// the peer is not blocked for the other messages
+	require.False(agent.unicastBlocklist.Blocked(old.Info().ID.Pretty(), time.Now()))
+	require.NoError(agent.UnicastOutbound(p2pCtx, old.Info(), &testingpb.TestPayload{MsgBody: []byte{1}}))
+	require.NoError(testutil.WaitUntil(100*time.Millisecond, 10*time.Second, func() (bool, error) {
+		mutex.RLock()
+		defer mutex.RUnlock()
+		return oldReceived == 1, nil
+	}))
+}
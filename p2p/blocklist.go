@@ -14,15 +14,24 @@ const (
 
 // BlockList is the struct for blocklist
 type BlockList struct {
-	counter *cache.ThreadSafeLruCache
-	timeout *cache.ThreadSafeLruCache
+	counter   *cache.ThreadSafeLruCache
+	timeout   *cache.ThreadSafeLruCache
+	threshold int
+	ttl       time.Duration
 }
 
 // NewBlockList creates an instance of blocklist
 func NewBlockList(size int) *BlockList {
+	return NewCustomBlockList(size, blockThreshold, blockListTTL)
+}
+
+// NewCustomBlockList creates an instance of blocklist, which blocks a name for ttl once it reaches threshold faults
+func NewCustomBlockList(size int, threshold int, ttl time.Duration) *BlockList {
 	return &BlockList{
-		counter: cache.NewThreadSafeLruCache(size),
-		timeout: cache.NewThreadSafeLruCache(size),
+		counter:   cache.NewThreadSafeLruCache(size),
+		timeout:   cache.NewThreadSafeLruCache(size),
+		threshold: threshold,
+		ttl:       ttl,
 	}
 }
 
@@ -44,18 +53,25 @@ func (bl *BlockList) Blocked(name string, t time.Time) bool {
 
 // Add tries to add the name to blocklist
 func (bl *BlockList) Add(name string, t time.Time) {
-	v, ok := bl.counter.Get(name)
-	if !ok {
-		bl.counter.Add(name, 1)
-		return
+	counter := 1
+	if v, ok := bl.counter.Get(name); ok {
+		counter = v.(int) + 1
 	}
 
-	// once reaching 3 faults, add to blocklist
-	counter := v.(int) + 1
+	// once reaching threshold faults, add to blocklist
 	bl.counter.Add(name, counter)
-	if counter >= blockThreshold {
-		bl.timeout.Add(name, t.Add(blockListTTL))
+	if counter >= bl.threshold {
+		bl.timeout.Add(name, t.Add(bl.ttl))
+	}
+}
+
+// Expiry returns the time when the name will be taken off the blocklist
+func (bl *BlockList) Expiry(name string) (time.Time, bool) {
+	v, ok := bl.timeout.Get(name)
+	if !ok {
+		return time.Time{}, false
 	}
+	return v.(time.Time), true
 }
 
 // Remove takes name off the blocklist
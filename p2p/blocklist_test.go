@@ -35,3 +35,22 @@ func TestBlockList(t *testing.T) {
 		r.Equal(v.blocked, list.Blocked(name, v.curTime))
 	}
 }
+
+func TestCustomBlockList(t *testing.T) {
+	r := require.New(t)
+
+	now := time.Now()
+	name := "bravo"
+	list := NewCustomBlockList(10, 1, time.Minute)
+	_, ok := list.Expiry(name)
+	r.False(ok)
+	list.Add(name, now)
+	r.True(list.Blocked(name, now))
+	expiry, ok := list.Expiry(name)
+	r.True(ok)
+	r.Equal(now.Add(time.Minute), expiry)
+	list.Remove(name)
+	r.False(list.Blocked(name, now))
+	_, ok = list.Expiry(name)
+	r.False(ok)
+}
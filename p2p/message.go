@@ -0,0 +1,73 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blocksync/blocksyncpb"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
+)
+
+// The message types below are only sent on the sync topic, which the nodes supporting them register, and which is
+// negotiated with the peer before any message is sent on it. They are scoped to the topic, so they do not collide with
+// the message types of iotex-proto on the other topics, and are never sent to the nodes not supporting them
+const (
+	// MessageTypeSnapshotRequest is the type of a request for the state snapshot
+	MessageTypeSnapshotRequest iotexrpc.MessageType = 1001
+	// MessageTypeSnapshotManifest is the type of a response with the manifest of the state snapshot
+	MessageTypeSnapshotManifest iotexrpc.MessageType = 1002
+	// MessageTypeSnapshotChunk is the type of a response with a chunk of the state snapshot
+	MessageTypeSnapshotChunk iotexrpc.MessageType = 1003
+	// MessageTypeHeadersRequest is the type of a request for block headers
+	MessageTypeHeadersRequest iotexrpc.MessageType = 1004
+	// MessageTypeHeaders is the type of a response with block headers
+	MessageTypeHeaders iotexrpc.MessageType = 1005
+)
+
+// getTypeFromSyncMsg returns the type of the message sent on the sync topic, and false if it is not sent on the topic
+func getTypeFromSyncMsg(msg proto.Message) (iotexrpc.MessageType, bool) {
+	switch msg.(type) {
+	case *snapshotpb.Request:
+		return MessageTypeSnapshotRequest, true
+	case *snapshotpb.ManifestResponse:
+		return MessageTypeSnapshotManifest, true
+	case *snapshotpb.ChunkResponse:
+		return MessageTypeSnapshotChunk, true
+	case *blocksyncpb.HeadersRequest:
+		return MessageTypeHeadersRequest, true
+	case *blocksyncpb.HeadersResponse:
+		return MessageTypeHeaders, true
+	default:
+		return 0, false
+	}
+}
+
+// typifySyncMsg unmarshals the message body of the type received on the sync topic
+func typifySyncMsg(t iotexrpc.MessageType, msg []byte) (proto.Message, error) {
+	var m proto.Message
+	switch t {
+	case MessageTypeSnapshotRequest:
+		m = &snapshotpb.Request{}
+	case MessageTypeSnapshotManifest:
+		m = &snapshotpb.ManifestResponse{}
+	case MessageTypeSnapshotChunk:
+		m = &snapshotpb.ChunkResponse{}
+	case MessageTypeHeadersRequest:
+		m = &blocksyncpb.HeadersRequest{}
+	case MessageTypeHeaders:
+		m = &blocksyncpb.HeadersResponse{}
+	default:
+		return nil, errors.Errorf("unknown message type %d on the sync topic", t)
+	}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal message of type %d", t)
+	}
+	return m, nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	goproto "github.com/iotexproject/iotex-proto/golang"
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/iotexproject/iotex-proto/golang/testingpb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blocksync/blocksyncpb"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
+)
+
+func TestConvertAppMsg(t *testing.T) {
+	require := require.New(t)
+	for _, v := range []struct {
+		msg  proto.Message
+		t    iotexrpc.MessageType
+		sync bool
+	}{
+		{&snapshotpb.Request{Manifest: true}, MessageTypeSnapshotRequest, true},
+		{&snapshotpb.ManifestResponse{Manifest: &snapshotpb.Manifest{Height: 10}}, MessageTypeSnapshotManifest, true},
+		{&snapshotpb.ChunkResponse{Height: 10, Chunk: 1, Data: []byte{1}}, MessageTypeSnapshotChunk, true},
+		{&blocksyncpb.HeadersRequest{Start: 1, End: 10}, MessageTypeHeadersRequest, true},
+		{&blocksyncpb.HeadersResponse{Headers: []*iotextypes.BlockHeader{{}}}, MessageTypeHeaders, true},
+		{&testingpb.TestPayload{MsgBody: []byte{1}}, iotexrpc.MessageType_TEST, false},
+	} {
+		msgType, body, err := convertAppMsg(v.msg)
+		require.NoError(err)
+		require.Equal(v.t, msgType)
+		_, ok := getTypeFromSyncMsg(v.msg)
+		require.Equal(v.sync, ok)
+		var msg proto.Message
+		if v.sync {
+			msg, err = typifySyncMsg(msgType, body)
+		} else {
+			msg, err = goproto.TypifyRPCMsg(msgType, body)
+		}
+		require.NoError(err)
+		require.True(proto.Equal(v.msg, msg))
+	}
+	_, err := typifySyncMsg(MessageTypeSnapshotChunk, []byte{0xff})
+	require.Error(err)
+	// the messages of the other topics are not received on the sync topic
+	_, err = typifySyncMsg(iotexrpc.MessageType_TEST, []byte{})
+	require.Error(err)
+}
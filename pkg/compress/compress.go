@@ -10,8 +10,11 @@ import (
 	"bytes"
 	"compress/gzip"
 	"io/ioutil"
+	"sync"
 
 	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
 	"github.com/pkg/errors"
 )
 
@@ -19,6 +22,8 @@ import (
 const (
 	Gzip   = "Gzip"
 	Snappy = "Snappy"
+	Zstd   = "Zstd"
+	Lz4    = "Lz4"
 )
 
 // error definition
@@ -26,6 +31,31 @@ var (
 	ErrInputEmpty = errors.New("input cannot be empty")
 )
 
+var (
+	// the zstd encoder and decoder without dictionary are shared, both are safe for concurrent use
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+// ZstdDict compresses and decompresses with a zstd dictionary, which is trained on the samples of the data to
+// compress, e.g. by `zstd --train`
+type ZstdDict struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+// IsSupported returns true if the compressor is supported
+func IsSupported(compressor string) bool {
+	switch compressor {
+	case Gzip, Snappy, Zstd, Lz4:
+		return true
+	default:
+		return false
+	}
+}
+
 // Compress compresses input according to compressor
 func Compress(value []byte, compressor string) ([]byte, error) {
 	if value == nil {
@@ -37,6 +67,10 @@ func Compress(value []byte, compressor string) ([]byte, error) {
 		return CompGzip(value)
 	case Snappy:
 		return CompSnappy(value)
+	case Zstd:
+		return CompZstd(value)
+	case Lz4:
+		return CompLz4(value)
 	default:
 		panic("unsupported compressor")
 	}
@@ -49,6 +83,10 @@ func Decompress(value []byte, compressor string) ([]byte, error) {
 		return DecompGzip(value)
 	case Snappy:
 		return DecompSnappy(value)
+	case Zstd:
+		return DecompZstd(value)
+	case Lz4:
+		return DecompLz4(value)
 	default:
 		panic("unsupported compressor")
 	}
@@ -94,3 +132,93 @@ func DecompSnappy(data []byte) ([]byte, error) {
 	}
 	return v, err
 }
+
+// CompZstd uses zstd to compress the input bytes
+func CompZstd(data []byte) ([]byte, error) {
+	if err := initZstd(); err != nil {
+		return nil, err
+	}
+	return zstdEncoder.EncodeAll(data, nil), nil
+}
+
+// DecompZstd uses zstd to decompress the input bytes
+func DecompZstd(data []byte) ([]byte, error) {
+	if err := initZstd(); err != nil {
+		return nil, err
+	}
+	return decodeZstd(zstdDecoder, data)
+}
+
+func decodeZstd(decoder *zstd.Decoder, data []byte) ([]byte, error) {
+	v, err := decoder.DecodeAll(data, nil)
+	if len(v) == 0 {
+		v = []byte{}
+	}
+	return v, err
+}
+
+func initZstd() error {
+	zstdOnce.Do(func() {
+		if zstdEncoder, zstdErr = zstd.NewWriter(nil); zstdErr != nil {
+			return
+		}
+		zstdDecoder, zstdErr = zstd.NewReader(nil)
+	})
+	return zstdErr
+}
+
+// NewZstdDict creates a ZstdDict with the dictionary
+func NewZstdDict(dict []byte) (*ZstdDict, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderDict(dict))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid zstd dictionary")
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderDicts(dict))
+	if err != nil {
+		encoder.Close()
+		return nil, errors.Wrap(err, "invalid zstd dictionary")
+	}
+	return &ZstdDict{encoder: encoder, decoder: decoder}, nil
+}
+
+// Compress uses zstd with the dictionary to compress the input bytes
+func (d *ZstdDict) Compress(data []byte) ([]byte, error) {
+	if data == nil {
+		return nil, ErrInputEmpty
+	}
+	return d.encoder.EncodeAll(data, nil), nil
+}
+
+// Decompress uses zstd with the dictionary to decompress the input bytes
+func (d *ZstdDict) Decompress(data []byte) ([]byte, error) {
+	return decodeZstd(d.decoder, data)
+}
+
+// Close releases the resources of the encoder and decoder
+func (d *ZstdDict) Close() {
+	d.encoder.Close()
+	d.decoder.Close()
+}
+
+// CompLz4 uses lz4 to compress the input bytes
+func CompLz4(data []byte) ([]byte, error) {
+	var bb bytes.Buffer
+	w := lz4.NewWriter(&bb)
+	// small blocks save the buffers of decompression, as block data is much smaller than the default 4MB
+	if err := w.Apply(lz4.BlockSizeOption(lz4.Block64Kb)); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return bb.Bytes(), nil
+}
+
+// DecompLz4 uses lz4 to decompress the input bytes
+func DecompLz4(data []byte) ([]byte, error) {
+	return ioutil.ReadAll(lz4.NewReader(bytes.NewReader(data)))
+}
@@ -23,6 +23,10 @@ func TestCompressDataBytes(t *testing.T) {
 	r.Error(err)
 	_, err = Decompress([]byte{}, Snappy)
 	r.Error(err)
+	_, err = Decompress([]byte("invalid data"), Zstd)
+	r.Error(err)
+	_, err = Decompress([]byte("invalid data"), Lz4)
+	r.Error(err)
 	r.Panics(func() { Compress([]byte{}, "invalid") })
 	r.Panics(func() { Decompress([]byte{}, "invalid") })
 
@@ -36,7 +40,7 @@ func TestCompressDataBytes(t *testing.T) {
 		[]byte("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ`1234567890-=~!@#$%^&*()_+å∫ç∂´´©˙ˆˆ˚¬µ˜˜πœ®ß†¨¨∑≈¥Ω[]',./{}|:<>?"),
 	}
 	for _, ser := range compressTests {
-		for _, compress := range []string{Gzip, Snappy} {
+		for _, compress := range []string{Gzip, Snappy, Zstd, Lz4} {
 			v, err := Compress(ser, compress)
 			r.NoError(err)
 
@@ -46,3 +50,14 @@ func TestCompressDataBytes(t *testing.T) {
 		}
 	}
 }
+
+func TestZstdDict(t *testing.T) {
+	r := require.New(t)
+
+	_, err := NewZstdDict([]byte("not a zstd dictionary"))
+	r.Error(err)
+	r.True(IsSupported(Zstd))
+	r.True(IsSupported(Lz4))
+	r.False(IsSupported(""))
+	r.False(IsSupported("invalid"))
+}
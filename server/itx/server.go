@@ -17,8 +17,10 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/chainservice"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus"
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/p2p"
 	"github.com/iotexproject/iotex-core/pkg/ha"
@@ -199,6 +201,11 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 		log.RegisterLevelConfigMux(mux)
 		haCtl := ha.New(svr.rootChainService.Consensus())
 		mux.Handle("/ha", http.HandlerFunc(haCtl.Handle))
+		banCtl := actpool.NewBanController(svr.rootChainService.ActionPool(), cfg.System.HTTPAdminToken)
+		mux.Handle("/actpool/bans", http.HandlerFunc(banCtl.Handle))
+		roundCtl := consensus.NewRoundController(svr.rootChainService.Consensus())
+		mux.Handle("/consensus/round", http.HandlerFunc(roundCtl.Handle))
+		mux.Handle("/consensus/round/stream", http.HandlerFunc(roundCtl.HandleStream))
 		mux.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
 		mux.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
 		mux.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
@@ -0,0 +1,111 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/iotexproject/iotex-core/signer/signerpb"
+)
+
+// RemoteSigner is the signer requesting the signatures from a remote signer over gRPC, so that the private key of the
+// block producer is not kept on the node
+type RemoteSigner struct {
+	conn    *grpc.ClientConn
+	client  signerpb.SignerServiceClient
+	pk      crypto.PublicKey
+	timeout time.Duration
+}
+
+// NewClientTLS returns the mutual TLS credentials of the node, which authenticates itself to the remote signer with
+// the client certificate, and verifies the remote signer with the CA certificate
+func NewClientTLS(certPath, keyPath, caCertPath string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load client certificate")
+	}
+	caCert, err := ioutil.ReadFile(caCertPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read CA certificate %s", caCertPath)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, errors.Errorf("invalid CA certificate %s", caCertPath)
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// NewRemoteSigner connects to the remote signer at the endpoint with the credentials, and fetches the public key of
+// the block producer
+func NewRemoteSigner(endpoint string, creds credentials.TransportCredentials, timeout time.Duration) (*RemoteSigner, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, endpoint, grpc.WithBlock(), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to remote signer %s", endpoint)
+	}
+	s := &RemoteSigner{
+		conn:    conn,
+		client:  signerpb.NewSignerServiceClient(conn),
+		timeout: timeout,
+	}
+	res, err := s.client.PublicKey(ctx, &signerpb.PublicKeyRequest{})
+	if err == nil {
+		s.pk, err = crypto.BytesToPublicKey(res.GetPublicKey())
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Wrap(err, "failed to get public key from remote signer")
+	}
+	return s, nil
+}
+
+// PublicKey returns the public key of the block producer
+func (s *RemoteSigner) PublicKey() crypto.PublicKey {
+	return s.pk
+}
+
+// Sign requests the remote signer to sign the document of the message, which is refused if it violates the slashing
+// protection rules. The signature is verified against the digest of the message, which the remote signer derives from
+// the document on its own
+func (s *RemoteSigner) Sign(msg *Message) ([]byte, error) {
+	if len(msg.Document) == 0 {
+		return nil, errors.Errorf("%s message has no document to sign remotely", msg.Kind)
+	}
+	req, err := msg.Proto()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	res, err := s.client.Sign(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign through remote signer")
+	}
+	if !s.pk.Verify(msg.Digest, res.GetSignature()) {
+		return nil, errors.New("invalid signature from remote signer")
+	}
+	return res.GetSignature(), nil
+}
+
+// Close closes the connection to the remote signer
+func (s *RemoteSigner) Close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ecrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/signer/signerpb"
+)
+
+const (
+	// BlockKind is the kind of the message signing a block header core
+	BlockKind = "block"
+	// VoteKind is the kind of the message endorsing a consensus vote
+	VoteKind = "vote"
+	// ProposalKind is the kind of the message endorsing a block proposal
+	ProposalKind = "proposal"
+	// EndorsementKind is the kind of the message endorsing a document which does not describe itself, and cannot be
+	// signed by a remote signer
+	EndorsementKind = "endorsement"
+	// ActionKind is the kind of the message signing an action core
+	ActionKind = "action"
+)
+
+type (
+	// Message is the digest to sign, along with the serialized document it is derived from, so that a remote signer
+	// derives the digest and what it commits to from the document, and checks them against its slashing protection
+	// rules
+	Message struct {
+		Kind string
+		// Document is the serialized block header core, consensus vote, block proposal or action core signed
+		Document []byte
+		// Timestamp is the time of an endorsement
+		Timestamp time.Time
+		Digest    []byte
+	}
+
+	// Signer signs the messages of the block producer
+	Signer interface {
+		// PublicKey returns the public key of the block producer
+		PublicKey() crypto.PublicKey
+		// Sign signs the digest of the message
+		Sign(*Message) ([]byte, error)
+	}
+
+	// Describer is a document describing its kind and serialized form, from which a remote signer derives the digest
+	Describer interface {
+		Describe(*Message) error
+	}
+
+	localSigner struct {
+		sk crypto.PrivateKey
+	}
+)
+
+// NewLocalSigner returns a signer with the private key in memory
+func NewLocalSigner(sk crypto.PrivateKey) Signer {
+	return &localSigner{sk: sk}
+}
+
+// NewKeystoreSigner returns a signer with the private key decrypted from the keystore file
+func NewKeystoreSigner(path, password string) (Signer, error) {
+	keyJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read keystore file %s", path)
+	}
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt keystore file")
+	}
+	sk, err := crypto.BytesToPrivateKey(ecrypto.FromECDSA(key.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalSigner(sk), nil
+}
+
+// NewSigner returns the signer of the block producer in the config
+func NewSigner(cfg config.Config) (Signer, error) {
+	var (
+		s   Signer
+		err error
+	)
+	sc := cfg.Chain.ProducerSigner
+	switch sc.Type {
+	case "", config.LocalSigner:
+		return NewLocalSigner(cfg.ProducerPrivateKey()), nil
+	case config.KeystoreSigner:
+		s, err = NewKeystoreSigner(sc.KeystorePath, sc.KeystorePassword)
+	case config.RemoteSigner:
+		var creds credentials.TransportCredentials
+		if creds, err = NewClientTLS(sc.CertPath, sc.KeyPath, sc.CACertPath); err == nil {
+			s, err = NewRemoteSigner(sc.Endpoint, creds, sc.Timeout)
+		}
+	default:
+		err = errors.Errorf("unsupported signer type %s", sc.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(s.PublicKey().Bytes(), cfg.ProducerPublicKey().Bytes()) {
+		if closer, ok := s.(io.Closer); ok {
+			_ = closer.Close()
+		}
+		return nil, errors.Errorf("public key of the %s signer does not match the config", sc.Type)
+	}
+	return s, nil
+}
+
+func (s *localSigner) PublicKey() crypto.PublicKey {
+	return s.sk.PublicKey()
+}
+
+func (s *localSigner) Sign(msg *Message) ([]byte, error) {
+	return s.sk.Sign(msg.Digest)
+}
+
+// Proto converts the message to the protobuf message of signing request, which does not carry the digest
+func (msg *Message) Proto() (*signerpb.SignRequest, error) {
+	req := &signerpb.SignRequest{
+		Kind:     msg.Kind,
+		Document: msg.Document,
+	}
+	if !msg.Timestamp.IsZero() {
+		ts, err := ptypes.TimestampProto(msg.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		req.Timestamp = ts
+	}
+	return req, nil
+}
+
+// LoadProto loads the message from the protobuf message of signing request, leaving the digest to be derived
+func (msg *Message) LoadProto(req *signerpb.SignRequest) error {
+	*msg = Message{
+		Kind:     req.GetKind(),
+		Document: req.GetDocument(),
+	}
+	if req.GetTimestamp() != nil {
+		ts, err := ptypes.Timestamp(req.GetTimestamp())
+		if err != nil {
+			return err
+		}
+		msg.Timestamp = ts
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestLocalSigner(t *testing.T) {
+	require := require.New(t)
+
+	sk := identityset.PrivateKey(1)
+	s := NewLocalSigner(sk)
+	require.Equal(sk.PublicKey(), s.PublicKey())
+	h := hash.Hash256b([]byte("block"))
+	sig, err := s.Sign(&Message{Kind: BlockKind, Document: []byte("block"), Digest: h[:]})
+	require.NoError(err)
+	require.True(sk.PublicKey().Verify(h[:], sig))
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "keystore")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	sk := identityset.PrivateKey(1)
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.ImportECDSA(sk.EcdsaPrivateKey().(*ecdsa.PrivateKey), "password")
+	require.NoError(err)
+
+	_, err = NewKeystoreSigner(acc.URL.Path, "wrong password")
+	require.Error(err)
+	_, err = NewKeystoreSigner(dir+"/not-exist", "password")
+	require.Error(err)
+	s, err := NewKeystoreSigner(acc.URL.Path, "password")
+	require.NoError(err)
+	require.Equal(sk.PublicKey().Bytes(), s.PublicKey().Bytes())
+
+	cfg := config.Default
+	cfg.Chain.ProducerSigner.Type = config.KeystoreSigner
+	cfg.Chain.ProducerSigner.KeystorePath = acc.URL.Path
+	cfg.Chain.ProducerSigner.KeystorePassword = "password"
+	cfg.Chain.ProducerSigner.PublicKey = sk.PublicKey().HexString()
+	s, err = NewSigner(cfg)
+	require.NoError(err)
+	require.Equal(sk.PublicKey().Bytes(), s.PublicKey().Bytes())
+	require.Equal(identityset.Address(1).String(), cfg.ProducerAddress().String())
+
+	// the key in the keystore does not match the one in config
+	cfg.Chain.ProducerSigner.PublicKey = identityset.PrivateKey(2).PublicKey().HexString()
+	_, err = NewSigner(cfg)
+	require.Error(err)
+}
+
+func TestNewSigner(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Chain.ProducerPrivKey = identityset.PrivateKey(1).HexString()
+	s, err := NewSigner(cfg)
+	require.NoError(err)
+	require.Equal(identityset.PrivateKey(1).PublicKey().Bytes(), s.PublicKey().Bytes())
+
+	cfg.Chain.ProducerSigner.Type = "hsm"
+	_, err = NewSigner(cfg)
+	require.Error(err)
+}
+
+func TestMessageProto(t *testing.T) {
+	require := require.New(t)
+
+	msg := &Message{
+		Kind:      VoteKind,
+		Document:  []byte("vote"),
+		Timestamp: time.Unix(1600000000, 500).UTC(),
+		Digest:    []byte("digest"),
+	}
+	req, err := msg.Proto()
+	require.NoError(err)
+	loaded := &Message{}
+	require.NoError(loaded.LoadProto(req))
+	// the digest is not sent, and is derived from the document by the remote signer
+	require.Nil(loaded.Digest)
+	loaded.Digest = msg.Digest
+	require.Equal(msg, loaded)
+
+	// timestamp is optional
+	msg = &Message{Kind: ActionKind, Document: []byte("action")}
+	req, err = msg.Proto()
+	require.NoError(err)
+	require.Nil(req.GetTimestamp())
+	require.NoError(loaded.LoadProto(req))
+	require.Equal(msg, loaded)
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signerserver
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
+	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/signer"
+)
+
+// Commitment is what signing a message commits to, which is derived from the document of the message rather than
+// trusting the client. The digest is recomputed from the document decoded, so that the signature commits to nothing
+// else than what the slashing protection rules are checked against
+type Commitment struct {
+	Kind string
+	// Height is the height of the block signed, or the block proposed
+	Height uint64
+	// Topic is the consensus topic of an endorsement
+	Topic string
+	// BlockHash is the hash of the block header core signed, or the block endorsed
+	BlockHash []byte
+	// Timestamp is the time of an endorsement
+	Timestamp time.Time
+	Digest    []byte
+}
+
+// NewCommitment decodes the document of the message signed by the block producer of the public key
+func NewCommitment(msg *signer.Message, pk crypto.PublicKey) (*Commitment, error) {
+	if len(msg.Document) == 0 {
+		return nil, errors.Wrap(ErrInvalidMessage, "empty document")
+	}
+	c := &Commitment{Kind: msg.Kind}
+	var err error
+	switch msg.Kind {
+	case signer.BlockKind:
+		err = c.loadBlock(msg.Document, pk)
+	case signer.VoteKind:
+		err = c.loadVote(msg.Document, msg.Timestamp)
+	case signer.ProposalKind:
+		err = c.loadProposal(msg.Document, msg.Timestamp)
+	case signer.ActionKind:
+		err = c.loadAction(msg.Document)
+	default:
+		return nil, errors.Wrapf(ErrInvalidMessage, "unsupported message kind %s", msg.Kind)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidMessage, "failed to decode %s: %v", msg.Kind, err)
+	}
+	return c, nil
+}
+
+func (c *Commitment) loadBlock(doc []byte, pk crypto.PublicKey) error {
+	core := &iotextypes.BlockHeaderCore{}
+	if err := proto.Unmarshal(doc, core); err != nil {
+		return err
+	}
+	header := &block.Header{}
+	if err := header.LoadFromBlockHeaderProto(&iotextypes.BlockHeader{
+		Core:           core,
+		ProducerPubkey: pk.Bytes(),
+	}); err != nil {
+		return err
+	}
+	h := header.HashHeaderCore()
+	c.Height = header.Height()
+	c.BlockHash = h[:]
+	c.Digest = h[:]
+	return nil
+}
+
+func (c *Commitment) loadVote(doc []byte, ts time.Time) error {
+	pb := &iotextypes.ConsensusVote{}
+	if err := proto.Unmarshal(doc, pb); err != nil {
+		return err
+	}
+	vote := &rolldpos.ConsensusVote{}
+	if err := vote.LoadProto(pb); err != nil {
+		return err
+	}
+	c.Topic = vote.Topic().String()
+	c.BlockHash = vote.BlockHash()
+	return c.loadEndorsement(vote, ts)
+}
+
+func (c *Commitment) loadProposal(doc []byte, ts time.Time) error {
+	pb := &iotextypes.BlockProposal{}
+	if err := proto.Unmarshal(doc, pb); err != nil {
+		return err
+	}
+	if pb.GetBlock() == nil {
+		return errors.New("missing block")
+	}
+	proposal, blk, err := rolldpos.LoadBlockProposal(pb)
+	if err != nil {
+		return err
+	}
+	blkHash := blk.HashBlock()
+	c.Height = blk.Height()
+	c.Topic = evidence.BlockProposalTopic
+	c.BlockHash = blkHash[:]
+	return c.loadEndorsement(proposal, ts)
+}
+
+func (c *Commitment) loadEndorsement(doc endorsement.Document, ts time.Time) error {
+	if ts.IsZero() {
+		return errors.New("missing endorsement time")
+	}
+	digest, err := endorsement.Digest(doc, ts)
+	if err != nil {
+		return err
+	}
+	c.Timestamp = ts
+	c.Digest = digest
+	return nil
+}
+
+// loadAction only accepts the system actions put into the blocks by the block producer
+func (c *Commitment) loadAction(doc []byte) error {
+	core := &iotextypes.ActionCore{}
+	if err := proto.Unmarshal(doc, core); err != nil {
+		return err
+	}
+	elp := &action.Envelope{}
+	if err := elp.LoadProto(core); err != nil {
+		return err
+	}
+	switch elp.Action().(type) {
+	case *action.GrantReward, *action.PutPollResult:
+	default:
+		return errors.Errorf("action %T is not a system action", elp.Action())
+	}
+	h := elp.Hash()
+	c.Digest = h[:]
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signerserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
+	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/signer"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestCommitment(t *testing.T) {
+	require := require.New(t)
+
+	sk := identityset.PrivateKey(1)
+	ts := time.Unix(1600000000, 0)
+	blk, err := block.NewBuilder(block.NewRunnableActionsBuilder().Build()).
+		SetHeight(10).
+		SetPrevBlockHash(hash.Hash256b([]byte("block9"))).
+		SetTimestamp(ts).
+		SignAndBuild(sk)
+	require.NoError(err)
+
+	// block header core
+	c, err := NewCommitment(&signer.Message{Kind: signer.BlockKind, Document: blk.Header.SerializeCore()}, sk.PublicKey())
+	require.NoError(err)
+	coreHash := blk.Header.HashHeaderCore()
+	require.Equal(uint64(10), c.Height)
+	require.Equal(coreHash[:], c.BlockHash)
+	require.Equal(coreHash[:], c.Digest)
+
+	// block proposal
+	pb := &iotextypes.BlockProposal{Block: blk.ConvertToBlockPb()}
+	doc, err := proto.Marshal(pb)
+	require.NoError(err)
+	msg := &signer.Message{Kind: signer.ProposalKind, Document: doc, Timestamp: ts}
+	c, err = NewCommitment(msg, sk.PublicKey())
+	require.NoError(err)
+	proposal, _, err := rolldpos.LoadBlockProposal(pb)
+	require.NoError(err)
+	digest, err := endorsement.Digest(proposal, ts)
+	require.NoError(err)
+	blkHash := blk.HashBlock()
+	require.Equal(uint64(10), c.Height)
+	require.Equal(evidence.BlockProposalTopic, c.Topic)
+	require.Equal(blkHash[:], c.BlockHash)
+	require.Equal(digest, c.Digest)
+
+	// an endorsement has to be timed
+	msg.Timestamp = time.Time{}
+	_, err = NewCommitment(msg, sk.PublicKey())
+	require.Equal(ErrInvalidMessage, errors.Cause(err))
+
+	// consensus vote
+	vote := rolldpos.NewConsensusVote(blkHash[:], rolldpos.LOCK)
+	msg = &signer.Message{Timestamp: ts}
+	require.NoError(vote.Describe(msg))
+	require.Equal(signer.VoteKind, msg.Kind)
+	c, err = NewCommitment(msg, sk.PublicKey())
+	require.NoError(err)
+	digest, err = endorsement.Digest(vote, ts)
+	require.NoError(err)
+	require.Equal("LOCK", c.Topic)
+	require.Equal(blkHash[:], c.BlockHash)
+	require.Equal(digest, c.Digest)
+
+	// documents which cannot be decoded, or do not describe themselves
+	for _, msg := range []*signer.Message{
+		{Kind: signer.VoteKind},
+		{Kind: signer.ProposalKind, Document: []byte("proposal"), Timestamp: ts},
+		{Kind: signer.ActionKind, Document: blkHash[:]},
+		{Kind: signer.EndorsementKind, Document: blkHash[:], Timestamp: ts},
+	} {
+		_, err = NewCommitment(msg, sk.PublicKey())
+		require.Equal(ErrInvalidMessage, errors.Cause(err))
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signerserver
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/signer"
+)
+
+const (
+	// signedBlockNS is the namespace of the hashes of the blocks signed, keyed by height
+	signedBlockNS = "SignedBlock"
+	// signedEndorsementNS is the namespace of the hashes of the blocks endorsed, keyed by topic and endorsement time
+	signedEndorsementNS = "SignedEndorsement"
+)
+
+var (
+	// ErrSlashable indicates signing the message would make the block producer slashable
+	ErrSlashable = errors.New("slashable message")
+	// ErrInvalidMessage indicates the document of the message cannot be signed
+	ErrInvalidMessage = errors.New("invalid message")
+)
+
+// Protection enforces the slashing protection rules, by recording the blocks signed and endorsed before signing:
+//  1. never sign two different blocks at the same height
+//  2. never endorse two different blocks at the same topic and time, which is the same stage of a consensus round
+type Protection struct {
+	mutex   sync.Mutex
+	kvStore db.KVStore
+}
+
+// NewProtection creates a slashing protection on the kv store
+func NewProtection(kvStore db.KVStore) *Protection {
+	return &Protection{kvStore: kvStore}
+}
+
+// Start starts the slashing protection
+func (p *Protection) Start(ctx context.Context) error {
+	return p.kvStore.Start(ctx)
+}
+
+// Stop stops the slashing protection
+func (p *Protection) Stop(ctx context.Context) error {
+	return p.kvStore.Stop(ctx)
+}
+
+// Check checks the commitment against the slashing protection rules, and records it if it is safe to sign
+func (p *Protection) Check(c *Commitment) error {
+	var ns string
+	var key []byte
+	switch c.Kind {
+	case signer.BlockKind:
+		ns = signedBlockNS
+		key = byteutil.Uint64ToBytesBigEndian(c.Height)
+	case signer.VoteKind, signer.ProposalKind:
+		if len(c.BlockHash) == 0 {
+			// endorsing no block does not conflict with anything
+			return nil
+		}
+		ns = signedEndorsementNS
+		key = append([]byte(c.Topic), byteutil.Uint64ToBytesBigEndian(uint64(c.Timestamp.Unix()))...)
+		key = append(key, byteutil.Uint32ToBytesBigEndian(uint32(c.Timestamp.Nanosecond()))...)
+	case signer.ActionKind:
+		// the system actions do not conflict with each other, and other actions are refused when decoded
+		return nil
+	default:
+		return errors.Wrapf(ErrInvalidMessage, "unknown message kind %s", c.Kind)
+	}
+	if len(c.BlockHash) == 0 {
+		return errors.Wrap(ErrInvalidMessage, "missing block hash")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	signed, err := p.kvStore.Get(ns, key)
+	switch errors.Cause(err) {
+	case nil:
+		if !bytes.Equal(signed, c.BlockHash) {
+			return errors.Wrapf(ErrSlashable, "block %x conflicts with block %x signed", c.BlockHash, signed)
+		}
+		return nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return p.kvStore.Put(ns, key, c.BlockHash)
+	default:
+		return errors.Wrap(err, "failed to read signed messages")
+	}
+}
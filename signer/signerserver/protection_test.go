@@ -0,0 +1,66 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signerserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/signer"
+)
+
+func TestProtection(t *testing.T) {
+	require := require.New(t)
+
+	p := NewProtection(db.NewMemKVStore())
+	ctx := context.Background()
+	require.NoError(p.Start(ctx))
+	defer func() {
+		require.NoError(p.Stop(ctx))
+	}()
+
+	blk1 := hash.Hash256b([]byte("block1"))
+	blk2 := hash.Hash256b([]byte("block2"))
+	ts := time.Unix(1600000000, 0)
+
+	// blocks
+	require.NoError(p.Check(&Commitment{Kind: signer.BlockKind, Height: 1, BlockHash: blk1[:]}))
+	require.NoError(p.Check(&Commitment{Kind: signer.BlockKind, Height: 1, BlockHash: blk1[:]}))
+	require.NoError(p.Check(&Commitment{Kind: signer.BlockKind, Height: 2, BlockHash: blk2[:]}))
+	err := p.Check(&Commitment{Kind: signer.BlockKind, Height: 1, BlockHash: blk2[:]})
+	require.Equal(ErrSlashable, errors.Cause(err))
+	err = p.Check(&Commitment{Kind: signer.BlockKind, Height: 3})
+	require.Equal(ErrInvalidMessage, errors.Cause(err))
+
+	// endorsements
+	endorse := func(topic string, ts time.Time, blkHash []byte) error {
+		return p.Check(&Commitment{
+			Kind:      signer.VoteKind,
+			Height:    1,
+			Topic:     topic,
+			BlockHash: blkHash,
+			Timestamp: ts,
+		})
+	}
+	require.NoError(endorse("PROPOSAL", ts, blk1[:]))
+	require.NoError(endorse("PROPOSAL", ts, blk1[:]))
+	require.NoError(endorse("PROPOSAL", ts, nil))
+	require.NoError(endorse("COMMIT", ts, blk2[:]))
+	require.NoError(endorse("PROPOSAL", ts.Add(time.Second), blk2[:]))
+	require.Equal(ErrSlashable, errors.Cause(endorse("PROPOSAL", ts, blk2[:])))
+
+	// actions and unknown messages
+	require.NoError(p.Check(&Commitment{Kind: signer.ActionKind}))
+	err = p.Check(&Commitment{Kind: "unknown", BlockHash: blk1[:]})
+	require.Equal(ErrInvalidMessage, errors.Cause(err))
+}
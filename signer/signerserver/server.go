@@ -0,0 +1,142 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signerserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/signer"
+	"github.com/iotexproject/iotex-core/signer/signerpb"
+)
+
+// Server is the reference remote signer, which signs the messages of the block producer with the signer it holds,
+// after checking them against the slashing protection rules
+type Server struct {
+	port       int
+	signer     signer.Signer
+	protection *Protection
+	grpcServer *grpc.Server
+}
+
+// NewServerTLS returns the mutual TLS credentials of the signer server, which only accepts the clients with the
+// certificates issued by the client CA
+func NewServerTLS(certPath, keyPath, clientCACertPath string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load server certificate")
+	}
+	caCert, err := ioutil.ReadFile(clientCACertPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read client CA certificate %s", clientCACertPath)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, errors.Errorf("invalid client CA certificate %s", clientCACertPath)
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// NewServer creates a remote signer server, which serves the clients authenticated by the mutual TLS credentials
+func NewServer(
+	port int,
+	s signer.Signer,
+	protection *Protection,
+	creds credentials.TransportCredentials,
+	opts ...grpc.ServerOption,
+) *Server {
+	svr := &Server{
+		port:       port,
+		signer:     s,
+		protection: protection,
+		grpcServer: grpc.NewServer(append(opts, grpc.Creds(creds))...),
+	}
+	signerpb.RegisterSignerServiceServer(svr.grpcServer, svr)
+	return svr
+}
+
+// Start starts the server
+func (svr *Server) Start(ctx context.Context) error {
+	if err := svr.protection.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start slashing protection")
+	}
+	lis, err := net.Listen("tcp", ":"+strconv.Itoa(svr.port))
+	if err != nil {
+		return errors.Wrap(err, "signer server failed to listen")
+	}
+	log.L().Info("Signer server is listening.", zap.String("addr", lis.Addr().String()))
+
+	go func() {
+		if err := svr.grpcServer.Serve(lis); err != nil {
+			log.L().Fatal("Signer server failed to serve.", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Stop stops the server
+func (svr *Server) Stop(ctx context.Context) error {
+	svr.grpcServer.GracefulStop()
+	return svr.protection.Stop(ctx)
+}
+
+// PublicKey returns the public key of the block producer
+func (svr *Server) PublicKey(ctx context.Context, in *signerpb.PublicKeyRequest) (*signerpb.PublicKeyResponse, error) {
+	return &signerpb.PublicKeyResponse{PublicKey: svr.signer.PublicKey().Bytes()}, nil
+}
+
+// Sign signs the digest derived from the document of the message, unless it violates the slashing protection rules
+func (svr *Server) Sign(ctx context.Context, in *signerpb.SignRequest) (*signerpb.SignResponse, error) {
+	msg := &signer.Message{}
+	if err := msg.LoadProto(in); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	c, err := NewCommitment(msg, svr.signer.PublicKey())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := svr.protection.Check(c); err != nil {
+		switch errors.Cause(err) {
+		case ErrSlashable:
+			log.L().Warn(
+				"Refuse to sign slashable message.",
+				zap.String("kind", c.Kind),
+				zap.Uint64("height", c.Height),
+				zap.String("topic", c.Topic),
+				zap.Error(err),
+			)
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case ErrInvalidMessage:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	msg.Digest = c.Digest
+	sig, err := svr.signer.Sign(msg)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &signerpb.SignResponse{Signature: sig}, nil
+}
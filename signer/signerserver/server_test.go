@@ -0,0 +1,179 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signerserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/signer"
+	"github.com/iotexproject/iotex-core/signer/signerpb"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// writeCert issues a certificate by the CA, or a self-signed CA certificate if ca is nil, and writes the certificate
+// and the key in PEM into the dir
+func writeCert(
+	t *testing.T,
+	dir, name string,
+	ca *x509.Certificate,
+	caKey *ecdsa.PrivateKey,
+) (*x509.Certificate, *ecdsa.PrivateKey) {
+	require := require.New(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if ca == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		ca, caKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	require.NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(err)
+	require.NoError(ioutil.WriteFile(
+		filepath.Join(dir, name+".crt"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		0600,
+	))
+	require.NoError(ioutil.WriteFile(
+		filepath.Join(dir, name+".key"),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+		0600,
+	))
+	return cert, key
+}
+
+func TestRemoteSigner(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "signer")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	ca, caKey := writeCert(t, dir, "ca", nil, nil)
+	writeCert(t, dir, "server", ca, caKey)
+	writeCert(t, dir, "node", ca, caKey)
+	writeCert(t, dir, "other", nil, nil)
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	sk := identityset.PrivateKey(1)
+	port := testutil.RandomPort()
+	endpoint := "127.0.0.1:" + strconv.Itoa(port)
+	svrCreds, err := NewServerTLS(path("server.crt"), path("server.key"), path("ca.crt"))
+	require.NoError(err)
+	svr := NewServer(port, signer.NewLocalSigner(sk), NewProtection(db.NewMemKVStore()), svrCreds)
+	ctx := context.Background()
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+
+	// a client without a certificate issued by the CA is refused
+	creds, err := signer.NewClientTLS(path("other.crt"), path("other.key"), path("ca.crt"))
+	require.NoError(err)
+	_, err = signer.NewRemoteSigner(endpoint, creds, time.Second)
+	require.Error(err)
+
+	creds, err = signer.NewClientTLS(path("node.crt"), path("node.key"), path("ca.crt"))
+	require.NoError(err)
+	s, err := signer.NewRemoteSigner(endpoint, creds, 5*time.Second)
+	require.NoError(err)
+	defer func() {
+		require.NoError(s.Close())
+	}()
+	require.Equal(sk.PublicKey().Bytes(), s.PublicKey().Bytes())
+
+	// blocks
+	ts := time.Unix(1600000000, 0)
+	buildBlock := func(prevHash hash.Hash256) (block.Block, error) {
+		return block.NewBuilder(block.NewRunnableActionsBuilder().Build()).
+			SetHeight(1).
+			SetPrevBlockHash(prevHash).
+			SetTimestamp(ts).
+			SignAndBuildWith(s)
+	}
+	blk1, err := buildBlock(hash.Hash256b([]byte("block0")))
+	require.NoError(err)
+	require.True(blk1.VerifySignature())
+	_, err = buildBlock(hash.Hash256b([]byte("block0")))
+	require.NoError(err)
+	// a different block at the same height is refused
+	_, err = buildBlock(hash.Hash256b([]byte("other")))
+	require.Equal(codes.FailedPrecondition, status.Code(errors.Cause(err)))
+
+	// endorsements
+	blkHash := blk1.HashBlock()
+	en, err := endorsement.Endorse(s, rolldpos.NewConsensusVote(blkHash[:], rolldpos.COMMIT), ts)
+	require.NoError(err)
+	require.True(endorsement.VerifyEndorsement(rolldpos.NewConsensusVote(blkHash[:], rolldpos.COMMIT), en))
+	other := hash.Hash256b([]byte("other"))
+	_, err = endorsement.Endorse(s, rolldpos.NewConsensusVote(other[:], rolldpos.COMMIT), ts)
+	require.Equal(codes.FailedPrecondition, status.Code(errors.Cause(err)))
+
+	// system actions
+	gb := action.GrantRewardBuilder{}
+	grant := gb.SetRewardType(action.BlockReward).SetHeight(1).Build()
+	eb := action.EnvelopeBuilder{}
+	selp, err := action.SignWith(eb.SetNonce(1).SetAction(&grant).Build(), s)
+	require.NoError(err)
+	require.NoError(action.Verify(selp))
+	tsf, err := action.NewTransfer(1, big.NewInt(1), identityset.Address(2).String(), nil, 10000, big.NewInt(0))
+	require.NoError(err)
+	_, err = action.SignWith(eb.SetNonce(1).SetAction(tsf).Build(), s)
+	require.Error(err)
+
+	// the digest is derived from the document, which has to be decoded
+	conn, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(creds))
+	require.NoError(err)
+	defer conn.Close()
+	client := signerpb.NewSignerServiceClient(conn)
+	for _, req := range []*signerpb.SignRequest{
+		{Kind: signer.BlockKind},
+		{Kind: signer.BlockKind, Document: other[:]},
+		{Kind: signer.EndorsementKind, Document: other[:]},
+	} {
+		_, err = client.Sign(ctx, req)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	}
+}
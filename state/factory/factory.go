@@ -14,6 +14,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -26,6 +27,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/actpool/actioniterator"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db"
@@ -37,6 +39,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
 )
 
 const (
@@ -81,10 +84,24 @@ type (
 		// NewBlockBuilder creates block builder
 		NewBlockBuilder(context.Context, actpool.ActPool, func(action.Envelope) (action.SealedEnvelope, error)) (*block.Builder, error)
 		SimulateExecution(context.Context, address.Address, *action.Execution, evm.GetBlockHash) ([]byte, *action.Receipt, error)
+		SimulateExecutionAtHeight(context.Context, uint64, address.Address, *action.Execution, evm.GetBlockHash) ([]byte, *action.Receipt, error)
+		// TraceAction re-executes the action at index of the committed block on top of the state before the block, and
+		// traces the contract it executes
+		TraceAction(context.Context, *block.Block, int, vm.Tracer) (*action.Receipt, error)
 		PutBlock(context.Context, *block.Block) error
 		DeleteTipBlock(*block.Block) error
 		StateAtHeight(uint64, interface{}, ...protocol.StateOption) error
 		StatesAtHeight(uint64, ...protocol.StateOption) (state.Iterator, error)
+		// ProveState returns the root hash of the state trie at height, and the proof of the state in the trie
+		ProveState(uint64, ...protocol.StateOption) ([]byte, [][]byte, error)
+		// SnapshotManifest returns the manifest of the latest state snapshot
+		SnapshotManifest() (*snapshotpb.Manifest, error)
+		// SnapshotChunk returns a chunk of the state snapshot at height
+		SnapshotChunk(uint64, uint32) ([]byte, error)
+		// ImportSnapshot imports a state snapshot into an empty factory before it starts
+		ImportSnapshot(context.Context, *snapshotpb.Manifest, func(uint32) ([]byte, error)) error
+		// VerifySnapshot verifies the imported state snapshot against its manifest
+		VerifySnapshot(context.Context, *snapshotpb.Manifest) error
 	}
 
 	// factory implements StateFactory interface, tracks changes to account/contract and batch-commits to DB
@@ -101,6 +118,10 @@ type (
 		workingsets              *cache.ThreadSafeLruCache // lru cache for workingsets
 		protocolView             protocol.View
 		skipBlockValidationOnPut bool
+		packingPolicy            actioniterator.Policy
+		snapshotStore            db.KVStore // the DB storing the latest state snapshot
+		snapshotMutex            sync.Mutex // serializes the exports of the state snapshot
+		snapshotWG               sync.WaitGroup
 	}
 )
 
@@ -126,7 +147,7 @@ func DefaultTrieOption() Option {
 			return errors.New("Invalid empty trie db path")
 		}
 		cfg.DB.DbPath = dbPath // TODO: remove this after moving TrieDBPath from cfg.Chain to cfg.DB
-		sf.dao = db.NewBoltDB(cfg.DB)
+		sf.dao = db.NewOnDiskDB(cfg.DB)
 		return nil
 	}
 }
@@ -176,6 +197,10 @@ func newTwoLayerTrie(ns string, dao db.KVStore, rootKey string, create bool) (tr
 
 // NewFactory creates a new state factory
 func NewFactory(cfg config.Config, opts ...Option) (Factory, error) {
+	packingPolicy, err := actioniterator.NewPolicy(cfg.ActPool)
+	if err != nil {
+		return nil, err
+	}
 	sf := &factory{
 		cfg:                cfg,
 		currentChainHeight: 0,
@@ -183,6 +208,7 @@ func NewFactory(cfg config.Config, opts ...Option) (Factory, error) {
 		saveHistory:        cfg.Chain.EnableArchiveMode,
 		protocolView:       protocol.View{},
 		workingsets:        cache.NewThreadSafeLruCache(int(cfg.Chain.WorkingSetCacheSize)),
+		packingPolicy:      packingPolicy,
 	}
 
 	for _, opt := range opts {
@@ -227,6 +253,9 @@ func (sf *factory) Start(ctx context.Context) error {
 			return err
 		}
 	case db.ErrNotExist:
+		if sf.cfg.DB.ReadOnly {
+			return errors.Wrap(err, "failed to get factory's height in read-only mode")
+		}
 		if err = sf.dao.Put(AccountKVNamespace, []byte(CurrentHeightKey), byteutil.Uint64ToBytes(0)); err != nil {
 			return errors.Wrap(err, "failed to init factory's height")
 		}
@@ -255,6 +284,7 @@ func (sf *factory) Start(ctx context.Context) error {
 func (sf *factory) Stop(ctx context.Context) error {
 	sf.mutex.Lock()
 	defer sf.mutex.Unlock()
+	sf.snapshotWG.Wait()
 	if err := sf.dao.Stop(ctx); err != nil {
 		return err
 	}
@@ -274,11 +304,16 @@ func (sf *factory) Height() (uint64, error) {
 }
 
 func (sf *factory) newWorkingSet(ctx context.Context, height uint64) (*workingSet, error) {
+	return sf.newWorkingSetAtRoot(ctx, height, ArchiveTrieRootKey, true)
+}
+
+// newWorkingSetAtRoot creates a working set on top of the trie whose root hash is stored at rootKey
+func (sf *factory) newWorkingSetAtRoot(ctx context.Context, height uint64, rootKey string, create bool) (*workingSet, error) {
 	flusher, err := db.NewKVStoreFlusher(sf.dao, batch.NewCachedBatch(), sf.flusherOptions(ctx, height)...)
 	if err != nil {
 		return nil, err
 	}
-	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, flusher.KVStoreWithBuffer(), ArchiveTrieRootKey, true)
+	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, flusher.KVStoreWithBuffer(), rootKey, create)
 	if err != nil {
 		return nil, err
 	}
@@ -483,7 +518,7 @@ func (sf *factory) NewBlockBuilder(
 			}
 		}
 	}
-	blkBuilder, err := ws.CreateBuilder(ctx, ap, postSystemActions, sf.cfg.Chain.AllowedBlockGasResidue)
+	blkBuilder, err := ws.CreateBuilder(ctx, ap, sf.packingPolicy, postSystemActions, sf.cfg.Chain.AllowedBlockGasResidue)
 	if err != nil {
 		return nil, err
 	}
@@ -512,6 +547,72 @@ func (sf *factory) SimulateExecution(
 	return evm.SimulateExecution(ctx, ws, caller, ex, getBlockHash)
 }
 
+// SimulateExecutionAtHeight simulates a running of smart contract operation on top of the state at height -- archive
+// mode. The tip in blockchain context of ctx is expected to be the block at height
+func (sf *factory) SimulateExecutionAtHeight(
+	ctx context.Context,
+	height uint64,
+	caller address.Address,
+	ex *action.Execution,
+	getBlockHash evm.GetBlockHash,
+) ([]byte, *action.Receipt, error) {
+	sf.mutex.Lock()
+	if !sf.saveHistory {
+		sf.mutex.Unlock()
+		return nil, nil, ErrNoArchiveData
+	}
+	if height > sf.currentChainHeight {
+		sf.mutex.Unlock()
+		return nil, nil, errors.Errorf("query height %d is higher than tip height %d", height, sf.currentChainHeight)
+	}
+	ws, err := sf.newWorkingSetAtRoot(ctx, height+1, fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height), false)
+	sf.mutex.Unlock()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to obtain working set at height %d from state factory", height)
+	}
+
+	return evm.SimulateExecution(ctx, ws, caller, ex, getBlockHash)
+}
+
+// TraceAction re-executes the action at index of the committed block on top of the state at the height before the
+// block -- archive mode. The tip in blockchain context of ctx is expected to be the block before
+func (sf *factory) TraceAction(ctx context.Context, blk *block.Block, index int, tracer vm.Tracer) (*action.Receipt, error) {
+	if index < 0 || index >= len(blk.Actions) {
+		return nil, errors.Errorf("invalid action index %d of block %d", index, blk.Height())
+	}
+	producer, err := address.FromBytes(blk.PublicKey().Hash())
+	if err != nil {
+		return nil, err
+	}
+	sf.mutex.Lock()
+	if !sf.saveHistory {
+		sf.mutex.Unlock()
+		return nil, ErrNoArchiveData
+	}
+	height := blk.Height()
+	if height == 0 || height > sf.currentChainHeight {
+		sf.mutex.Unlock()
+		return nil, errors.Errorf("block height %d is not committed, tip height %d", height, sf.currentChainHeight)
+	}
+	ws, err := sf.newWorkingSetAtRoot(ctx, height, fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height-1), false)
+	sf.mutex.Unlock()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to obtain working set at height %d from state factory", height-1)
+	}
+	bcCtx := protocol.MustGetBlockchainCtx(ctx)
+	ctx = protocol.WithBlockCtx(
+		protocol.WithRegistry(ctx, sf.registry),
+		protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: blk.Timestamp(),
+			GasLimit:       bcCtx.Genesis.BlockGasLimit,
+			Producer:       producer,
+		},
+	)
+
+	return ws.traceAction(ctx, blk.Actions, index, tracer)
+}
+
 // PutBlock persists all changes in RunActions() into the DB
 func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
 	sf.mutex.Lock()
@@ -564,8 +665,16 @@ func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
 			sf.currentChainHeight, h,
 		)
 	}
-
-	return ws.Commit(ctx)
+	if err := ws.Commit(ctx); err != nil {
+		return err
+	}
+	if sf.snapshotDue(h) {
+		// the snapshot is auxiliary to the peers, failing to export it does not fail the block
+		if err := sf.startSnapshotExport(blk); err != nil {
+			log.L().Error("Failed to export state snapshot.", zap.Uint64("height", h), zap.Error(err))
+		}
+	}
+	return nil
 }
 
 func (sf *factory) DeleteTipBlock(_ *block.Block) error {
@@ -596,6 +705,61 @@ func (sf *factory) StatesAtHeight(height uint64, opts ...protocol.StateOption) (
 	return nil, errors.Wrap(ErrNotSupported, "Read historical states has not been implemented yet")
 }
 
+// ProveState returns the root hash of the state trie at height, and the proof of the state in the trie. The state
+// below the tip height is only available in archive mode
+func (sf *factory) ProveState(height uint64, opts ...protocol.StateOption) ([]byte, [][]byte, error) {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	cfg, err := processOptions(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	rootKey := ArchiveTrieRootKey
+	switch {
+	case height > sf.currentChainHeight:
+		return nil, nil, errors.Errorf("query height %d is higher than tip height %d", height, sf.currentChainHeight)
+	case height < sf.currentChainHeight:
+		if !sf.saveHistory {
+			return nil, nil, ErrNoArchiveData
+		}
+		rootKey = fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height)
+	}
+	// the trie of the factory is not safe to read concurrently, so a new trie is loaded for the proof
+	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, sf.dao, rootKey, false)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to generate trie for %d", height)
+	}
+	if err := tlt.Start(context.Background()); err != nil {
+		return nil, nil, err
+	}
+	defer tlt.Stop(context.Background())
+
+	rootHash, err := tlt.RootHash()
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := tlt.Prove(namespaceKey(cfg.Namespace), toLegacyKey(cfg.Key))
+	if err != nil {
+		return nil, nil, err
+	}
+	return rootHash, proof, nil
+}
+
+// VerifyStateProof verifies the proof of a state generated by ProveState against the root hash of the state trie, and
+// returns the serialized state, or state.ErrStateNotExist if the proof proves the absence of the state
+func VerifyStateProof(rootHash []byte, proof [][]byte, opts ...protocol.StateOption) ([]byte, error) {
+	cfg, err := processOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	tlt := mptrie.NewTwoLayerTrie(trie.NewMemKVStore(), ArchiveTrieRootKey)
+	value, err := tlt.VerifyProof(rootHash, namespaceKey(cfg.Namespace), toLegacyKey(cfg.Key), proof)
+	if errors.Cause(err) == trie.ErrNotExist {
+		return nil, errors.Wrapf(state.ErrStateNotExist, "failed to get state of ns = %x and key = %x", cfg.Namespace, cfg.Key)
+	}
+	return value, err
+}
+
 // State returns a confirmed state in the state factory
 func (sf *factory) State(s interface{}, opts ...protocol.StateOption) (uint64, error) {
 	sf.mutex.RLock()
@@ -26,11 +26,13 @@ import (
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-election/test/mock/mock_committee"
 	"github.com/iotexproject/iotex-election/types"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
@@ -39,6 +41,7 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/trie"
 	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
 	"github.com/iotexproject/iotex-core/state"
@@ -276,6 +279,7 @@ func testCandidates(sf Factory, t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		cfg.Genesis.NumCandidateDelegates,
 		cfg.Genesis.NumDelegates,
 		cfg.Genesis.DardanellesNumSubEpochs,
@@ -560,6 +564,68 @@ func testHistoryState(sf Factory, t *testing.T, statetx, archive bool) {
 			require.Equal(t, big.NewInt(0), accountB.Balance)
 		}
 	}
+
+	// simulate execution on top of archive data
+	ex, err := action.NewExecution(action.EmptyAddress, 2, big.NewInt(0), uint64(100000), big.NewInt(0), nil)
+	require.NoError(t, err)
+	getBlockHash := func(uint64) (hash.Hash256, error) {
+		return hash.ZeroHash256, nil
+	}
+	_, _, err = sf.SimulateExecutionAtHeight(ctx, 0, identityset.Address(28), ex, getBlockHash)
+	switch {
+	case statetx:
+		require.Equal(t, ErrNotSupported, errors.Cause(err))
+	case !archive:
+		require.Equal(t, ErrNoArchiveData, errors.Cause(err))
+	default:
+		require.NoError(t, err)
+		_, _, err = sf.SimulateExecutionAtHeight(ctx, 2, identityset.Address(28), ex, getBlockHash)
+		require.Error(t, err)
+	}
+
+	// trace the committed action on top of archive data
+	tracer, err := evm.NewTracer(evm.CallTracer, nil)
+	require.NoError(t, err)
+	receipt, err := sf.TraceAction(ctx, &blk, 0, tracer)
+	switch {
+	case statetx:
+		require.Equal(t, ErrNotSupported, errors.Cause(err))
+	case !archive:
+		require.Equal(t, ErrNoArchiveData, errors.Cause(err))
+	default:
+		require.NoError(t, err)
+		require.Equal(t, selp.Hash(), receipt.ActionHash)
+		require.EqualValues(t, iotextypes.ReceiptStatus_Success, receipt.Status)
+		_, err = sf.TraceAction(ctx, &blk, 1, tracer)
+		require.Error(t, err)
+	}
+
+	// prove the account states
+	keyA := protocol.LegacyKeyOption(hash.BytesToHash160(identityset.Address(28).Bytes()))
+	keyB := protocol.LegacyKeyOption(hash.BytesToHash160(identityset.Address(31).Bytes()))
+	root, proof, err := sf.ProveState(1, keyA)
+	if statetx {
+		require.Equal(t, ErrNotSupported, errors.Cause(err))
+		return
+	}
+	require.NoError(t, err)
+	data, err := VerifyStateProof(root, proof, keyA)
+	require.NoError(t, err)
+	var account state.Account
+	require.NoError(t, state.Deserialize(&account, data))
+	require.Equal(t, big.NewInt(90), account.Balance)
+	_, err = VerifyStateProof(root, proof, keyB)
+	require.Equal(t, trie.ErrInvalidProof, errors.Cause(err))
+	_, _, err = sf.ProveState(2, keyA)
+	require.Error(t, err)
+	root, proof, err = sf.ProveState(0, keyB)
+	if !archive {
+		require.Equal(t, ErrNoArchiveData, errors.Cause(err))
+		return
+	}
+	require.NoError(t, err)
+	_, err = VerifyStateProof(root, proof, keyB)
+	require.Equal(t, state.ErrStateNotExist, errors.Cause(err))
 }
 
 func testFactoryStates(sf Factory, t *testing.T) {
@@ -1032,6 +1098,66 @@ func TestSTXPickAndRunActions(t *testing.T) {
 	testNewBlockBuilder(sdb, t)
 }
 
+func TestNewBlockBuilderReservedGas(t *testing.T) {
+	require := require.New(t)
+
+	for _, c := range []struct {
+		reservedGas uint64
+		numActs     int
+	}{
+		{0, 2},
+		// the second transfer no longer fits the gas left to the pending actions
+		{900000, 1},
+		{1000000, 0},
+	} {
+		testStateDBPath, err := testutil.PathOfTempFile(stateDBPath)
+		require.NoError(err)
+		cfg := config.Default
+		cfg.Chain.TrieDBPath = testStateDBPath
+		cfg.ActPool.ReservedSystemActionGas = c.reservedGas
+		cfg.Genesis.InitBalanceMap[identityset.Address(28).String()] = "100"
+		cfg.Genesis.InitBalanceMap[identityset.Address(29).String()] = "200"
+		registry := protocol.NewRegistry()
+		sdb, err := NewStateDB(cfg, CachedStateDBOption(), RegistryStateDBOption(registry))
+		require.NoError(err)
+		require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+		ctx := protocol.WithBlockCtx(
+			protocol.WithBlockchainCtx(context.Background(), protocol.BlockchainCtx{Genesis: cfg.Genesis}),
+			protocol.BlockCtx{},
+		)
+		require.NoError(sdb.Start(ctx))
+
+		accMap := make(map[string][]action.SealedEnvelope)
+		tx1, err := action.NewTransfer(1, big.NewInt(10), identityset.Address(29).String(), nil, 100000, big.NewInt(0))
+		require.NoError(err)
+		selp1, err := action.Sign((&action.EnvelopeBuilder{}).SetNonce(1).SetGasLimit(100000).SetAction(tx1).Build(), identityset.PrivateKey(28))
+		require.NoError(err)
+		accMap[identityset.Address(28).String()] = []action.SealedEnvelope{selp1}
+		tx2, err := action.NewTransfer(1, big.NewInt(20), identityset.Address(28).String(), nil, 100000, big.NewInt(0))
+		require.NoError(err)
+		selp2, err := action.Sign((&action.EnvelopeBuilder{}).SetNonce(1).SetGasLimit(100000).SetAction(tx2).Build(), identityset.PrivateKey(29))
+		require.NoError(err)
+		accMap[identityset.Address(29).String()] = []action.SealedEnvelope{selp2}
+		ctrl := gomock.NewController(t)
+		ap := mock_actpool.NewMockActPool(ctrl)
+		ap.EXPECT().PendingActionMap().Return(accMap).AnyTimes()
+
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: 1,
+			Producer:    identityset.Address(27),
+			GasLimit:    1000000,
+		})
+		blkBuilder, err := sdb.NewBlockBuilder(ctx, ap, nil)
+		require.NoError(err)
+		blk, err := blkBuilder.SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		require.Len(blk.Actions, c.numActs)
+		require.NoError(sdb.Stop(ctx))
+		ctrl.Finish()
+		testutil.CleanupPath(t, testStateDBPath)
+	}
+}
+
 func testNewBlockBuilder(factory Factory, t *testing.T) {
 	require := require.New(t)
 	a := identityset.Address(28).String()
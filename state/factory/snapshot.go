@@ -0,0 +1,391 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/db/trie/mptrie"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
+)
+
+// A snapshot of the state consists of the records of all the namespaces in the state trie, split into chunks. The
+// manifest of the snapshot lists the hashes of the chunks and the root hash of the state trie, so that a new node
+// verifies every chunk it downloads, and verifies the whole snapshot by rebuilding the state trie
+const (
+	// SnapshotNamespace is the bucket for the manifest and the chunks of the latest snapshot
+	SnapshotNamespace = "Snapshot"
+	// SnapshotManifestKey is the key of the manifest of the latest snapshot
+	SnapshotManifestKey = "manifest"
+)
+
+// ErrInvalidSnapshot indicates the snapshot does not match its manifest
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// SnapshotOption exports a snapshot of the state into kv at the last block of every cfg.Chain.SnapshotInterval epochs,
+// which is served to the new nodes
+func SnapshotOption(kv db.KVStore) Option {
+	return func(sf *factory, cfg config.Config) error {
+		if kv == nil {
+			return errors.New("Invalid empty snapshot db")
+		}
+		if cfg.Chain.SnapshotInterval == 0 {
+			return errors.New("Invalid zero snapshot interval")
+		}
+		sf.snapshotStore = kv
+		sf.lifecycle.Add(kv)
+		return nil
+	}
+}
+
+// SnapshotManifest returns the manifest of the latest snapshot
+func (sf *factory) SnapshotManifest() (*snapshotpb.Manifest, error) {
+	if sf.snapshotStore == nil {
+		return nil, errors.Wrap(ErrNotSupported, "state snapshot is not enabled")
+	}
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	return sf.snapshotManifest()
+}
+
+// SnapshotChunk returns the chunk of the snapshot at height
+func (sf *factory) SnapshotChunk(height uint64, index uint32) ([]byte, error) {
+	if sf.snapshotStore == nil {
+		return nil, errors.Wrap(ErrNotSupported, "state snapshot is not enabled")
+	}
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	return sf.snapshotStore.Get(SnapshotNamespace, snapshotChunkKey(height, index))
+}
+
+// ImportSnapshot imports the snapshot into an empty factory which has not been started yet. The chunks are fetched one
+// by one, and the root hash of the rebuilt state trie has to match the manifest
+func (sf *factory) ImportSnapshot(
+	ctx context.Context,
+	manifest *snapshotpb.Manifest,
+	chunk func(uint32) ([]byte, error),
+) (err error) {
+	sf.mutex.Lock()
+	defer sf.mutex.Unlock()
+	if err = sf.dao.Start(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if e := sf.dao.Stop(ctx); err == nil {
+			err = e
+		}
+	}()
+	if _, err = sf.dao.Get(AccountKVNamespace, []byte(CurrentHeightKey)); errors.Cause(err) != db.ErrNotExist {
+		if err == nil {
+			err = errors.New("cannot import snapshot into a non-empty state factory")
+		}
+		return err
+	}
+	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, sf.dao, ArchiveTrieRootKey, true)
+	if err != nil {
+		return err
+	}
+	if err = tlt.Start(ctx); err != nil {
+		return err
+	}
+	for i, chunkHash := range manifest.GetChunkHashes() {
+		data, err := chunk(uint32(i))
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch chunk %d", i)
+		}
+		if h := hash.Hash256b(data); !bytes.Equal(h[:], chunkHash) {
+			return errors.Wrapf(ErrInvalidSnapshot, "hash of chunk %d does not match", i)
+		}
+		entries := snapshotpb.Entries{}
+		if err := proto.Unmarshal(data, &entries); err != nil {
+			return errors.Wrapf(ErrInvalidSnapshot, "failed to unmarshal chunk %d", i)
+		}
+		b := batch.NewBatch()
+		for _, e := range entries.GetEntries() {
+			if !isSnapshotState(e.GetNamespace(), e.GetKey()) {
+				return errors.Wrapf(ErrInvalidSnapshot, "unexpected record in namespace %s", e.GetNamespace())
+			}
+			b.Put(e.GetNamespace(), e.GetKey(), e.GetValue(), "failed to put state")
+			if err := tlt.Upsert(namespaceKey(e.GetNamespace()), toLegacyKey(e.GetKey()), e.GetValue()); err != nil {
+				return err
+			}
+		}
+		if err := sf.dao.WriteBatch(b); err != nil {
+			return err
+		}
+	}
+	root, err := tlt.RootHash()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(root, manifest.GetStateRoot()) {
+		return errors.Wrapf(ErrInvalidSnapshot, "state root %x does not match %x", root, manifest.GetStateRoot())
+	}
+	if err = tlt.Stop(ctx); err != nil {
+		return err
+	}
+	height := manifest.GetHeight()
+	b := batch.NewBatch()
+	b.Put(AccountKVNamespace, []byte(CurrentHeightKey), byteutil.Uint64ToBytes(height), "failed to put height")
+	b.Put(ArchiveTrieNamespace, []byte(ArchiveTrieRootKey), root, "failed to put root")
+	b.Put(ArchiveTrieNamespace, []byte(fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height)), root, "failed to put root")
+	return sf.dao.WriteBatch(b)
+}
+
+// VerifySnapshot verifies the snapshot imported into the factory before it starts. The state trie is rebuilt from the
+// imported states, and its root hash has to match the manifest and the root hash recorded on import
+func (sf *factory) VerifySnapshot(ctx context.Context, manifest *snapshotpb.Manifest) (err error) {
+	sf.mutex.Lock()
+	defer sf.mutex.Unlock()
+	kv, ok := sf.dao.(db.KVStoreWithForEach)
+	if !ok {
+		return errors.Wrap(ErrNotSupported, "cannot iterate through the underlying DB")
+	}
+	if err = sf.dao.Start(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if e := sf.dao.Stop(ctx); err == nil {
+			err = e
+		}
+	}()
+	h, err := sf.dao.Get(AccountKVNamespace, []byte(CurrentHeightKey))
+	if err != nil {
+		return err
+	}
+	if height := byteutil.BytesToUint64(h); height != manifest.GetHeight() {
+		return errors.Wrapf(ErrInvalidSnapshot, "height %d does not match %d", height, manifest.GetHeight())
+	}
+	imported, err := sf.dao.Get(ArchiveTrieNamespace, []byte(ArchiveTrieRootKey))
+	if err != nil {
+		return err
+	}
+	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, db.NewMemKVStore(), ArchiveTrieRootKey, true)
+	if err != nil {
+		return err
+	}
+	if err = tlt.Start(ctx); err != nil {
+		return err
+	}
+	if err = kv.ForEach(func(ns string, k, v []byte) error {
+		if !isSnapshotState(ns, k) {
+			return nil
+		}
+		return tlt.Upsert(namespaceKey(ns), toLegacyKey(k), v)
+	}); err != nil {
+		return err
+	}
+	root, err := tlt.RootHash()
+	if err != nil {
+		return err
+	}
+	if err = tlt.Stop(ctx); err != nil {
+		return err
+	}
+	if !bytes.Equal(root, manifest.GetStateRoot()) || !bytes.Equal(root, imported) {
+		return errors.Wrapf(ErrInvalidSnapshot, "state root %x does not match %x", root, manifest.GetStateRoot())
+	}
+	return nil
+}
+
+// snapshotDue returns true if a snapshot is taken at height
+func (sf *factory) snapshotDue(height uint64) bool {
+	if sf.snapshotStore == nil {
+		return false
+	}
+	rp := rolldpos.FindProtocol(sf.registry)
+	if rp == nil {
+		return false
+	}
+	epochNum := rp.GetEpochNum(height)
+	return rp.GetEpochLastBlockHeight(epochNum) == height && epochNum%sf.cfg.Chain.SnapshotInterval == 0
+}
+
+// startSnapshotExport takes a read view of the state just committed at the height of blk, and exports the snapshot
+// from the view in the background, so that the next blocks are committed meanwhile
+func (sf *factory) startSnapshotExport(blk *block.Block) error {
+	kv, ok := sf.dao.(db.KVStoreWithReadView)
+	if !ok {
+		return errors.Wrap(ErrNotSupported, "cannot take a read view of the underlying DB")
+	}
+	root, err := sf.twoLayerTrie.RootHash()
+	if err != nil {
+		return err
+	}
+	view, err := kv.ReadView()
+	if err != nil {
+		return err
+	}
+	blkHash := blk.HashBlock()
+	manifest := &snapshotpb.Manifest{
+		Height:    blk.Height(),
+		BlockHash: blkHash[:],
+		StateRoot: root,
+	}
+	sf.snapshotWG.Add(1)
+	go func() {
+		defer sf.snapshotWG.Done()
+		defer view.Release()
+		sf.snapshotMutex.Lock()
+		defer sf.snapshotMutex.Unlock()
+		if err := sf.exportSnapshot(view, manifest); err != nil {
+			log.L().Error("Failed to export state snapshot.", zap.Uint64("height", manifest.GetHeight()), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// exportSnapshot exports the snapshot of the state in the view, and replaces the previous snapshot
+func (sf *factory) exportSnapshot(view db.ReadView, manifest *snapshotpb.Manifest) error {
+	height := manifest.GetHeight()
+	var tlt trie.TwoLayerTrie
+	if sf.saveHistory {
+		dbForTrie, err := trie.NewKVStore(ArchiveTrieNamespace, &readViewStore{view})
+		if err != nil {
+			return err
+		}
+		tlt = mptrie.NewTwoLayerTrie(dbForTrie, ArchiveTrieRootKey)
+		if err := tlt.Start(context.Background()); err != nil {
+			return err
+		}
+		defer tlt.Stop(context.Background())
+	}
+	var (
+		entries snapshotpb.Entries
+		size    int
+	)
+	putChunk := func() error {
+		data, err := proto.Marshal(&entries)
+		if err != nil {
+			return err
+		}
+		if err := sf.snapshotStore.Put(
+			SnapshotNamespace,
+			snapshotChunkKey(height, uint32(len(manifest.ChunkHashes))),
+			data,
+		); err != nil {
+			return err
+		}
+		h := hash.Hash256b(data)
+		manifest.ChunkHashes = append(manifest.ChunkHashes, h[:])
+		entries.Entries = nil
+		size = 0
+		return nil
+	}
+	if err := view.ForEach(func(ns string, k, v []byte) error {
+		if !isSnapshotState(ns, k) {
+			return nil
+		}
+		if tlt != nil {
+			// in archive mode, a deleted state is archived but remains in its namespace, so only the states in the
+			// state trie are exported
+			value, err := tlt.Get(namespaceKey(ns), toLegacyKey(k))
+			switch errors.Cause(err) {
+			case nil:
+				if !bytes.Equal(value, v) {
+					return nil
+				}
+			case trie.ErrNotExist:
+				return nil
+			default:
+				return err
+			}
+		}
+		entries.Entries = append(entries.Entries, &snapshotpb.Entry{
+			Namespace: ns,
+			Key:       k,
+			Value:     v,
+		})
+		if size += len(ns) + len(k) + len(v); size >= sf.cfg.Chain.SnapshotChunkSize {
+			return putChunk()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "failed to export snapshot at height %d", height)
+	}
+	if len(entries.Entries) > 0 || len(manifest.ChunkHashes) == 0 {
+		if err := putChunk(); err != nil {
+			return err
+		}
+	}
+	prev, err := sf.snapshotManifest()
+	if err != nil && errors.Cause(err) != db.ErrNotExist && errors.Cause(err) != db.ErrBucketNotExist {
+		return err
+	}
+	data, err := proto.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	b := batch.NewBatch()
+	b.Put(SnapshotNamespace, []byte(SnapshotManifestKey), data, "failed to put manifest")
+	if prev != nil && prev.GetHeight() != height {
+		for i := range prev.GetChunkHashes() {
+			b.Delete(SnapshotNamespace, snapshotChunkKey(prev.GetHeight(), uint32(i)), "failed to delete chunk")
+		}
+	}
+	return sf.snapshotStore.WriteBatch(b)
+}
+
+// readViewStore is a read-only db.KVStoreBasic on a read view, to open the state trie in the view
+type readViewStore struct {
+	db.ReadView
+}
+
+func (s *readViewStore) Start(context.Context) error { return nil }
+
+func (s *readViewStore) Stop(context.Context) error { return nil }
+
+func (s *readViewStore) Put(string, []byte, []byte) error {
+	return errors.New("cannot write to a read view")
+}
+
+func (s *readViewStore) Delete(string, []byte) error {
+	return errors.New("cannot write to a read view")
+}
+
+func (sf *factory) snapshotManifest() (*snapshotpb.Manifest, error) {
+	data, err := sf.snapshotStore.Get(SnapshotNamespace, []byte(SnapshotManifestKey))
+	if err != nil {
+		return nil, err
+	}
+	manifest := &snapshotpb.Manifest{}
+	if err := proto.Unmarshal(data, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func snapshotChunkKey(height uint64, index uint32) []byte {
+	return append(byteutil.Uint64ToBytesBigEndian(height), byteutil.Uint32ToBytesBigEndian(index)...)
+}
+
+// isSnapshotState returns true if the record is a state in the state trie
+func isSnapshotState(ns string, key []byte) bool {
+	switch {
+	case ns == ArchiveTrieNamespace, strings.HasPrefix(ns, ArchiveNamespacePrefix+"-"):
+		return false
+	case ns == AccountKVNamespace && string(key) == CurrentHeightKey:
+		return false
+	}
+	return true
+}
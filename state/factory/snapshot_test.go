@@ -0,0 +1,158 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/go-pkgs/hash"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestStateSnapshot(t *testing.T) {
+	for _, archive := range []bool{false, true} {
+		testStateSnapshot(t, archive)
+	}
+}
+
+func testStateSnapshot(t *testing.T, archive bool) {
+	require := require.New(t)
+	cfg := config.Default
+	cfg.Chain.EnableArchiveMode = archive
+	cfg.Chain.SnapshotInterval = 2
+	cfg.Chain.SnapshotChunkSize = 64
+	a := identityset.Address(28).String()
+	cfg.Genesis.InitBalanceMap[a] = "100"
+	newFactory := func(opts ...Option) Factory {
+		registry := protocol.NewRegistry()
+		require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+		// an epoch consists of 2 blocks
+		require.NoError(rolldpos.NewProtocol(2, 2, 1).Register(registry))
+		sf, err := NewFactory(cfg, append(opts, InMemTrieOption(), RegistryOption(registry), SkipBlockValidationOption())...)
+		require.NoError(err)
+		return sf
+	}
+	ctx := protocol.WithBlockchainCtx(
+		protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{Producer: identityset.Address(27)}),
+		protocol.BlockchainCtx{Genesis: cfg.Genesis},
+	)
+
+	sf := newFactory(SnapshotOption(db.NewMemKVStore()))
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+	_, err := sf.SnapshotManifest()
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+	var blks []*block.Block
+	for i := uint64(1); i <= 6; i++ {
+		tsf, err := action.NewTransfer(i, big.NewInt(1), identityset.Address(int(i)).String(), nil, 20000, big.NewInt(0))
+		require.NoError(err)
+		elp := (&action.EnvelopeBuilder{}).SetNonce(i).SetAction(tsf).SetGasLimit(20000).Build()
+		selp, err := action.Sign(elp, identityset.PrivateKey(28))
+		require.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(i).
+			SetPrevBlockHash(hash.ZeroHash256).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(selp).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		require.NoError(sf.PutBlock(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: i,
+			Producer:    identityset.Address(27),
+			GasLimit:    cfg.Genesis.BlockGasLimit,
+		}), &blk))
+		blks = append(blks, &blk)
+	}
+
+	// the snapshot is taken at the last block of the second epoch, and exported in the background
+	sf.(*factory).snapshotWG.Wait()
+	manifest, err := sf.SnapshotManifest()
+	require.NoError(err)
+	require.Equal(uint64(4), manifest.GetHeight())
+	blkHash := blks[3].HashBlock()
+	require.Equal(blkHash[:], manifest.GetBlockHash())
+	require.True(len(manifest.GetChunkHashes()) > 1)
+	fetch := func(i uint32) ([]byte, error) {
+		return sf.SnapshotChunk(manifest.GetHeight(), i)
+	}
+
+	// invalid snapshots
+	tampered := proto.Clone(manifest).(*snapshotpb.Manifest)
+	tampered.StateRoot = hash.ZeroHash256[:]
+	require.Equal(ErrInvalidSnapshot, errors.Cause(newFactory().ImportSnapshot(ctx, tampered, fetch)))
+	tampered = proto.Clone(manifest).(*snapshotpb.Manifest)
+	tampered.ChunkHashes[1] = hash.ZeroHash256[:]
+	require.Equal(ErrInvalidSnapshot, errors.Cause(newFactory().ImportSnapshot(ctx, tampered, fetch)))
+	require.Error(newFactory().ImportSnapshot(ctx, manifest, func(i uint32) ([]byte, error) {
+		return sf.SnapshotChunk(2, i)
+	}))
+
+	// import the snapshot into a new factory
+	sf2 := newFactory()
+	require.NoError(sf2.ImportSnapshot(ctx, manifest, fetch))
+	require.NoError(sf2.VerifySnapshot(ctx, manifest))
+	tampered = proto.Clone(manifest).(*snapshotpb.Manifest)
+	tampered.Height = 5
+	require.Equal(ErrInvalidSnapshot, errors.Cause(sf2.VerifySnapshot(ctx, tampered)))
+	require.NoError(sf2.Start(ctx))
+	defer func() {
+		require.NoError(sf2.Stop(ctx))
+	}()
+	height, err := sf2.Height()
+	require.NoError(err)
+	require.Equal(uint64(4), height)
+	accountA, err := accountutil.AccountState(sf2, a)
+	require.NoError(err)
+	require.Equal(big.NewInt(96), accountA.Balance)
+	require.Equal(uint64(4), accountA.Nonce)
+
+	// the states after the snapshot are replayed on top of it
+	for _, blk := range blks[4:] {
+		require.NoError(sf2.PutBlock(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: blk.Height(),
+			Producer:    identityset.Address(27),
+			GasLimit:    cfg.Genesis.BlockGasLimit,
+		}), blk))
+	}
+	root, _, err := sf.ProveState(6, protocol.LegacyKeyOption(hash.BytesToHash160(identityset.Address(28).Bytes())))
+	require.NoError(err)
+	root2, _, err := sf2.ProveState(6, protocol.LegacyKeyOption(hash.BytesToHash160(identityset.Address(28).Bytes())))
+	require.NoError(err)
+	require.Equal(root, root2)
+
+	// cannot import into a non-empty factory
+	require.Error(sf2.ImportSnapshot(ctx, manifest, fetch))
+
+	// a state altered after import
+	sf3 := newFactory()
+	require.NoError(sf3.ImportSnapshot(ctx, manifest, fetch))
+	dao := sf3.(*factory).dao
+	require.NoError(dao.Start(ctx))
+	require.NoError(dao.Put(AccountKVNamespace, identityset.Address(28).Bytes(), []byte{1}))
+	require.NoError(dao.Stop(ctx))
+	require.Equal(ErrInvalidSnapshot, errors.Cause(sf3.VerifySnapshot(ctx, manifest)))
+}
@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/actpool/actioniterator"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db"
@@ -32,6 +34,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
 )
 
 // stateDB implements StateFactory interface, tracks changes to account/contract and batch-commits to DB
@@ -45,6 +48,7 @@ type stateDB struct {
 	workingsets              *cache.ThreadSafeLruCache // lru cache for workingsets
 	protocolView             protocol.View
 	skipBlockValidationOnPut bool
+	packingPolicy            actioniterator.Policy
 }
 
 // StateDBOption sets stateDB construction parameter
@@ -69,7 +73,7 @@ func DefaultStateDBOption() StateDBOption {
 			return errors.New("Invalid empty trie db path")
 		}
 		cfg.DB.DbPath = dbPath // TODO: remove this after moving TrieDBPath from cfg.Chain to cfg.DB
-		sdb.dao = db.NewBoltDB(cfg.DB)
+		sdb.dao = db.NewOnDiskDB(cfg.DB)
 
 		return nil
 	}
@@ -83,7 +87,7 @@ func CachedStateDBOption() StateDBOption {
 			return errors.New("Invalid empty trie db path")
 		}
 		cfg.DB.DbPath = dbPath // TODO: remove this after moving TrieDBPath from cfg.Chain to cfg.DB
-		sdb.dao = db.NewKvStoreWithCache(db.NewBoltDB(cfg.DB), cfg.Chain.StateDBCacheSize)
+		sdb.dao = db.NewKvStoreWithCache(db.NewOnDiskDB(cfg.DB), cfg.Chain.StateDBCacheSize)
 
 		return nil
 	}
@@ -115,12 +119,17 @@ func SkipBlockValidationStateDBOption() StateDBOption {
 
 // NewStateDB creates a new state db
 func NewStateDB(cfg config.Config, opts ...StateDBOption) (Factory, error) {
+	packingPolicy, err := actioniterator.NewPolicy(cfg.ActPool)
+	if err != nil {
+		return nil, err
+	}
 	sdb := stateDB{
 		cfg:                cfg,
 		currentChainHeight: 0,
 		registry:           protocol.NewRegistry(),
 		protocolView:       protocol.View{},
 		workingsets:        cache.NewThreadSafeLruCache(int(cfg.Chain.WorkingSetCacheSize)),
+		packingPolicy:      packingPolicy,
 	}
 	for _, opt := range opts {
 		if err := opt(&sdb, cfg); err != nil {
@@ -156,6 +165,9 @@ func (sdb *stateDB) Start(ctx context.Context) error {
 			return err
 		}
 	case db.ErrNotExist:
+		if sdb.cfg.DB.ReadOnly {
+			return errors.Wrap(err, "failed to get statedb's height in read-only mode")
+		}
 		if err = sdb.dao.Put(AccountKVNamespace, []byte(CurrentHeightKey), byteutil.Uint64ToBytes(0)); err != nil {
 			return errors.Wrap(err, "failed to init statedb's height")
 		}
@@ -322,7 +334,7 @@ func (sdb *stateDB) NewBlockBuilder(
 			}
 		}
 	}
-	blkBuilder, err := ws.CreateBuilder(ctx, ap, postSystemActions, sdb.cfg.Chain.AllowedBlockGasResidue)
+	blkBuilder, err := ws.CreateBuilder(ctx, ap, sdb.packingPolicy, postSystemActions, sdb.cfg.Chain.AllowedBlockGasResidue)
 	if err != nil {
 		return nil, err
 	}
@@ -450,6 +462,23 @@ func (sdb *stateDB) States(opts ...protocol.StateOption) (uint64, state.Iterator
 	return sdb.currentChainHeight, state.NewIterator(values), nil
 }
 
+// SimulateExecutionAtHeight simulates a running of smart contract operation on top of the state at height -- archive
+// mode
+func (sdb *stateDB) SimulateExecutionAtHeight(
+	ctx context.Context,
+	height uint64,
+	caller address.Address,
+	ex *action.Execution,
+	getBlockHash evm.GetBlockHash,
+) ([]byte, *action.Receipt, error) {
+	return nil, nil, errors.Wrap(ErrNotSupported, "state db does not support archive mode")
+}
+
+// TraceAction re-executes the action at index of the committed block -- archive mode
+func (sdb *stateDB) TraceAction(ctx context.Context, blk *block.Block, index int, tracer vm.Tracer) (*action.Receipt, error) {
+	return nil, errors.Wrap(ErrNotSupported, "state db does not support archive mode")
+}
+
 // StateAtHeight returns a confirmed state at height -- archive mode
 func (sdb *stateDB) StateAtHeight(height uint64, s interface{}, opts ...protocol.StateOption) error {
 	return ErrNotSupported
@@ -460,6 +489,31 @@ func (sdb *stateDB) StatesAtHeight(height uint64, opts ...protocol.StateOption)
 	return nil, errors.Wrap(ErrNotSupported, "state db does not support archive mode")
 }
 
+// ProveState is not supported by state db, which does not maintain a state trie
+func (sdb *stateDB) ProveState(height uint64, opts ...protocol.StateOption) ([]byte, [][]byte, error) {
+	return nil, nil, errors.Wrap(ErrNotSupported, "state db does not support state proof")
+}
+
+// SnapshotManifest is not supported by state db, whose snapshot cannot be verified without a state trie
+func (sdb *stateDB) SnapshotManifest() (*snapshotpb.Manifest, error) {
+	return nil, errors.Wrap(ErrNotSupported, "state db does not support state snapshot")
+}
+
+// SnapshotChunk is not supported by state db
+func (sdb *stateDB) SnapshotChunk(uint64, uint32) ([]byte, error) {
+	return nil, errors.Wrap(ErrNotSupported, "state db does not support state snapshot")
+}
+
+// ImportSnapshot is not supported by state db
+func (sdb *stateDB) ImportSnapshot(context.Context, *snapshotpb.Manifest, func(uint32) ([]byte, error)) error {
+	return errors.Wrap(ErrNotSupported, "state db does not support state snapshot")
+}
+
+// VerifySnapshot is not supported by state db
+func (sdb *stateDB) VerifySnapshot(context.Context, *snapshotpb.Manifest) error {
+	return errors.Wrap(ErrNotSupported, "state db does not support state snapshot")
+}
+
 // ReadView reads the view
 func (sdb *stateDB) ReadView(name string) (interface{}, error) {
 	return sdb.protocolView.Read(name)
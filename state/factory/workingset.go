@@ -10,6 +10,7 @@ import (
 	"context"
 	"sort"
 
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/pkg/errors"
@@ -19,6 +20,7 @@ import (
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/actpool/actioniterator"
 	"github.com/iotexproject/iotex-core/blockchain/block"
@@ -360,9 +362,43 @@ func (ws *workingSet) process(ctx context.Context, actions []action.SealedEnvelo
 	return ws.finalize()
 }
 
+// traceAction runs the actions up to the one at index, which is traced by tracer, and returns its receipt
+func (ws *workingSet) traceAction(
+	ctx context.Context,
+	actions []action.SealedEnvelope,
+	index int,
+	tracer vm.Tracer,
+) (*action.Receipt, error) {
+	if err := ws.validate(ctx); err != nil {
+		return nil, err
+	}
+	for _, p := range protocol.MustGetRegistry(ctx).All() {
+		if pp, ok := p.(protocol.PreStatesCreator); ok {
+			if err := pp.CreatePreStates(ctx, ws); err != nil {
+				return nil, err
+			}
+		}
+	}
+	var receipt *action.Receipt
+	for i, act := range actions[:index+1] {
+		actCtx, err := withActionCtx(ctx, act)
+		if err != nil {
+			return nil, err
+		}
+		if i == index {
+			actCtx = evm.WithTracerCtx(actCtx, tracer)
+		}
+		if receipt, err = ws.runAction(actCtx, act); err != nil {
+			return nil, errors.Wrap(err, "error when run action")
+		}
+	}
+	return receipt, nil
+}
+
 func (ws *workingSet) pickAndRunActions(
 	ctx context.Context,
 	ap actpool.ActPool,
+	packingPolicy actioniterator.Policy,
 	postSystemActions []action.SealedEnvelope,
 	allowedBlockGasResidue uint64,
 ) ([]action.SealedEnvelope, error) {
@@ -382,10 +418,29 @@ func (ws *workingSet) pickAndRunActions(
 		}
 	}
 
-	// initial action iterator
+	// keep the gas of the system actions out of reach of the pending actions
 	blkCtx := protocol.MustGetBlockCtx(ctx)
-	if ap != nil {
-		actionIterator := actioniterator.NewActionIterator(ap.PendingActionMap())
+	reservedGas := packingPolicy.ReservedGas()
+	var systemGas uint64
+	for _, selp := range postSystemActions {
+		gas, err := selp.IntrinsicGas()
+		if err != nil {
+			return nil, err
+		}
+		systemGas += gas
+	}
+	if reservedGas < systemGas {
+		reservedGas = systemGas
+	}
+	if reservedGas > blkCtx.GasLimit {
+		reservedGas = blkCtx.GasLimit
+	}
+	blkCtx.GasLimit -= reservedGas
+	ctx = protocol.WithBlockCtx(ctx, blkCtx)
+
+	// initial action iterator
+	if ap != nil && blkCtx.GasLimit > 0 {
+		actionIterator := packingPolicy.NewIterator(ap)
 		for {
 			nextAction, ok := actionIterator.Next()
 			if !ok {
@@ -438,6 +493,8 @@ func (ws *workingSet) pickAndRunActions(
 		}
 	}
 
+	blkCtx.GasLimit += reservedGas
+	ctx = protocol.WithBlockCtx(ctx, blkCtx)
 	for _, selp := range postSystemActions {
 		if ctx, err = withActionCtx(ctx, selp); err != nil {
 			return nil, err
@@ -482,10 +539,11 @@ func (ws *workingSet) ValidateBlock(ctx context.Context, blk *block.Block) error
 func (ws *workingSet) CreateBuilder(
 	ctx context.Context,
 	ap actpool.ActPool,
+	packingPolicy actioniterator.Policy,
 	postSystemActions []action.SealedEnvelope,
 	allowedBlockGasResidue uint64,
 ) (*block.Builder, error) {
-	actions, err := ws.pickAndRunActions(ctx, ap, postSystemActions, allowedBlockGasResidue)
+	actions, err := ws.pickAndRunActions(ctx, ap, packingPolicy, postSystemActions, allowedBlockGasResidue)
 	if err != nil {
 		return nil, err
 	}
@@ -6,6 +6,7 @@ package mock_apiresponder
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	action "github.com/iotexproject/iotex-core/action"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
 	reflect "reflect"
 )
@@ -58,3 +59,52 @@ func (mr *MockResponderMockRecorder) Exit() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exit", reflect.TypeOf((*MockResponder)(nil).Exit))
 }
+
+// MockActionResponder is a mock of ActionResponder interface
+type MockActionResponder struct {
+	ctrl     *gomock.Controller
+	recorder *MockActionResponderMockRecorder
+}
+
+// MockActionResponderMockRecorder is the mock recorder for MockActionResponder
+type MockActionResponderMockRecorder struct {
+	mock *MockActionResponder
+}
+
+// NewMockActionResponder creates a new mock instance
+func NewMockActionResponder(ctrl *gomock.Controller) *MockActionResponder {
+	mock := &MockActionResponder{ctrl: ctrl}
+	mock.recorder = &MockActionResponderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockActionResponder) EXPECT() *MockActionResponderMockRecorder {
+	return m.recorder
+}
+
+// RespondAction mocks base method
+func (m *MockActionResponder) RespondAction(arg0 action.SealedEnvelope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondAction", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RespondAction indicates an expected call of RespondAction
+func (mr *MockActionResponderMockRecorder) RespondAction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondAction", reflect.TypeOf((*MockActionResponder)(nil).RespondAction), arg0)
+}
+
+// Exit mocks base method
+func (m *MockActionResponder) Exit() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Exit")
+}
+
+// Exit indicates an expected call of Exit
+func (mr *MockActionResponderMockRecorder) Exit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exit", reflect.TypeOf((*MockActionResponder)(nil).Exit))
+}
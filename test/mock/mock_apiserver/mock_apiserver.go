@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ./api/apitestserver.go
+// Source: github.com/iotexproject/iotex-core/api (interfaces: StreamBlocksServer,StreamPendingActionsServer,StreamConsensusRoundServer)
 
 // Package mock_apiserver is a generated GoMock package.
 package mock_apiserver
@@ -7,6 +7,7 @@ package mock_apiserver
 import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
+	apipb "github.com/iotexproject/iotex-core/api/apipb"
 	iotexapi "github.com/iotexproject/iotex-proto/golang/iotexapi"
 	metadata "google.golang.org/grpc/metadata"
 	reflect "reflect"
@@ -35,6 +36,34 @@ func (m *MockStreamBlocksServer) EXPECT() *MockStreamBlocksServerMockRecorder {
 	return m.recorder
 }
 
+// Context mocks base method
+func (m *MockStreamBlocksServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockStreamBlocksServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockStreamBlocksServer)(nil).Context))
+}
+
+// RecvMsg mocks base method
+func (m *MockStreamBlocksServer) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockStreamBlocksServerMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockStreamBlocksServer)(nil).RecvMsg), arg0)
+}
+
 // Send mocks base method
 func (m *MockStreamBlocksServer) Send(arg0 *iotexapi.StreamBlocksResponse) error {
 	m.ctrl.T.Helper()
@@ -49,6 +78,34 @@ func (mr *MockStreamBlocksServerMockRecorder) Send(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockStreamBlocksServer)(nil).Send), arg0)
 }
 
+// SendHeader mocks base method
+func (m *MockStreamBlocksServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader
+func (mr *MockStreamBlocksServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockStreamBlocksServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method
+func (m *MockStreamBlocksServer) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockStreamBlocksServerMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockStreamBlocksServer)(nil).SendMsg), arg0)
+}
+
 // SetHeader mocks base method
 func (m *MockStreamBlocksServer) SetHeader(arg0 metadata.MD) error {
 	m.ctrl.T.Helper()
@@ -63,8 +120,85 @@ func (mr *MockStreamBlocksServerMockRecorder) SetHeader(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockStreamBlocksServer)(nil).SetHeader), arg0)
 }
 
+// SetTrailer mocks base method
+func (m *MockStreamBlocksServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer
+func (mr *MockStreamBlocksServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockStreamBlocksServer)(nil).SetTrailer), arg0)
+}
+
+// MockStreamPendingActionsServer is a mock of StreamPendingActionsServer interface
+type MockStreamPendingActionsServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamPendingActionsServerMockRecorder
+}
+
+// MockStreamPendingActionsServerMockRecorder is the mock recorder for MockStreamPendingActionsServer
+type MockStreamPendingActionsServerMockRecorder struct {
+	mock *MockStreamPendingActionsServer
+}
+
+// NewMockStreamPendingActionsServer creates a new mock instance
+func NewMockStreamPendingActionsServer(ctrl *gomock.Controller) *MockStreamPendingActionsServer {
+	mock := &MockStreamPendingActionsServer{ctrl: ctrl}
+	mock.recorder = &MockStreamPendingActionsServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStreamPendingActionsServer) EXPECT() *MockStreamPendingActionsServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method
+func (m *MockStreamPendingActionsServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockStreamPendingActionsServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockStreamPendingActionsServer)(nil).Context))
+}
+
+// RecvMsg mocks base method
+func (m *MockStreamPendingActionsServer) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockStreamPendingActionsServerMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockStreamPendingActionsServer)(nil).RecvMsg), arg0)
+}
+
+// Send mocks base method
+func (m *MockStreamPendingActionsServer) Send(arg0 *apipb.StreamPendingActionsResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send
+func (mr *MockStreamPendingActionsServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockStreamPendingActionsServer)(nil).Send), arg0)
+}
+
 // SendHeader mocks base method
-func (m *MockStreamBlocksServer) SendHeader(arg0 metadata.MD) error {
+func (m *MockStreamPendingActionsServer) SendHeader(arg0 metadata.MD) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SendHeader", arg0)
 	ret0, _ := ret[0].(error)
@@ -72,25 +206,76 @@ func (m *MockStreamBlocksServer) SendHeader(arg0 metadata.MD) error {
 }
 
 // SendHeader indicates an expected call of SendHeader
-func (mr *MockStreamBlocksServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+func (mr *MockStreamPendingActionsServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockStreamBlocksServer)(nil).SendHeader), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockStreamPendingActionsServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method
+func (m *MockStreamPendingActionsServer) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockStreamPendingActionsServerMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockStreamPendingActionsServer)(nil).SendMsg), arg0)
+}
+
+// SetHeader mocks base method
+func (m *MockStreamPendingActionsServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader
+func (mr *MockStreamPendingActionsServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockStreamPendingActionsServer)(nil).SetHeader), arg0)
 }
 
 // SetTrailer mocks base method
-func (m *MockStreamBlocksServer) SetTrailer(arg0 metadata.MD) {
+func (m *MockStreamPendingActionsServer) SetTrailer(arg0 metadata.MD) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "SetTrailer", arg0)
 }
 
 // SetTrailer indicates an expected call of SetTrailer
-func (mr *MockStreamBlocksServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+func (mr *MockStreamPendingActionsServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockStreamBlocksServer)(nil).SetTrailer), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockStreamPendingActionsServer)(nil).SetTrailer), arg0)
+}
+
+// MockStreamConsensusRoundServer is a mock of StreamConsensusRoundServer interface
+type MockStreamConsensusRoundServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamConsensusRoundServerMockRecorder
+}
+
+// MockStreamConsensusRoundServerMockRecorder is the mock recorder for MockStreamConsensusRoundServer
+type MockStreamConsensusRoundServerMockRecorder struct {
+	mock *MockStreamConsensusRoundServer
+}
+
+// NewMockStreamConsensusRoundServer creates a new mock instance
+func NewMockStreamConsensusRoundServer(ctrl *gomock.Controller) *MockStreamConsensusRoundServer {
+	mock := &MockStreamConsensusRoundServer{ctrl: ctrl}
+	mock.recorder = &MockStreamConsensusRoundServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStreamConsensusRoundServer) EXPECT() *MockStreamConsensusRoundServerMockRecorder {
+	return m.recorder
 }
 
 // Context mocks base method
-func (m *MockStreamBlocksServer) Context() context.Context {
+func (m *MockStreamConsensusRoundServer) Context() context.Context {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Context")
 	ret0, _ := ret[0].(context.Context)
@@ -98,35 +283,89 @@ func (m *MockStreamBlocksServer) Context() context.Context {
 }
 
 // Context indicates an expected call of Context
-func (mr *MockStreamBlocksServerMockRecorder) Context() *gomock.Call {
+func (mr *MockStreamConsensusRoundServerMockRecorder) Context() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockStreamBlocksServer)(nil).Context))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockStreamConsensusRoundServer)(nil).Context))
+}
+
+// RecvMsg mocks base method
+func (m *MockStreamConsensusRoundServer) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockStreamConsensusRoundServerMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockStreamConsensusRoundServer)(nil).RecvMsg), arg0)
+}
+
+// Send mocks base method
+func (m *MockStreamConsensusRoundServer) Send(arg0 *apipb.ConsensusRound) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send
+func (mr *MockStreamConsensusRoundServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockStreamConsensusRoundServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method
+func (m *MockStreamConsensusRoundServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader
+func (mr *MockStreamConsensusRoundServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockStreamConsensusRoundServer)(nil).SendHeader), arg0)
 }
 
 // SendMsg mocks base method
-func (m_2 *MockStreamBlocksServer) SendMsg(m interface{}) error {
-	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+func (m *MockStreamConsensusRoundServer) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SendMsg indicates an expected call of SendMsg
-func (mr *MockStreamBlocksServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+func (mr *MockStreamConsensusRoundServerMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockStreamBlocksServer)(nil).SendMsg), m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockStreamConsensusRoundServer)(nil).SendMsg), arg0)
 }
 
-// RecvMsg mocks base method
-func (m_2 *MockStreamBlocksServer) RecvMsg(m interface{}) error {
-	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+// SetHeader mocks base method
+func (m *MockStreamConsensusRoundServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RecvMsg indicates an expected call of RecvMsg
-func (mr *MockStreamBlocksServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+// SetHeader indicates an expected call of SetHeader
+func (mr *MockStreamConsensusRoundServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockStreamConsensusRoundServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method
+func (m *MockStreamConsensusRoundServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer
+func (mr *MockStreamConsensusRoundServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockStreamBlocksServer)(nil).RecvMsg), m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockStreamConsensusRoundServer)(nil).SetTrailer), arg0)
 }
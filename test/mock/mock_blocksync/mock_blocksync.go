@@ -7,7 +7,10 @@ package mock_blocksync
 import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
+	proto "github.com/golang/protobuf/proto"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
+	blocksyncpb "github.com/iotexproject/iotex-core/blocksync/blocksyncpb"
+	snapshotpb "github.com/iotexproject/iotex-core/state/factory/snapshotpb"
 	iotexrpc "github.com/iotexproject/iotex-proto/golang/iotexrpc"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	reflect "reflect"
@@ -130,6 +133,34 @@ func (mr *MockBlockSyncMockRecorder) ProcessSyncRequest(ctx, peer, sync interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessSyncRequest", reflect.TypeOf((*MockBlockSync)(nil).ProcessSyncRequest), ctx, peer, sync)
 }
 
+// ProcessHeadersRequest mocks base method
+func (m *MockBlockSync) ProcessHeadersRequest(ctx context.Context, peer peerstore.PeerInfo, req *blocksyncpb.HeadersRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessHeadersRequest", ctx, peer, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessHeadersRequest indicates an expected call of ProcessHeadersRequest
+func (mr *MockBlockSyncMockRecorder) ProcessHeadersRequest(ctx, peer, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessHeadersRequest", reflect.TypeOf((*MockBlockSync)(nil).ProcessHeadersRequest), ctx, peer, req)
+}
+
+// ProcessHeaders mocks base method
+func (m *MockBlockSync) ProcessHeaders(ctx context.Context, peer peerstore.PeerInfo, resp *blocksyncpb.HeadersResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessHeaders", ctx, peer, resp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessHeaders indicates an expected call of ProcessHeaders
+func (mr *MockBlockSyncMockRecorder) ProcessHeaders(ctx, peer, resp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessHeaders", reflect.TypeOf((*MockBlockSync)(nil).ProcessHeaders), ctx, peer, resp)
+}
+
 // ProcessBlock mocks base method
 func (m *MockBlockSync) ProcessBlock(ctx context.Context, blk *block.Block) error {
 	m.ctrl.T.Helper()
@@ -157,3 +188,46 @@ func (mr *MockBlockSyncMockRecorder) ProcessBlockSync(ctx, blk interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockSync", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockSync), ctx, blk)
 }
+
+// ProcessSnapshotRequest mocks base method
+func (m *MockBlockSync) ProcessSnapshotRequest(ctx context.Context, peer peerstore.PeerInfo, req *snapshotpb.Request) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessSnapshotRequest", ctx, peer, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessSnapshotRequest indicates an expected call of ProcessSnapshotRequest
+func (mr *MockBlockSyncMockRecorder) ProcessSnapshotRequest(ctx, peer, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessSnapshotRequest", reflect.TypeOf((*MockBlockSync)(nil).ProcessSnapshotRequest), ctx, peer, req)
+}
+
+// ProcessSnapshotResponse mocks base method
+func (m *MockBlockSync) ProcessSnapshotResponse(ctx context.Context, peer peerstore.PeerInfo, msg proto.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessSnapshotResponse", ctx, peer, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessSnapshotResponse indicates an expected call of ProcessSnapshotResponse
+func (mr *MockBlockSyncMockRecorder) ProcessSnapshotResponse(ctx, peer, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessSnapshotResponse", reflect.TypeOf((*MockBlockSync)(nil).ProcessSnapshotResponse), ctx, peer, msg)
+}
+
+// SyncSnapshot mocks base method
+func (m *MockBlockSync) SyncSnapshot(ctx context.Context) (*block.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncSnapshot", ctx)
+	ret0, _ := ret[0].(*block.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncSnapshot indicates an expected call of SyncSnapshot
+func (mr *MockBlockSyncMockRecorder) SyncSnapshot(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncSnapshot", reflect.TypeOf((*MockBlockSync)(nil).SyncSnapshot), ctx)
+}
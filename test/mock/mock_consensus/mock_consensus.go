@@ -8,6 +8,7 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
+	evidence "github.com/iotexproject/iotex-core/consensus/evidence"
 	scheme "github.com/iotexproject/iotex-core/consensus/scheme"
 	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
 	reflect "reflect"
@@ -104,6 +105,20 @@ func (mr *MockConsensusMockRecorder) ValidateBlockFooter(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlockFooter", reflect.TypeOf((*MockConsensus)(nil).ValidateBlockFooter), arg0)
 }
 
+// ValidateBlockProducer mocks base method
+func (m *MockConsensus) ValidateBlockProducer(arg0 *block.Header) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateBlockProducer", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateBlockProducer indicates an expected call of ValidateBlockProducer
+func (mr *MockConsensusMockRecorder) ValidateBlockProducer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlockProducer", reflect.TypeOf((*MockConsensus)(nil).ValidateBlockProducer), arg0)
+}
+
 // Metrics mocks base method
 func (m *MockConsensus) Metrics() (scheme.ConsensusMetrics, error) {
 	m.ctrl.T.Helper()
@@ -144,3 +159,48 @@ func (mr *MockConsensusMockRecorder) Active() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Active", reflect.TypeOf((*MockConsensus)(nil).Active))
 }
+
+// CurrentRound mocks base method
+func (m *MockConsensus) CurrentRound() (*scheme.ConsensusRound, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CurrentRound")
+	ret0, _ := ret[0].(*scheme.ConsensusRound)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CurrentRound indicates an expected call of CurrentRound
+func (mr *MockConsensusMockRecorder) CurrentRound() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentRound", reflect.TypeOf((*MockConsensus)(nil).CurrentRound))
+}
+
+// RoundHistory mocks base method
+func (m *MockConsensus) RoundHistory(n int) ([]*scheme.ConsensusRound, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoundHistory", n)
+	ret0, _ := ret[0].([]*scheme.ConsensusRound)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RoundHistory indicates an expected call of RoundHistory
+func (mr *MockConsensusMockRecorder) RoundHistory(n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoundHistory", reflect.TypeOf((*MockConsensus)(nil).RoundHistory), n)
+}
+
+// Evidence mocks base method
+func (m *MockConsensus) Evidence(startHeight, count uint64) ([]*evidence.Evidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evidence", startHeight, count)
+	ret0, _ := ret[0].([]*evidence.Evidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evidence indicates an expected call of Evidence
+func (mr *MockConsensusMockRecorder) Evidence(startHeight, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evidence", reflect.TypeOf((*MockConsensus)(nil).Evidence), startHeight, count)
+}
@@ -8,7 +8,9 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	proto "github.com/golang/protobuf/proto"
+	blocksyncpb "github.com/iotexproject/iotex-core/blocksync/blocksyncpb"
 	dispatcher "github.com/iotexproject/iotex-core/dispatcher"
+	snapshotpb "github.com/iotexproject/iotex-core/state/factory/snapshotpb"
 	iotexrpc "github.com/iotexproject/iotex-proto/golang/iotexrpc"
 	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
@@ -94,6 +96,34 @@ func (mr *MockSubscriberMockRecorder) HandleSyncRequest(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSyncRequest", reflect.TypeOf((*MockSubscriber)(nil).HandleSyncRequest), arg0, arg1, arg2)
 }
 
+// HandleHeadersRequest mocks base method
+func (m *MockSubscriber) HandleHeadersRequest(arg0 context.Context, arg1 peerstore.PeerInfo, arg2 *blocksyncpb.HeadersRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleHeadersRequest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleHeadersRequest indicates an expected call of HandleHeadersRequest
+func (mr *MockSubscriberMockRecorder) HandleHeadersRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleHeadersRequest", reflect.TypeOf((*MockSubscriber)(nil).HandleHeadersRequest), arg0, arg1, arg2)
+}
+
+// HandleHeaders mocks base method
+func (m *MockSubscriber) HandleHeaders(arg0 context.Context, arg1 peerstore.PeerInfo, arg2 *blocksyncpb.HeadersResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleHeaders", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleHeaders indicates an expected call of HandleHeaders
+func (mr *MockSubscriberMockRecorder) HandleHeaders(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleHeaders", reflect.TypeOf((*MockSubscriber)(nil).HandleHeaders), arg0, arg1, arg2)
+}
+
 // HandleConsensusMsg mocks base method
 func (m *MockSubscriber) HandleConsensusMsg(arg0 *iotextypes.ConsensusMessage) error {
 	m.ctrl.T.Helper()
@@ -108,6 +138,34 @@ func (mr *MockSubscriberMockRecorder) HandleConsensusMsg(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleConsensusMsg", reflect.TypeOf((*MockSubscriber)(nil).HandleConsensusMsg), arg0)
 }
 
+// HandleSnapshotRequest mocks base method
+func (m *MockSubscriber) HandleSnapshotRequest(arg0 context.Context, arg1 peerstore.PeerInfo, arg2 *snapshotpb.Request) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleSnapshotRequest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleSnapshotRequest indicates an expected call of HandleSnapshotRequest
+func (mr *MockSubscriberMockRecorder) HandleSnapshotRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSnapshotRequest", reflect.TypeOf((*MockSubscriber)(nil).HandleSnapshotRequest), arg0, arg1, arg2)
+}
+
+// HandleSnapshotResponse mocks base method
+func (m *MockSubscriber) HandleSnapshotResponse(arg0 context.Context, arg1 peerstore.PeerInfo, arg2 proto.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleSnapshotResponse", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleSnapshotResponse indicates an expected call of HandleSnapshotResponse
+func (mr *MockSubscriberMockRecorder) HandleSnapshotResponse(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSnapshotResponse", reflect.TypeOf((*MockSubscriber)(nil).HandleSnapshotResponse), arg0, arg1, arg2)
+}
+
 // MockDispatcher is a mock of Dispatcher interface
 type MockDispatcher struct {
 	ctrl     *gomock.Controller
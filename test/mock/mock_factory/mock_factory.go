@@ -6,6 +6,7 @@ package mock_factory
 
 import (
 	context "context"
+	vm "github.com/ethereum/go-ethereum/core/vm"
 	gomock "github.com/golang/mock/gomock"
 	address "github.com/iotexproject/iotex-address/address"
 	action "github.com/iotexproject/iotex-core/action"
@@ -14,6 +15,7 @@ import (
 	actpool "github.com/iotexproject/iotex-core/actpool"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
 	state "github.com/iotexproject/iotex-core/state"
+	snapshotpb "github.com/iotexproject/iotex-core/state/factory/snapshotpb"
 	reflect "reflect"
 )
 
@@ -197,6 +199,37 @@ func (mr *MockFactoryMockRecorder) SimulateExecution(arg0, arg1, arg2, arg3 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateExecution", reflect.TypeOf((*MockFactory)(nil).SimulateExecution), arg0, arg1, arg2, arg3)
 }
 
+// SimulateExecutionAtHeight mocks base method
+func (m *MockFactory) SimulateExecutionAtHeight(arg0 context.Context, arg1 uint64, arg2 address.Address, arg3 *action.Execution, arg4 evm.GetBlockHash) ([]byte, *action.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateExecutionAtHeight", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*action.Receipt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SimulateExecutionAtHeight indicates an expected call of SimulateExecutionAtHeight
+func (mr *MockFactoryMockRecorder) SimulateExecutionAtHeight(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateExecutionAtHeight", reflect.TypeOf((*MockFactory)(nil).SimulateExecutionAtHeight), arg0, arg1, arg2, arg3, arg4)
+}
+
+// TraceAction mocks base method
+func (m *MockFactory) TraceAction(arg0 context.Context, arg1 *block.Block, arg2 int, arg3 vm.Tracer) (*action.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TraceAction", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*action.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TraceAction indicates an expected call of TraceAction
+func (mr *MockFactoryMockRecorder) TraceAction(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceAction", reflect.TypeOf((*MockFactory)(nil).TraceAction), arg0, arg1, arg2, arg3)
+}
+
 // PutBlock mocks base method
 func (m *MockFactory) PutBlock(arg0 context.Context, arg1 *block.Block) error {
 	m.ctrl.T.Helper()
@@ -263,3 +296,82 @@ func (mr *MockFactoryMockRecorder) StatesAtHeight(arg0 interface{}, arg1 ...inte
 	varargs := append([]interface{}{arg0}, arg1...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatesAtHeight", reflect.TypeOf((*MockFactory)(nil).StatesAtHeight), varargs...)
 }
+
+// ProveState mocks base method
+func (m *MockFactory) ProveState(arg0 uint64, arg1 ...protocol.StateOption) ([]byte, [][]byte, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProveState", varargs...)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ProveState indicates an expected call of ProveState
+func (mr *MockFactoryMockRecorder) ProveState(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProveState", reflect.TypeOf((*MockFactory)(nil).ProveState), varargs...)
+}
+
+// SnapshotManifest mocks base method
+func (m *MockFactory) SnapshotManifest() (*snapshotpb.Manifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotManifest")
+	ret0, _ := ret[0].(*snapshotpb.Manifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotManifest indicates an expected call of SnapshotManifest
+func (mr *MockFactoryMockRecorder) SnapshotManifest() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotManifest", reflect.TypeOf((*MockFactory)(nil).SnapshotManifest))
+}
+
+// SnapshotChunk mocks base method
+func (m *MockFactory) SnapshotChunk(arg0 uint64, arg1 uint32) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotChunk", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotChunk indicates an expected call of SnapshotChunk
+func (mr *MockFactoryMockRecorder) SnapshotChunk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotChunk", reflect.TypeOf((*MockFactory)(nil).SnapshotChunk), arg0, arg1)
+}
+
+// ImportSnapshot mocks base method
+func (m *MockFactory) ImportSnapshot(arg0 context.Context, arg1 *snapshotpb.Manifest, arg2 func(uint32) ([]byte, error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSnapshot", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportSnapshot indicates an expected call of ImportSnapshot
+func (mr *MockFactoryMockRecorder) ImportSnapshot(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSnapshot", reflect.TypeOf((*MockFactory)(nil).ImportSnapshot), arg0, arg1, arg2)
+}
+
+// VerifySnapshot mocks base method
+func (m *MockFactory) VerifySnapshot(arg0 context.Context, arg1 *snapshotpb.Manifest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifySnapshot", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifySnapshot indicates an expected call of VerifySnapshot
+func (mr *MockFactoryMockRecorder) VerifySnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySnapshot", reflect.TypeOf((*MockFactory)(nil).VerifySnapshot), arg0, arg1)
+}
@@ -186,6 +186,36 @@ func (mr *MockTrieMockRecorder) IsEmpty() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEmpty", reflect.TypeOf((*MockTrie)(nil).IsEmpty))
 }
 
+// Prove mocks base method
+func (m *MockTrie) Prove(arg0 []byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prove", arg0)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prove indicates an expected call of Prove
+func (mr *MockTrieMockRecorder) Prove(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prove", reflect.TypeOf((*MockTrie)(nil).Prove), arg0)
+}
+
+// VerifyProof mocks base method
+func (m *MockTrie) VerifyProof(arg0, arg1 []byte, arg2 [][]byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyProof", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyProof indicates an expected call of VerifyProof
+func (mr *MockTrieMockRecorder) VerifyProof(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyProof", reflect.TypeOf((*MockTrie)(nil).VerifyProof), arg0, arg1, arg2)
+}
+
 // MockTwoLayerTrie is a mock of TwoLayerTrie interface
 type MockTwoLayerTrie struct {
 	ctrl     *gomock.Controller
@@ -308,3 +338,33 @@ func (mr *MockTwoLayerTrieMockRecorder) Delete(arg0, arg1 interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTwoLayerTrie)(nil).Delete), arg0, arg1)
 }
+
+// Prove mocks base method
+func (m *MockTwoLayerTrie) Prove(arg0, arg1 []byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prove", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prove indicates an expected call of Prove
+func (mr *MockTwoLayerTrieMockRecorder) Prove(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prove", reflect.TypeOf((*MockTwoLayerTrie)(nil).Prove), arg0, arg1)
+}
+
+// VerifyProof mocks base method
+func (m *MockTwoLayerTrie) VerifyProof(arg0, arg1, arg2 []byte, arg3 [][]byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyProof", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyProof indicates an expected call of VerifyProof
+func (mr *MockTwoLayerTrieMockRecorder) VerifyProof(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyProof", reflect.TypeOf((*MockTwoLayerTrie)(nil).VerifyProof), arg0, arg1, arg2, arg3)
+}
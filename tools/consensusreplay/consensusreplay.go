@@ -0,0 +1,183 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// This is a debugging tool that replays the consensus events recorded by the flight recorder of a node, and prints the
+// decision path of the rounds.
+// To use, run "make build-consensusreplay", and then
+// "./bin/consensusreplay -config-path=[string] -recording-path=[string] -height=[int]"
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	glog "log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/consensus/consensusfsm/flightrecorderpb"
+)
+
+var (
+	// recordingPath is the file recorded by the flight recorder
+	recordingPath string
+	// height is the consensus height to replay, or all the heights recorded if it is 0
+	height uint64
+)
+
+func init() {
+	flag.StringVar(&recordingPath, "recording-path", "", "Flight recorder file")
+	flag.Uint64Var(&height, "height", 0, "Consensus height to replay")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr,
+			"usage: consensusreplay -config-path=[string]\n -recording-path=[string]\n -height=[int]\n")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	flag.Parse()
+}
+
+func main() {
+	genesisCfg, err := genesis.New()
+	if err != nil {
+		glog.Fatalln("Failed to new genesis config.", zap.Error(err))
+	}
+	cfg, err := config.New()
+	if err != nil {
+		glog.Fatalln("Failed to new config.", zap.Error(err))
+	}
+	cfg.Genesis = genesisCfg
+	if recordingPath == "" {
+		recordingPath = cfg.Consensus.RollDPoS.FlightRecorderPath
+	}
+
+	entries, err := consensusfsm.ReadFlightRecording(recordingPath)
+	if err != nil {
+		glog.Fatalln("Failed to read the flight recorder file.", zap.Error(err))
+	}
+	if height != 0 {
+		filtered := entries[:0]
+		for _, entry := range entries {
+			if entry.GetHeight() == height {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+	steps, err := consensusfsm.Replay(entries, consensusfsm.NewConsensusConfig(cfg))
+	if err != nil {
+		glog.Fatalln("Failed to replay the consensus events.", zap.Error(err))
+	}
+	diverged := 0
+	for _, step := range steps {
+		printStep(step)
+		if step.Diverged() {
+			diverged++
+		}
+	}
+	fmt.Printf("replayed %d events, %d diverged from the recording\n", len(steps), diverged)
+}
+
+func printStep(step *consensusfsm.ReplayStep) {
+	entry := step.Entry
+	fmt.Printf(
+		"%s height %d round %d %s: %s -> %s (%s)",
+		time.Unix(0, entry.GetHandleTime()).UTC().Format(time.RFC3339Nano),
+		entry.GetHeight(),
+		entry.GetRound(),
+		entry.GetType(),
+		step.Src,
+		step.Dst,
+		step.Status,
+	)
+	if msg := describeMessage(entry.GetData()); msg != "" {
+		fmt.Printf(" %s", msg)
+	}
+	if step.Err != nil {
+		fmt.Printf(" error: %v", step.Err)
+	}
+	fmt.Println()
+	for _, call := range entry.GetCalls() {
+		fmt.Printf("\t%s\n", describeCall(call))
+	}
+	if step.Diverged() {
+		fmt.Printf(
+			"\tDIVERGED: recorded %s -> %s (%s), missing decisions %v\n",
+			entry.GetSrc(),
+			entry.GetDst(),
+			entry.GetStatus(),
+			step.Missing,
+		)
+	}
+}
+
+func describeCall(call *flightrecorderpb.Call) string {
+	var b strings.Builder
+	b.WriteString(call.GetMethod())
+	switch call.GetMethod() {
+	case "Height":
+		fmt.Fprintf(&b, " = %d", call.GetValue())
+	case "WaitUntilRoundStart":
+		fmt.Fprintf(&b, " = %s", time.Duration(call.GetValue()))
+	case "Prepare":
+	default:
+		fmt.Fprintf(&b, " = %t", call.GetOk())
+	}
+	if msg := describeMessage(call.GetData()); msg != "" {
+		fmt.Fprintf(&b, " %s", msg)
+	}
+	if call.GetError() != "" {
+		fmt.Fprintf(&b, " error: %s", call.GetError())
+	}
+	return b.String()
+}
+
+// describeMessage describes the endorsed consensus message encoded in data
+func describeMessage(data []byte) string {
+	msg := &iotextypes.ConsensusMessage{}
+	if len(data) == 0 || proto.Unmarshal(data, msg) != nil || msg.GetEndorsement() == nil {
+		return ""
+	}
+	endorser := "unknown"
+	if pk, err := crypto.BytesToPublicKey(msg.GetEndorsement().GetEndorser()); err == nil {
+		if addr, err := address.FromBytes(pk.Hash()); err == nil {
+			endorser = addr.String()
+		}
+	}
+	switch {
+	case msg.GetVote() != nil:
+		return fmt.Sprintf(
+			"[%s vote on block %s by %s]",
+			msg.GetVote().GetTopic(),
+			hex.EncodeToString(msg.GetVote().GetBlockHash()),
+			endorser,
+		)
+	case msg.GetBlockProposal() != nil:
+		blk := &block.Block{}
+		if err := blk.ConvertFromBlockPb(msg.GetBlockProposal().GetBlock()); err != nil {
+			return fmt.Sprintf("[invalid proposal by %s]", endorser)
+		}
+		blkHash := blk.HashBlock()
+		return fmt.Sprintf(
+			"[proposal of block %s at height %d by %s]",
+			hex.EncodeToString(blkHash[:]),
+			blk.Height(),
+			endorser,
+		)
+	}
+	return ""
+}
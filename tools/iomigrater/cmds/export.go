@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/schollz/progressbar/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/blockchain/blockstream"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/compress"
+	"github.com/iotexproject/iotex-core/tools/iomigrater/common"
+)
+
+// Multi-language support
+var (
+	exportCmdShorts = map[string]string{
+		"english": "Sub-Command for export IoTeX blockchain db file to a block stream file.",
+		"chinese": "导出IoTeX区块链 db 文件到区块流文件的子命令",
+	}
+	exportCmdLongs = map[string]string{
+		"english": "Sub-Command for export the blocks, receipts and transaction logs of a height range in IoTeX blockchain db file to a versioned, compressed and checksummed block stream file.",
+		"chinese": "将IoTeX区块链 db 文件中指定高度范围的区块、收据和交易日志导出到带版本、压缩和校验的区块流文件的子命令",
+	}
+	exportCmdUse = map[string]string{
+		"english": "export",
+		"chinese": "export",
+	}
+	exportFlagDbFileUse = map[string]string{
+		"english": "The db file you want to export.",
+		"chinese": "您要导出的 db 文件。",
+	}
+	exportFlagOutputUse = map[string]string{
+		"english": "The block stream file you want to export to.",
+		"chinese": "您要导出到的区块流文件。",
+	}
+	exportFlagStartUse = map[string]string{
+		"english": "The height of the first block to export.",
+		"chinese": "要导出的第一个区块的高度。",
+	}
+	exportFlagEndUse = map[string]string{
+		"english": "The height of the last block to export, 0 for the height of the db file.",
+		"chinese": "要导出的最后一个区块的高度，0 表示 db 文件的高度。",
+	}
+	exportFlagCompressorUse = map[string]string{
+		"english": "The compressor of the block stream file, Gzip, Snappy, Zstd, Lz4 or empty for no compression.",
+		"chinese": "区块流文件的压缩方式，Gzip、Snappy、Zstd、Lz4 或为空表示不压缩。",
+	}
+)
+
+var (
+	// Export used to Sub command.
+	Export = &cobra.Command{
+		Use:   common.TranslateInLang(exportCmdUse),
+		Short: common.TranslateInLang(exportCmdShorts),
+		Long:  common.TranslateInLang(exportCmdLongs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportBlocks()
+		},
+	}
+)
+
+var (
+	exportDbFile     = ""
+	exportOutput     = ""
+	exportStart      = uint64(1)
+	exportEnd        = uint64(0)
+	exportCompressor = compress.Gzip
+)
+
+func init() {
+	Export.PersistentFlags().StringVarP(&exportDbFile, "db-file", "d", "", common.TranslateInLang(exportFlagDbFileUse))
+	Export.PersistentFlags().StringVarP(&exportOutput, "output", "f", "", common.TranslateInLang(exportFlagOutputUse))
+	Export.PersistentFlags().Uint64VarP(&exportStart, "start", "s", uint64(1), common.TranslateInLang(exportFlagStartUse))
+	Export.PersistentFlags().Uint64VarP(&exportEnd, "end", "e", uint64(0), common.TranslateInLang(exportFlagEndUse))
+	Export.PersistentFlags().StringVarP(&exportCompressor, "compressor", "c", compress.Gzip, common.TranslateInLang(exportFlagCompressorUse))
+}
+
+func exportBlocks() error {
+	// Check flags
+	if exportDbFile == "" {
+		return fmt.Errorf("--db-file is empty")
+	}
+	if exportOutput == "" {
+		return fmt.Errorf("--output is empty")
+	}
+	if exportStart == 0 {
+		return fmt.Errorf("--start is 0")
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		return fmt.Errorf("Failed to new config: %v", err)
+	}
+	cfg.DB.DbPath = exportDbFile
+	cfg.DB.CompressLegacy = cfg.Chain.CompressBlock
+	dao := blockdao.NewBlockDAO(nil, cfg.DB)
+	if dao == nil {
+		return fmt.Errorf("Failed to open the db file")
+	}
+
+	ctx := protocol.WithBlockchainCtx(context.Background(), protocol.BlockchainCtx{Genesis: cfg.Genesis})
+	if err := dao.Start(ctx); err != nil {
+		return fmt.Errorf("Failed to start the db file: %v", err)
+	}
+	defer dao.Stop(ctx)
+
+	height, err := dao.Height()
+	if err != nil {
+		return err
+	}
+	end := exportEnd
+	if end == 0 {
+		end = height
+	}
+	if end > height {
+		return fmt.Errorf("The --end cannot be larger than the height of the db file")
+	}
+	if exportStart > end {
+		return fmt.Errorf("The --start cannot be larger than the --end")
+	}
+
+	file, err := os.Create(exportOutput)
+	if err != nil {
+		return fmt.Errorf("Failed to create the block stream file: %v", err)
+	}
+	defer file.Close()
+	w, err := blockstream.NewWriter(file, cfg.Chain.ID, exportStart, end, exportCompressor)
+	if err != nil {
+		return err
+	}
+
+	// Show the progressbar
+	intHeight, step := getProgressMod(end - exportStart + 1)
+	bar := progressbar.New(intHeight)
+
+	for i := exportStart; i <= end; i++ {
+		blk, err := dao.GetBlockByHeight(i)
+		if err != nil {
+			return fmt.Errorf("Failed to get block on height %d: %v", i, err)
+		}
+		receipts, err := dao.GetReceipts(i)
+		if err != nil {
+			return fmt.Errorf("Failed to get receipts on height %d: %v", i, err)
+		}
+		var logs *iotextypes.TransactionLogs
+		if dao.ContainsTransactionLog() {
+			// the legacy db file only has the transaction logs of the blocks with any
+			if logs, err = dao.TransactionLogs(i); err != nil && errors.Cause(err) != db.ErrNotExist {
+				return fmt.Errorf("Failed to get transaction logs on height %d: %v", i, err)
+			}
+		}
+		if err := w.Write(blk, receipts, logs); err != nil {
+			return fmt.Errorf("Failed to export block on height %d: %v", i, err)
+		}
+
+		if (i-exportStart+1)%uint64(step) == 0 {
+			bar.Add(1)
+			intHeight--
+		}
+	}
+	if intHeight > 0 {
+		bar.Add(intHeight)
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return file.Sync()
+}
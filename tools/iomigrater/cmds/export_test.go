@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// getTestBlocks returns the blocks with a transfer each, and only the blocks after the first have transaction logs
+func getTestBlocks(t *testing.T, n int) []*block.Block {
+	require := require.New(t)
+	var (
+		blks     []*block.Block
+		prevHash = hash.ZeroHash256
+	)
+	for i := 1; i <= n; i++ {
+		tsf, err := testutil.SignedTransfer(identityset.Address(29).String(), identityset.PrivateKey(28), uint64(i), big.NewInt(int64(i)), nil, testutil.TestGasLimit, big.NewInt(0))
+		require.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(uint64(i)).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow().UTC()).
+			AddActions(tsf).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		receipt := &action.Receipt{
+			Status:      uint64(iotextypes.ReceiptStatus_Success),
+			BlockHeight: uint64(i),
+			ActionHash:  tsf.Hash(),
+			GasConsumed: 10000,
+		}
+		if i > 1 {
+			receipt.AddTransactionLogs(&action.TransactionLog{
+				Type:      iotextypes.TransactionLogType_NATIVE_TRANSFER,
+				Amount:    big.NewInt(int64(i)),
+				Sender:    identityset.Address(28).String(),
+				Recipient: identityset.Address(29).String(),
+			})
+		}
+		blk.Receipts = []*action.Receipt{receipt}
+		blks = append(blks, &blk)
+		prevHash = blk.HashBlock()
+	}
+	return blks
+}
+
+func TestExportImport(t *testing.T) {
+	require := require.New(t)
+	ctx := protocol.WithBlockchainCtx(context.Background(), protocol.BlockchainCtx{Genesis: config.Default.Genesis})
+	blks := getTestBlocks(t, 3)
+
+	for _, legacy := range []bool{true, false} {
+		dir, err := testutil.PathOfTempFile("iomigrater")
+		require.NoError(err)
+		testutil.CleanupPath(t, dir)
+		cfg := config.Default.DB
+		cfg.DbPath = filepath.Join(dir, "chain.db")
+		require.NoError(os.MkdirAll(dir, 0700))
+
+		// write the blocks into a db file in the legacy or the v2 format
+		var fd filedao.FileDAO
+		if legacy {
+			fd, err = filedao.CreateFileDAO(true, cfg)
+		} else {
+			fd, err = filedao.NewFileDAO(cfg)
+		}
+		require.NoError(err)
+		require.NoError(fd.Start(ctx))
+		for _, blk := range blks {
+			require.NoError(fd.PutBlock(ctx, blk))
+		}
+		require.True(fd.ContainsTransactionLog())
+		require.NoError(fd.Stop(ctx))
+
+		exportDbFile, exportOutput = cfg.DbPath, filepath.Join(dir, "blocks.stream")
+		exportStart, exportEnd = 1, 0
+		require.NoError(exportBlocks())
+		importInput, importNewFile = exportOutput, filepath.Join(dir, "imported.db")
+		importValidate = false
+		require.NoError(importBlocks())
+
+		cfg.DbPath = importNewFile
+		dao := blockdao.NewBlockDAO(nil, cfg)
+		require.NotNil(dao)
+		require.NoError(dao.Start(ctx))
+		height, err := dao.Height()
+		require.NoError(err)
+		require.EqualValues(len(blks), height)
+		for _, expected := range blks {
+			blk, err := dao.GetBlockByHeight(expected.Height())
+			require.NoError(err)
+			require.Equal(expected.HashBlock(), blk.HashBlock())
+			receipts, err := dao.GetReceipts(expected.Height())
+			require.NoError(err)
+			require.Len(receipts, 1)
+			require.Equal(expected.Receipts[0].Hash(), receipts[0].Hash())
+			logs, err := dao.TransactionLogs(expected.Height())
+			require.NoError(err)
+			if expected.Height() == 1 {
+				require.Empty(logs.GetLogs())
+			} else {
+				require.Len(logs.GetLogs(), 1)
+				require.Equal(expected.Receipts[0].TransactionLogs()[0].Amount.String(), logs.GetLogs()[0].GetTransactions()[0].GetAmount())
+			}
+		}
+		require.NoError(dao.Stop(ctx))
+		testutil.CleanupPath(t, dir)
+	}
+}
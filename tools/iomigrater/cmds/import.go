@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/schollz/progressbar/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/blockchain/blockstream"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
+	"github.com/iotexproject/iotex-core/server/itx"
+	"github.com/iotexproject/iotex-core/tools/iomigrater/common"
+)
+
+// Multi-language support
+var (
+	importCmdShorts = map[string]string{
+		"english": "Sub-Command for import a block stream file into IoTeX blockchain db file.",
+		"chinese": "将区块流文件导入IoTeX区块链 db 文件的子命令",
+	}
+	importCmdLongs = map[string]string{
+		"english": "Sub-Command for import a block stream file. The blocks are either validated and committed to the chain of the node config, or trusted and put into a new db file as is.",
+		"chinese": "导入区块流文件的子命令。区块或者经过验证后提交到节点配置的链上，或者被信任并直接写入新的 db 文件。",
+	}
+	importCmdUse = map[string]string{
+		"english": "import",
+		"chinese": "import",
+	}
+	importFlagInputUse = map[string]string{
+		"english": "The block stream file you want to import.",
+		"chinese": "您要导入的区块流文件。",
+	}
+	importFlagNewFileUse = map[string]string{
+		"english": "The new db file you want to import into without validation.",
+		"chinese": "您要在不验证的情况下导入的新 db 文件。",
+	}
+	importFlagValidateUse = map[string]string{
+		"english": "Validate the blocks and commit them to the chain of the node config, instead of importing into a new db file.",
+		"chinese": "验证区块并提交到节点配置的链上，而不是导入新的 db 文件。",
+	}
+	importFlagConfigPathUse = map[string]string{
+		"english": "The config file of the node to validate the blocks.",
+		"chinese": "用于验证区块的节点配置文件。",
+	}
+	importFlagGenesisPathUse = map[string]string{
+		"english": "The genesis file of the node to validate the blocks.",
+		"chinese": "用于验证区块的节点创世文件。",
+	}
+)
+
+var (
+	// Import used to Sub command.
+	Import = &cobra.Command{
+		Use:   common.TranslateInLang(importCmdUse),
+		Short: common.TranslateInLang(importCmdShorts),
+		Long:  common.TranslateInLang(importCmdLongs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return importBlocks()
+		},
+	}
+)
+
+var (
+	importInput       = ""
+	importNewFile     = ""
+	importValidate    = false
+	importConfigPath  = ""
+	importGenesisPath = ""
+)
+
+func init() {
+	Import.PersistentFlags().StringVarP(&importInput, "input", "f", "", common.TranslateInLang(importFlagInputUse))
+	Import.PersistentFlags().StringVarP(&importNewFile, "new-file", "n", "", common.TranslateInLang(importFlagNewFileUse))
+	Import.PersistentFlags().BoolVarP(&importValidate, "validate", "v", false, common.TranslateInLang(importFlagValidateUse))
+	Import.PersistentFlags().StringVar(&importConfigPath, "config-path", "", common.TranslateInLang(importFlagConfigPathUse))
+	Import.PersistentFlags().StringVar(&importGenesisPath, "genesis-path", "", common.TranslateInLang(importFlagGenesisPathUse))
+}
+
+func importBlocks() error {
+	// Check flags
+	if importInput == "" {
+		return fmt.Errorf("--input is empty")
+	}
+	if importValidate == (importNewFile != "") {
+		return fmt.Errorf("Either --validate or --new-file should be set")
+	}
+
+	file, err := os.Open(importInput)
+	if err != nil {
+		return fmt.Errorf("Failed to open the block stream file: %v", err)
+	}
+	defer file.Close()
+	r, err := blockstream.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("Failed to read the block stream file: %v", err)
+	}
+
+	if importValidate {
+		return importAndValidate(r)
+	}
+	return importTrusted(r)
+}
+
+// importAndValidate validates the blocks and commits them to the chain, as the blocks synced from the network
+func importAndValidate(r *blockstream.Reader) error {
+	// the node config is loaded from the paths of the flags shared with the server
+	if importConfigPath != "" {
+		if err := flag.Set("config-path", importConfigPath); err != nil {
+			return err
+		}
+	}
+	if importGenesisPath != "" {
+		if err := flag.Set("genesis-path", importGenesisPath); err != nil {
+			return err
+		}
+	}
+	genesisCfg, err := genesis.New()
+	if err != nil {
+		return fmt.Errorf("Failed to new genesis config: %v", err)
+	}
+	cfg, err := config.New()
+	if err != nil {
+		return fmt.Errorf("Failed to new config: %v", err)
+	}
+	cfg.Genesis = genesisCfg
+	if r.Header().GetChainID() != cfg.Chain.ID {
+		return fmt.Errorf("The chain id %d of the block stream does not match the config %d", r.Header().GetChainID(), cfg.Chain.ID)
+	}
+
+	svr, err := itx.NewServer(cfg)
+	if err != nil {
+		return fmt.Errorf("Failed to create server: %v", err)
+	}
+	bc := svr.ChainService(cfg.Chain.ID).Blockchain()
+	ctx := context.Background()
+	if err := bc.Start(ctx); err != nil {
+		return fmt.Errorf("Failed to start the blockchain: %v", err)
+	}
+	defer bc.Stop(ctx)
+	if tip := bc.TipHeight(); tip+1 != r.Header().GetStart() {
+		return fmt.Errorf("The block stream starts at height %d, but the chain is at height %d", r.Header().GetStart(), tip)
+	}
+
+	return readBlocks(r, func(blk *block.Block) error {
+		// the receipts are generated by executing the block
+		blk.Receipts = nil
+		if err := bc.ValidateBlock(blk); err != nil {
+			return err
+		}
+		return bc.CommitBlock(blk)
+	})
+}
+
+// importTrusted puts the blocks with their receipts and transaction logs into a new db file without validation
+func importTrusted(r *blockstream.Reader) error {
+	if fileutil.FileExists(importNewFile) {
+		return fmt.Errorf("The --new-file already exists")
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		return fmt.Errorf("Failed to new config: %v", err)
+	}
+	cfg.DB.DbPath = importNewFile
+	dao := blockdao.NewBlockDAO(nil, cfg.DB)
+	if dao == nil {
+		return fmt.Errorf("Failed to create the new db file")
+	}
+
+	// a stream not starting from genesis becomes the bottom of the new db file
+	if r.Header().GetStart() > 1 {
+		blk, err := r.Read()
+		if err != nil {
+			return fmt.Errorf("Failed to read block on height %d: %v", r.Header().GetStart(), err)
+		}
+		if err := filedao.ImportBottomBlock(cfg.DB, blk); err != nil {
+			return fmt.Errorf("Failed to import block on height %d: %v", blk.Height(), err)
+		}
+	}
+
+	ctx := protocol.WithBlockchainCtx(context.Background(), protocol.BlockchainCtx{Genesis: cfg.Genesis})
+	if err := dao.Start(ctx); err != nil {
+		return fmt.Errorf("Failed to start the new db file: %v", err)
+	}
+	defer dao.Stop(ctx)
+
+	return readBlocks(r, func(blk *block.Block) error {
+		return dao.PutBlock(ctx, blk)
+	})
+}
+
+func readBlocks(r *blockstream.Reader, put func(*block.Block) error) error {
+	header := r.Header()
+	// Show the progressbar
+	intHeight, step := getProgressMod(header.GetEnd() - header.GetStart() + 1)
+	bar := progressbar.New(intHeight)
+
+	for i := uint64(1); ; i++ {
+		blk, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to read block: %v", err)
+		}
+		if err := put(blk); err != nil {
+			return fmt.Errorf("Failed to import block on height %d: %v", blk.Height(), err)
+		}
+
+		if i%uint64(step) == 0 {
+			bar.Add(1)
+			intHeight--
+		}
+	}
+	if intHeight > 0 {
+		bar.Add(intHeight)
+	}
+	return nil
+}
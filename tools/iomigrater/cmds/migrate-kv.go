@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
+	"github.com/iotexproject/iotex-core/tools/iomigrater/common"
+)
+
+// Multi-language support
+var (
+	migrateKVCmdShorts = map[string]string{
+		"english": "Sub-Command for migration IoTeX state or index db file to another KV engine.",
+		"chinese": "迁移IoTeX状态或索引 db 文件到另一个KV引擎的子命令",
+	}
+	migrateKVCmdLongs = map[string]string{
+		"english": "Sub-Command for migration IoTeX state or index db file, such as trie.db and index.db, between bolt and leveldb KV engines. The chain db file cannot be migrated.",
+		"chinese": "在bolt和leveldb KV引擎之间迁移IoTeX状态或索引 db 文件（例如 trie.db 和 index.db）的子命令。区块链 db 文件不能迁移。",
+	}
+	migrateKVCmdUse = map[string]string{
+		"english": "migrate-kv",
+		"chinese": "migrate-kv",
+	}
+	migrateKVFlagOldFileUse = map[string]string{
+		"english": "The db file you want to migrate.",
+		"chinese": "您要迁移的 db 文件。",
+	}
+	migrateKVFlagOldEngineUse = map[string]string{
+		"english": "The KV engine of the db file you want to migrate, bolt or leveldb.",
+		"chinese": "您要迁移的 db 文件的KV引擎，bolt 或 leveldb。",
+	}
+	migrateKVFlagNewFileUse = map[string]string{
+		"english": "The path you want to migrate to, which must not exist.",
+		"chinese": "您要迁移到的路径，该路径必须不存在。",
+	}
+	migrateKVFlagNewEngineUse = map[string]string{
+		"english": "The KV engine you want to migrate to, bolt or leveldb.",
+		"chinese": "您要迁移到的KV引擎，bolt 或 leveldb。",
+	}
+	migrateKVFlagBatchSizeUse = map[string]string{
+		"english": "The number of records committed in a batch.",
+		"chinese": "每批提交的记录数量。",
+	}
+)
+
+var (
+	// MigrateKV Used to Sub command.
+	MigrateKV = &cobra.Command{
+		Use:   common.TranslateInLang(migrateKVCmdUse),
+		Short: common.TranslateInLang(migrateKVCmdShorts),
+		Long:  common.TranslateInLang(migrateKVCmdLongs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return migrateKVFile()
+		},
+	}
+)
+
+var (
+	migrateKVOldFile   = ""
+	migrateKVOldEngine = config.BoltDBEngine
+	migrateKVNewFile   = ""
+	migrateKVNewEngine = config.LevelDBEngine
+	migrateKVBatchSize = 10000
+)
+
+func init() {
+	MigrateKV.PersistentFlags().StringVarP(&migrateKVOldFile, "old-file", "o", "", common.TranslateInLang(migrateKVFlagOldFileUse))
+	MigrateKV.PersistentFlags().StringVarP(&migrateKVOldEngine, "old-engine", "", config.BoltDBEngine, common.TranslateInLang(migrateKVFlagOldEngineUse))
+	MigrateKV.PersistentFlags().StringVarP(&migrateKVNewFile, "new-file", "n", "", common.TranslateInLang(migrateKVFlagNewFileUse))
+	MigrateKV.PersistentFlags().StringVarP(&migrateKVNewEngine, "new-engine", "", config.LevelDBEngine, common.TranslateInLang(migrateKVFlagNewEngineUse))
+	MigrateKV.PersistentFlags().IntVarP(&migrateKVBatchSize, "batch-size", "s", 10000, common.TranslateInLang(migrateKVFlagBatchSizeUse))
+}
+
+func migrateKVFile() error {
+	// Check flags
+	if migrateKVOldFile == "" {
+		return fmt.Errorf("--old-file is empty")
+	}
+	if migrateKVNewFile == "" {
+		return fmt.Errorf("--new-file is empty")
+	}
+	if migrateKVOldFile == migrateKVNewFile {
+		return fmt.Errorf("The values of --old-file --new-file flags cannot be the same")
+	}
+	if !fileutil.FileExists(migrateKVOldFile) {
+		return fmt.Errorf("The db file %s does not exist", migrateKVOldFile)
+	}
+	if fileutil.FileExists(migrateKVNewFile) {
+		return fmt.Errorf("The path %s already exists", migrateKVNewFile)
+	}
+	if migrateKVBatchSize <= 0 {
+		return fmt.Errorf("--batch-size is not positive")
+	}
+	for _, engine := range []string{migrateKVOldEngine, migrateKVNewEngine} {
+		if engine != config.BoltDBEngine && engine != config.LevelDBEngine {
+			return fmt.Errorf("Unsupported KV engine %s", engine)
+		}
+	}
+
+	cfg := config.Default.DB
+	cfg.DbPath = migrateKVOldFile
+	cfg.KVEngine = migrateKVOldEngine
+	cfg.ReadOnly = true
+	oldKV, ok := db.NewOnDiskDB(cfg).(db.KVStoreWithForEach)
+	if !ok {
+		return fmt.Errorf("KV engine %s does not support iteration", migrateKVOldEngine)
+	}
+
+	cfg.DbPath = migrateKVNewFile
+	cfg.KVEngine = migrateKVNewEngine
+	cfg.ReadOnly = false
+	newKV := db.NewOnDiskDB(cfg)
+
+	ctx := context.Background()
+	if err := oldKV.Start(ctx); err != nil {
+		return fmt.Errorf("Failed to start the old db file: %v", err)
+	}
+	defer oldKV.Stop(ctx)
+	if err := newKV.Start(ctx); err != nil {
+		return fmt.Errorf("Failed to start the new db file: %v", err)
+	}
+	defer newKV.Stop(ctx)
+
+	var (
+		b     = batch.NewBatch()
+		total = 0
+	)
+	if err := oldKV.ForEach(func(ns string, k, v []byte) error {
+		b.Put(ns, k, v, "failed to put key %x", k)
+		if b.Size() < migrateKVBatchSize {
+			return nil
+		}
+		if err := newKV.WriteBatch(b); err != nil {
+			return err
+		}
+		total += b.Size()
+		b.Clear()
+		fmt.Printf("Migrated %d records\n", total)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("Failed to migrate the db file: %v", err)
+	}
+	if err := newKV.WriteBatch(b); err != nil {
+		return fmt.Errorf("Failed to migrate the db file: %v", err)
+	}
+	total += b.Size()
+	fmt.Printf("Migrated %d records from %s to %s\n", total, migrateKVOldFile, migrateKVNewFile)
+	return nil
+}
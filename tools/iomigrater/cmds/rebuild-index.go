@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/schollz/progressbar/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/blockindex"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/tools/iomigrater/common"
+)
+
+// Multi-language support
+var (
+	rebuildIndexCmdShorts = map[string]string{
+		"english": "Sub-Command for rebuild the index db files from IoTeX blockchain db file.",
+		"chinese": "从IoTeX区块链 db 文件重建索引 db 文件的子命令",
+	}
+	rebuildIndexCmdLongs = map[string]string{
+		"english": "Sub-Command for drop the index and bloom filter index db files, and rebuild them from IoTeX blockchain db file. An interrupted rebuild is resumed by running the command again.",
+		"chinese": "删除索引和布隆过滤器索引 db 文件，并从IoTeX区块链 db 文件重建它们的子命令。中断的重建可以通过再次运行该命令继续。",
+	}
+	rebuildIndexCmdUse = map[string]string{
+		"english": "rebuild-index",
+		"chinese": "rebuild-index",
+	}
+	rebuildIndexFlagDbFileUse = map[string]string{
+		"english": "The db file you want to rebuild the index from.",
+		"chinese": "您要从中重建索引的 db 文件。",
+	}
+	rebuildIndexFlagIndexFileUse = map[string]string{
+		"english": "The index db file you want to rebuild, empty to skip.",
+		"chinese": "您要重建的索引 db 文件，为空表示跳过。",
+	}
+	rebuildIndexFlagBloomFilterFileUse = map[string]string{
+		"english": "The bloom filter index db file you want to rebuild, empty to skip.",
+		"chinese": "您要重建的布隆过滤器索引 db 文件，为空表示跳过。",
+	}
+	rebuildIndexFlagWorkersUse = map[string]string{
+		"english": "The number of blocks read in parallel.",
+		"chinese": "并行读取的区块数量。",
+	}
+)
+
+var (
+	// RebuildIndex used to Sub command.
+	RebuildIndex = &cobra.Command{
+		Use:   common.TranslateInLang(rebuildIndexCmdUse),
+		Short: common.TranslateInLang(rebuildIndexCmdShorts),
+		Long:  common.TranslateInLang(rebuildIndexCmdLongs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return rebuildIndex()
+		},
+	}
+)
+
+var (
+	rebuildIndexDbFile          = ""
+	rebuildIndexIndexFile       = ""
+	rebuildIndexBloomFilterFile = ""
+	rebuildIndexWorkers         = runtime.NumCPU()
+)
+
+func init() {
+	RebuildIndex.PersistentFlags().StringVarP(&rebuildIndexDbFile, "db-file", "d", "", common.TranslateInLang(rebuildIndexFlagDbFileUse))
+	RebuildIndex.PersistentFlags().StringVarP(&rebuildIndexIndexFile, "index-file", "i", "", common.TranslateInLang(rebuildIndexFlagIndexFileUse))
+	RebuildIndex.PersistentFlags().StringVarP(&rebuildIndexBloomFilterFile, "bloomfilter-file", "b", "", common.TranslateInLang(rebuildIndexFlagBloomFilterFileUse))
+	RebuildIndex.PersistentFlags().IntVarP(&rebuildIndexWorkers, "workers", "w", runtime.NumCPU(), common.TranslateInLang(rebuildIndexFlagWorkersUse))
+}
+
+func rebuildIndex() error {
+	// Check flags
+	if rebuildIndexDbFile == "" {
+		return fmt.Errorf("--db-file is empty")
+	}
+	if rebuildIndexIndexFile == "" && rebuildIndexBloomFilterFile == "" {
+		return fmt.Errorf("both --index-file and --bloomfilter-file are empty")
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		return fmt.Errorf("Failed to new config: %v", err)
+	}
+	var names []string
+	if rebuildIndexIndexFile != "" {
+		cfg.Chain.IndexDBPath = rebuildIndexIndexFile
+		names = append(names, blockindex.IndexerBlock)
+	}
+	if rebuildIndexBloomFilterFile != "" {
+		cfg.Chain.BloomfilterIndexDBPath = rebuildIndexBloomFilterFile
+		names = append(names, blockindex.IndexerBloomFilter)
+	}
+
+	dbCfg := cfg.DB
+	dbCfg.DbPath = rebuildIndexDbFile
+	dbCfg.CompressLegacy = cfg.Chain.CompressBlock
+	dao, err := filedao.NewFileDAO(dbCfg)
+	if err != nil {
+		return fmt.Errorf("Failed to open the db file: %v", err)
+	}
+	ctx := context.Background()
+	if err := dao.Start(ctx); err != nil {
+		return fmt.Errorf("Failed to start the db file: %v", err)
+	}
+	defer dao.Stop(ctx)
+
+	// Show the progressbar of each indexer
+	var (
+		bar       *progressbar.ProgressBar
+		barName   string
+		intHeight int
+		step      int
+		done      int
+	)
+	progress := func(name string, height, tipHeight uint64) {
+		if name != barName {
+			barName = name
+			intHeight, step = getProgressMod(tipHeight)
+			bar = progressbar.New(intHeight)
+			done = 0
+			fmt.Printf("Rebuilding %s\n", name)
+		}
+		if n := int(height / uint64(step)); n > done && n <= intHeight {
+			bar.Add(n - done)
+			done = n
+		}
+		if height == tipHeight {
+			if done < intHeight {
+				bar.Add(intHeight - done)
+			}
+			fmt.Println()
+		}
+	}
+	rebuilder, err := blockindex.NewRebuilder(dao, cfg, blockindex.WithRebuildWorkers(rebuildIndexWorkers), blockindex.WithRebuildProgress(progress))
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := rebuilder.Rebuild(ctx, name); err != nil {
+			return fmt.Errorf("Failed to rebuild %s: %v", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"runtime"
+
+	"github.com/schollz/progressbar/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/blockchain/integrity"
+	"github.com/iotexproject/iotex-core/blockindex"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/tools/iomigrater/common"
+)
+
+// Multi-language support
+var (
+	verifyCmdShorts = map[string]string{
+		"english": "Sub-Command for verify the integrity of IoTeX blockchain db file.",
+		"chinese": "校验IoTeX区块链 db 文件完整性的子命令",
+	}
+	verifyCmdLongs = map[string]string{
+		"english": "Sub-Command for verify the block hashes, tx roots, receipt roots and header chaining of a height range in IoTeX blockchain db file, and cross-check the index and bloom filter db files against the blocks. The db files are opened read-only, and the damaged heights are reported in JSON.",
+		"chinese": "校验IoTeX区块链 db 文件中指定高度范围的区块哈希、交易根、收据根和区块头链接，并将索引和布隆过滤器 db 文件与区块进行交叉校验的子命令。db 文件以只读方式打开，损坏的高度以 JSON 格式报告。",
+	}
+	verifyCmdUse = map[string]string{
+		"english": "verify",
+		"chinese": "verify",
+	}
+	verifyFlagDbFileUse = map[string]string{
+		"english": "The db file you want to verify.",
+		"chinese": "您要校验的 db 文件。",
+	}
+	verifyFlagIndexFileUse = map[string]string{
+		"english": "The index db file you want to cross-check, empty to skip.",
+		"chinese": "您要交叉校验的索引 db 文件，为空表示跳过。",
+	}
+	verifyFlagBloomFilterFileUse = map[string]string{
+		"english": "The bloom filter index db file you want to cross-check, empty to skip.",
+		"chinese": "您要交叉校验的布隆过滤器索引 db 文件，为空表示跳过。",
+	}
+	verifyFlagStartUse = map[string]string{
+		"english": "The height of the first block to verify.",
+		"chinese": "要校验的第一个区块的高度。",
+	}
+	verifyFlagEndUse = map[string]string{
+		"english": "The height of the last block to verify, 0 for the height of the db file.",
+		"chinese": "要校验的最后一个区块的高度，0 表示 db 文件的高度。",
+	}
+	verifyFlagOutputUse = map[string]string{
+		"english": "The file you want to write the report to, empty for stdout.",
+		"chinese": "您要写入报告的文件，为空表示标准输出。",
+	}
+	verifyFlagWorkersUse = map[string]string{
+		"english": "The number of blocks verified in parallel.",
+		"chinese": "并行校验的区块数量。",
+	}
+)
+
+var (
+	// Verify used to Sub command.
+	Verify = &cobra.Command{
+		Use:   common.TranslateInLang(verifyCmdUse),
+		Short: common.TranslateInLang(verifyCmdShorts),
+		Long:  common.TranslateInLang(verifyCmdLongs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return verifyBlocks()
+		},
+	}
+)
+
+var (
+	verifyDbFile          = ""
+	verifyIndexFile       = ""
+	verifyBloomFilterFile = ""
+	verifyStart           = uint64(1)
+	verifyEnd             = uint64(0)
+	verifyOutput          = ""
+	verifyWorkers         = runtime.NumCPU()
+)
+
+func init() {
+	Verify.PersistentFlags().StringVarP(&verifyDbFile, "db-file", "d", "", common.TranslateInLang(verifyFlagDbFileUse))
+	Verify.PersistentFlags().StringVarP(&verifyIndexFile, "index-file", "i", "", common.TranslateInLang(verifyFlagIndexFileUse))
+	Verify.PersistentFlags().StringVarP(&verifyBloomFilterFile, "bloomfilter-file", "b", "", common.TranslateInLang(verifyFlagBloomFilterFileUse))
+	Verify.PersistentFlags().Uint64VarP(&verifyStart, "start", "s", uint64(1), common.TranslateInLang(verifyFlagStartUse))
+	Verify.PersistentFlags().Uint64VarP(&verifyEnd, "end", "e", uint64(0), common.TranslateInLang(verifyFlagEndUse))
+	Verify.PersistentFlags().StringVarP(&verifyOutput, "output", "f", "", common.TranslateInLang(verifyFlagOutputUse))
+	Verify.PersistentFlags().IntVarP(&verifyWorkers, "workers", "w", runtime.NumCPU(), common.TranslateInLang(verifyFlagWorkersUse))
+}
+
+func verifyBlocks() error {
+	// Check flags
+	if verifyDbFile == "" {
+		return fmt.Errorf("--db-file is empty")
+	}
+	if verifyStart == 0 {
+		return fmt.Errorf("--start is 0")
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		return fmt.Errorf("Failed to new config: %v", err)
+	}
+	// the db files of a node, which may be running, or a snapshot of them, are opened read-only and never written
+	cfg.DB.ReadOnly = true
+	cfg.DB.BlockDataRetention = 0
+	cfg.DB.DbPath = verifyDbFile
+	cfg.DB.CompressLegacy = cfg.Chain.CompressBlock
+	ctx := context.Background()
+	dao, err := filedao.NewFileDAO(cfg.DB)
+	if err != nil {
+		return fmt.Errorf("Failed to open the db file: %v", err)
+	}
+	if err := dao.Start(ctx); err != nil {
+		return fmt.Errorf("Failed to start the db file: %v", err)
+	}
+	defer dao.Stop(ctx)
+
+	var opts []integrity.Option
+	if verifyIndexFile != "" {
+		dbCfg := cfg.DB
+		dbCfg.DbPath = verifyIndexFile
+		indexer, err := blockindex.NewIndexer(db.NewOnDiskDB(dbCfg), cfg.Genesis.Hash())
+		if err != nil {
+			return err
+		}
+		if err := indexer.Start(ctx); err != nil {
+			return fmt.Errorf("Failed to start the index file: %v", err)
+		}
+		defer indexer.Stop(ctx)
+		opts = append(opts, integrity.WithIndexer(indexer))
+	}
+	if verifyBloomFilterFile != "" {
+		dbCfg := cfg.DB
+		dbCfg.DbPath = verifyBloomFilterFile
+		bfIndexer, err := blockindex.NewBloomfilterIndexer(db.NewOnDiskDB(dbCfg), cfg.Chain.RangeBloomFilterSize)
+		if err != nil {
+			return err
+		}
+		if err := bfIndexer.Start(ctx); err != nil {
+			return fmt.Errorf("Failed to start the bloom filter index file: %v", err)
+		}
+		defer bfIndexer.Stop(ctx)
+		opts = append(opts, integrity.WithBloomFilterIndexer(bfIndexer))
+	}
+
+	height, err := dao.Height()
+	if err != nil {
+		return err
+	}
+	end := verifyEnd
+	if end == 0 {
+		end = height
+	}
+	if end > height {
+		return fmt.Errorf("The --end cannot be larger than the height of the db file")
+	}
+	if verifyStart > end {
+		return fmt.Errorf("The --start cannot be larger than the --end")
+	}
+
+	// Show the progressbar
+	intHeight, step := getProgressMod(end - verifyStart + 1)
+	bar := progressbar.New(intHeight)
+	done := 0
+	opts = append(opts, integrity.WithWorkers(verifyWorkers), integrity.WithProgress(func(verified uint64) {
+		if n := int(verified / uint64(step)); n > done {
+			bar.Add(n - done)
+			done = n
+		}
+	}))
+
+	verifier, err := integrity.NewVerifier(dao, cfg.Genesis.Hash(), opts...)
+	if err != nil {
+		return err
+	}
+	report, err := verifier.Verify(ctx, verifyStart, end)
+	if err != nil {
+		return err
+	}
+	if done < intHeight {
+		bar.Add(intHeight - done)
+	}
+	fmt.Println()
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if verifyOutput == "" {
+		fmt.Println(string(data))
+	} else if err := ioutil.WriteFile(verifyOutput, data, 0644); err != nil {
+		return fmt.Errorf("Failed to write the report: %v", err)
+	}
+	if len(report.Damages) > 0 {
+		return fmt.Errorf("Found damages on %d heights", len(report.DamagedHeights()))
+	}
+	return nil
+}
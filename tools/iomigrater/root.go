@@ -48,6 +48,11 @@ func Execute() {
 func init() {
 	RootCmd.AddCommand(cmd.CheckHeight)
 	RootCmd.AddCommand(cmd.MigrateDb)
+	RootCmd.AddCommand(cmd.Export)
+	RootCmd.AddCommand(cmd.Import)
+	RootCmd.AddCommand(cmd.Verify)
+	RootCmd.AddCommand(cmd.RebuildIndex)
+	RootCmd.AddCommand(cmd.MigrateKV)
 
 	RootCmd.HelpFunc()
 }
@@ -0,0 +1,100 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// This is the reference remote signer, which keeps the private key of a block producer in an encrypted keystore file
+// off the node, and refuses to sign the blocks and endorsements violating the slashing protection rules.
+// The node connects with mutual TLS, authenticated by a client certificate issued by the client CA.
+// To use, run "make build-signer", and then
+// "SIGNER_KEYSTORE_PASSWORD=[string] ./bin/signer -port=[int] -keystore-path=[string] -protection-db-path=[string]
+// -tls-cert=[string] -tls-key=[string] -tls-client-ca=[string]"
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	glog "log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/signer"
+	"github.com/iotexproject/iotex-core/signer/signerserver"
+)
+
+// passwordEnv is the environment variable of the keystore password, which is not taken from the command line to keep
+// it out of the process list
+const passwordEnv = "SIGNER_KEYSTORE_PASSWORD"
+
+var (
+	// port is the port the signer listens on
+	port int
+	// keystorePath is the encrypted keystore file of the block producer
+	keystorePath string
+	// protectionDBPath is the file recording the messages signed for slashing protection
+	protectionDBPath string
+	// tlsCertPath and tlsKeyPath are the certificate and key of the signer server
+	tlsCertPath string
+	tlsKeyPath  string
+	// tlsClientCAPath is the CA certificate issuing the client certificates of the nodes
+	tlsClientCAPath string
+)
+
+func init() {
+	flag.IntVar(&port, "port", 14016, "Port to listen on")
+	flag.StringVar(&keystorePath, "keystore-path", "", "Keystore file of the block producer")
+	flag.StringVar(&protectionDBPath, "protection-db-path", "./signer.db", "Slashing protection db file")
+	flag.StringVar(&tlsCertPath, "tls-cert", "", "Certificate of the signer server")
+	flag.StringVar(&tlsKeyPath, "tls-key", "", "Key of the signer server certificate")
+	flag.StringVar(&tlsClientCAPath, "tls-client-ca", "", "CA certificate of the node client certificates")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr,
+			"usage: signer -port=[int]\n -keystore-path=[string]\n -protection-db-path=[string]\n"+
+				" -tls-cert=[string]\n -tls-key=[string]\n -tls-client-ca=[string]\n")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	flag.Parse()
+}
+
+func main() {
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	signal.Notify(stop, syscall.SIGTERM)
+
+	if keystorePath == "" {
+		glog.Fatalln("Keystore path is not specified.")
+	}
+	if tlsCertPath == "" || tlsKeyPath == "" || tlsClientCAPath == "" {
+		glog.Fatalln("TLS certificates are not specified.")
+	}
+	creds, err := signerserver.NewServerTLS(tlsCertPath, tlsKeyPath, tlsClientCAPath)
+	if err != nil {
+		glog.Fatalln("Failed to load the TLS certificates.", zap.Error(err))
+	}
+	s, err := signer.NewKeystoreSigner(keystorePath, os.Getenv(passwordEnv))
+	if err != nil {
+		glog.Fatalln("Failed to load the keystore.", zap.Error(err))
+	}
+	dbCfg := config.Default.DB
+	dbCfg.DbPath = protectionDBPath
+	svr := signerserver.NewServer(port, s, signerserver.NewProtection(db.NewBoltDB(dbCfg)), creds)
+
+	ctx := context.Background()
+	if err := svr.Start(ctx); err != nil {
+		log.L().Fatal("Failed to start signer server.", zap.Error(err))
+	}
+	log.L().Info("Signer server started.", zap.String("publicKey", s.PublicKey().HexString()))
+	<-stop
+	if err := svr.Stop(ctx); err != nil {
+		log.L().Panic("Failed to stop signer server.", zap.Error(err))
+	}
+}
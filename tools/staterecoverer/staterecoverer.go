@@ -93,7 +93,7 @@ func recoverChainAndState(dao blockdao.BlockDAO, sf factory.Factory, cfg config.
 	}
 	if targetHeight < stateHeight {
 		// delete existing state DB (build from scratch)
-		if fileutil.FileExists(cfg.Chain.TrieDBPath) && os.Remove(cfg.Chain.TrieDBPath) != nil {
+		if fileutil.FileExists(cfg.Chain.TrieDBPath) && os.RemoveAll(cfg.Chain.TrieDBPath) != nil {
 			return errors.New("failed to delete existing state DB")
 		}
 	}
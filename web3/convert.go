@@ -0,0 +1,440 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package web3
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+)
+
+// block numbers which are resolved against the tip of the chain
+const (
+	_latestBlock   = blockNumber(-1)
+	_pendingBlock  = blockNumber(-2)
+	_earliestBlock = blockNumber(0)
+)
+
+type (
+	// blockNumber is a block height, or one of the tags "latest", "pending" and "earliest"
+	blockNumber int64
+
+	// header is the Ethereum representation of a block header
+	header struct {
+		Number           hexutil.Uint64 `json:"number"`
+		Hash             string         `json:"hash"`
+		ParentHash       string         `json:"parentHash"`
+		Nonce            hexutil.Bytes  `json:"nonce"`
+		Sha3Uncles       string         `json:"sha3Uncles"`
+		LogsBloom        hexutil.Bytes  `json:"logsBloom"`
+		TransactionsRoot string         `json:"transactionsRoot"`
+		StateRoot        string         `json:"stateRoot"`
+		ReceiptsRoot     string         `json:"receiptsRoot"`
+		Miner            string         `json:"miner"`
+		Difficulty       hexutil.Uint64 `json:"difficulty"`
+		ExtraData        hexutil.Bytes  `json:"extraData"`
+		Size             hexutil.Uint64 `json:"size"`
+		GasLimit         hexutil.Uint64 `json:"gasLimit"`
+		GasUsed          hexutil.Uint64 `json:"gasUsed"`
+		Timestamp        hexutil.Uint64 `json:"timestamp"`
+	}
+
+	// blockObject is the Ethereum representation of a block, whose transactions are either hashes or objects
+	blockObject struct {
+		header
+		TotalDifficulty hexutil.Uint64 `json:"totalDifficulty"`
+		Transactions    []interface{}  `json:"transactions"`
+		Uncles          []string       `json:"uncles"`
+	}
+
+	// transaction is the Ethereum representation of an action
+	transaction struct {
+		BlockHash        *string         `json:"blockHash"`
+		BlockNumber      *hexutil.Uint64 `json:"blockNumber"`
+		From             string          `json:"from"`
+		Gas              hexutil.Uint64  `json:"gas"`
+		GasPrice         *hexutil.Big    `json:"gasPrice"`
+		Hash             string          `json:"hash"`
+		Input            hexutil.Bytes   `json:"input"`
+		Nonce            hexutil.Uint64  `json:"nonce"`
+		To               *string         `json:"to"`
+		TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
+		Value            *hexutil.Big    `json:"value"`
+		V                hexutil.Uint64  `json:"v"`
+		R                hexutil.Bytes   `json:"r"`
+		S                hexutil.Bytes   `json:"s"`
+	}
+
+	// receiptObject is the Ethereum representation of a receipt
+	receiptObject struct {
+		BlockHash         string         `json:"blockHash"`
+		BlockNumber       hexutil.Uint64 `json:"blockNumber"`
+		ContractAddress   *string        `json:"contractAddress"`
+		CumulativeGasUsed hexutil.Uint64 `json:"cumulativeGasUsed"`
+		From              string         `json:"from"`
+		GasUsed           hexutil.Uint64 `json:"gasUsed"`
+		Logs              []*logObject   `json:"logs"`
+		LogsBloom         hexutil.Bytes  `json:"logsBloom"`
+		Status            hexutil.Uint64 `json:"status"`
+		To                *string        `json:"to"`
+		TransactionHash   string         `json:"transactionHash"`
+		TransactionIndex  hexutil.Uint64 `json:"transactionIndex"`
+	}
+
+	// logObject is the Ethereum representation of a log
+	logObject struct {
+		Address          string         `json:"address"`
+		Topics           []string       `json:"topics"`
+		Data             hexutil.Bytes  `json:"data"`
+		BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+		BlockHash        string         `json:"blockHash"`
+		TransactionHash  string         `json:"transactionHash"`
+		TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+		LogIndex         hexutil.Uint64 `json:"logIndex"`
+		Removed          bool           `json:"removed"`
+	}
+
+	// blockData is a block along with its receipts, from which the Ethereum objects are built
+	blockData struct {
+		blk      *block.Block
+		receipts []*iotextypes.Receipt
+		size     uint64
+	}
+)
+
+// UnmarshalJSON parses a block tag or a hex encoded block number
+func (bn *blockNumber) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrap(err, "block number must be a string")
+	}
+	switch s {
+	case "latest":
+		*bn = _latestBlock
+	case "pending":
+		*bn = _pendingBlock
+	case "earliest":
+		*bn = _earliestBlock
+	default:
+		height, err := hexutil.DecodeUint64(s)
+		if err != nil {
+			return errors.Wrapf(err, "invalid block number %s", s)
+		}
+		*bn = blockNumber(height)
+	}
+	return nil
+}
+
+// ioAddress converts an Ethereum hex address or an IoTeX address to an IoTeX address
+func ioAddress(addr string) (string, error) {
+	if strings.HasPrefix(addr, "0x") || strings.HasPrefix(addr, "0X") {
+		if !common.IsHexAddress(addr) {
+			return "", errInvalidParams("invalid address %s", addr)
+		}
+		ioAddr, err := address.FromBytes(common.HexToAddress(addr).Bytes())
+		if err != nil {
+			return "", errInvalidParams("invalid address %s", addr)
+		}
+		return ioAddr.String(), nil
+	}
+	if _, err := address.FromString(addr); err != nil {
+		return "", errInvalidParams("invalid address %s", addr)
+	}
+	return addr, nil
+}
+
+// ethAddress converts an IoTeX address to an Ethereum hex address, which is empty if the address is empty or invalid
+func ethAddress(ioAddr string) string {
+	addr, err := address.FromString(ioAddr)
+	if err != nil {
+		return ""
+	}
+	return common.BytesToAddress(addr.Bytes()).Hex()
+}
+
+func ethAddressOrNil(ioAddr string) *string {
+	if addr := ethAddress(ioAddr); addr != "" {
+		return &addr
+	}
+	return nil
+}
+
+func hexHash(h hash.Hash256) string {
+	return "0x" + hex.EncodeToString(h[:])
+}
+
+func hexBytesHash(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// actionHash converts a 0x prefixed transaction hash to the hex string the api service accepts
+func actionHash(h string) (string, error) {
+	b, err := hexutil.Decode(h)
+	if err != nil || len(b) != len(hash.ZeroHash256) {
+		return "", errInvalidParams("invalid transaction hash %s", h)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// newBlockData builds a block from its protobuf
+func newBlockData(info *iotexapi.BlockInfo) (*blockData, error) {
+	blk := &block.Block{}
+	if err := blk.ConvertFromBlockPb(info.GetBlock()); err != nil {
+		return nil, errors.Wrap(err, "failed to load block")
+	}
+	return &blockData{
+		blk:      blk,
+		receipts: info.GetReceipts(),
+		size:     uint64(proto.Size(info.GetBlock())),
+	}, nil
+}
+
+func (bd *blockData) hash() string {
+	return hexHash(bd.blk.HashBlock())
+}
+
+func (bd *blockData) height() uint64 {
+	return bd.blk.Height()
+}
+
+// index returns the index of the action in the block, or -1 if the action is not in the block
+func (bd *blockData) index(actHash hash.Hash256) int {
+	for i := range bd.blk.Actions {
+		if bd.blk.Actions[i].Hash() == actHash {
+			return i
+		}
+	}
+	return -1
+}
+
+func (bd *blockData) header(gasLimit uint64) header {
+	var gasUsed uint64
+	for _, receipt := range bd.receipts {
+		gasUsed += receipt.GetGasConsumed()
+	}
+	prevHash := bd.blk.PrevHash()
+	txRoot := bd.blk.TxRoot()
+	stateRoot := bd.blk.DeltaStateDigest()
+	receiptRoot := bd.blk.ReceiptRoot()
+	return header{
+		Number:           hexutil.Uint64(bd.height()),
+		Hash:             bd.hash(),
+		ParentHash:       hexHash(prevHash),
+		Nonce:            make([]byte, 8),
+		Sha3Uncles:       types.EmptyUncleHash.Hex(),
+		LogsBloom:        logsBloom(bd.receipts),
+		TransactionsRoot: hexHash(txRoot),
+		StateRoot:        hexHash(stateRoot),
+		ReceiptsRoot:     hexHash(receiptRoot),
+		Miner:            ethAddress(bd.blk.ProducerAddress()),
+		ExtraData:        []byte{},
+		Size:             hexutil.Uint64(bd.size),
+		GasLimit:         hexutil.Uint64(gasLimit),
+		GasUsed:          hexutil.Uint64(gasUsed),
+		Timestamp:        hexutil.Uint64(bd.blk.Timestamp().Unix()),
+	}
+}
+
+func (bd *blockData) blockObject(gasLimit uint64, fullTx bool) *blockObject {
+	obj := &blockObject{
+		header:       bd.header(gasLimit),
+		Transactions: make([]interface{}, 0, len(bd.blk.Actions)),
+		Uncles:       []string{},
+	}
+	blkHash := bd.hash()
+	for i := range bd.blk.Actions {
+		if fullTx {
+			obj.Transactions = append(obj.Transactions, newTransaction(&bd.blk.Actions[i], &blkHash, bd.height(), i))
+		} else {
+			obj.Transactions = append(obj.Transactions, hexHash(bd.blk.Actions[i].Hash()))
+		}
+	}
+	return obj
+}
+
+// receiptObject returns the receipt of the action at index i of the block
+func (bd *blockData) receiptObject(i int) *receiptObject {
+	selp := &bd.blk.Actions[i]
+	var (
+		receipt    *iotextypes.Receipt
+		cumulative uint64
+		logIndex   uint64
+	)
+	actHash := selp.Hash()
+	for _, r := range bd.receipts {
+		cumulative += r.GetGasConsumed()
+		if hash.BytesToHash256(r.GetActHash()) == actHash {
+			receipt = r
+			break
+		}
+		logIndex += uint64(len(r.GetLogs()))
+	}
+	if receipt == nil {
+		return nil
+	}
+	tx := newTransaction(selp, nil, 0, i)
+	status := uint64(0)
+	if receipt.GetStatus() == uint64(iotextypes.ReceiptStatus_Success) {
+		status = 1
+	}
+	obj := &receiptObject{
+		BlockHash:         bd.hash(),
+		BlockNumber:       hexutil.Uint64(bd.height()),
+		ContractAddress:   ethAddressOrNil(receipt.GetContractAddress()),
+		CumulativeGasUsed: hexutil.Uint64(cumulative),
+		From:              tx.From,
+		GasUsed:           hexutil.Uint64(receipt.GetGasConsumed()),
+		Logs:              make([]*logObject, 0, len(receipt.GetLogs())),
+		LogsBloom:         logsBloom([]*iotextypes.Receipt{receipt}),
+		Status:            hexutil.Uint64(status),
+		To:                tx.To,
+		TransactionHash:   tx.Hash,
+		TransactionIndex:  hexutil.Uint64(i),
+	}
+	for j, l := range receipt.GetLogs() {
+		obj.Logs = append(obj.Logs, bd.logObject(l, i, logIndex+uint64(j)))
+	}
+	return obj
+}
+
+// logObjects returns the logs of the block matching the given logs returned by the api service
+func (bd *blockData) logObjects(logs []*iotextypes.Log) []*logObject {
+	var (
+		objs     []*logObject
+		logIndex uint64
+	)
+	for _, r := range bd.receipts {
+		actHash := hash.BytesToHash256(r.GetActHash())
+		for _, l := range r.GetLogs() {
+			for _, target := range logs {
+				if hash.BytesToHash256(target.GetActHash()) == actHash && target.GetIndex() == l.GetIndex() {
+					objs = append(objs, bd.logObject(l, bd.index(actHash), logIndex))
+					break
+				}
+			}
+			logIndex++
+		}
+	}
+	return objs
+}
+
+func (bd *blockData) logObject(l *iotextypes.Log, txIndex int, logIndex uint64) *logObject {
+	obj := &logObject{
+		Address:          ethAddress(l.GetContractAddress()),
+		Topics:           make([]string, 0, len(l.GetTopics())),
+		Data:             l.GetData(),
+		BlockNumber:      hexutil.Uint64(bd.height()),
+		BlockHash:        bd.hash(),
+		TransactionHash:  hexBytesHash(l.GetActHash()),
+		TransactionIndex: hexutil.Uint64(txIndex),
+		LogIndex:         hexutil.Uint64(logIndex),
+	}
+	if obj.Data == nil {
+		obj.Data = []byte{}
+	}
+	for _, topic := range l.GetTopics() {
+		obj.Topics = append(obj.Topics, hexBytesHash(topic))
+	}
+	return obj
+}
+
+// newTransaction converts an action to an Ethereum transaction, which is pending if the block hash is nil
+func newTransaction(selp *action.SealedEnvelope, blkHash *string, height uint64, index int) *transaction {
+	tx := &transaction{
+		From:     common.BytesToAddress(selp.SrcPubkey().Hash()).Hex(),
+		Gas:      hexutil.Uint64(selp.GasLimit()),
+		GasPrice: (*hexutil.Big)(selp.GasPrice()),
+		Hash:     hexHash(selp.Hash()),
+		Input:    []byte{},
+		Nonce:    hexutil.Uint64(selp.Nonce()),
+		Value:    (*hexutil.Big)(new(big.Int)),
+	}
+	if blkHash != nil {
+		blkNum := hexutil.Uint64(height)
+		txIndex := hexutil.Uint64(index)
+		tx.BlockHash, tx.BlockNumber, tx.TransactionIndex = blkHash, &blkNum, &txIndex
+	}
+	switch act := selp.Action().(type) {
+	case *action.Transfer:
+		tx.To = ethAddressOrNil(act.Recipient())
+		tx.Value = (*hexutil.Big)(act.Amount())
+		tx.Input = act.Payload()
+	case *action.Execution:
+		tx.To = ethAddressOrNil(act.Contract())
+		tx.Value = (*hexutil.Big)(act.Amount())
+		tx.Input = act.Data()
+	}
+	if tx.Input == nil {
+		tx.Input = []byte{}
+	}
+	if sig := selp.Signature(); len(sig) == 65 {
+		tx.R, tx.S = sig[:32], sig[32:64]
+		v := uint64(sig[64])
+		switch {
+		case selp.Encoding() == action.EthereumRLP:
+			v += uint64(selp.EVMNetworkID())*2 + 35
+		case v < 27:
+			v += 27
+		}
+		tx.V = hexutil.Uint64(v)
+	}
+	return tx
+}
+
+// ethSignature returns the signature of the Ethereum transaction on the EVM network in the [R || S || V] format, where
+// V is the recovery ID
+func ethSignature(tx *types.Transaction, evmNetworkID uint32) []byte {
+	v, r, s := tx.RawSignatureValues()
+	recID := new(big.Int).Sub(v, new(big.Int).SetUint64(uint64(evmNetworkID)*2+35))
+	sig := make([]byte, 65)
+	copy(sig[32-len(r.Bytes()):32], r.Bytes())
+	copy(sig[64-len(s.Bytes()):64], s.Bytes())
+	sig[64] = byte(recID.Uint64())
+	return sig
+}
+
+// logsBloom computes the Ethereum bloom filter of the logs in the receipts
+func logsBloom(receipts []*iotextypes.Receipt) hexutil.Bytes {
+	var logs []*types.Log
+	for _, r := range receipts {
+		for _, l := range r.GetLogs() {
+			addr, err := address.FromString(l.GetContractAddress())
+			if err != nil {
+				continue
+			}
+			ethLog := &types.Log{Address: common.BytesToAddress(addr.Bytes())}
+			for _, topic := range l.GetTopics() {
+				ethLog.Topics = append(ethLog.Topics, common.BytesToHash(topic))
+			}
+			logs = append(logs, ethLog)
+		}
+	}
+	bloom := types.BytesToBloom(types.LogsBloom(logs).Bytes())
+	return bloom.Bytes()
+}
+
+func loadAction(pb *iotextypes.Action) (*action.SealedEnvelope, error) {
+	selp := &action.SealedEnvelope{}
+	if err := selp.LoadProto(pb); err != nil {
+		return nil, errors.Wrap(err, "failed to load action")
+	}
+	return selp, nil
+}
@@ -0,0 +1,743 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package web3
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/api"
+	"github.com/iotexproject/iotex-core/api/apipb"
+)
+
+type (
+	// callArgs are the arguments of eth_call and eth_estimateGas
+	callArgs struct {
+		From     string          `json:"from"`
+		To       string          `json:"to"`
+		Gas      *hexutil.Uint64 `json:"gas"`
+		GasPrice *hexutil.Big    `json:"gasPrice"`
+		Value    *hexutil.Big    `json:"value"`
+		Data     *hexutil.Bytes  `json:"data"`
+		Input    *hexutil.Bytes  `json:"input"`
+	}
+
+	// filterArgs are the arguments of eth_getLogs and the logs subscription
+	filterArgs struct {
+		BlockHash *string           `json:"blockHash"`
+		FromBlock *blockNumber      `json:"fromBlock"`
+		ToBlock   *blockNumber      `json:"toBlock"`
+		Address   json.RawMessage   `json:"address"`
+		Topics    []json.RawMessage `json:"topics"`
+	}
+
+	// traceConfig is the options of debug_traceTransaction and debug_traceCall
+	traceConfig struct {
+		Tracer         string `json:"tracer"`
+		DisableStack   bool   `json:"disableStack"`
+		EnableMemory   bool   `json:"enableMemory"`
+		DisableStorage bool   `json:"disableStorage"`
+		Limit          uint64 `json:"limit"`
+	}
+)
+
+var _handlers = map[string]handler{
+	"web3_clientVersion":        (*Server).clientVersion,
+	"web3_sha3":                 (*Server).sha3,
+	"net_version":               (*Server).netVersion,
+	"net_listening":             (*Server).netListening,
+	"eth_chainId":               (*Server).chainID,
+	"eth_syncing":               (*Server).syncing,
+	"eth_accounts":              (*Server).accounts,
+	"eth_blockNumber":           (*Server).blockNumber,
+	"eth_gasPrice":              (*Server).gasPrice,
+	"eth_getBalance":            (*Server).getBalance,
+	"eth_getTransactionCount":   (*Server).getTransactionCount,
+	"eth_call":                  (*Server).call,
+	"eth_estimateGas":           (*Server).estimateGas,
+	"eth_sendRawTransaction":    (*Server).sendRawTransaction,
+	"eth_getTransactionByHash":  (*Server).getTransactionByHash,
+	"eth_getTransactionReceipt": (*Server).getTransactionReceipt,
+	"eth_getBlockByNumber":      (*Server).getBlockByNumber,
+	"eth_getBlockByHash":        (*Server).getBlockByHash,
+	"eth_getLogs":               (*Server).getLogs,
+	"debug_traceTransaction":    (*Server).traceTransaction,
+	"debug_traceCall":           (*Server).traceCall,
+}
+
+func (svr *Server) clientVersion(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	res, err := svr.backend.GetServerMeta(ctx, &iotexapi.GetServerMetaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	meta := res.GetServerMeta()
+	return "iotex-core/" + meta.GetPackageVersion() + "/" + meta.GetGoVersion(), nil
+}
+
+func (svr *Server) sha3(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var data hexutil.Bytes
+	if err := parseParams(params, 1, &data); err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(crypto.Keccak256(data)), nil
+}
+
+func (svr *Server) netVersion(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	return strconv.FormatUint(uint64(svr.evmNetworkID), 10), nil
+}
+
+func (svr *Server) netListening(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	return true, nil
+}
+
+func (svr *Server) chainID(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	return hexutil.Uint64(svr.evmNetworkID), nil
+}
+
+func (svr *Server) syncing(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	return false, nil
+}
+
+func (svr *Server) accounts(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	return []string{}, nil
+}
+
+func (svr *Server) blockNumber(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	tip, err := svr.tipHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Uint64(tip), nil
+}
+
+func (svr *Server) gasPrice(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	res, err := svr.backend.SuggestGasPrice(ctx, &iotexapi.SuggestGasPriceRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Uint64(res.GetGasPrice()), nil
+}
+
+func (svr *Server) getBalance(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var (
+		addr string
+		bn   = _latestBlock
+	)
+	if err := parseParams(params, 1, &addr, &bn); err != nil {
+		return nil, err
+	}
+	meta, err := svr.accountMeta(ctx, addr, bn)
+	if err != nil {
+		return nil, err
+	}
+	balance, ok := new(big.Int).SetString(meta.GetBalance(), 10)
+	if !ok {
+		return nil, errServer("invalid balance %s", meta.GetBalance())
+	}
+	return (*hexutil.Big)(balance), nil
+}
+
+func (svr *Server) getTransactionCount(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var (
+		addr string
+		bn   = _latestBlock
+	)
+	if err := parseParams(params, 1, &addr, &bn); err != nil {
+		return nil, err
+	}
+	meta, err := svr.accountMeta(ctx, addr, bn)
+	if err != nil {
+		return nil, err
+	}
+	if bn == _pendingBlock {
+		return hexutil.Uint64(meta.GetPendingNonce()), nil
+	}
+	return hexutil.Uint64(meta.GetNonce()), nil
+}
+
+func (svr *Server) call(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var (
+		args callArgs
+		bn   = _latestBlock
+	)
+	if err := parseParams(params, 1, &args, &bn); err != nil {
+		return nil, err
+	}
+	caller, err := args.caller()
+	if err != nil {
+		return nil, err
+	}
+	if args.To == "" {
+		return nil, errInvalidParams("missing contract address")
+	}
+	contract, err := ioAddress(args.To)
+	if err != nil {
+		return nil, err
+	}
+	res, err := svr.backend.ReadContract(stateContext(ctx, bn), &iotexapi.ReadContractRequest{
+		Execution: &iotextypes.Execution{
+			Amount:   args.value().String(),
+			Contract: contract,
+			Data:     args.data(),
+		},
+		CallerAddress: caller,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := hex.DecodeString(res.GetData())
+	if err != nil {
+		return nil, errServer("invalid return value %s", res.GetData())
+	}
+	switch status := res.GetReceipt().GetStatus(); status {
+	case uint64(iotextypes.ReceiptStatus_Success):
+		return hexutil.Bytes(data), nil
+	case uint64(iotextypes.ReceiptStatus_ErrExecutionReverted):
+		msg := "execution reverted"
+		if reason := evm.DecodeRevertReason(data); reason != "" {
+			msg += ": " + reason
+		}
+		return nil, &Error{Code: _errCodeExecutionRevert, Message: msg, Data: hexutil.Bytes(data)}
+	default:
+		return nil, errServer("execution failed with status %d", status)
+	}
+}
+
+func (svr *Server) estimateGas(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var args callArgs
+	if err := parseParams(params, 1, &args); err != nil {
+		return nil, err
+	}
+	caller, err := args.caller()
+	if err != nil {
+		return nil, err
+	}
+	var to string
+	if args.To != "" {
+		if to, err = ioAddress(args.To); err != nil {
+			return nil, err
+		}
+	}
+	req := &iotexapi.EstimateActionGasConsumptionRequest{CallerAddress: caller}
+	data := args.data()
+	if to == "" || len(data) > 0 {
+		req.Action = &iotexapi.EstimateActionGasConsumptionRequest_Execution{
+			Execution: &iotextypes.Execution{
+				Amount:   args.value().String(),
+				Contract: to,
+				Data:     data,
+			},
+		}
+	} else {
+		req.Action = &iotexapi.EstimateActionGasConsumptionRequest_Transfer{
+			Transfer: &iotextypes.Transfer{
+				Amount:    args.value().String(),
+				Recipient: to,
+			},
+		}
+	}
+	res, err := svr.backend.EstimateActionGasConsumption(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Uint64(res.GetGas()), nil
+}
+
+func (svr *Server) sendRawTransaction(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var raw hexutil.Bytes
+	if err := parseParams(params, 1, &raw); err != nil {
+		return nil, err
+	}
+	var actPb *iotextypes.Action
+	tx := &types.Transaction{}
+	if err := rlp.DecodeBytes(raw, tx); err == nil {
+		selp, err := svr.ethTxToAction(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		actPb = selp.Proto()
+	} else {
+		// a signed IoTeX action is accepted as well
+		actPb = &iotextypes.Action{}
+		if err := proto.Unmarshal(raw, actPb); err != nil || actPb.GetCore() == nil || len(actPb.GetSignature()) == 0 {
+			return nil, errInvalidParams("raw transaction is neither an Ethereum transaction nor a signed IoTeX action")
+		}
+	}
+	res, err := svr.backend.SendAction(ctx, &iotexapi.SendActionRequest{Action: actPb})
+	if err != nil {
+		return nil, err
+	}
+	return "0x" + res.GetActionHash(), nil
+}
+
+// ethTxToAction converts the signed Ethereum transaction to an action signed as it. The transaction is an execution if
+// it deploys a contract, carries data or calls a contract, or a transfer otherwise
+func (svr *Server) ethTxToAction(ctx context.Context, tx *types.Transaction) (action.SealedEnvelope, error) {
+	if !tx.Protected() || tx.ChainId().Cmp(new(big.Int).SetUint64(uint64(svr.evmNetworkID))) != 0 {
+		return action.SealedEnvelope{}, errInvalidParams("transaction is not signed for chain ID %d", svr.evmNetworkID)
+	}
+	var (
+		to         string
+		isContract bool
+	)
+	if tx.To() != nil {
+		addr, err := address.FromBytes(tx.To().Bytes())
+		if err != nil {
+			return action.SealedEnvelope{}, errInvalidParams("invalid recipient %s", tx.To().Hex())
+		}
+		to = addr.String()
+		if len(tx.Data()) == 0 {
+			res, err := svr.backend.GetAccount(ctx, &iotexapi.GetAccountRequest{Address: to})
+			switch {
+			case isNotFound(err):
+			case err != nil:
+				return action.SealedEnvelope{}, err
+			default:
+				isContract = res.GetAccountMeta().GetIsContract()
+			}
+		}
+	}
+	bd := (&action.EnvelopeBuilder{}).
+		SetNonce(tx.Nonce()).
+		SetGasLimit(tx.Gas()).
+		SetGasPrice(tx.GasPrice())
+	if to == action.EmptyAddress || len(tx.Data()) > 0 || isContract {
+		exec, err := action.NewExecution(to, tx.Nonce(), tx.Value(), tx.Gas(), tx.GasPrice(), tx.Data())
+		if err != nil {
+			return action.SealedEnvelope{}, errInvalidParams("%v", err)
+		}
+		bd.SetAction(exec)
+	} else {
+		tsf, err := action.NewTransfer(tx.Nonce(), tx.Value(), to, nil, tx.Gas(), tx.GasPrice())
+		if err != nil {
+			return action.SealedEnvelope{}, errInvalidParams("%v", err)
+		}
+		bd.SetAction(tsf)
+	}
+	elp := bd.Build()
+	selp, err := action.SealEthTx(elp, svr.evmNetworkID, ethSignature(tx, svr.evmNetworkID))
+	if err != nil {
+		return action.SealedEnvelope{}, errInvalidParams("%v", err)
+	}
+	return selp, nil
+}
+
+func (svr *Server) getTransactionByHash(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var h string
+	if err := parseParams(params, 1, &h); err != nil {
+		return nil, err
+	}
+	actHash, err := actionHash(h)
+	if err != nil {
+		return nil, err
+	}
+	res, err := svr.backend.GetActions(ctx, &iotexapi.GetActionsRequest{
+		Lookup: &iotexapi.GetActionsRequest_ByHash{
+			ByHash: &iotexapi.GetActionByHashRequest{ActionHash: actHash, CheckPending: true},
+		},
+	})
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(res.GetActionInfo()) == 0 {
+		return nil, nil
+	}
+	info := res.GetActionInfo()[0]
+	if info.GetBlkHeight() == 0 {
+		// the action is pending in actpool
+		selp, err := loadAction(info.GetAction())
+		if err != nil {
+			return nil, err
+		}
+		return newTransaction(selp, nil, 0, 0), nil
+	}
+	bd, err := svr.blockData(ctx, info.GetBlkHeight())
+	if err != nil {
+		return nil, err
+	}
+	i := bd.index(hash.BytesToHash256(hexutil.MustDecode(h)))
+	if i < 0 {
+		return nil, nil
+	}
+	blkHash := bd.hash()
+	return newTransaction(&bd.blk.Actions[i], &blkHash, bd.height(), i), nil
+}
+
+func (svr *Server) getTransactionReceipt(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var h string
+	if err := parseParams(params, 1, &h); err != nil {
+		return nil, err
+	}
+	actHash, err := actionHash(h)
+	if err != nil {
+		return nil, err
+	}
+	res, err := svr.backend.GetReceiptByAction(ctx, &iotexapi.GetReceiptByActionRequest{ActionHash: actHash})
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	bd, err := svr.blockData(ctx, res.GetReceiptInfo().GetReceipt().GetBlkHeight())
+	if err != nil {
+		return nil, err
+	}
+	i := bd.index(hash.BytesToHash256(hexutil.MustDecode(h)))
+	if i < 0 {
+		return nil, nil
+	}
+	if receipt := bd.receiptObject(i); receipt != nil {
+		return receipt, nil
+	}
+	return nil, nil
+}
+
+func (svr *Server) getBlockByNumber(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var (
+		bn     blockNumber
+		fullTx bool
+	)
+	if err := parseParams(params, 2, &bn, &fullTx); err != nil {
+		return nil, err
+	}
+	height, err := svr.height(ctx, bn)
+	if err != nil {
+		return nil, err
+	}
+	bd, err := svr.blockData(ctx, height)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bd.blockObject(svr.gasLimit, fullTx), nil
+}
+
+func (svr *Server) getBlockByHash(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var (
+		h      string
+		fullTx bool
+	)
+	if err := parseParams(params, 2, &h, &fullTx); err != nil {
+		return nil, err
+	}
+	height, err := svr.heightByHash(ctx, h)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	bd, err := svr.blockData(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return bd.blockObject(svr.gasLimit, fullTx), nil
+}
+
+func (svr *Server) getLogs(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var args filterArgs
+	if err := parseParams(params, 1, &args); err != nil {
+		return nil, err
+	}
+	filter, err := args.logsFilter()
+	if err != nil {
+		return nil, err
+	}
+	req := &iotexapi.GetLogsRequest{Filter: filter}
+	if args.BlockHash != nil {
+		b, err := hexutil.Decode(*args.BlockHash)
+		if err != nil {
+			return nil, errInvalidParams("invalid block hash %s", *args.BlockHash)
+		}
+		req.Lookup = &iotexapi.GetLogsRequest_ByBlock{ByBlock: &iotexapi.GetLogsByBlock{BlockHash: b}}
+	} else {
+		from, to := _latestBlock, _latestBlock
+		if args.FromBlock != nil {
+			from = *args.FromBlock
+		}
+		if args.ToBlock != nil {
+			to = *args.ToBlock
+		}
+		fromHeight, err := svr.height(ctx, from)
+		if err != nil {
+			return nil, err
+		}
+		toHeight, err := svr.height(ctx, to)
+		if err != nil {
+			return nil, err
+		}
+		if fromHeight == 0 {
+			fromHeight = 1
+		}
+		if fromHeight > toHeight {
+			return []*logObject{}, nil
+		}
+		req.Lookup = &iotexapi.GetLogsRequest_ByRange{
+			ByRange: &iotexapi.GetLogsByRange{FromBlock: fromHeight, ToBlock: toHeight},
+		}
+	}
+	res, err := svr.backend.GetLogs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// group the logs by block to look up the block hash, the transaction index and the log index in block
+	var (
+		heights []uint64
+		logs    = make(map[uint64][]*iotextypes.Log)
+		objs    = []*logObject{}
+	)
+	for _, l := range res.GetLogs() {
+		if _, ok := logs[l.GetBlkHeight()]; !ok {
+			heights = append(heights, l.GetBlkHeight())
+		}
+		logs[l.GetBlkHeight()] = append(logs[l.GetBlkHeight()], l)
+	}
+	for _, height := range heights {
+		bd, err := svr.blockData(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, bd.logObjects(logs[height])...)
+	}
+	return objs, nil
+}
+
+func (svr *Server) traceTransaction(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var (
+		h   string
+		cfg traceConfig
+	)
+	if err := parseParams(params, 1, &h, &cfg); err != nil {
+		return nil, err
+	}
+	actHash, err := actionHash(h)
+	if err != nil {
+		return nil, err
+	}
+	res, err := svr.backend.TraceTransaction(ctx, &apipb.TraceTransactionRequest{
+		ActionHash: actHash,
+		Options:    cfg.options(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(res.GetTrace()), nil
+}
+
+func (svr *Server) traceCall(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var (
+		args callArgs
+		bn   = _latestBlock
+		cfg  traceConfig
+	)
+	if err := parseParams(params, 1, &args, &bn, &cfg); err != nil {
+		return nil, err
+	}
+	caller, err := args.caller()
+	if err != nil {
+		return nil, err
+	}
+	var contract string
+	if args.To != "" {
+		if contract, err = ioAddress(args.To); err != nil {
+			return nil, err
+		}
+	}
+	req := &apipb.TraceCallRequest{
+		CallerAddress: caller,
+		Execution: &iotextypes.Execution{
+			Amount:   args.value().String(),
+			Contract: contract,
+			Data:     args.data(),
+		},
+		Options: cfg.options(),
+	}
+	if args.Gas != nil {
+		req.GasLimit = uint64(*args.Gas)
+	}
+	res, err := svr.backend.TraceCall(stateContext(ctx, bn), req)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(res.GetTrace()), nil
+}
+
+func (svr *Server) tipHeight(ctx context.Context) (uint64, error) {
+	res, err := svr.backend.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return res.GetChainMeta().GetHeight(), nil
+}
+
+// height resolves the block number to a height
+func (svr *Server) height(ctx context.Context, bn blockNumber) (uint64, error) {
+	if bn == _latestBlock || bn == _pendingBlock {
+		return svr.tipHeight(ctx)
+	}
+	return uint64(bn), nil
+}
+
+func (svr *Server) heightByHash(ctx context.Context, h string) (uint64, error) {
+	b, err := hexutil.Decode(h)
+	if err != nil || len(b) != len(hash.ZeroHash256) {
+		return 0, errInvalidParams("invalid block hash %s", h)
+	}
+	res, err := svr.backend.GetBlockMetas(ctx, &iotexapi.GetBlockMetasRequest{
+		Lookup: &iotexapi.GetBlockMetasRequest_ByHash{
+			ByHash: &iotexapi.GetBlockMetaByHashRequest{BlkHash: hex.EncodeToString(b)},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(res.GetBlkMetas()) == 0 {
+		return 0, errServer("block %s not found", h)
+	}
+	return res.GetBlkMetas()[0].GetHeight(), nil
+}
+
+func (svr *Server) blockData(ctx context.Context, height uint64) (*blockData, error) {
+	res, err := svr.backend.GetRawBlocks(ctx, &iotexapi.GetRawBlocksRequest{
+		StartHeight:  height,
+		Count:        1,
+		WithReceipts: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.GetBlocks()) == 0 {
+		return nil, errServer("block %d not found", height)
+	}
+	return newBlockData(res.GetBlocks()[0])
+}
+
+// stateContext returns the context which queries the state of the block number, the backend answers for the tip if
+// the block number is not specified
+func stateContext(ctx context.Context, bn blockNumber) context.Context {
+	if bn == _latestBlock || bn == _pendingBlock {
+		return ctx
+	}
+	return api.WithStateHeight(ctx, uint64(bn))
+}
+
+func (svr *Server) accountMeta(ctx context.Context, addr string, bn blockNumber) (*iotextypes.AccountMeta, error) {
+	ioAddr, err := ioAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	res, err := svr.backend.GetAccount(stateContext(ctx, bn), &iotexapi.GetAccountRequest{Address: ioAddr})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetAccountMeta(), nil
+}
+
+// caller returns the IoTeX address of the caller, which is the zero address if not specified
+func (args *callArgs) caller() (string, error) {
+	if args.From == "" {
+		addr, err := address.FromBytes(make([]byte, 20))
+		if err != nil {
+			return "", err
+		}
+		return addr.String(), nil
+	}
+	return ioAddress(args.From)
+}
+
+func (args *callArgs) value() *big.Int {
+	if args.Value == nil {
+		return big.NewInt(0)
+	}
+	return args.Value.ToInt()
+}
+
+func (args *callArgs) data() []byte {
+	if args.Input != nil {
+		return *args.Input
+	}
+	if args.Data != nil {
+		return *args.Data
+	}
+	return nil
+}
+
+func (cfg *traceConfig) options() *apipb.TraceOptions {
+	return &apipb.TraceOptions{
+		Tracer:         cfg.Tracer,
+		DisableStack:   cfg.DisableStack,
+		EnableMemory:   cfg.EnableMemory,
+		DisableStorage: cfg.DisableStorage,
+		Limit:          cfg.Limit,
+	}
+}
+
+// logsFilter converts the addresses and topics to the filter of the api service
+func (args *filterArgs) logsFilter() (*iotexapi.LogsFilter, error) {
+	filter := &iotexapi.LogsFilter{}
+	if len(args.Address) > 0 && string(args.Address) != "null" {
+		var addrs []string
+		if err := json.Unmarshal(args.Address, &addrs); err != nil {
+			var addr string
+			if err := json.Unmarshal(args.Address, &addr); err != nil {
+				return nil, errInvalidParams("invalid address filter")
+			}
+			addrs = []string{addr}
+		}
+		for _, addr := range addrs {
+			ioAddr, err := ioAddress(addr)
+			if err != nil {
+				return nil, err
+			}
+			filter.Address = append(filter.Address, ioAddr)
+		}
+	}
+	for _, t := range args.Topics {
+		topics := &iotexapi.Topics{}
+		if len(t) > 0 && string(t) != "null" {
+			var hashes []string
+			if err := json.Unmarshal(t, &hashes); err != nil {
+				var h string
+				if err := json.Unmarshal(t, &h); err != nil {
+					return nil, errInvalidParams("invalid topic filter")
+				}
+				hashes = []string{h}
+			}
+			for _, h := range hashes {
+				b, err := hexutil.Decode(h)
+				if err != nil || len(b) != len(hash.ZeroHash256) {
+					return nil, errInvalidParams("invalid topic %s", h)
+				}
+				topics.Topic = append(topics.Topic, b)
+			}
+		}
+		filter.Topics = append(filter.Topics, topics)
+	}
+	return filter, nil
+}
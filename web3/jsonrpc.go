@@ -0,0 +1,152 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package web3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const _jsonRPCVersion = "2.0"
+
+// error codes defined by JSON-RPC 2.0 and the Ethereum JSON-RPC API
+const (
+	_errCodeParse           = -32700
+	_errCodeInvalidRequest  = -32600
+	_errCodeMethodNotFound  = -32601
+	_errCodeInvalidParams   = -32602
+	_errCodeInternal        = -32603
+	_errCodeServer          = -32000
+	_errCodeExecutionRevert = 3
+)
+
+type (
+	// request is a JSON-RPC 2.0 request. A request without id is a notification which does not need a response
+	request struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id,omitempty"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params,omitempty"`
+	}
+
+	// response is a JSON-RPC 2.0 response, which carries either a result or an error
+	response struct {
+		JSONRPC string           `json:"jsonrpc"`
+		ID      json.RawMessage  `json:"id"`
+		Result  *json.RawMessage `json:"result,omitempty"`
+		Error   *Error           `json:"error,omitempty"`
+	}
+
+	// notification is sent to a websocket client when a subscribed event happens
+	notification struct {
+		JSONRPC string             `json:"jsonrpc"`
+		Method  string             `json:"method"`
+		Params  subscriptionResult `json:"params"`
+	}
+
+	subscriptionResult struct {
+		Subscription string      `json:"subscription"`
+		Result       interface{} `json:"result"`
+	}
+
+	// Error is a JSON-RPC error
+	Error struct {
+		Code    int         `json:"code"`
+		Message string      `json:"message"`
+		Data    interface{} `json:"data,omitempty"`
+	}
+)
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+func errInvalidParams(format string, args ...interface{}) *Error {
+	return &Error{Code: _errCodeInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+func errServer(format string, args ...interface{}) *Error {
+	return &Error{Code: _errCodeServer, Message: fmt.Sprintf(format, args...)}
+}
+
+// toError converts the error returned by a handler or by the api service to a JSON-RPC error
+func toError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.InvalidArgument:
+			return &Error{Code: _errCodeInvalidParams, Message: s.Message()}
+		case codes.Unimplemented:
+			return &Error{Code: _errCodeMethodNotFound, Message: s.Message()}
+		default:
+			return &Error{Code: _errCodeServer, Message: s.Message()}
+		}
+	}
+	return &Error{Code: _errCodeInternal, Message: err.Error()}
+}
+
+// isNotFound returns true if the api service reports that the queried object does not exist, in which case the
+// Ethereum JSON-RPC API returns null instead of an error
+func isNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
+
+func (req *request) isNotification() bool {
+	return len(req.ID) == 0
+}
+
+func (req *request) params() ([]json.RawMessage, error) {
+	var params []json.RawMessage
+	if len(bytes.TrimSpace(req.Params)) == 0 {
+		return params, nil
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return nil, errInvalidParams("params must be an array")
+	}
+	return params, nil
+}
+
+func newResponse(id json.RawMessage, result interface{}, err error) *response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	res := &response{JSONRPC: _jsonRPCVersion, ID: id}
+	if err != nil {
+		res.Error = toError(err)
+		return res
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		res.Error = &Error{Code: _errCodeInternal, Message: err.Error()}
+		return res
+	}
+	raw := json.RawMessage(data)
+	res.Result = &raw
+	return res
+}
+
+// parseParams decodes the positional params into args, of which the first required ones must be present
+func parseParams(params []json.RawMessage, required int, args ...interface{}) error {
+	if len(params) < required {
+		return errInvalidParams("missing value for required argument %d", len(params))
+	}
+	if len(params) > len(args) {
+		return errInvalidParams("too many arguments, want at most %d", len(args))
+	}
+	for i, param := range params {
+		if err := json.Unmarshal(param, args[i]); err != nil {
+			return errInvalidParams("invalid argument %d: %v", i, err)
+		}
+	}
+	return nil
+}
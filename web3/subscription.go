@@ -0,0 +1,247 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package web3
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/websocket"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/iotexproject/iotex-core/api/apipb"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// subscription kinds of eth_subscribe
+const (
+	_newHeads               = "newHeads"
+	_logs                   = "logs"
+	_newPendingTransactions = "newPendingTransactions"
+)
+
+var errSubscriptionClosed = errors.New("subscription is closed")
+
+type (
+	// wsConn is a websocket connection, which holds the subscriptions made over it
+	wsConn struct {
+		ws     *websocket.Conn
+		ctx    context.Context
+		cancel context.CancelFunc
+
+		mutex sync.Mutex
+		subs  map[string]*subscription
+	}
+
+	// subscription streams the events from the api service to the websocket client. It implements the grpc server
+	// stream of the api service, so the streaming rpcs serve the subscription the same way they serve a grpc client
+	subscription struct {
+		id     string
+		conn   *wsConn
+		ctx    context.Context
+		cancel context.CancelFunc
+	}
+
+	blockStream struct {
+		*subscription
+		gasLimit uint64
+	}
+
+	logStream struct {
+		*subscription
+		svr *Server
+	}
+
+	pendingActionStream struct {
+		*subscription
+	}
+)
+
+func newWSConn(ws *websocket.Conn) *wsConn {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &wsConn{
+		ws:     ws,
+		ctx:    ctx,
+		cancel: cancel,
+		subs:   make(map[string]*subscription),
+	}
+}
+
+func (conn *wsConn) write(msg interface{}) error {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	return conn.ws.WriteJSON(msg)
+}
+
+// close cancels all the subscriptions and closes the connection
+func (conn *wsConn) close() {
+	conn.cancel()
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	conn.subs = make(map[string]*subscription)
+	if err := conn.ws.Close(); err != nil {
+		log.L().Debug("Failed to close websocket connection.", zap.Error(err))
+	}
+}
+
+func (conn *wsConn) newSubscription() (*subscription, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, errors.Wrap(err, "failed to generate subscription id")
+	}
+	ctx, cancel := context.WithCancel(conn.ctx)
+	sub := &subscription{
+		id:     hexutil.Encode(b),
+		conn:   conn,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	conn.subs[sub.id] = sub
+	return sub, nil
+}
+
+func (conn *wsConn) removeSubscription(id string) bool {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	sub, ok := conn.subs[id]
+	if !ok {
+		return false
+	}
+	sub.cancel()
+	delete(conn.subs, id)
+	return true
+}
+
+func (svr *Server) subscribe(conn *wsConn, params []json.RawMessage) (interface{}, error) {
+	var (
+		kind string
+		args filterArgs
+	)
+	if err := parseParams(params, 1, &kind, &args); err != nil {
+		return nil, err
+	}
+	var run func(*subscription) error
+	switch kind {
+	case _newHeads:
+		run = func(sub *subscription) error {
+			return svr.backend.StreamBlocks(
+				&iotexapi.StreamBlocksRequest{},
+				&blockStream{subscription: sub, gasLimit: svr.gasLimit},
+			)
+		}
+	case _logs:
+		filter, err := args.logsFilter()
+		if err != nil {
+			return nil, err
+		}
+		run = func(sub *subscription) error {
+			return svr.backend.StreamLogs(
+				&iotexapi.StreamLogsRequest{Filter: filter},
+				&logStream{subscription: sub, svr: svr},
+			)
+		}
+	case _newPendingTransactions:
+		run = func(sub *subscription) error {
+			return svr.backend.StreamPendingActions(
+				&apipb.StreamPendingActionsRequest{},
+				&pendingActionStream{subscription: sub},
+			)
+		}
+	default:
+		return nil, errInvalidParams("unsupported subscription %s", kind)
+	}
+	sub, err := conn.newSubscription()
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		// the stream returns once sending to a cancelled subscription fails
+		if err := run(sub); err != nil && errors.Cause(err) != errSubscriptionClosed {
+			log.L().Debug("web3 subscription is closed.", zap.String("id", sub.id), zap.Error(err))
+		}
+		conn.removeSubscription(sub.id)
+	}()
+	return sub.id, nil
+}
+
+func (svr *Server) unsubscribe(conn *wsConn, params []json.RawMessage) (interface{}, error) {
+	var id string
+	if err := parseParams(params, 1, &id); err != nil {
+		return nil, err
+	}
+	return conn.removeSubscription(id), nil
+}
+
+// notify sends the result of the subscription to the client
+func (sub *subscription) notify(result interface{}) error {
+	select {
+	case <-sub.ctx.Done():
+		return errSubscriptionClosed
+	default:
+	}
+	return sub.conn.write(&notification{
+		JSONRPC: _jsonRPCVersion,
+		Method:  "eth_subscription",
+		Params:  subscriptionResult{Subscription: sub.id, Result: result},
+	})
+}
+
+// SetHeader is not used by the subscription
+func (sub *subscription) SetHeader(metadata.MD) error { return nil }
+
+// SendHeader is not used by the subscription
+func (sub *subscription) SendHeader(metadata.MD) error { return nil }
+
+// SetTrailer is not used by the subscription
+func (sub *subscription) SetTrailer(metadata.MD) {}
+
+// Context returns the context of the subscription
+func (sub *subscription) Context() context.Context { return sub.ctx }
+
+// SendMsg is not used by the subscription
+func (sub *subscription) SendMsg(m interface{}) error { return nil }
+
+// RecvMsg is not used by the subscription
+func (sub *subscription) RecvMsg(m interface{}) error { return nil }
+
+// Send notifies the header of the new block
+func (s *blockStream) Send(res *iotexapi.StreamBlocksResponse) error {
+	bd, err := newBlockData(res.GetBlock())
+	if err != nil {
+		return err
+	}
+	return s.notify(bd.header(s.gasLimit))
+}
+
+// Send notifies the log matching the filter
+func (s *logStream) Send(res *iotexapi.StreamLogsResponse) error {
+	l := res.GetLog()
+	bd, err := s.svr.blockData(s.ctx, l.GetBlkHeight())
+	if err != nil {
+		return err
+	}
+	for _, obj := range bd.logObjects([]*iotextypes.Log{l}) {
+		if err := s.notify(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Send notifies the hash of the new pending action
+func (s *pendingActionStream) Send(res *apipb.StreamPendingActionsResponse) error {
+	return s.notify("0x" + res.GetActHash())
+}
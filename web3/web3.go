@@ -0,0 +1,240 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package web3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/api"
+	"github.com/iotexproject/iotex-core/api/apipb"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// _maxRequestSize is the max size of an HTTP request body or a websocket message
+const (
+	_maxRequestSize = 5 * 1024 * 1024
+	// _maxInflightRequestsPerConn is the max number of requests of a websocket connection being handled at the same
+	// time, further messages are not read until one of them is done
+	_maxInflightRequestsPerConn = 16
+)
+
+type (
+	// Backend is the api service which serves the web3 requests
+	Backend interface {
+		iotexapi.APIServiceServer
+		StreamPendingActions(*apipb.StreamPendingActionsRequest, apipb.APIService_StreamPendingActionsServer) error
+		TraceTransaction(context.Context, *apipb.TraceTransactionRequest) (*apipb.TraceResponse, error)
+		TraceCall(context.Context, *apipb.TraceCallRequest) (*apipb.TraceResponse, error)
+	}
+
+	// Server is an Ethereum-compatible JSON-RPC 2.0 server over HTTP and websocket, which translates the eth_*
+	// methods into the api service calls
+	Server struct {
+		backend      Backend
+		port         int
+		evmNetworkID uint32
+		gasLimit     uint64
+		upgrader     websocket.Upgrader
+		httpServer   *http.Server
+
+		mutex sync.Mutex
+		conns map[*wsConn]struct{}
+	}
+
+	handler func(*Server, context.Context, []json.RawMessage) (interface{}, error)
+)
+
+var _ Backend = (*api.Server)(nil)
+
+// NewServer creates a web3 server serving the requests by the backend
+func NewServer(cfg config.Config, backend Backend) *Server {
+	svr := &Server{
+		backend:      backend,
+		port:         cfg.API.Web3Port,
+		evmNetworkID: cfg.Chain.EVMNetworkID,
+		gasLimit:     cfg.Genesis.BlockGasLimit,
+		upgrader: websocket.Upgrader{
+			// web3 clients run in browsers of any origin
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		conns: make(map[*wsConn]struct{}),
+	}
+	svr.httpServer = &http.Server{Handler: svr}
+	return svr
+}
+
+// Start starts the web3 server
+func (svr *Server) Start() error {
+	lis, err := net.Listen("tcp", ":"+strconv.Itoa(svr.port))
+	if err != nil {
+		log.L().Error("web3 server failed to listen.", zap.Error(err))
+		return errors.Wrap(err, "web3 server failed to listen")
+	}
+	log.L().Info("web3 server is listening.", zap.String("addr", lis.Addr().String()))
+
+	go func() {
+		if err := svr.httpServer.Serve(lis); err != nil && err != http.ErrServerClosed {
+			log.L().Fatal("web3 server failed to serve.", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Stop stops the web3 server and closes the websocket connections
+func (svr *Server) Stop() error {
+	svr.mutex.Lock()
+	for conn := range svr.conns {
+		conn.close()
+	}
+	svr.mutex.Unlock()
+	return svr.httpServer.Shutdown(context.Background())
+}
+
+// ServeHTTP serves a JSON-RPC request or a batch of requests, or upgrades the connection to websocket
+func (svr *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if websocket.IsWebSocketUpgrade(r) {
+		svr.serveWebsocket(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, _maxRequestSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	res := svr.handleMessage(r.Context(), body, nil)
+	if res == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		log.L().Warn("Failed to write web3 response.", zap.Error(err))
+	}
+}
+
+func (svr *Server) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	ws, err := svr.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.L().Warn("Failed to upgrade web3 connection to websocket.", zap.Error(err))
+		return
+	}
+	ws.SetReadLimit(_maxRequestSize)
+	conn := newWSConn(ws)
+	svr.mutex.Lock()
+	svr.conns[conn] = struct{}{}
+	svr.mutex.Unlock()
+	defer func() {
+		svr.mutex.Lock()
+		delete(svr.conns, conn)
+		svr.mutex.Unlock()
+		conn.close()
+	}()
+
+	inflight := make(chan struct{}, _maxInflightRequestsPerConn)
+	for {
+		_, msg, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		select {
+		case inflight <- struct{}{}:
+		case <-conn.ctx.Done():
+			return
+		}
+		go func() {
+			defer func() { <-inflight }()
+			if res := svr.handleMessage(conn.ctx, msg, conn); res != nil {
+				if err := conn.write(res); err != nil {
+					log.L().Debug("Failed to write web3 response.", zap.Error(err))
+				}
+			}
+		}()
+	}
+}
+
+// handleMessage handles a single request or a batch of requests, and returns nil if there is nothing to respond,
+// i.e., all the requests are notifications
+func (svr *Server) handleMessage(ctx context.Context, msg []byte, conn *wsConn) interface{} {
+	msg = bytes.TrimSpace(msg)
+	if len(msg) > 0 && msg[0] == '[' {
+		var reqs []json.RawMessage
+		if err := json.Unmarshal(msg, &reqs); err != nil {
+			return newResponse(nil, nil, &Error{Code: _errCodeParse, Message: err.Error()})
+		}
+		if len(reqs) == 0 {
+			return newResponse(nil, nil, &Error{Code: _errCodeInvalidRequest, Message: "empty batch"})
+		}
+		var ress []*response
+		for _, req := range reqs {
+			if res := svr.handleRequest(ctx, req, conn); res != nil {
+				ress = append(ress, res)
+			}
+		}
+		if len(ress) == 0 {
+			return nil
+		}
+		return ress
+	}
+	if res := svr.handleRequest(ctx, msg, conn); res != nil {
+		return res
+	}
+	return nil
+}
+
+func (svr *Server) handleRequest(ctx context.Context, msg []byte, conn *wsConn) *response {
+	var req request
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return newResponse(nil, nil, &Error{Code: _errCodeParse, Message: err.Error()})
+	}
+	if req.JSONRPC != _jsonRPCVersion || req.Method == "" {
+		return newResponse(req.ID, nil, &Error{Code: _errCodeInvalidRequest, Message: "invalid request"})
+	}
+	result, err := svr.dispatch(ctx, &req, conn)
+	if req.isNotification() {
+		return nil
+	}
+	return newResponse(req.ID, result, err)
+}
+
+func (svr *Server) dispatch(ctx context.Context, req *request, conn *wsConn) (interface{}, error) {
+	params, err := req.params()
+	if err != nil {
+		return nil, err
+	}
+	switch req.Method {
+	case "eth_subscribe", "eth_unsubscribe":
+		if conn == nil {
+			return nil, &Error{Code: _errCodeMethodNotFound, Message: "notifications not supported"}
+		}
+		if req.Method == "eth_subscribe" {
+			return svr.subscribe(conn, params)
+		}
+		return svr.unsubscribe(conn, params)
+	}
+	h, ok := _handlers[req.Method]
+	if !ok {
+		return nil, &Error{Code: _errCodeMethodNotFound, Message: "the method " + req.Method + " does not exist"}
+	}
+	return h(svr, ctx, params)
+}
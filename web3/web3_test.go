@@ -0,0 +1,548 @@
+// Copyright (c) 2020 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package web3
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/api"
+	"github.com/iotexproject/iotex-core/api/apipb"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// testBackend is an in-memory api service serving a chain of a single block
+type testBackend struct {
+	iotexapi.UnimplementedAPIServiceServer
+
+	blk      *block.Block
+	receipts []*iotextypes.Receipt
+	sent     []*iotextypes.Action
+	readRes  *iotexapi.ReadContractResponse
+	blocks   chan *iotexapi.StreamBlocksResponse
+	traceTx  *apipb.TraceTransactionRequest
+	traceReq *apipb.TraceCallRequest
+}
+
+func (b *testBackend) GetChainMeta(context.Context, *iotexapi.GetChainMetaRequest) (*iotexapi.GetChainMetaResponse, error) {
+	return &iotexapi.GetChainMetaResponse{ChainMeta: &iotextypes.ChainMeta{Height: b.blk.Height()}}, nil
+}
+
+func (b *testBackend) GetAccount(ctx context.Context, in *iotexapi.GetAccountRequest) (*iotexapi.GetAccountResponse, error) {
+	if in.GetAddress() != identityset.Address(1).String() {
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(api.BlockHeightMetadataKey)) > 0 {
+		h, err := strconv.ParseUint(md.Get(api.BlockHeightMetadataKey)[0], 10, 64)
+		if err != nil || h > b.blk.Height() {
+			return nil, status.Error(codes.InvalidArgument, "invalid block height")
+		}
+		if h < b.blk.Height() {
+			return &iotexapi.GetAccountResponse{AccountMeta: &iotextypes.AccountMeta{
+				Address:      in.GetAddress(),
+				Balance:      "0",
+				PendingNonce: 1,
+			}}, nil
+		}
+	}
+	return &iotexapi.GetAccountResponse{AccountMeta: &iotextypes.AccountMeta{
+		Address:      in.GetAddress(),
+		Balance:      "1000000000000000000",
+		Nonce:        3,
+		PendingNonce: 5,
+	}}, nil
+}
+
+func (b *testBackend) ReadContract(context.Context, *iotexapi.ReadContractRequest) (*iotexapi.ReadContractResponse, error) {
+	return b.readRes, nil
+}
+
+func (b *testBackend) SendAction(ctx context.Context, in *iotexapi.SendActionRequest) (*iotexapi.SendActionResponse, error) {
+	b.sent = append(b.sent, in.GetAction())
+	selp, err := loadAction(in.GetAction())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	h := selp.Hash()
+	return &iotexapi.SendActionResponse{ActionHash: hex.EncodeToString(h[:])}, nil
+}
+
+func (b *testBackend) GetRawBlocks(ctx context.Context, in *iotexapi.GetRawBlocksRequest) (*iotexapi.GetRawBlocksResponse, error) {
+	if in.GetStartHeight() != b.blk.Height() {
+		return nil, status.Error(codes.NotFound, "block not found")
+	}
+	return &iotexapi.GetRawBlocksResponse{Blocks: []*iotexapi.BlockInfo{b.blockInfo()}}, nil
+}
+
+func (b *testBackend) GetBlockMetas(ctx context.Context, in *iotexapi.GetBlockMetasRequest) (*iotexapi.GetBlockMetasResponse, error) {
+	blkHash := b.blk.HashBlock()
+	if in.GetByHash().GetBlkHash() != hex.EncodeToString(blkHash[:]) {
+		return nil, status.Error(codes.NotFound, "block not found")
+	}
+	return &iotexapi.GetBlockMetasResponse{
+		Total:    1,
+		BlkMetas: []*iotextypes.BlockMeta{{Hash: hex.EncodeToString(blkHash[:]), Height: b.blk.Height()}},
+	}, nil
+}
+
+func (b *testBackend) GetActions(ctx context.Context, in *iotexapi.GetActionsRequest) (*iotexapi.GetActionsResponse, error) {
+	for _, selp := range b.blk.Actions {
+		h := selp.Hash()
+		if hex.EncodeToString(h[:]) == in.GetByHash().GetActionHash() {
+			return &iotexapi.GetActionsResponse{
+				Total:      1,
+				ActionInfo: []*iotexapi.ActionInfo{{Action: selp.Proto(), BlkHeight: b.blk.Height()}},
+			}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "action not found")
+}
+
+func (b *testBackend) GetReceiptByAction(ctx context.Context, in *iotexapi.GetReceiptByActionRequest) (*iotexapi.GetReceiptByActionResponse, error) {
+	for _, r := range b.receipts {
+		if hex.EncodeToString(r.GetActHash()) == in.GetActionHash() {
+			return &iotexapi.GetReceiptByActionResponse{ReceiptInfo: &iotexapi.ReceiptInfo{Receipt: r}}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "receipt not found")
+}
+
+func (b *testBackend) GetLogs(ctx context.Context, in *iotexapi.GetLogsRequest) (*iotexapi.GetLogsResponse, error) {
+	res := &iotexapi.GetLogsResponse{}
+	for _, r := range b.receipts {
+		for _, l := range r.GetLogs() {
+			if len(in.GetFilter().GetAddress()) == 0 || in.GetFilter().GetAddress()[0] == l.GetContractAddress() {
+				res.Logs = append(res.Logs, l)
+			}
+		}
+	}
+	return res, nil
+}
+
+func (b *testBackend) StreamBlocks(in *iotexapi.StreamBlocksRequest, stream iotexapi.APIService_StreamBlocksServer) error {
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case res := <-b.blocks:
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (b *testBackend) StreamPendingActions(*apipb.StreamPendingActionsRequest, apipb.APIService_StreamPendingActionsServer) error {
+	return status.Error(codes.Unimplemented, "not implemented")
+}
+
+func (b *testBackend) TraceTransaction(ctx context.Context, in *apipb.TraceTransactionRequest) (*apipb.TraceResponse, error) {
+	b.traceTx = in
+	return &apipb.TraceResponse{Trace: []byte(`{"gas":21000,"failed":false,"returnValue":"","structLogs":[]}`)}, nil
+}
+
+func (b *testBackend) TraceCall(ctx context.Context, in *apipb.TraceCallRequest) (*apipb.TraceResponse, error) {
+	b.traceReq = in
+	return &apipb.TraceResponse{Trace: []byte(`{"type":"CALL","calls":[{"type":"STATICCALL"}]}`)}, nil
+}
+
+func (b *testBackend) blockInfo() *iotexapi.BlockInfo {
+	return &iotexapi.BlockInfo{Block: b.blk.ConvertToBlockPb(), Receipts: b.receipts}
+}
+
+func newTestBackend(t *testing.T) *testBackend {
+	require := require.New(t)
+	tsf, err := testutil.SignedTransfer(identityset.Address(2).String(), identityset.PrivateKey(1), 1, big.NewInt(10), nil, 10000, big.NewInt(1))
+	require.NoError(err)
+	exec, err := testutil.SignedExecution(identityset.Address(3).String(), identityset.PrivateKey(1), 2, big.NewInt(0), 100000, big.NewInt(1), []byte{1, 2})
+	require.NoError(err)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(tsf, exec).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	tsfHash, execHash := tsf.Hash(), exec.Hash()
+	topic := hash.Hash256b([]byte("Transfer"))
+	return &testBackend{
+		blk: &blk,
+		receipts: []*iotextypes.Receipt{
+			{Status: 1, BlkHeight: 1, ActHash: tsfHash[:], GasConsumed: 10000},
+			{Status: 1, BlkHeight: 1, ActHash: execHash[:], GasConsumed: 20000, Logs: []*iotextypes.Log{{
+				ContractAddress: identityset.Address(3).String(),
+				Topics:          [][]byte{topic[:]},
+				Data:            []byte{3},
+				BlkHeight:       1,
+				ActHash:         execHash[:],
+			}}},
+		},
+		blocks: make(chan *iotexapi.StreamBlocksResponse),
+	}
+}
+
+func newTestServer(t *testing.T) (*testBackend, *httptest.Server) {
+	backend := newTestBackend(t)
+	cfg := config.Default
+	return backend, httptest.NewServer(NewServer(cfg, backend))
+}
+
+func post(t *testing.T, url string, body string) []byte {
+	res, err := http.Post(url, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(res.Body)
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func call(t *testing.T, url string, method string, params ...interface{}) *response {
+	res := &response{}
+	require.NoError(t, json.Unmarshal(rawCall(t, url, method, params...), res))
+	return res
+}
+
+func rawCall(t *testing.T, url string, method string, params ...interface{}) []byte {
+	if params == nil {
+		params = []interface{}{}
+	}
+	req, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	require.NoError(t, err)
+	return post(t, url, string(req))
+}
+
+func result(t *testing.T, res *response, v interface{}) {
+	require.Nil(t, res.Error)
+	require.NotNil(t, res.Result)
+	require.NoError(t, json.Unmarshal(*res.Result, v))
+}
+
+func TestServer_Basic(t *testing.T) {
+	require := require.New(t)
+	_, svr := newTestServer(t)
+	defer svr.Close()
+
+	var s string
+	result(t, call(t, svr.URL, "eth_chainId"), &s)
+	require.Equal("0x1251", s)
+	result(t, call(t, svr.URL, "net_version"), &s)
+	require.Equal("4689", s)
+	result(t, call(t, svr.URL, "eth_blockNumber"), &s)
+	require.Equal("0x1", s)
+
+	res := call(t, svr.URL, "eth_foo")
+	require.Equal(_errCodeMethodNotFound, res.Error.Code)
+	res = call(t, svr.URL, "eth_subscribe", "newHeads")
+	require.Equal(_errCodeMethodNotFound, res.Error.Code)
+
+	// batch with a notification, which is not responded
+	var ress []*response
+	require.NoError(json.Unmarshal(post(t, svr.URL, `[
+		{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},
+		{"jsonrpc":"2.0","method":"eth_chainId"},
+		{"jsonrpc":"2.0","id":"2","method":"eth_blockNumber"}
+	]`), &ress))
+	require.Equal(2, len(ress))
+	require.Equal(`"2"`, string(ress[1].ID))
+
+	res = &response{}
+	require.NoError(json.Unmarshal(post(t, svr.URL, `{"jsonrpc":"2.0","id":1`), res))
+	require.Equal(_errCodeParse, res.Error.Code)
+}
+
+func TestServer_Account(t *testing.T) {
+	require := require.New(t)
+	_, svr := newTestServer(t)
+	defer svr.Close()
+
+	ethAddr := common.BytesToAddress(identityset.Address(1).Bytes()).Hex()
+	var s string
+	result(t, call(t, svr.URL, "eth_getBalance", ethAddr, "latest"), &s)
+	require.Equal("0xde0b6b3a7640000", s)
+	// IoTeX address is accepted as well
+	result(t, call(t, svr.URL, "eth_getBalance", identityset.Address(1).String()), &s)
+	require.Equal("0xde0b6b3a7640000", s)
+	result(t, call(t, svr.URL, "eth_getTransactionCount", ethAddr, "latest"), &s)
+	require.Equal("0x3", s)
+	result(t, call(t, svr.URL, "eth_getTransactionCount", ethAddr, "pending"), &s)
+	require.Equal("0x5", s)
+
+	res := call(t, svr.URL, "eth_getBalance", "0x1234")
+	require.Equal(_errCodeInvalidParams, res.Error.Code)
+	// state of an earlier block
+	result(t, call(t, svr.URL, "eth_getBalance", ethAddr, "earliest"), &s)
+	require.Equal("0x0", s)
+	res = call(t, svr.URL, "eth_getBalance", ethAddr, "0x10")
+	require.Equal(_errCodeInvalidParams, res.Error.Code)
+}
+
+func TestServer_Call(t *testing.T) {
+	require := require.New(t)
+	backend, svr := newTestServer(t)
+	defer svr.Close()
+
+	to := common.BytesToAddress(identityset.Address(3).Bytes()).Hex()
+	backend.readRes = &iotexapi.ReadContractResponse{
+		Data:    "0102",
+		Receipt: &iotextypes.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success)},
+	}
+	var s string
+	result(t, call(t, svr.URL, "eth_call", map[string]string{"to": to, "data": "0x1234"}, "latest"), &s)
+	require.Equal("0x0102", s)
+
+	// Error(string) with message "nope!"
+	revert := "08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000005" +
+		"6e6f706521000000000000000000000000000000000000000000000000000000"
+	backend.readRes = &iotexapi.ReadContractResponse{
+		Data:    revert,
+		Receipt: &iotextypes.Receipt{Status: uint64(iotextypes.ReceiptStatus_ErrExecutionReverted)},
+	}
+	res := call(t, svr.URL, "eth_call", map[string]string{"to": to})
+	require.Equal(_errCodeExecutionRevert, res.Error.Code)
+	require.Equal("execution reverted: nope!", res.Error.Message)
+	require.Equal("0x"+revert, res.Error.Data)
+
+	res = call(t, svr.URL, "eth_call", map[string]string{})
+	require.Equal(_errCodeInvalidParams, res.Error.Code)
+}
+
+func TestServer_Trace(t *testing.T) {
+	require := require.New(t)
+	backend, svr := newTestServer(t)
+	defer svr.Close()
+
+	execHash := backend.blk.Actions[1].Hash()
+	var trace map[string]interface{}
+	result(t, call(t, svr.URL, "debug_traceTransaction", "0x"+hex.EncodeToString(execHash[:]),
+		map[string]interface{}{"disableStack": true, "enableMemory": true, "limit": 10}), &trace)
+	require.Equal(false, trace["failed"])
+	require.Equal(hex.EncodeToString(execHash[:]), backend.traceTx.GetActionHash())
+	require.True(backend.traceTx.GetOptions().GetDisableStack())
+	require.True(backend.traceTx.GetOptions().GetEnableMemory())
+	require.EqualValues(10, backend.traceTx.GetOptions().GetLimit())
+	res := call(t, svr.URL, "debug_traceTransaction", "0x1234")
+	require.Equal(_errCodeInvalidParams, res.Error.Code)
+
+	to := common.BytesToAddress(identityset.Address(3).Bytes()).Hex()
+	result(t, call(t, svr.URL, "debug_traceCall", map[string]string{"to": to, "data": "0x1234", "gas": "0x5208"},
+		"latest", map[string]string{"tracer": "callTracer"}), &trace)
+	require.Equal("CALL", trace["type"])
+	require.Equal(identityset.Address(3).String(), backend.traceReq.GetExecution().GetContract())
+	require.Equal([]byte{0x12, 0x34}, backend.traceReq.GetExecution().GetData())
+	require.EqualValues(21000, backend.traceReq.GetGasLimit())
+	require.Equal("callTracer", backend.traceReq.GetOptions().GetTracer())
+}
+
+func TestServer_SendRawTransaction(t *testing.T) {
+	require := require.New(t)
+	backend, svr := newTestServer(t)
+	defer svr.Close()
+
+	tsf, err := testutil.SignedTransfer(identityset.Address(2).String(), identityset.PrivateKey(1), 3, big.NewInt(10), nil, 10000, big.NewInt(1))
+	require.NoError(err)
+	raw, err := proto.Marshal(tsf.Proto())
+	require.NoError(err)
+	var s string
+	result(t, call(t, svr.URL, "eth_sendRawTransaction", "0x"+hex.EncodeToString(raw)), &s)
+	require.Equal(hexHash(tsf.Hash()), s)
+	require.Equal(1, len(backend.sent))
+
+	// an Ethereum transaction signed by ethers style wallets
+	sk := identityset.PrivateKey(1).EcdsaPrivateKey().(*ecdsa.PrivateKey)
+	signer := types.NewEIP155Signer(big.NewInt(int64(config.Default.Chain.EVMNetworkID)))
+	to := common.BytesToAddress(identityset.Address(2).Bytes())
+	tx, err := types.SignTx(types.NewTransaction(4, to, big.NewInt(10), 21000, big.NewInt(1), nil), signer, sk)
+	require.NoError(err)
+	raw, err = rlp.EncodeToBytes(tx)
+	require.NoError(err)
+	result(t, call(t, svr.URL, "eth_sendRawTransaction", hexutil.Encode(raw)), &s)
+	require.Equal(tx.Hash().Hex(), s)
+	require.Equal(2, len(backend.sent))
+	selp := action.SealedEnvelope{}
+	require.NoError(selp.LoadProto(backend.sent[1]))
+	require.Equal(action.EthereumRLP, selp.Encoding())
+	require.Equal(identityset.PrivateKey(1).PublicKey().Bytes(), selp.SrcPubkey().Bytes())
+	sent, ok := selp.Action().(*action.Transfer)
+	require.True(ok)
+	require.Equal(identityset.Address(2).String(), sent.Recipient())
+	require.Equal(uint64(4), selp.Nonce())
+	require.Equal(tx.Hash().Hex(), hexHash(selp.Hash()))
+
+	// a contract deployment is an execution
+	tx, err = types.SignTx(types.NewContractCreation(5, big.NewInt(0), 100000, big.NewInt(1), []byte{0x60, 0x80}), signer, sk)
+	require.NoError(err)
+	raw, err = rlp.EncodeToBytes(tx)
+	require.NoError(err)
+	result(t, call(t, svr.URL, "eth_sendRawTransaction", hexutil.Encode(raw)), &s)
+	require.Equal(tx.Hash().Hex(), s)
+	require.NoError(selp.LoadProto(backend.sent[2]))
+	exec, ok := selp.Action().(*action.Execution)
+	require.True(ok)
+	require.Equal(action.EmptyAddress, exec.Contract())
+	require.Equal([]byte{0x60, 0x80}, exec.Data())
+
+	// a transaction signed for another chain is rejected
+	res := call(t, svr.URL, "eth_sendRawTransaction", "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83")
+	require.Equal(_errCodeInvalidParams, res.Error.Code)
+	require.Equal(3, len(backend.sent))
+}
+
+func TestServer_BlockAndReceipt(t *testing.T) {
+	require := require.New(t)
+	backend, svr := newTestServer(t)
+	defer svr.Close()
+
+	blkHash := hexHash(backend.blk.HashBlock())
+	tsfHash := hexHash(backend.blk.Actions[0].Hash())
+	execHash := hexHash(backend.blk.Actions[1].Hash())
+
+	var blk map[string]interface{}
+	result(t, call(t, svr.URL, "eth_getBlockByNumber", "latest", false), &blk)
+	require.Equal(blkHash, blk["hash"])
+	require.Equal("0x1", blk["number"])
+	require.Equal("0x7530", blk["gasUsed"])
+	require.Equal([]interface{}{tsfHash, execHash}, blk["transactions"])
+	require.Equal(common.BytesToAddress(identityset.Address(27).Bytes()).Hex(), blk["miner"])
+
+	result(t, call(t, svr.URL, "eth_getBlockByHash", blkHash, true), &blk)
+	txs := blk["transactions"].([]interface{})
+	require.Equal(2, len(txs))
+	tx := txs[0].(map[string]interface{})
+	require.Equal(tsfHash, tx["hash"])
+	require.Equal(common.BytesToAddress(identityset.Address(1).Bytes()).Hex(), tx["from"])
+	require.Equal(common.BytesToAddress(identityset.Address(2).Bytes()).Hex(), tx["to"])
+	require.Equal("0xa", tx["value"])
+
+	require.Contains(string(rawCall(t, svr.URL, "eth_getBlockByNumber", "0x5", false)), `"result":null`)
+
+	result(t, call(t, svr.URL, "eth_getTransactionByHash", execHash), &tx)
+	require.Equal("0x1", tx["transactionIndex"])
+	require.Equal(blkHash, tx["blockHash"])
+	require.Equal("0x0102", tx["input"])
+
+	var receipt map[string]interface{}
+	result(t, call(t, svr.URL, "eth_getTransactionReceipt", execHash), &receipt)
+	require.Equal("0x1", receipt["status"])
+	require.Equal("0x1", receipt["transactionIndex"])
+	require.Equal("0x4e20", receipt["gasUsed"])
+	require.Equal("0x7530", receipt["cumulativeGasUsed"])
+	logs := receipt["logs"].([]interface{})
+	require.Equal(1, len(logs))
+	require.Equal(common.BytesToAddress(identityset.Address(3).Bytes()).Hex(), logs[0].(map[string]interface{})["address"])
+
+	require.Contains(string(rawCall(t, svr.URL, "eth_getTransactionReceipt", hexHash(hash.ZeroHash256))), `"result":null`)
+}
+
+func TestServer_GetLogs(t *testing.T) {
+	require := require.New(t)
+	backend, svr := newTestServer(t)
+	defer svr.Close()
+
+	contract := common.BytesToAddress(identityset.Address(3).Bytes()).Hex()
+	var logs []map[string]interface{}
+	result(t, call(t, svr.URL, "eth_getLogs", map[string]interface{}{
+		"fromBlock": "earliest",
+		"toBlock":   "latest",
+		"address":   contract,
+		"topics":    []interface{}{nil},
+	}), &logs)
+	require.Equal(1, len(logs))
+	require.Equal(contract, logs[0]["address"])
+	require.Equal(hexHash(backend.blk.Actions[1].Hash()), logs[0]["transactionHash"])
+	require.Equal("0x1", logs[0]["transactionIndex"])
+	require.Equal("0x0", logs[0]["logIndex"])
+	require.Equal("0x03", logs[0]["data"])
+
+	res := call(t, svr.URL, "eth_getLogs", map[string]interface{}{"topics": []interface{}{"0x12"}})
+	require.Equal(_errCodeInvalidParams, res.Error.Code)
+}
+
+func TestServer_Subscribe(t *testing.T) {
+	require := require.New(t)
+	backend, svr := newTestServer(t)
+	defer svr.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(svr.URL, "http"), nil)
+	require.NoError(err)
+	defer ws.Close()
+
+	require.NoError(ws.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_subscribe","params":["newHeads"]}`)))
+	res := &response{}
+	require.NoError(ws.ReadJSON(res))
+	var id string
+	result(t, res, &id)
+
+	backend.blocks <- &iotexapi.StreamBlocksResponse{Block: backend.blockInfo()}
+	var n struct {
+		Method string `json:"method"`
+		Params struct {
+			Subscription string                 `json:"subscription"`
+			Result       map[string]interface{} `json:"result"`
+		} `json:"params"`
+	}
+	require.NoError(ws.ReadJSON(&n))
+	require.Equal("eth_subscription", n.Method)
+	require.Equal(id, n.Params.Subscription)
+	require.Equal(hexHash(backend.blk.HashBlock()), n.Params.Result["hash"])
+
+	require.NoError(ws.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0", "id": 2, "method": "eth_unsubscribe", "params": []string{id},
+	}))
+	res = &response{}
+	require.NoError(ws.ReadJSON(res))
+	var ok bool
+	result(t, res, &ok)
+	require.True(ok)
+
+	// other requests are served over websocket as well
+	require.NoError(ws.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":3,"method":"eth_blockNumber"}`)))
+	res = &response{}
+	require.NoError(ws.ReadJSON(res))
+	var s string
+	result(t, res, &s)
+	require.Equal("0x1", s)
+
+	require.NoError(ws.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":4,"method":"eth_subscribe","params":["foo"]}`)))
+	res = &response{}
+	require.NoError(ws.ReadJSON(res))
+	require.Equal(_errCodeInvalidParams, res.Error.Code)
+}